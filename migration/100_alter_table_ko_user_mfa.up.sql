@@ -0,0 +1,2 @@
+ALTER TABLE `ko_user_mfa` ADD COLUMN `failed_count` int(11) DEFAULT 0 AFTER `last_counter`;
+ALTER TABLE `ko_user_mfa` ADD COLUMN `locked_until` datetime DEFAULT NULL AFTER `failed_count`;
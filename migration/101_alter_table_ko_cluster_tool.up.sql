@@ -0,0 +1 @@
+ALTER TABLE `ko_cluster_tool` ADD COLUMN `secret` text AFTER `vars`;
@@ -0,0 +1,4 @@
+ALTER TABLE `ko_credential` MODIFY COLUMN `password` varchar(1024) DEFAULT NULL;
+ALTER TABLE `ko_f5_setting` MODIFY COLUMN `password` varchar(1024) DEFAULT NULL;
+ALTER TABLE `ko_user_mfa` MODIFY COLUMN `secret` varchar(1024) DEFAULT NULL;
+ALTER TABLE `ko_system_setting` MODIFY COLUMN `value` varchar(1024) NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE `ko_user` ADD COLUMN `deactivated_by_sync` tinyint(1) DEFAULT 0 AFTER `type`;
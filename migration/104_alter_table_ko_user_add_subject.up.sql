@@ -0,0 +1 @@
+ALTER TABLE `ko_user` ADD COLUMN `subject` varchar(256) DEFAULT NULL AFTER `type`;
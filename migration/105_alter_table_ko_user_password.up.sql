@@ -0,0 +1 @@
+ALTER TABLE `ko_user` MODIFY COLUMN `password` varchar(1024) DEFAULT NULL;
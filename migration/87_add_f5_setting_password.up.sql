@@ -0,0 +1,2 @@
+ALTER TABLE `ko_f5_setting` ADD COLUMN `password` varchar(255) DEFAULT NULL AFTER `user`;
+ALTER TABLE `ko_f5_setting` ADD COLUMN `message` text AFTER `status`;
\ No newline at end of file
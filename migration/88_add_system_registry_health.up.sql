@@ -0,0 +1,4 @@
+ALTER TABLE `ko_system_registry` ADD COLUMN `priority` int NOT NULL DEFAULT 0 AFTER `registry_hosted_port`;
+ALTER TABLE `ko_system_registry` ADD COLUMN `status` varchar(64) DEFAULT NULL AFTER `priority`;
+ALTER TABLE `ko_system_registry` ADD COLUMN `message` text AFTER `status`;
+ALTER TABLE `ko_system_registry` ADD COLUMN `last_check_time` datetime DEFAULT NULL AFTER `message`;
\ No newline at end of file
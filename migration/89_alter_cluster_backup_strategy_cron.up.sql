@@ -0,0 +1,5 @@
+ALTER TABLE `ko_cluster_backup_strategy` MODIFY COLUMN `cron` varchar(255) DEFAULT NULL;
+UPDATE `ko_cluster_backup_strategy` SET `cron` = IF(CAST(`cron` AS UNSIGNED) > 31, '0 0 1 * *', CONCAT('0 0 */', `cron`, ' * *'));
+ALTER TABLE `ko_cluster_backup_strategy` ADD COLUMN `retain_hourly` int(64) DEFAULT 0 AFTER `save_num`;
+ALTER TABLE `ko_cluster_backup_strategy` ADD COLUMN `retain_daily` int(64) DEFAULT 0 AFTER `retain_hourly`;
+ALTER TABLE `ko_cluster_backup_strategy` ADD COLUMN `retain_weekly` int(64) DEFAULT 0 AFTER `retain_daily`;
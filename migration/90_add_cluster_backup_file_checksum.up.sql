@@ -0,0 +1,7 @@
+ALTER TABLE `ko_cluster_backup_file` ADD COLUMN `checksum` varchar(128) DEFAULT NULL AFTER `folder`;
+ALTER TABLE `ko_cluster_backup_file` ADD COLUMN `verify_status` varchar(64) DEFAULT NULL AFTER `checksum`;
+ALTER TABLE `ko_cluster_backup_file` ADD COLUMN `verify_message` text AFTER `verify_status`;
+ALTER TABLE `ko_cluster_backup_file` ADD COLUMN `verify_time` datetime DEFAULT NULL AFTER `verify_message`;
+ALTER TABLE `ko_cluster_backup_file` ADD COLUMN `key_count` bigint(20) DEFAULT 0 AFTER `verify_time`;
+ALTER TABLE `ko_cluster_backup_file` ADD COLUMN `revision` bigint(20) DEFAULT 0 AFTER `key_count`;
+ALTER TABLE `ko_cluster_backup_strategy` ADD COLUMN `verify_cron` varchar(255) DEFAULT NULL AFTER `retain_weekly`;
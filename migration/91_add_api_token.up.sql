@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `ko_api_token` (
+    `id` varchar(64) NOT NULL,
+    `created_at` datetime DEFAULT NULL,
+    `updated_at` datetime DEFAULT NULL,
+    `user_id` varchar(64) DEFAULT NULL,
+    `name` varchar(255) DEFAULT NULL,
+    `prefix` varchar(64) DEFAULT NULL,
+    `token_hash` varchar(128) DEFAULT NULL,
+    `scopes` varchar(255) DEFAULT NULL,
+    `expires_at` datetime DEFAULT NULL,
+    `last_used_at` datetime DEFAULT NULL,
+    `last_used_ip` varchar(64) DEFAULT NULL,
+    PRIMARY KEY (`id`) USING BTREE,
+    UNIQUE KEY `token_hash` (`token_hash`),
+    KEY `user_id` (`user_id`)
+) ;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `ko_user_mfa` (
+    `id` varchar(64) NOT NULL,
+    `created_at` datetime DEFAULT NULL,
+    `updated_at` datetime DEFAULT NULL,
+    `user_id` varchar(64) DEFAULT NULL,
+    `secret` varchar(255) DEFAULT NULL,
+    `enabled` tinyint(1) DEFAULT 0,
+    `recovery_codes` text,
+    `last_counter` bigint(20) DEFAULT 0,
+    PRIMARY KEY (`id`) USING BTREE,
+    UNIQUE KEY `user_id` (`user_id`)
+) ;
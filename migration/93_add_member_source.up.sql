@@ -0,0 +1,2 @@
+ALTER TABLE `ko_project_member` ADD COLUMN `source` varchar(64) DEFAULT NULL AFTER `role`;
+ALTER TABLE `ko_cluster_member` ADD COLUMN `source` varchar(64) DEFAULT NULL AFTER `role`;
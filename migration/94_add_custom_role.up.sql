@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS `ko_role` (
+    `id` varchar(64) NOT NULL,
+    `created_at` datetime DEFAULT NULL,
+    `updated_at` datetime DEFAULT NULL,
+    `name` varchar(255) DEFAULT NULL,
+    `description` varchar(255) DEFAULT NULL,
+    `rules` mediumtext,
+    PRIMARY KEY (`id`) USING BTREE,
+    UNIQUE KEY `name` (`name`)
+) ;
+
+CREATE TABLE IF NOT EXISTS `ko_role_binding` (
+    `id` varchar(64) NOT NULL,
+    `created_at` datetime DEFAULT NULL,
+    `updated_at` datetime DEFAULT NULL,
+    `role_id` varchar(64) DEFAULT NULL,
+    `user_id` varchar(64) DEFAULT NULL,
+    `scope` varchar(64) DEFAULT NULL,
+    `resource_id` varchar(64) DEFAULT NULL,
+    PRIMARY KEY (`id`) USING BTREE,
+    KEY `role_id` (`role_id`),
+    KEY `user_id` (`user_id`)
+) ;
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS `ko_audit_event` (
+    `id` varchar(64) NOT NULL,
+    `created_at` datetime DEFAULT NULL,
+    `updated_at` datetime DEFAULT NULL,
+    `seq` bigint(20) NOT NULL,
+    `timestamp` bigint(20) NOT NULL,
+    `actor` varchar(255) DEFAULT NULL,
+    `source_ip` varchar(64) DEFAULT NULL,
+    `resource_type` varchar(64) DEFAULT NULL,
+    `resource_name` varchar(255) DEFAULT NULL,
+    `action` varchar(64) DEFAULT NULL,
+    `method` varchar(16) DEFAULT NULL,
+    `path` varchar(1024) DEFAULT NULL,
+    `request_id` varchar(64) DEFAULT NULL,
+    `outcome` varchar(64) DEFAULT NULL,
+    `status_code` int(11) DEFAULT NULL,
+    `before` mediumtext,
+    `after` mediumtext,
+    `prev_hash` varchar(64) DEFAULT NULL,
+    `hash` varchar(64) DEFAULT NULL,
+    PRIMARY KEY (`id`) USING BTREE,
+    UNIQUE KEY `seq` (`seq`),
+    KEY `actor` (`actor`),
+    KEY `resource` (`resource_type`, `resource_name`),
+    KEY `request_id` (`request_id`),
+    KEY `timestamp` (`timestamp`)
+) ;
+
+CREATE TABLE IF NOT EXISTS `ko_audit_chain` (
+    `id` varchar(64) NOT NULL,
+    `seq` bigint(20) NOT NULL,
+    `hash` varchar(64) DEFAULT NULL,
+    PRIMARY KEY (`id`) USING BTREE
+) ;
+
+INSERT INTO `ko_audit_chain` (`id`, `seq`, `hash`) VALUES ('head', 0, '');
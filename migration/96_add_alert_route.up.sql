@@ -0,0 +1,58 @@
+CREATE TABLE IF NOT EXISTS `ko_alert_route` (
+    `id` varchar(64) NOT NULL,
+    `created_at` datetime DEFAULT NULL,
+    `updated_at` datetime DEFAULT NULL,
+    `name` varchar(255) DEFAULT NULL,
+    `priority` int(11) DEFAULT 0,
+    `cluster_name` varchar(255) DEFAULT NULL,
+    `project_name` varchar(255) DEFAULT NULL,
+    `message_type` varchar(64) DEFAULT NULL,
+    `level` varchar(64) DEFAULT NULL,
+    `send_types` varchar(255) DEFAULT NULL,
+    `users` text,
+    `escalate_users` text,
+    `escalate_after` int(11) DEFAULT 0,
+    `repeat_interval` int(11) DEFAULT 0,
+    PRIMARY KEY (`id`) USING BTREE,
+    UNIQUE KEY `name` (`name`)
+) ;
+
+CREATE TABLE IF NOT EXISTS `ko_alert_silence` (
+    `id` varchar(64) NOT NULL,
+    `created_at` datetime DEFAULT NULL,
+    `updated_at` datetime DEFAULT NULL,
+    `cluster_name` varchar(255) DEFAULT NULL,
+    `project_name` varchar(255) DEFAULT NULL,
+    `message_type` varchar(64) DEFAULT NULL,
+    `level` varchar(64) DEFAULT NULL,
+    `starts_at` datetime DEFAULT NULL,
+    `ends_at` datetime DEFAULT NULL,
+    `comment` varchar(255) DEFAULT NULL,
+    `created_by` varchar(255) DEFAULT NULL,
+    PRIMARY KEY (`id`) USING BTREE,
+    KEY `ends_at` (`ends_at`)
+) ;
+
+CREATE TABLE IF NOT EXISTS `ko_alert` (
+    `id` varchar(64) NOT NULL,
+    `created_at` datetime DEFAULT NULL,
+    `updated_at` datetime DEFAULT NULL,
+    `fingerprint` varchar(64) DEFAULT NULL,
+    `route_id` varchar(64) DEFAULT NULL,
+    `message_id` varchar(64) DEFAULT NULL,
+    `cluster_name` varchar(255) DEFAULT NULL,
+    `project_name` varchar(255) DEFAULT NULL,
+    `message_type` varchar(64) DEFAULT NULL,
+    `level` varchar(64) DEFAULT NULL,
+    `status` varchar(64) DEFAULT NULL,
+    `count` int(11) DEFAULT 0,
+    `first_at` datetime DEFAULT NULL,
+    `last_at` datetime DEFAULT NULL,
+    `last_sent_at` datetime DEFAULT NULL,
+    `escalate_at` datetime DEFAULT NULL,
+    `acked_by` varchar(255) DEFAULT NULL,
+    `acked_at` datetime DEFAULT NULL,
+    PRIMARY KEY (`id`) USING BTREE,
+    UNIQUE KEY `fingerprint` (`fingerprint`),
+    KEY `status` (`status`)
+) ;
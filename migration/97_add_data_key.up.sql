@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `ko_data_key` (
+    `id` varchar(64) NOT NULL,
+    `created_at` datetime DEFAULT NULL,
+    `updated_at` datetime DEFAULT NULL,
+    `version` int(11) NOT NULL,
+    `wrapped_key` text,
+    `status` varchar(64) DEFAULT NULL,
+    PRIMARY KEY (`id`) USING BTREE,
+    UNIQUE KEY `version` (`version`)
+) ;
+
+ALTER TABLE `ko_multi_cluster_repository`
+MODIFY COLUMN `password` text;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS `ko_cluster_certificate` (
+    `id` varchar(64) NOT NULL,
+    `created_at` datetime DEFAULT NULL,
+    `updated_at` datetime DEFAULT NULL,
+    `cluster_id` varchar(64) DEFAULT NULL,
+    `node_name` varchar(255) DEFAULT NULL,
+    `name` varchar(64) DEFAULT NULL,
+    `endpoint` varchar(255) DEFAULT NULL,
+    `subject` varchar(255) DEFAULT NULL,
+    `issuer` varchar(255) DEFAULT NULL,
+    `not_before` datetime DEFAULT NULL,
+    `not_after` datetime DEFAULT NULL,
+    `notified_days` int(11) DEFAULT 0,
+    `message` text,
+    PRIMARY KEY (`id`) USING BTREE,
+    UNIQUE KEY `cluster_node_name` (`cluster_id`, `node_name`, `name`)
+) ;
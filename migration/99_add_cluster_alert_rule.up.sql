@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `ko_cluster_alert_rule_group` (
+    `id` varchar(64) NOT NULL,
+    `created_at` datetime DEFAULT NULL,
+    `updated_at` datetime DEFAULT NULL,
+    `cluster_id` varchar(64) DEFAULT NULL,
+    `name` varchar(255) DEFAULT NULL,
+    `eval_interval` varchar(64) DEFAULT NULL,
+    `rules` mediumtext,
+    `builtin` tinyint(1) DEFAULT 0,
+    PRIMARY KEY (`id`) USING BTREE,
+    UNIQUE KEY `cluster_name` (`cluster_id`, `name`)
+) ;
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/kmpp/pkg/cloud_storage/client"
 	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/util/secret"
 )
 
 var (
@@ -19,6 +20,11 @@ type CloudStorageClient interface {
 }
 
 func NewCloudStorageClient(vars map[string]interface{}) (CloudStorageClient, error) {
+	// 账号中的密钥可以是 Vault 或挂载文件的引用，构造客户端时解析
+	vars, err := secret.ResolveVars(vars)
+	if err != nil {
+		return nil, err
+	}
 	if vars["type"] == constant.Azure {
 		return client.NewAzureClient(vars)
 	}
@@ -0,0 +1,10 @@
+package constant
+
+const (
+	AlertFiring       = "FIRING"
+	AlertAcknowledged = "ACKNOWLEDGED"
+	AlertEscalated    = "ESCALATED"
+)
+
+// AlertDefaultRepeatInterval 未匹配路由或路由未设置时，同一告警重复通知的最小间隔（分钟）
+const AlertDefaultRepeatInterval = 60
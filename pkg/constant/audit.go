@@ -0,0 +1,26 @@
+package constant
+
+const AuditSettingTab = "AUDIT"
+
+// RequestIDHeader 客户端可以自带请求 ID，未携带时由 kmpp 生成并在响应中返回
+const RequestIDHeader = "X-Request-Id"
+
+const (
+	AuditActionCreate = "CREATE"
+	AuditActionUpdate = "UPDATE"
+	AuditActionDelete = "DELETE"
+	AuditActionRead   = "READ"
+	AuditActionLogin  = "LOGIN"
+	AuditActionLogout = "LOGOUT"
+)
+
+const (
+	AuditOutcomeSuccess = "SUCCESS"
+	AuditOutcomeFailure = "FAILURE"
+	AuditOutcomeDenied  = "DENIED"
+)
+
+const (
+	AuditExportJSONLines = "jsonl"
+	AuditExportCSV       = "csv"
+)
@@ -16,6 +16,7 @@ const (
 	ClusterLogTypeBackup     = "CLUSTER_BACKUP"
 	ClusterLogTypeRestore    = "CLUSTER_RESTORE"
 	ClusterLogTypeUpgrade    = "CLUSTER_UPGRADE"
+	ClusterLogTypeRenewCerts = "CLUSTER_RENEW_CERTIFICATES"
 
 	ClusterLogStatusSuccess = "SUCCESS"
 	ClusterLogStatusFailed  = "FAILED"
@@ -34,6 +35,41 @@ const (
 	ConditionFalse   = "False"
 	ConditionUnknown = "Unknown"
 
+	ClusterPhaseTypeCreate  = "create"
+	ClusterPhaseTypeUpgrade = "upgrade"
+
+	ClusterPhaseOperationRetry = "retry"
+	ClusterPhaseOperationSkip  = "skip"
+	ClusterPhaseSkippedMessage = "skipped"
+
+	ClusterDryRunCreate       = "create"
+	ClusterDryRunUpgrade      = "upgrade"
+	ClusterDryRunAddWorker    = "addWorker"
+	ClusterDryRunRemoveWorker = "removeWorker"
+	ClusterDryRunAddMaster    = "addMaster"
+	ClusterDryRunRemoveMaster = "removeMaster"
+	DryRunHostActionCreate    = "create"
+	DryRunHostActionDelete    = "delete"
+
+	ClusterApplyKindSpec           = "spec"
+	ClusterApplyKindNode           = "node"
+	ClusterApplyKindTool           = "tool"
+	ClusterApplyKindIstio          = "istio"
+	ClusterApplyKindProvisioner    = "provisioner"
+	ClusterApplyKindBackupStrategy = "backupStrategy"
+
+	ClusterApplyActionCreate      = "create"
+	ClusterApplyActionUpdate      = "update"
+	ClusterApplyActionDelete      = "delete"
+	ClusterApplyActionEnable      = "enable"
+	ClusterApplyActionDisable     = "disable"
+	ClusterApplyActionUpgrade     = "upgrade"
+	ClusterApplyActionUnsupported = "unsupported"
+
+	ClusterApplyStatusApplied = "applied"
+	ClusterApplyStatusFailed  = "failed"
+	ClusterApplyStatusPending = "pending"
+
 	NodeRoleNameMaster = "master"
 	NodeRoleNameWorker = "worker"
 
@@ -44,6 +80,11 @@ const (
 	F5Namespace          = "kube-system"
 	DefaultApiServerPort = 8443
 
+	F5CisDeploymentName     = "k8s-bigip-ctlr"
+	F5CisServiceAccountName = "bigip-ctlr"
+	F5CisSecretName         = "bigip-login"
+	F5CisVersion            = "2.3.0"
+
 	DefaultIngress            = "apps.ko.com"
 	DefaultPrometheusIngress  = "prometheus." + DefaultIngress
 	DefaultLoggingIngress     = "logging." + DefaultIngress
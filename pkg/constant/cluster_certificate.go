@@ -0,0 +1,11 @@
+package constant
+
+const (
+	CertificateApiServer = "apiserver"
+	CertificateEtcd      = "etcd"
+	CertificateKubelet   = "kubelet"
+
+	DefaultKubeApiServerPort = 6443
+	EtcdClientPort           = 2379
+	KubeletPort              = 10250
+)
@@ -0,0 +1,6 @@
+package constant
+
+const (
+	DataKeyActive   = "ACTIVE"
+	DataKeyInactive = "INACTIVE"
+)
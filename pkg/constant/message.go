@@ -19,16 +19,21 @@ const (
 
 //message type
 const (
-	ClusterInstall      = "CLUSTER_INSTALL"
-	ClusterUnInstall    = "CLUSTER_UN_INSTALL"
-	ClusterUpgrade      = "CLUSTER_UPGRADE"
-	ClusterDelete       = "CLUSTER_DELETE"
-	ClusterScale        = "CLUSTER_SCALE"
-	ClusterAddWorker    = "CLUSTER_ADD_WORKER"
-	ClusterRemoveWorker = "CLUSTER_REMOVE_WORKER"
-	ClusterRestore      = "CLUSTER_RESTORE"
-	ClusterBackup       = "CLUSTER_BACKUP"
-	ClusterEventWarning = "CLUSTER_EVENT_WARNING"
+	ClusterInstall         = "CLUSTER_INSTALL"
+	ClusterUnInstall       = "CLUSTER_UN_INSTALL"
+	ClusterUpgrade         = "CLUSTER_UPGRADE"
+	ClusterDelete          = "CLUSTER_DELETE"
+	ClusterScale           = "CLUSTER_SCALE"
+	ClusterAddWorker       = "CLUSTER_ADD_WORKER"
+	ClusterRemoveWorker    = "CLUSTER_REMOVE_WORKER"
+	ClusterRestore         = "CLUSTER_RESTORE"
+	ClusterBackup          = "CLUSTER_BACKUP"
+	ClusterEventWarning    = "CLUSTER_EVENT_WARNING"
+	ClusterRepointRegistry = "CLUSTER_REPOINT_REGISTRY"
+	ClusterBackupVerify    = "CLUSTER_BACKUP_VERIFY"
+	ClusterHealth          = "CLUSTER_HEALTH"
+	ClusterCertExpiry      = "CLUSTER_CERTIFICATE_EXPIRY"
+	ClusterRenewCerts      = "CLUSTER_RENEW_CERTIFICATES"
 )
 
 //message level
@@ -36,3 +41,11 @@ const (
 	MsgWarning = "Warning"
 	MsgInfo    = "Info"
 )
+
+//webhook payload format
+const (
+	Webhook            = "WEBHOOK"
+	WebhookFormatJSON  = "json"
+	WebhookFormatSlack = "slack"
+	WebhookFormatTeams = "teams"
+)
@@ -0,0 +1,10 @@
+package constant
+
+const (
+	// 拉取 git 仓库的默认超时时间，单位秒
+	DefaultMultiClusterGitTimeout = 60
+	// 默认同步间隔，单位分钟
+	DefaultMultiClusterSyncInterval = 5
+	// 同步状态在 git 超时时间之外再保持 Running 超过该时长，视为进程异常退出遗留的状态，单位分钟
+	MultiClusterSyncStaleMargin = 30
+)
@@ -0,0 +1,46 @@
+package constant
+
+const (
+	DefaultAlertmanagerImageName = "prom/alertmanager"
+	DefaultAlertmanagerImageTag  = "v0.21.0"
+
+	AlertmanagerSecretName      = "kmpp-alertmanager"
+	AlertmanagerConfigKey       = "alertmanager.yml"
+	PrometheusRulesConfigMap    = "kmpp-prometheus-rules"
+	PrometheusRulesKey          = "kmpp-rules.yml"
+	PrometheusRulesMountPath    = "/etc/kmpp-rules"
+	PrometheusAlert             = "PROMETHEUS_ALERT"
+	AlertmanagerReceiverDefault = "kmpp-default"
+)
+
+const (
+	// PrometheusStackStandalone 单独部署的 prometheus chart，PrometheusStackKube 为 kube-prometheus-stack
+	PrometheusStackStandalone = "prometheus"
+	PrometheusStackKube       = "kube-prometheus-stack"
+
+	KubePrometheusStackChartName           = "nexus/kube-prometheus-stack"
+	DefaultKubePrometheusStackChartVersion = "16.12.0"
+	KubePrometheusStackServiceName         = "prometheus-prometheus"
+	KubePrometheusStackServicePort         = 9090
+	KubePrometheusStackOperatorName        = "prometheus-operator"
+	// KubePrometheusStackFullname chart 资源名前缀，Prometheus 服务即为 prometheus-prometheus
+	KubePrometheusStackFullname = "prometheus"
+	// KubePrometheusStackAlertmanagerSecret 开启 useExistingSecret 后 operator 读取的 Alertmanager 配置
+	KubePrometheusStackAlertmanagerSecret = "alertmanager-prometheus-alertmanager"
+	KubePrometheusStackAlertmanagerKey    = "alertmanager.yaml"
+
+	// RemoteWriteCentral 写入 kmpp 配置的中心存储，RemoteWriteCustom 写入用户指定的地址
+	RemoteWriteCentral    = "central"
+	RemoteWriteCustom     = "custom"
+	RemoteWriteSecretName = "kmpp-remote-write"
+	RemoteWriteMountPath  = "/etc/kmpp-remote-write"
+	// RemoteWriteVarPrefix 远端写入配置只由 kmpp 使用，不作为 chart 参数下发
+	RemoteWriteVarPrefix = "remoteWrite."
+	// RemoteWritePasswordVar 自定义远端写入的密码，保存时移到工具的 secret 列
+	RemoteWritePasswordVar = "remoteWrite.password"
+	// PrometheusClusterLabel 写入中心存储的样本通过该标签区分集群
+	PrometheusClusterLabel = "cluster"
+	// GrafanaCentralSecretName Grafana 通过环境变量读取中心存储的密码，不写入数据源配置
+	GrafanaCentralSecretName  = "kmpp-grafana-central"
+	GrafanaCentralPasswordEnv = "KMPP_CENTRAL_PASSWORD"
+)
@@ -9,6 +9,8 @@ const (
 	RoleAdmin          = "ADMIN"
 	RoleProjectManager = "PROJECT_MANAGER"
 	RoleClusterManager = "CLUSTER_MANAGER"
+	// RoleProjectViewer 项目只读成员，只能查看集群，不能读取集群凭据或打开 kubectl
+	RoleProjectViewer = "PROJECT_VIEWER"
 )
 
 const (
@@ -19,6 +21,22 @@ const (
 const (
 	ProjectRoleProjectManager = "PROJECT_MANAGER"
 	ProjectRoleClusterManager = "CLUSTER_MANAGER"
+	ProjectRoleProjectViewer  = "PROJECT_VIEWER"
+)
+
+// 通过 API 代理访问集群时模拟的 Kubernetes 用户前缀及用户组
+const (
+	KubernetesUserPrefix          = "kmpp:"
+	KubernetesGroupAdmin          = "kmpp:admins"
+	KubernetesGroupClusterManager = "kmpp:cluster-managers"
+	KubernetesGroupViewer         = "kmpp:viewers"
+)
+
+// 自定义角色绑定的作用范围
+const (
+	RoleBindingScopeSystem  = "SYSTEM"
+	RoleBindingScopeProject = "PROJECT"
+	RoleBindingScopeCluster = "CLUSTER"
 )
 
 var Roles = loader.AdvancedRules{
@@ -30,7 +48,7 @@ var Roles = loader.AdvancedRules{
 		},
 		Method: []string{"GET"},
 		Permission: &grbac.Permission{
-			AuthorizedRoles: []string{RoleAdmin, RoleProjectManager, RoleClusterManager},
+			AuthorizedRoles: []string{RoleAdmin, RoleProjectManager, RoleClusterManager, RoleProjectViewer},
 			AllowAnyone:     false,
 		},
 	},
@@ -45,6 +63,49 @@ var Roles = loader.AdvancedRules{
 			AllowAnyone:     false,
 		},
 	},
+	{
+		Host: []string{"*"},
+		Path: []string{
+			"/api/v1/tokens",
+			"/api/v1/tokens/{**}",
+			"/api/v1/mfa",
+			"/api/v1/mfa/{enroll,activate,disable}",
+			"/api/v1/mfa/recovery/codes",
+		},
+		Method: []string{"GET", "POST", "DELETE"},
+		Permission: &grbac.Permission{
+			AuthorizedRoles: []string{RoleAdmin, RoleProjectManager, RoleClusterManager},
+			AllowAnyone:     false,
+		},
+	},
+	{
+		Host: []string{"*"},
+		Path: []string{
+			"/api/v1/serviceaccounts",
+			"/api/v1/serviceaccounts/{**}",
+			"/api/v1/serviceaccounts/{**}/tokens",
+			"/api/v1/serviceaccounts/{**}/tokens/{**}",
+			"/api/v1/mfa/reset/{**}",
+			"/api/v1/roles",
+			"/api/v1/roles/{**}",
+			"/api/v1/roles/{**}/bindings",
+			"/api/v1/roles/{**}/bindings/{**}",
+			"/api/v1/audits/{search,export,verify}",
+			"/api/v1/alerts",
+			"/api/v1/alerts/ack/{**}",
+			"/api/v1/alerts/routes",
+			"/api/v1/alerts/routes/{**}",
+			"/api/v1/alerts/silences",
+			"/api/v1/alerts/silences/{**}",
+			"/api/v1/datakeys",
+			"/api/v1/datakeys/{rotate,reencrypt}",
+		},
+		Method: []string{"GET", "POST", "DELETE", "PATCH"},
+		Permission: &grbac.Permission{
+			AuthorizedRoles: []string{RoleAdmin},
+			AllowAnyone:     false,
+		},
+	},
 	{
 		Host: []string{"*"},
 		Path: []string{
@@ -116,7 +177,7 @@ var Roles = loader.AdvancedRules{
 		},
 		Method: []string{"GET"},
 		Permission: &grbac.Permission{
-			AuthorizedRoles: []string{RoleAdmin, RoleProjectManager, RoleClusterManager},
+			AuthorizedRoles: []string{RoleAdmin, RoleProjectManager, RoleClusterManager, RoleProjectViewer},
 			AllowAnyone:     false,
 		},
 	},
@@ -148,7 +209,7 @@ var Roles = loader.AdvancedRules{
 		},
 		Method: []string{"GET"},
 		Permission: &grbac.Permission{
-			AuthorizedRoles: []string{RoleAdmin, RoleProjectManager, RoleClusterManager},
+			AuthorizedRoles: []string{RoleAdmin, RoleProjectManager, RoleClusterManager, RoleProjectViewer},
 			AllowAnyone:     false,
 		},
 	},
@@ -289,4 +350,50 @@ var Roles = loader.AdvancedRules{
 			AllowAnyone:     false,
 		},
 	},
+	// 以下规则放在最后，同一请求命中多条规则时以后声明的为准
+	// 项目只读成员只能查看集群和项目，其余角色的权限与上面的规则相同
+	{
+		Host: []string{"*"},
+		Path: []string{
+			"/api/v1/clusters",
+			"/api/v1/clusters/{**}",
+			"/api/v1/clusters/{status,phases,certificates,provisioner,tool,node,log,health,existence,name,cis,logger,backupaccounts}/{**}",
+			"/api/v1/clusters/node/detail/{**}/{**}",
+			"/api/v1/clusters/provisioner/log/{**}/{**}",
+			"/api/v1/clusters/{events,istio,f5,backup}/{**}",
+			"/api/v1/clusters/{events,istio,f5,backup}/{**}/{**}",
+			"/api/v1/clusters/{**}/alertrules",
+			"/api/v1/message/{**}",
+			"/api/v1/message/{**}/{**}",
+		},
+		Method: []string{"GET"},
+		Permission: &grbac.Permission{
+			AuthorizedRoles: []string{RoleAdmin, RoleProjectManager, RoleClusterManager, RoleProjectViewer},
+			AllowAnyone:     false,
+		},
+	},
+	{
+		Host: []string{"*"},
+		Path: []string{
+			"/api/v1/projects/{**}",
+			"/api/v1/projects/{**}/{resources,members}",
+		},
+		Method: []string{"GET"},
+		Permission: &grbac.Permission{
+			AuthorizedRoles: []string{RoleAdmin, RoleProjectManager, RoleProjectViewer},
+			AllowAnyone:     false,
+		},
+	},
+	// 集群凭据、webkubectl 和 kubeconfig 具有集群管理员权限，只读成员不能访问
+	{
+		Host: []string{"*"},
+		Path: []string{
+			"/api/v1/clusters/{secret,webkubectl,kubeconfig}/{**}",
+		},
+		Method: []string{"GET"},
+		Permission: &grbac.Permission{
+			AuthorizedRoles: []string{RoleAdmin, RoleProjectManager, RoleClusterManager},
+			AllowAnyone:     false,
+		},
+	},
 }
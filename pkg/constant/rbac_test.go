@@ -0,0 +1,52 @@
+package constant
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/storyicon/grbac"
+)
+
+func TestRolesProjectViewer(t *testing.T) {
+	r, err := grbac.New(grbac.WithAdvancedRules(Roles))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		method, path string
+		granted      bool
+	}{
+		{"GET", "/api/v1/clusters", true},
+		{"GET", "/api/v1/clusters/test", true},
+		{"GET", "/api/v1/clusters/status/test", true},
+		{"GET", "/api/v1/clusters/node/test", true},
+		{"GET", "/api/v1/projects/demo", true},
+		{"GET", "/api/v1/projects/demo/resources", true},
+		{"GET", "/api/v1/clusters/secret/test", false},
+		{"GET", "/api/v1/clusters/webkubectl/test", false},
+		{"GET", "/api/v1/clusters/kubeconfig/test", false},
+		{"DELETE", "/api/v1/clusters/test", false},
+		{"POST", "/api/v1/clusters/node/batch/test", false},
+		{"POST", "/api/v1/clusters", false},
+		{"DELETE", "/api/v1/projects/demo", false},
+		{"GET", "/api/v1/settings", false},
+	}
+	for _, c := range cases {
+		state, err := r.IsRequestGranted(httptest.NewRequest(c.method, c.path, nil), []string{RoleProjectViewer})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state.IsGranted() != c.granted {
+			t.Errorf("viewer %s %s: expected granted=%v, got %s", c.method, c.path, c.granted, state)
+		}
+	}
+	// 只读规则不能收窄其他角色原有的权限
+	for _, role := range []string{RoleProjectManager, RoleClusterManager} {
+		for _, path := range []string{"/api/v1/clusters/status/test", "/api/v1/clusters/secret/test", "/api/v1/clusters/webkubectl/test", "/api/v1/clusters/kubeconfig/test"} {
+			state, _ := r.IsRequestGranted(httptest.NewRequest("GET", path, nil), []string{role})
+			if !state.IsGranted() {
+				t.Errorf("%s GET %s should be granted", role, path)
+			}
+		}
+	}
+}
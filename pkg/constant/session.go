@@ -8,6 +8,10 @@ import (
 const (
 	SessionUserKey         = "user"
 	CookieNameForSessionID = "ksession"
+	SessionSsoStateKey     = "sso_state"
+	SessionSsoNonceKey     = "sso_nonce"
+	SessionSsoVerifierKey  = "sso_verifier"
+	SessionSsoMfaKey       = "sso_mfa"
 
 	AuthMethodSession = "session"
 	AuthMethodJWT     = "jwt"
@@ -38,8 +38,32 @@ const (
 	HEALTH_CHECK    = "集群健康检查|Health check"
 	HEALTH_RECOVER  = "集群健康恢复|Health recover"
 
-	CREATE_CLUSTER_NODE = "添加集群节点|Create cluster node"
-	DELETE_CLUSTER_NODE = "删除集群节点|Delete cluster node"
+	CHECK_CLUSTER_CERTIFICATES = "检查集群证书有效期|Check cluster certificates"
+	RENEW_CLUSTER_CERTIFICATES = "更新集群证书|Renew cluster certificates"
+
+	CREATE_CLUSTER_ALERT_RULE          = "添加集群告警规则|Create cluster alert rule"
+	UPDATE_CLUSTER_ALERT_RULE          = "修改集群告警规则|Update cluster alert rule"
+	DELETE_CLUSTER_ALERT_RULE          = "删除集群告警规则|Delete cluster alert rule"
+	INSTALL_CLUSTER_BUILTIN_ALERT_RULE = "安装集群内置告警规则|Install cluster builtin alert rules"
+	APPLY_CLUSTER_ALERT_RULE           = "下发集群告警配置|Apply cluster alert config"
+
+	RETRY_CLUSTER_PHASE = "从指定阶段重试集群安装|Retry cluster installation from phase"
+	SKIP_CLUSTER_PHASE  = "跳过集群安装阶段|Skip cluster installation phase"
+	APPLY_CLUSTER       = "声明式更新集群|Apply cluster declaration"
+
+	CREATE_CLUSTER_NODE    = "添加集群节点|Create cluster node"
+	DELETE_CLUSTER_NODE    = "删除集群节点|Delete cluster node"
+	REPLACE_CLUSTER_MASTER = "替换集群 master 节点|Replace cluster master node"
+
+	REPOINT_CLUSTER_REGISTRY = "切换集群仓库|Repoint cluster registry"
+	SYNC_CLUSTER_RBAC        = "同步集群权限绑定|Sync cluster RBAC bindings"
+
+	CREATE_MULTI_CLUSTER_REPOSITORY = "添加多集群仓库|Create multi cluster repository"
+	UPDATE_MULTI_CLUSTER_REPOSITORY = "更新多集群仓库|Update multi cluster repository"
+	DELETE_MULTI_CLUSTER_REPOSITORY = "删除多集群仓库|Delete multi cluster repository"
+	BIND_MULTI_CLUSTER_REPOSITORY   = "多集群仓库关联集群|Bind clusters to multi cluster repository"
+	UNBIND_MULTI_CLUSTER_REPOSITORY = "多集群仓库解除关联集群|Unbind clusters from multi cluster repository"
+	SYNC_MULTI_CLUSTER_REPOSITORY   = "同步多集群仓库|Sync multi cluster repository"
 
 	CREATE_CLUSTER_STORAGE_SUPPLIER = "添加集群存储供应商|Create cluster storage vendor"
 	DELETE_CLUSTER_STORAGE_SUPPLIER = "删除集群存储供应商|Delete cluster storage vendor"
@@ -47,6 +71,10 @@ const (
 	CREATE_CLUSTER_PVC              = "添加集群持久卷|Create cluster pvc"
 	DELETE_CLUSTER_PVC              = "删除集群持久卷|Delete cluster pvc"
 
+	CREATE_CLUSTER_F5 = "添加集群 F5 配置|Create cluster F5 setting"
+	UPDATE_CLUSTER_F5 = "更新集群 F5 配置|Update cluster F5 setting"
+	DELETE_CLUSTER_F5 = "删除集群 F5 配置|Delete cluster F5 setting"
+
 	ENABLE_CLUSTER_NPD  = "启用NPD|Enable cluster NPD"
 	DISABLE_CLUSTER_NPD = "关闭NPD|Disable cluster NPD"
 
@@ -62,6 +90,7 @@ const (
 	DELETE_CLUSTER_NAMESPACE       = "删除命名空间|Delete cluster namespace"
 	CREATE_CLUSTER_BACKUP_STRATEGY = "添加集群备份策略|Create cluster backup strategy"
 	START_CLUSTER_BACKUP           = "开始备份|Start cluster backup"
+	VERIFY_CLUSTER_BACKUP          = "校验集群备份|Verify cluster backup"
 	UPLOAD_LOCAL_RECOVERY_FILE     = "上传本地恢复文件|Upload local recovery file"
 	DELETE_RECOVERY_LIST           = "删除备份文件|Delete backup files"
 	RECOVER_FROM_RECOVERY          = "从备份列表恢复|Restore from backup list"
@@ -98,6 +127,27 @@ const (
 	DELETE_USER          = "删除用户|Delete user"
 	FORGOT_USER_PASSWORD = "忘记密码|forgot password"
 
+	CREATE_API_TOKEN       = "创建 API Token|Create api token"
+	DELETE_API_TOKEN       = "吊销 API Token|Revoke api token"
+	CREATE_SERVICE_ACCOUNT = "创建服务账号|Create service account"
+	DELETE_SERVICE_ACCOUNT = "删除服务账号|Delete service account"
+	ENABLE_USER_MFA        = "启用二次认证|Enable MFA"
+	DISABLE_USER_MFA       = "关闭二次认证|Disable MFA"
+	RESET_USER_MFA         = "重置用户二次认证|Reset user MFA"
+	CREATE_ROLE            = "添加自定义角色|Create role"
+	UPDATE_ROLE            = "修改自定义角色|Update role"
+	DELETE_ROLE            = "删除自定义角色|Delete role"
+	CREATE_ROLE_BINDING    = "授予自定义角色|Create role binding"
+	DELETE_ROLE_BINDING    = "撤销自定义角色|Delete role binding"
+	CREATE_ALERT_ROUTE     = "添加告警路由|Create alert route"
+	UPDATE_ALERT_ROUTE     = "修改告警路由|Update alert route"
+	DELETE_ALERT_ROUTE     = "删除告警路由|Delete alert route"
+	CREATE_ALERT_SILENCE   = "添加告警静默|Create alert silence"
+	DELETE_ALERT_SILENCE   = "删除告警静默|Delete alert silence"
+	ACK_ALERT              = "确认告警|Acknowledge alert"
+	ROTATE_DATA_KEY        = "轮换数据密钥|Rotate data key"
+	REENCRYPT_DATA_KEY     = "重新加密敏感数据|Reencrypt secrets"
+
 	// 版本
 	ENABLE_VERSION  = "启用ko版本|Enable ko version"
 	DISABLE_VERSION = "停用ko版本|Disable ko version"
@@ -114,4 +164,5 @@ const (
 	DELETE_BACKUP_ACCOUNT = "删除备份账号|Delete backup account"
 	CREATE_EMAIL          = "设置系统配置|Set system config"
 	IMPORT_LICENCE        = "导入许可证书|import licence"
+	SYNC_LDAP             = "同步 LDAP 用户|Sync LDAP users"
 )
@@ -6,3 +6,8 @@ const (
 	ArchitectureOfAMD64 = "x86_64"
 	ArchitectureOfARM64 = "aarch64"
 )
+
+const (
+	RegistryStatusHealthy   = "Healthy"
+	RegistryStatusUnhealthy = "Unhealthy"
+)
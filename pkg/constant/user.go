@@ -1,11 +1,38 @@
 package constant
 
+import "time"
+
 const (
 	Local = "LOCAL"
 	Ldap  = "LDAP"
+	Oidc  = "OIDC"
+	// 服务账号不能通过密码登录，只能使用 API Token 访问
+	ServiceAccount = "SERVICE_ACCOUNT"
+)
+
+const (
+	ApiTokenPrefix     = "kmpp_"
+	ApiTokenScopeRead  = "read"
+	ApiTokenScopeWrite = "write"
 )
 
 const (
 	UserStatusActive  = "active"
 	UserStatusPassive = "passive"
 )
+
+// OIDC 单点登录配置保存在系统设置的该分组中
+const OidcSettingTab = "OIDC"
+
+// 二次认证配置保存在系统设置的该分组中，mfa_admin_required 为 ENABLE 时管理员必须启用二次认证
+const (
+	MfaSettingTab         = "MFA"
+	MfaAdminRequiredKey   = "mfa_admin_required"
+	MfaIssuer             = "KubeOperator"
+	MfaRecoveryCodeNumber = 10
+	// 同一个待验证 Token 失败次数达到上限后失效，需要重新登录
+	MfaMaxChallengeFailures = 5
+	// 用户连续失败次数达到上限后锁定一段时间
+	MfaMaxUserFailures = 10
+	MfaLockDuration    = 15 * time.Minute
+)
@@ -0,0 +1,231 @@
+package controller
+
+import (
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/controller/kolog"
+	"github.com/kmpp/pkg/controller/page"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/service"
+	"github.com/go-playground/validator/v10"
+	"github.com/kataras/iris/v12/context"
+)
+
+type AlertController struct {
+	Ctx          context.Context
+	AlertService service.AlertService
+}
+
+func NewAlertController() *AlertController {
+	return &AlertController{
+		AlertService: service.NewAlertService(),
+	}
+}
+
+// List Alert
+// @Tags alerts
+// @Summary Show alerts
+// @Description 分页获取告警，可按状态和集群过滤
+// @Accept  json
+// @Produce  json
+// @Param status query string false "FIRING, ACKNOWLEDGED 或 ESCALATED"
+// @Param clusterName query string false "集群名称"
+// @Success 200 {object} page.Page
+// @Security ApiKeyAuth
+// @Router /alerts/ [get]
+func (a AlertController) Get() (*page.Page, error) {
+	num, _ := a.Ctx.Values().GetInt(constant.PageNumQueryKey)
+	size, _ := a.Ctx.Values().GetInt(constant.PageSizeQueryKey)
+	if num <= 0 || size <= 0 {
+		num, size = 1, 10
+	}
+	return a.AlertService.Page(num, size, a.Ctx.URLParam("status"), a.Ctx.URLParam("clusterName"))
+}
+
+// Acknowledge Alert
+// @Tags alerts
+// @Summary Acknowledge an alert
+// @Description 确认告警，确认后不再重复通知和升级
+// @Accept  json
+// @Produce  json
+// @Param id path string true "告警 ID"
+// @Success 200 {object} dto.Alert
+// @Security ApiKeyAuth
+// @Router /alerts/ack/{id}/ [post]
+func (a AlertController) PostAckBy(id string) (*dto.Alert, error) {
+	operator := a.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.ACK_ALERT, id)
+	return a.AlertService.Ack(id, operator)
+}
+
+type AlertRouteController struct {
+	Ctx          context.Context
+	AlertService service.AlertService
+}
+
+func NewAlertRouteController() *AlertRouteController {
+	return &AlertRouteController{
+		AlertService: service.NewAlertService(),
+	}
+}
+
+// List AlertRoute
+// @Tags alerts
+// @Summary Show alert routes
+// @Description 按优先级获取告警路由列表
+// @Accept  json
+// @Produce  json
+// @Success 200 {Array} dto.AlertRoute
+// @Security ApiKeyAuth
+// @Router /alerts/routes/ [get]
+func (a AlertRouteController) Get() ([]dto.AlertRoute, error) {
+	return a.AlertService.ListRoutes()
+}
+
+// Get AlertRoute
+// @Tags alerts
+// @Summary Show an alert route
+// @Description 获取单个告警路由
+// @Accept  json
+// @Produce  json
+// @Param name path string true "路由名称"
+// @Success 200 {object} dto.AlertRoute
+// @Security ApiKeyAuth
+// @Router /alerts/routes/{name}/ [get]
+func (a AlertRouteController) GetBy(name string) (*dto.AlertRoute, error) {
+	return a.AlertService.GetRoute(name)
+}
+
+// Create AlertRoute
+// @Tags alerts
+// @Summary Create an alert route
+// @Description 创建告警路由，按集群、项目、消息类型和级别匹配消息并发送给指定用户
+// @Accept  json
+// @Produce  json
+// @Param request body dto.AlertRouteCreate true "request"
+// @Success 200 {object} dto.AlertRoute
+// @Security ApiKeyAuth
+// @Router /alerts/routes/ [post]
+func (a AlertRouteController) Post() (*dto.AlertRoute, error) {
+	var req dto.AlertRouteCreate
+	if err := a.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+	operator := a.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.CREATE_ALERT_ROUTE, req.Name)
+	return a.AlertService.CreateRoute(req)
+}
+
+// Update AlertRoute
+// @Tags alerts
+// @Summary Update an alert route
+// @Description 更新告警路由
+// @Accept  json
+// @Produce  json
+// @Param name path string true "路由名称"
+// @Param request body dto.AlertRouteUpdate true "request"
+// @Success 200 {object} dto.AlertRoute
+// @Security ApiKeyAuth
+// @Router /alerts/routes/{name}/ [patch]
+func (a AlertRouteController) PatchBy(name string) (*dto.AlertRoute, error) {
+	var req dto.AlertRouteUpdate
+	if err := a.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+	if before, err := a.AlertService.GetRoute(name); err == nil {
+		kolog.AuditBefore(a.Ctx, before)
+	}
+	operator := a.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.UPDATE_ALERT_ROUTE, name)
+	return a.AlertService.UpdateRoute(name, req)
+}
+
+// Delete AlertRoute
+// @Tags alerts
+// @Summary Delete an alert route
+// @Description 删除告警路由
+// @Accept  json
+// @Produce  json
+// @Param name path string true "路由名称"
+// @Security ApiKeyAuth
+// @Router /alerts/routes/{name}/ [delete]
+func (a AlertRouteController) DeleteBy(name string) error {
+	if before, err := a.AlertService.GetRoute(name); err == nil {
+		kolog.AuditBefore(a.Ctx, before)
+	}
+	operator := a.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.DELETE_ALERT_ROUTE, name)
+	return a.AlertService.DeleteRoute(name)
+}
+
+type AlertSilenceController struct {
+	Ctx          context.Context
+	AlertService service.AlertService
+}
+
+func NewAlertSilenceController() *AlertSilenceController {
+	return &AlertSilenceController{
+		AlertService: service.NewAlertService(),
+	}
+}
+
+// List AlertSilence
+// @Tags alerts
+// @Summary Show alert silences
+// @Description 获取未过期的告警静默，all=true 时包含已过期的静默
+// @Accept  json
+// @Produce  json
+// @Param all query bool false "是否包含已过期的静默"
+// @Success 200 {Array} dto.AlertSilence
+// @Security ApiKeyAuth
+// @Router /alerts/silences/ [get]
+func (a AlertSilenceController) Get() ([]dto.AlertSilence, error) {
+	all, _ := a.Ctx.URLParamBool("all")
+	return a.AlertService.ListSilences(all)
+}
+
+// Create AlertSilence
+// @Tags alerts
+// @Summary Create an alert silence
+// @Description 在时间窗口内屏蔽匹配的消息，startsAt 为空时立即生效
+// @Accept  json
+// @Produce  json
+// @Param request body dto.AlertSilenceCreate true "request"
+// @Success 200 {object} dto.AlertSilence
+// @Security ApiKeyAuth
+// @Router /alerts/silences/ [post]
+func (a AlertSilenceController) Post() (*dto.AlertSilence, error) {
+	var req dto.AlertSilenceCreate
+	if err := a.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+	operator := a.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.CREATE_ALERT_SILENCE, req.Comment)
+	return a.AlertService.CreateSilence(req, operator)
+}
+
+// Delete AlertSilence
+// @Tags alerts
+// @Summary Delete an alert silence
+// @Description 删除告警静默
+// @Accept  json
+// @Produce  json
+// @Param id path string true "静默 ID"
+// @Security ApiKeyAuth
+// @Router /alerts/silences/{id}/ [delete]
+func (a AlertSilenceController) DeleteBy(id string) error {
+	operator := a.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.DELETE_ALERT_SILENCE, id)
+	return a.AlertService.DeleteSilence(id)
+}
@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/controller/kolog"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/service"
+	"github.com/go-playground/validator/v10"
+	"github.com/kataras/iris/v12/context"
+)
+
+type ApiTokenController struct {
+	Ctx             context.Context
+	ApiTokenService service.ApiTokenService
+}
+
+func NewApiTokenController() *ApiTokenController {
+	return &ApiTokenController{
+		ApiTokenService: service.NewApiTokenService(),
+	}
+}
+
+// List ApiToken
+// @Tags tokens
+// @Summary Show api tokens of current user
+// @Description 获取当前用户的 API Token 列表
+// @Accept  json
+// @Produce  json
+// @Success 200 {Array} dto.ApiToken
+// @Security ApiKeyAuth
+// @Router /tokens/ [get]
+func (a ApiTokenController) Get() ([]dto.ApiToken, error) {
+	return a.ApiTokenService.List(a.Ctx.Values().GetString("operator"))
+}
+
+// Create ApiToken
+// @Tags tokens
+// @Summary Create an api token for current user
+// @Description 创建 API Token，Token 明文只在创建时返回一次
+// @Accept  json
+// @Produce  json
+// @Param request body dto.ApiTokenCreate true "request"
+// @Success 200 {object} dto.ApiTokenCreated
+// @Security ApiKeyAuth
+// @Router /tokens/ [post]
+func (a ApiTokenController) Post() (*dto.ApiTokenCreated, error) {
+	var req dto.ApiTokenCreate
+	if err := a.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+	operator := a.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.CREATE_API_TOKEN, req.Name)
+	return a.ApiTokenService.Create(operator, req)
+}
+
+// Delete ApiToken
+// @Tags tokens
+// @Summary Revoke an api token of current user
+// @Description 吊销 API Token
+// @Accept  json
+// @Produce  json
+// @Param name path string true "Token 名称"
+// @Security ApiKeyAuth
+// @Router /tokens/{name}/ [delete]
+func (a ApiTokenController) DeleteBy(name string) error {
+	operator := a.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.DELETE_API_TOKEN, name)
+	return a.ApiTokenService.Delete(operator, name)
+}
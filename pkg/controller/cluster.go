@@ -14,6 +14,7 @@ import (
 	"github.com/kmpp/pkg/dto"
 	"github.com/kmpp/pkg/service"
 	"github.com/kmpp/pkg/util/ansible"
+	"github.com/ghodss/yaml"
 	"github.com/kataras/iris/v12/context"
 )
 
@@ -30,6 +31,11 @@ type ClusterController struct {
 	ClusterUpgradeService            service.ClusterUpgradeService
 	ClusterHealthService             service.ClusterHealthService
 	BackupAccountService             service.BackupAccountService
+	ClusterPhaseService              service.ClusterPhaseService
+	ClusterDryRunService             service.ClusterDryRunService
+	ClusterApplyService              service.ClusterApplyService
+	ClusterRbacService               service.ClusterRbacService
+	ClusterCertificateService        service.ClusterCertificateService
 }
 
 func NewClusterController() *ClusterController {
@@ -45,6 +51,11 @@ func NewClusterController() *ClusterController {
 		ClusterUpgradeService:            service.NewClusterUpgradeService(),
 		ClusterHealthService:             service.NewClusterHealthService(),
 		BackupAccountService:             service.NewBackupAccountService(),
+		ClusterPhaseService:              service.NewClusterPhaseService(),
+		ClusterDryRunService:             service.NewClusterDryRunService(),
+		ClusterApplyService:              service.NewClusterApplyService(),
+		ClusterRbacService:               service.NewClusterRbacService(),
+		ClusterCertificateService:        service.NewClusterCertificateService(),
 	}
 }
 
@@ -154,6 +165,120 @@ func (c ClusterController) GetStatusBy(name string) (*dto.ClusterStatus, error)
 	return &cs, nil
 }
 
+// List Cluster Phases
+// @Tags clusters
+// @Summary Show the phases of a cluster
+// @Description Show the install or upgrade phases of a cluster in execution order
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.ClusterPhases
+// @Security ApiKeyAuth
+// @Router /clusters/phases/{name} [get]
+func (c ClusterController) GetPhasesBy(name string) (*dto.ClusterPhases, error) {
+	return c.ClusterPhaseService.List(name)
+}
+
+// Retry Or Skip Cluster Phase
+// @Tags clusters
+// @Summary Retry from or skip a phase of a failed cluster
+// @Description Retry the installation of a failed cluster from a phase, or skip the failed phase
+// @Param request body dto.ClusterPhaseOperation true "request"
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Router /clusters/phases/{name} [post]
+func (c ClusterController) PostPhasesBy(name string) error {
+	var req dto.ClusterPhaseOperation
+	if err := c.Ctx.ReadJSON(&req); err != nil {
+		return err
+	}
+	if err := c.ClusterPhaseService.Operate(name, req); err != nil {
+		return err
+	}
+	operator := c.Ctx.Values().GetString("operator")
+	if req.Operation == constant.ClusterPhaseOperationSkip {
+		go kolog.Save(operator, constant.SKIP_CLUSTER_PHASE, name+"-"+req.Phase)
+	} else {
+		go kolog.Save(operator, constant.RETRY_CLUSTER_PHASE, name+"-"+req.Phase)
+	}
+	return nil
+}
+
+// Repoint Cluster Registry
+// @Tags clusters
+// @Summary Repoint cluster nodes to the active registry
+// @Description 将集群节点的软件源和镜像仓库切换到当前可用的仓库
+// @Param name path string true "集群名称"
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Router /clusters/registry/repoint/{name} [post]
+func (c ClusterController) PostRegistryRepointBy(name string) error {
+	operator := c.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.REPOINT_CLUSTER_REGISTRY, name)
+	return c.ClusterNodeService.RepointRegistry(name)
+}
+
+// Sync Cluster Rbac
+// @Tags clusters
+// @Summary Sync kmpp ClusterRoleBindings into the cluster
+// @Description 同步 kmpp 用户组对应的 ClusterRoleBinding 到集群
+// @Param name path string true "集群名称"
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Router /clusters/rbac/sync/{name} [post]
+func (c ClusterController) PostRbacSyncBy(name string) error {
+	operator := c.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.SYNC_CLUSTER_RBAC, name)
+	return c.ClusterRbacService.Sync(name)
+}
+
+// List Cluster Certificates
+// @Tags clusters
+// @Summary Show certificate expiry of a cluster
+// @Description 获取集群各节点 apiserver、etcd 和 kubelet 证书的有效期
+// @Param name path string true "集群名称"
+// @Accept  json
+// @Produce  json
+// @Success 200 {Array} dto.ClusterCertificate
+// @Security ApiKeyAuth
+// @Router /clusters/certificates/{name} [get]
+func (c ClusterController) GetCertificatesBy(name string) ([]dto.ClusterCertificate, error) {
+	return c.ClusterCertificateService.List(name)
+}
+
+// Check Cluster Certificates
+// @Tags clusters
+// @Summary Read certificate expiry from cluster endpoints
+// @Description 立即从集群端点读取证书有效期，即将过期时发送消息
+// @Param name path string true "集群名称"
+// @Accept  json
+// @Produce  json
+// @Success 200 {Array} dto.ClusterCertificate
+// @Security ApiKeyAuth
+// @Router /clusters/certificates/check/{name} [post]
+func (c ClusterController) PostCertificatesCheckBy(name string) ([]dto.ClusterCertificate, error) {
+	operator := c.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.CHECK_CLUSTER_CERTIFICATES, name)
+	return c.ClusterCertificateService.Check(name)
+}
+
+// Renew Cluster Certificates
+// @Tags clusters
+// @Summary Renew certificates of a cluster
+// @Description 执行证书 playbook 更新集群证书，不升级集群版本
+// @Param name path string true "集群名称"
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Router /clusters/certificates/renew/{name} [post]
+func (c ClusterController) PostCertificatesRenewBy(name string) error {
+	operator := c.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.RENEW_CLUSTER_CERTIFICATES, name)
+	return c.ClusterCertificateService.Renew(name)
+}
+
 // Create Cluster
 // @Tags clusters
 // @Summary Create a cluster
@@ -201,6 +326,100 @@ func (c ClusterController) PostUpgrade() error {
 	return c.ClusterUpgradeService.Upgrade(req)
 }
 
+// Create Cluster Dry Run
+// @Tags clusters
+// @Summary Show the plan of creating a cluster
+// @Description 返回创建集群时的 inventory、变量和执行阶段，不会创建集群
+// @Accept  json
+// @Produce  json
+// @Param request body dto.ClusterCreate true "request"
+// @Success 200 {object} dto.ClusterDryRun
+// @Security ApiKeyAuth
+// @Router /clusters/dryrun [post]
+func (c ClusterController) PostDryrun() (*dto.ClusterDryRun, error) {
+	var req dto.ClusterCreate
+	if err := c.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	return c.ClusterDryRunService.Create(req)
+}
+
+// Upgrade Cluster Dry Run
+// @Tags clusters
+// @Summary Show the plan of upgrading a cluster
+// @Description 返回升级集群时的 inventory、变量和执行阶段，不会升级集群
+// @Accept  json
+// @Produce  json
+// @Param request body dto.ClusterUpgrade true "request"
+// @Success 200 {object} dto.ClusterDryRun
+// @Security ApiKeyAuth
+// @Router /clusters/upgrade/dryrun [post]
+func (c ClusterController) PostUpgradeDryrun() (*dto.ClusterDryRun, error) {
+	var req dto.ClusterUpgrade
+	if err := c.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	return c.ClusterDryRunService.Upgrade(req)
+}
+
+// Apply Cluster
+// @Tags clusters
+// @Summary Apply a cluster declaration
+// @Description 对比 YAML/JSON 格式的集群描述与当前状态，并调用节点、工具、Istio、存储供应商和备份策略接口使其一致
+// @Accept  json
+// @Produce  json
+// @Param request body dto.ClusterApply true "request"
+// @Success 200 {object} dto.ClusterApplyDiff
+// @Security ApiKeyAuth
+// @Router /clusters/apply [post]
+func (c ClusterController) PostApply() (*dto.ClusterApplyDiff, error) {
+	req, err := c.readClusterApply()
+	if err != nil {
+		return nil, err
+	}
+	diff, err := c.ClusterApplyService.Apply(req)
+	if err != nil {
+		return diff, err
+	}
+	operator := c.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.APPLY_CLUSTER, req.Name)
+	return diff, nil
+}
+
+// Diff Cluster
+// @Tags clusters
+// @Summary Show the difference between a cluster declaration and the cluster
+// @Description 返回集群描述与当前状态的差异，不做任何修改
+// @Accept  json
+// @Produce  json
+// @Param request body dto.ClusterApply true "request"
+// @Success 200 {object} dto.ClusterApplyDiff
+// @Security ApiKeyAuth
+// @Router /clusters/apply/diff [post]
+func (c ClusterController) PostApplyDiff() (*dto.ClusterApplyDiff, error) {
+	req, err := c.readClusterApply()
+	if err != nil {
+		return nil, err
+	}
+	return c.ClusterApplyService.Diff(req)
+}
+
+// readClusterApply 同时支持 YAML 和 JSON 格式的请求体
+func (c ClusterController) readClusterApply() (dto.ClusterApply, error) {
+	var req dto.ClusterApply
+	body, err := c.Ctx.GetBody()
+	if err != nil {
+		return req, err
+	}
+	if err := yaml.Unmarshal(body, &req); err != nil {
+		return req, err
+	}
+	if req.Name == "" {
+		return req, errors.New("CLUSTER_APPLY_NAME_REQUIRED")
+	}
+	return req, nil
+}
+
 func (c ClusterController) GetProvisionerBy(name string) ([]dto.ClusterStorageProvisioner, error) {
 	csp, err := c.ClusterStorageProvisionerService.ListStorageProvisioner(name)
 	if err != nil {
@@ -441,6 +660,49 @@ func (c ClusterController) PostNodeBatchBy(clusterName string) error {
 	return nil
 }
 
+// Replace Master Node
+// @Tags clusters
+// @Summary Replace a failed master node
+// @Description 使用新主机替换故障的 master 节点，etcd 失去多数成员时从备份快照恢复
+// @Accept  json
+// @Produce  json
+// @Param request body dto.NodeReplace true "request"
+// @Param name path string true "集群名称"
+// @Success 200
+// @Security ApiKeyAuth
+// @Router /clusters/node/replace/{name} [post]
+func (c ClusterController) PostNodeReplaceBy(clusterName string) error {
+	var req dto.NodeReplace
+	if err := c.Ctx.ReadJSON(&req); err != nil {
+		return err
+	}
+	if err := c.ClusterNodeService.Replace(clusterName, req); err != nil {
+		return err
+	}
+	operator := c.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.REPLACE_CLUSTER_MASTER, clusterName+"("+req.Node+")")
+	return nil
+}
+
+// Node Batch Dry Run
+// @Tags clusters
+// @Summary Show the plan of adding or removing cluster nodes
+// @Description 返回扩缩容节点时的 inventory、变量和需要创建或删除的主机，不会修改节点
+// @Accept  json
+// @Produce  json
+// @Param request body dto.NodeBatch true "request"
+// @Param name path string true "集群名称"
+// @Success 200 {object} dto.ClusterDryRun
+// @Security ApiKeyAuth
+// @Router /clusters/node/batch/dryrun/{name} [post]
+func (c ClusterController) PostNodeBatchDryrunBy(clusterName string) (*dto.ClusterDryRun, error) {
+	var req dto.NodeBatch
+	if err := c.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	return c.ClusterDryRunService.NodeBatch(clusterName, req)
+}
+
 func (c ClusterController) GetWebkubectlBy(clusterName string) (*dto.WebkubectlToken, error) {
 	tk, err := c.ClusterService.GetWebkubectlToken(clusterName)
 	if err != nil {
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/controller/kolog"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/service"
+	"github.com/go-playground/validator/v10"
+	"github.com/kataras/iris/v12/context"
+)
+
+type ClusterAlertRuleController struct {
+	Ctx                     context.Context
+	ClusterAlertRuleService service.ClusterAlertRuleService
+}
+
+func NewClusterAlertRuleController() *ClusterAlertRuleController {
+	return &ClusterAlertRuleController{
+		ClusterAlertRuleService: service.NewClusterAlertRuleService(),
+	}
+}
+
+// List Cluster Alert Rule Group
+// @Tags clusters
+// @Summary Show all alert rule groups of a cluster
+// @Description 获取集群的告警规则组
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} dto.ClusterAlertRuleGroup
+// @Security ApiKeyAuth
+// @Router /clusters/{cluster}/alertrules/ [get]
+func (c ClusterAlertRuleController) Get() ([]dto.ClusterAlertRuleGroup, error) {
+	return c.ClusterAlertRuleService.List(c.Ctx.Params().GetString("cluster"))
+}
+
+// Get Cluster Alert Rule Group
+// @Tags clusters
+// @Summary Show an alert rule group
+// @Description 获取单个告警规则组
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.ClusterAlertRuleGroup
+// @Security ApiKeyAuth
+// @Router /clusters/{cluster}/alertrules/{name}/ [get]
+func (c ClusterAlertRuleController) GetBy(name string) (*dto.ClusterAlertRuleGroup, error) {
+	return c.ClusterAlertRuleService.Get(c.Ctx.Params().GetString("cluster"), name)
+}
+
+// Create Cluster Alert Rule Group
+// @Tags clusters
+// @Summary Create an alert rule group
+// @Description 创建告警规则组
+// @Accept  json
+// @Produce  json
+// @Param request body dto.ClusterAlertRuleGroupCreate true "request"
+// @Success 200 {object} dto.ClusterAlertRuleGroup
+// @Security ApiKeyAuth
+// @Router /clusters/{cluster}/alertrules/ [post]
+func (c ClusterAlertRuleController) Post() (*dto.ClusterAlertRuleGroup, error) {
+	var req dto.ClusterAlertRuleGroupCreate
+	if err := c.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+	clusterName := c.Ctx.Params().GetString("cluster")
+	operator := c.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.CREATE_CLUSTER_ALERT_RULE, clusterName+"-"+req.Name)
+	return c.ClusterAlertRuleService.Create(clusterName, req)
+}
+
+// Update Cluster Alert Rule Group
+// @Tags clusters
+// @Summary Update an alert rule group
+// @Description 修改告警规则组
+// @Accept  json
+// @Produce  json
+// @Param request body dto.ClusterAlertRuleGroupUpdate true "request"
+// @Success 200 {object} dto.ClusterAlertRuleGroup
+// @Security ApiKeyAuth
+// @Router /clusters/{cluster}/alertrules/{name}/ [patch]
+func (c ClusterAlertRuleController) PatchBy(name string) (*dto.ClusterAlertRuleGroup, error) {
+	var req dto.ClusterAlertRuleGroupUpdate
+	if err := c.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+	clusterName := c.Ctx.Params().GetString("cluster")
+	if before, err := c.ClusterAlertRuleService.Get(clusterName, name); err == nil {
+		kolog.AuditBefore(c.Ctx, before)
+	}
+	operator := c.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.UPDATE_CLUSTER_ALERT_RULE, clusterName+"-"+name)
+	return c.ClusterAlertRuleService.Update(clusterName, name, req)
+}
+
+// Delete Cluster Alert Rule Group
+// @Tags clusters
+// @Summary Delete an alert rule group
+// @Description 删除告警规则组
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Router /clusters/{cluster}/alertrules/{name}/ [delete]
+func (c ClusterAlertRuleController) DeleteBy(name string) error {
+	clusterName := c.Ctx.Params().GetString("cluster")
+	if before, err := c.ClusterAlertRuleService.Get(clusterName, name); err == nil {
+		kolog.AuditBefore(c.Ctx, before)
+	}
+	operator := c.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.DELETE_CLUSTER_ALERT_RULE, clusterName+"-"+name)
+	return c.ClusterAlertRuleService.Delete(clusterName, name)
+}
+
+// Install Builtin Alert Rule Groups
+// @Tags clusters
+// @Summary Install or restore the builtin node, etcd and apiserver alert rule groups
+// @Description 安装内置告警规则
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} dto.ClusterAlertRuleGroup
+// @Security ApiKeyAuth
+// @Router /clusters/{cluster}/alertrules/builtin/ [post]
+func (c ClusterAlertRuleController) PostBuiltin() ([]dto.ClusterAlertRuleGroup, error) {
+	clusterName := c.Ctx.Params().GetString("cluster")
+	operator := c.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.INSTALL_CLUSTER_BUILTIN_ALERT_RULE, clusterName)
+	return c.ClusterAlertRuleService.InstallBuiltin(clusterName)
+}
+
+// Apply Alert Rules And Alertmanager Config
+// @Tags clusters
+// @Summary Apply alert rules and regenerate the alertmanager config from notification settings
+// @Description 重新下发告警规则和 Alertmanager 配置
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Router /clusters/{cluster}/alertrules/apply/ [post]
+func (c ClusterAlertRuleController) PostApply() error {
+	clusterName := c.Ctx.Params().GetString("cluster")
+	operator := c.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.APPLY_CLUSTER_ALERT_RULE, clusterName)
+	return c.ClusterAlertRuleService.Apply(clusterName)
+}
@@ -116,6 +116,38 @@ func (b BackupFileController) PostBackup() error {
 	return err
 }
 
+// Verify Backup File
+// @Tags backupFiles
+// @Summary Verify a backup file
+// @Description 校验备份文件 checksum，并恢复到临时 etcd 中统计 key 数量和 revision
+// @Accept  json
+// @Produce  json
+// @Param request body dto.ClusterBackupFileVerify true "request"
+// @Success 200
+// @Security ApiKeyAuth
+// @Router /cluster/backup/files/verify/ [post]
+func (b BackupFileController) PostVerify() error {
+	var req dto.ClusterBackupFileVerify
+	err := b.Ctx.ReadJSON(&req)
+	if err != nil {
+		return err
+	}
+	validate := validator.New()
+	err = validate.Struct(req)
+	if err != nil {
+		return err
+	}
+	err = b.ClusterBackupFileService.Verify(req)
+	if err != nil {
+		return err
+	}
+
+	operator := b.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.VERIFY_CLUSTER_BACKUP, req.ClusterName+"-"+req.Name)
+
+	return err
+}
+
 // CLuster Restore
 // @Tags backupFiles
 // @Summary Restore CLuster
@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/kataras/iris/v12/context"
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/controller/kolog"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/service"
+)
+
+type ClusterF5Controller struct {
+	Ctx              context.Context
+	ClusterF5Service service.ClusterF5Service
+}
+
+func NewClusterF5Controller() *ClusterF5Controller {
+	return &ClusterF5Controller{
+		ClusterF5Service: service.NewClusterF5Service(),
+	}
+}
+
+// Get F5Setting
+// @Tags clusters
+// @Summary Show f5 setting of a cluster
+// @Description 获取集群 F5 配置
+// @Accept  json
+// @Produce  json
+// @Param clusterName path string true "集群名称"
+// @Success 200 {object} dto.F5Setting
+// @Security ApiKeyAuth
+// @Router /clusters/f5/{clusterName}/ [get]
+func (c ClusterF5Controller) GetBy(clusterName string) (*dto.F5Setting, error) {
+	return c.ClusterF5Service.Get(clusterName)
+}
+
+// Create F5Setting
+// @Tags clusters
+// @Summary Create f5 setting and deploy f5 cis
+// @Description 添加集群 F5 配置并部署 F5 CIS
+// @Accept  json
+// @Produce  json
+// @Param clusterName path string true "集群名称"
+// @Param request body dto.F5SettingCreate true "request"
+// @Success 200 {object} dto.F5Setting
+// @Security ApiKeyAuth
+// @Router /clusters/f5/{clusterName}/ [post]
+func (c ClusterF5Controller) PostBy(clusterName string) (*dto.F5Setting, error) {
+	var req dto.F5SettingCreate
+	if err := c.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	operator := c.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.CREATE_CLUSTER_F5, clusterName)
+
+	return c.ClusterF5Service.Create(clusterName, req)
+}
+
+// Update F5Setting
+// @Tags clusters
+// @Summary Update f5 setting and redeploy f5 cis
+// @Description 更新集群 F5 配置，密码为空时保留原密码
+// @Accept  json
+// @Produce  json
+// @Param clusterName path string true "集群名称"
+// @Param request body dto.F5SettingUpdate true "request"
+// @Success 200 {object} dto.F5Setting
+// @Security ApiKeyAuth
+// @Router /clusters/f5/{clusterName}/ [patch]
+func (c ClusterF5Controller) PatchBy(clusterName string) (*dto.F5Setting, error) {
+	var req dto.F5SettingUpdate
+	if err := c.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	operator := c.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.UPDATE_CLUSTER_F5, clusterName)
+
+	return c.ClusterF5Service.Update(clusterName, req)
+}
+
+// Delete F5Setting
+// @Tags clusters
+// @Summary Delete f5 setting and remove f5 cis
+// @Description 删除集群 F5 配置并卸载 F5 CIS
+// @Accept  json
+// @Produce  json
+// @Param clusterName path string true "集群名称"
+// @Security ApiKeyAuth
+// @Router /clusters/f5/{clusterName}/ [delete]
+func (c ClusterF5Controller) DeleteBy(clusterName string) error {
+	operator := c.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.DELETE_CLUSTER_F5, clusterName)
+
+	return c.ClusterF5Service.Delete(clusterName)
+}
+
+// Check F5Setting
+// @Tags clusters
+// @Summary Check f5 connectivity and f5 cis status
+// @Description 检查 BIG-IP 连接和 F5 CIS 状态
+// @Accept  json
+// @Produce  json
+// @Param clusterName path string true "集群名称"
+// @Success 200 {object} dto.F5Setting
+// @Security ApiKeyAuth
+// @Router /clusters/f5/check/{clusterName}/ [post]
+func (c ClusterF5Controller) PostCheckBy(clusterName string) (*dto.F5Setting, error) {
+	return c.ClusterF5Service.Check(clusterName)
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/controller/kolog"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/service"
+	"github.com/kataras/iris/v12/context"
+)
+
+type DataKeyController struct {
+	Ctx            context.Context
+	DataKeyService service.DataKeyService
+}
+
+func NewDataKeyController() *DataKeyController {
+	return &DataKeyController{
+		DataKeyService: service.NewDataKeyService(),
+	}
+}
+
+// List DataKey
+// @Tags datakeys
+// @Summary Show data keys
+// @Description 获取数据密钥版本及各版本加密的数据数量
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.DataKeyList
+// @Security ApiKeyAuth
+// @Router /datakeys/ [get]
+func (d DataKeyController) Get() (*dto.DataKeyList, error) {
+	return d.DataKeyService.List()
+}
+
+// Rotate DataKey
+// @Tags datakeys
+// @Summary Rotate data key
+// @Description 生成新的数据密钥版本，并在后台分批将敏感数据重新加密
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.DataKey
+// @Security ApiKeyAuth
+// @Router /datakeys/rotate [post]
+func (d DataKeyController) PostRotate() (*dto.DataKey, error) {
+	operator := d.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.ROTATE_DATA_KEY, "")
+	return d.DataKeyService.Rotate()
+}
+
+// Reencrypt DataKey
+// @Tags datakeys
+// @Summary Reencrypt secrets
+// @Description 将仍使用旧版本或未加密的敏感数据重新加密到当前版本
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Router /datakeys/reencrypt [post]
+func (d DataKeyController) PostReencrypt() error {
+	operator := d.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.REENCRYPT_DATA_KEY, "")
+	return d.DataKeyService.Reencrypt()
+}
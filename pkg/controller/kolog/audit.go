@@ -0,0 +1,75 @@
+package kolog
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/service"
+	"github.com/kataras/iris/v12/context"
+)
+
+// AuditBeforeKey 控制器在修改资源前保存的原始状态，由 AuditMiddleware 写入审计事件
+const AuditBeforeKey = "auditBefore"
+
+// 名称包含这些字段的值在写入审计事件前会被隐藏
+var sensitiveKeys = []string{"password", "secret", "token", "privatekey", "passphrase", "code"}
+
+func Audit(creation dto.AuditEventCreate) {
+	if err := service.NewAuditService().Record(creation); err != nil {
+		logger.Log.Errorf("save audit event failed, error: %s", err.Error())
+	}
+}
+
+// AuditBefore 记录资源修改前的状态，用于审计事件的 before/after 对比
+func AuditBefore(ctx context.Context, before interface{}) {
+	if before == nil {
+		return
+	}
+	bs, err := json.Marshal(before)
+	if err != nil {
+		return
+	}
+	ctx.Values().Set(AuditBeforeKey, Redact(bs))
+}
+
+// Redact 隐藏 JSON 中的敏感字段，非 JSON 内容原样返回
+func Redact(data []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return string(data)
+	}
+	bs, _ := json.Marshal(redactValue(v))
+	return string(bs)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, item := range value {
+			if isSensitiveKey(k) {
+				value[k] = "******"
+				continue
+			}
+			value[k] = redactValue(item)
+		}
+		return value
+	case []interface{}:
+		for i := range value {
+			value[i] = redactValue(value[i])
+		}
+		return value
+	}
+	return v
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
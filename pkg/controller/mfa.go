@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/controller/kolog"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/service"
+	"github.com/go-playground/validator/v10"
+	"github.com/kataras/iris/v12/context"
+)
+
+type MfaController struct {
+	Ctx        context.Context
+	MfaService service.MfaService
+}
+
+func NewMfaController() *MfaController {
+	return &MfaController{
+		MfaService: service.NewMfaService(),
+	}
+}
+
+// Get Mfa Status
+// @Tags mfa
+// @Summary Show mfa status of current user
+// @Description 获取当前用户的二次认证状态
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.MfaStatus
+// @Security ApiKeyAuth
+// @Router /mfa/ [get]
+func (m MfaController) Get() (*dto.MfaStatus, error) {
+	u, err := m.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	return m.MfaService.Status(u)
+}
+
+// Enroll Mfa
+// @Tags mfa
+// @Summary Generate totp secret and qr code
+// @Description 生成 TOTP 密钥和二维码，需要再调用 activate 校验动态码后才会启用
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.MfaEnroll
+// @Security ApiKeyAuth
+// @Router /mfa/enroll [post]
+func (m MfaController) PostEnroll() (*dto.MfaEnroll, error) {
+	u, err := m.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	return m.MfaService.Enroll(u)
+}
+
+// Activate Mfa
+// @Tags mfa
+// @Summary Activate mfa with a totp code
+// @Description 校验动态码并启用二次认证，返回恢复码
+// @Accept  json
+// @Produce  json
+// @Param request body dto.MfaCode true "request"
+// @Success 200 {object} dto.MfaRecoveryCodes
+// @Security ApiKeyAuth
+// @Router /mfa/activate [post]
+func (m MfaController) PostActivate() (*dto.MfaRecoveryCodes, error) {
+	req, err := m.readCode()
+	if err != nil {
+		return nil, err
+	}
+	u, err := m.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	go kolog.Save(u.Name, constant.ENABLE_USER_MFA, u.Name)
+	return m.MfaService.Activate(u, req.Code)
+}
+
+// Disable Mfa
+// @Tags mfa
+// @Summary Disable mfa
+// @Description 校验动态码或恢复码后关闭二次认证
+// @Accept  json
+// @Produce  json
+// @Param request body dto.MfaCode true "request"
+// @Security ApiKeyAuth
+// @Router /mfa/disable [post]
+func (m MfaController) PostDisable() error {
+	req, err := m.readCode()
+	if err != nil {
+		return err
+	}
+	u, err := m.currentUser()
+	if err != nil {
+		return err
+	}
+	go kolog.Save(u.Name, constant.DISABLE_USER_MFA, u.Name)
+	return m.MfaService.Disable(u, req.Code)
+}
+
+// Regenerate Recovery Codes
+// @Tags mfa
+// @Summary Regenerate recovery codes
+// @Description 重新生成恢复码，旧的恢复码全部失效
+// @Accept  json
+// @Produce  json
+// @Param request body dto.MfaCode true "request"
+// @Success 200 {object} dto.MfaRecoveryCodes
+// @Security ApiKeyAuth
+// @Router /mfa/recovery/codes [post]
+func (m MfaController) PostRecoveryCodes() (*dto.MfaRecoveryCodes, error) {
+	req, err := m.readCode()
+	if err != nil {
+		return nil, err
+	}
+	u, err := m.currentUser()
+	if err != nil {
+		return nil, err
+	}
+	return m.MfaService.RegenerateRecoveryCodes(u, req.Code)
+}
+
+// Reset Mfa
+// @Tags mfa
+// @Summary Reset mfa of a user
+// @Description 管理员清除用户的二次认证配置
+// @Accept  json
+// @Produce  json
+// @Param name path string true "用户名称"
+// @Security ApiKeyAuth
+// @Router /mfa/reset/{name} [post]
+func (m MfaController) PostResetBy(name string) error {
+	operator := m.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.RESET_USER_MFA, name)
+	return m.MfaService.Reset(name)
+}
+
+func (m MfaController) readCode() (*dto.MfaCode, error) {
+	var req dto.MfaCode
+	if err := m.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+func (m MfaController) currentUser() (*model.User, error) {
+	sessionUser := m.Ctx.Values().Get("user").(dto.SessionUser)
+	var u model.User
+	if err := db.DB.Where(&model.User{ID: sessionUser.UserId}).First(&u).Error; err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
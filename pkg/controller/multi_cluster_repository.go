@@ -0,0 +1,266 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/kataras/iris/v12/context"
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/controller/kolog"
+	"github.com/kmpp/pkg/controller/page"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/service"
+)
+
+type MultiClusterRepositoryController struct {
+	Ctx                           context.Context
+	MultiClusterRepositoryService service.MultiClusterRepositoryService
+}
+
+func NewMultiClusterRepositoryController() *MultiClusterRepositoryController {
+	return &MultiClusterRepositoryController{
+		MultiClusterRepositoryService: service.NewMultiClusterRepositoryService(),
+	}
+}
+
+// List MultiClusterRepository
+// @Tags multiClusterRepositories
+// @Summary Show all multi cluster repositories
+// @Description 获取多集群仓库列表
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} page.Page
+// @Security ApiKeyAuth
+// @Router /multicluster/repositories/ [get]
+func (m MultiClusterRepositoryController) Get() (*page.Page, error) {
+	p, _ := m.Ctx.Values().GetBool("page")
+	if p {
+		num, _ := m.Ctx.Values().GetInt(constant.PageNumQueryKey)
+		size, _ := m.Ctx.Values().GetInt(constant.PageSizeQueryKey)
+		return m.MultiClusterRepositoryService.Page(num, size)
+	}
+	var pg page.Page
+	items, err := m.MultiClusterRepositoryService.List()
+	if err != nil {
+		return &pg, err
+	}
+	pg.Items = items
+	pg.Total = len(items)
+	return &pg, nil
+}
+
+// Get MultiClusterRepository
+// @Tags multiClusterRepositories
+// @Summary Show a multi cluster repository
+// @Description 获取单个多集群仓库
+// @Accept  json
+// @Produce  json
+// @Param name path string true "仓库名称"
+// @Success 200 {object} dto.MultiClusterRepository
+// @Security ApiKeyAuth
+// @Router /multicluster/repositories/{name}/ [get]
+func (m MultiClusterRepositoryController) GetBy(name string) (*dto.MultiClusterRepository, error) {
+	return m.MultiClusterRepositoryService.Get(name)
+}
+
+// Create MultiClusterRepository
+// @Tags multiClusterRepositories
+// @Summary Create a multi cluster repository
+// @Description 添加多集群仓库
+// @Accept  json
+// @Produce  json
+// @Param request body dto.MultiClusterRepositoryCreateRequest true "request"
+// @Success 200 {object} dto.MultiClusterRepository
+// @Security ApiKeyAuth
+// @Router /multicluster/repositories/ [post]
+func (m MultiClusterRepositoryController) Post() (*dto.MultiClusterRepository, error) {
+	var req dto.MultiClusterRepositoryCreateRequest
+	if err := m.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+
+	operator := m.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.CREATE_MULTI_CLUSTER_REPOSITORY, req.Name)
+
+	return m.MultiClusterRepositoryService.Create(req)
+}
+
+// Update MultiClusterRepository
+// @Tags multiClusterRepositories
+// @Summary Update a multi cluster repository
+// @Description 更新多集群仓库同步配置
+// @Accept  json
+// @Produce  json
+// @Param request body dto.MultiClusterRepositoryUpdateRequest true "request"
+// @Param name path string true "仓库名称"
+// @Success 200 {object} dto.MultiClusterRepository
+// @Security ApiKeyAuth
+// @Router /multicluster/repositories/{name}/ [patch]
+func (m MultiClusterRepositoryController) PatchBy(name string) (*dto.MultiClusterRepository, error) {
+	var req dto.MultiClusterRepositoryUpdateRequest
+	if err := m.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+
+	operator := m.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.UPDATE_MULTI_CLUSTER_REPOSITORY, name)
+
+	return m.MultiClusterRepositoryService.Update(name, req)
+}
+
+// Delete MultiClusterRepository
+// @Tags multiClusterRepositories
+// @Summary Delete a multi cluster repository
+// @Description 删除多集群仓库
+// @Accept  json
+// @Produce  json
+// @Param name path string true "仓库名称"
+// @Security ApiKeyAuth
+// @Router /multicluster/repositories/{name}/ [delete]
+func (m MultiClusterRepositoryController) DeleteBy(name string) error {
+	operator := m.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.DELETE_MULTI_CLUSTER_REPOSITORY, name)
+
+	return m.MultiClusterRepositoryService.Delete(name)
+}
+
+func (m MultiClusterRepositoryController) PostBatch() error {
+	var req dto.MultiClusterRepositoryBatch
+	if err := m.Ctx.ReadJSON(&req); err != nil {
+		return err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return err
+	}
+	if err := m.MultiClusterRepositoryService.Batch(req); err != nil {
+		return err
+	}
+
+	operator := m.Ctx.Values().GetString("operator")
+	names := ""
+	for _, item := range req.Items {
+		names += item.Name + ","
+	}
+	go kolog.Save(operator, constant.DELETE_MULTI_CLUSTER_REPOSITORY, names)
+
+	return nil
+}
+
+// List ClusterRelations
+// @Tags multiClusterRepositories
+// @Summary Show clusters bound to a multi cluster repository
+// @Description 获取仓库关联的集群及同步状态
+// @Accept  json
+// @Produce  json
+// @Param name path string true "仓库名称"
+// @Success 200 {Array} []dto.ClusterRelation
+// @Security ApiKeyAuth
+// @Router /multicluster/repositories/relations/{name}/ [get]
+func (m MultiClusterRepositoryController) GetRelationsBy(name string) ([]dto.ClusterRelation, error) {
+	return m.MultiClusterRepositoryService.GetClusterRelations(name)
+}
+
+// Update ClusterRelations
+// @Tags multiClusterRepositories
+// @Summary Bind or unbind clusters
+// @Description 关联或解除关联集群
+// @Accept  json
+// @Produce  json
+// @Param request body dto.UpdateRelationRequest true "request"
+// @Param name path string true "仓库名称"
+// @Security ApiKeyAuth
+// @Router /multicluster/repositories/relations/{name}/ [post]
+func (m MultiClusterRepositoryController) PostRelationsBy(name string) error {
+	var req dto.UpdateRelationRequest
+	if err := m.Ctx.ReadJSON(&req); err != nil {
+		return err
+	}
+	if err := m.MultiClusterRepositoryService.UpdateClusterRelations(name, req); err != nil {
+		return err
+	}
+
+	operation := constant.BIND_MULTI_CLUSTER_REPOSITORY
+	if req.Delete {
+		operation = constant.UNBIND_MULTI_CLUSTER_REPOSITORY
+	}
+	clusters := ""
+	for _, c := range req.ClusterNames {
+		clusters += c + ","
+	}
+	operator := m.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, operation, name+"-"+clusters)
+
+	return nil
+}
+
+// Sync MultiClusterRepository
+// @Tags multiClusterRepositories
+// @Summary Sync a multi cluster repository
+// @Description 立即同步仓库到所有关联集群
+// @Accept  json
+// @Produce  json
+// @Param name path string true "仓库名称"
+// @Security ApiKeyAuth
+// @Router /multicluster/repositories/sync/{name}/ [post]
+func (m MultiClusterRepositoryController) PostSyncBy(name string) error {
+	repo, err := m.MultiClusterRepositoryService.Get(name)
+	if err != nil {
+		return err
+	}
+	if repo.Status != constant.StatusRunning {
+		return errors.New(service.MultiClusterRepositoryNotReady)
+	}
+	if repo.SyncStatus == constant.StatusRunning {
+		return errors.New(service.MultiClusterRepositorySyncing)
+	}
+	go func() {
+		if err := m.MultiClusterRepositoryService.Sync(name, true); err != nil {
+			logger.Log.Errorf("sync multi cluster repository %s error %s", name, err.Error())
+		}
+	}()
+
+	operator := m.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.SYNC_MULTI_CLUSTER_REPOSITORY, name)
+
+	return nil
+}
+
+// List SyncLogs
+// @Tags multiClusterRepositories
+// @Summary Show sync logs of a multi cluster repository
+// @Description 分页获取仓库同步记录
+// @Accept  json
+// @Produce  json
+// @Param name path string true "仓库名称"
+// @Success 200 {object} page.Page
+// @Security ApiKeyAuth
+// @Router /multicluster/repositories/logs/{name}/ [get]
+func (m MultiClusterRepositoryController) GetLogsBy(name string) (*page.Page, error) {
+	num, _ := m.Ctx.Values().GetInt(constant.PageNumQueryKey)
+	size, _ := m.Ctx.Values().GetInt(constant.PageSizeQueryKey)
+	if num <= 0 || size <= 0 {
+		num, size = 1, 10
+	}
+	return m.MultiClusterRepositoryService.PageSyncLogs(name, num, size)
+}
+
+// Get SyncLogDetail
+// @Tags multiClusterRepositories
+// @Summary Show a sync log with cluster and resource results
+// @Description 获取同步记录中每个集群、每个资源的结果
+// @Accept  json
+// @Produce  json
+// @Param name path string true "仓库名称"
+// @Param logId path string true "同步记录 ID"
+// @Success 200 {object} dto.MultiClusterSyncLogDetail
+// @Security ApiKeyAuth
+// @Router /multicluster/repositories/logs/detail/{name}/{logId}/ [get]
+func (m MultiClusterRepositoryController) GetLogsDetailBy(name string, logId string) (*dto.MultiClusterSyncLogDetail, error) {
+	return m.MultiClusterRepositoryService.GetSyncLogDetail(name, logId)
+}
@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/controller/kolog"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/service"
+	"github.com/go-playground/validator/v10"
+	"github.com/kataras/iris/v12/context"
+)
+
+type RoleController struct {
+	Ctx         context.Context
+	RoleService service.RoleService
+}
+
+func NewRoleController() *RoleController {
+	return &RoleController{
+		RoleService: service.NewRoleService(),
+	}
+}
+
+// List Role
+// @Tags roles
+// @Summary Show custom roles
+// @Description 获取自定义角色列表
+// @Accept  json
+// @Produce  json
+// @Success 200 {Array} dto.Role
+// @Security ApiKeyAuth
+// @Router /roles/ [get]
+func (r RoleController) Get() ([]dto.Role, error) {
+	return r.RoleService.List()
+}
+
+// Get Role
+// @Tags roles
+// @Summary Show a custom role
+// @Description 获取单个自定义角色
+// @Accept  json
+// @Produce  json
+// @Param name path string true "角色名称"
+// @Success 200 {object} dto.Role
+// @Security ApiKeyAuth
+// @Router /roles/{name}/ [get]
+func (r RoleController) GetBy(name string) (*dto.Role, error) {
+	return r.RoleService.Get(name)
+}
+
+// Create Role
+// @Tags roles
+// @Summary Create a custom role
+// @Description 创建自定义角色，规则写法与内置角色相同，只能授权 /api/v1 下的接口
+// @Accept  json
+// @Produce  json
+// @Param request body dto.RoleCreate true "request"
+// @Success 200 {object} dto.Role
+// @Security ApiKeyAuth
+// @Router /roles/ [post]
+func (r RoleController) Post() (*dto.Role, error) {
+	var req dto.RoleCreate
+	if err := r.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+	operator := r.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.CREATE_ROLE, req.Name)
+	return r.RoleService.Create(req)
+}
+
+// Update Role
+// @Tags roles
+// @Summary Update a custom role
+// @Description 更新自定义角色的描述和规则，对已绑定的用户立即生效
+// @Accept  json
+// @Produce  json
+// @Param name path string true "角色名称"
+// @Param request body dto.RoleUpdate true "request"
+// @Success 200 {object} dto.Role
+// @Security ApiKeyAuth
+// @Router /roles/{name}/ [patch]
+func (r RoleController) PatchBy(name string) (*dto.Role, error) {
+	var req dto.RoleUpdate
+	if err := r.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+	if before, err := r.RoleService.Get(name); err == nil {
+		kolog.AuditBefore(r.Ctx, before)
+	}
+	operator := r.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.UPDATE_ROLE, name)
+	return r.RoleService.Update(name, req)
+}
+
+// Delete Role
+// @Tags roles
+// @Summary Delete a custom role
+// @Description 删除自定义角色及其全部绑定
+// @Accept  json
+// @Produce  json
+// @Param name path string true "角色名称"
+// @Security ApiKeyAuth
+// @Router /roles/{name}/ [delete]
+func (r RoleController) DeleteBy(name string) error {
+	if before, err := r.RoleService.Get(name); err == nil {
+		kolog.AuditBefore(r.Ctx, before)
+	}
+	operator := r.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.DELETE_ROLE, name)
+	return r.RoleService.Delete(name)
+}
+
+type RoleBindingController struct {
+	Ctx         context.Context
+	RoleService service.RoleService
+}
+
+func NewRoleBindingController() *RoleBindingController {
+	return &RoleBindingController{
+		RoleService: service.NewRoleService(),
+	}
+}
+
+// List RoleBinding
+// @Tags roles
+// @Summary Show bindings of a custom role
+// @Description 获取自定义角色的绑定列表
+// @Accept  json
+// @Produce  json
+// @Param name path string true "角色名称"
+// @Success 200 {Array} dto.RoleBinding
+// @Security ApiKeyAuth
+// @Router /roles/{name}/bindings/ [get]
+func (r RoleBindingController) Get() ([]dto.RoleBinding, error) {
+	name := r.Ctx.Params().GetString("name")
+	return r.RoleService.ListBindings(name)
+}
+
+// Create RoleBinding
+// @Tags roles
+// @Summary Bind a custom role to a user
+// @Description 在系统、项目或集群范围内将自定义角色授予用户
+// @Accept  json
+// @Produce  json
+// @Param name path string true "角色名称"
+// @Param request body dto.RoleBindingCreate true "request"
+// @Success 200 {object} dto.RoleBinding
+// @Security ApiKeyAuth
+// @Router /roles/{name}/bindings/ [post]
+func (r RoleBindingController) Post() (*dto.RoleBinding, error) {
+	name := r.Ctx.Params().GetString("name")
+	var req dto.RoleBindingCreate
+	if err := r.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+	operator := r.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.CREATE_ROLE_BINDING, name+"-"+req.Username)
+	return r.RoleService.CreateBinding(name, req)
+}
+
+// Delete RoleBinding
+// @Tags roles
+// @Summary Delete a binding of a custom role
+// @Description 撤销用户的自定义角色绑定
+// @Accept  json
+// @Produce  json
+// @Param name path string true "角色名称"
+// @Param id path string true "绑定 ID"
+// @Security ApiKeyAuth
+// @Router /roles/{name}/bindings/{id}/ [delete]
+func (r RoleBindingController) DeleteBy(id string) error {
+	name := r.Ctx.Params().GetString("name")
+	operator := r.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.DELETE_ROLE_BINDING, name+"-"+id)
+	return r.RoleService.DeleteBinding(name, id)
+}
@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/controller/kolog"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/service"
+	"github.com/go-playground/validator/v10"
+	"github.com/kataras/iris/v12/context"
+)
+
+type ServiceAccountController struct {
+	Ctx                   context.Context
+	ServiceAccountService service.ServiceAccountService
+}
+
+func NewServiceAccountController() *ServiceAccountController {
+	return &ServiceAccountController{
+		ServiceAccountService: service.NewServiceAccountService(),
+	}
+}
+
+// List ServiceAccount
+// @Tags serviceAccounts
+// @Summary Show service accounts
+// @Description 获取服务账号列表
+// @Accept  json
+// @Produce  json
+// @Success 200 {Array} dto.User
+// @Security ApiKeyAuth
+// @Router /serviceaccounts/ [get]
+func (s ServiceAccountController) Get() ([]dto.User, error) {
+	return s.ServiceAccountService.List()
+}
+
+// Create ServiceAccount
+// @Tags serviceAccounts
+// @Summary Create a service account
+// @Description 创建服务账号，服务账号不能通过密码登录
+// @Accept  json
+// @Produce  json
+// @Param request body dto.ServiceAccountCreate true "request"
+// @Success 200 {object} dto.User
+// @Security ApiKeyAuth
+// @Router /serviceaccounts/ [post]
+func (s ServiceAccountController) Post() (*dto.User, error) {
+	var req dto.ServiceAccountCreate
+	if err := s.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+	operator := s.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.CREATE_SERVICE_ACCOUNT, req.Name)
+	return s.ServiceAccountService.Create(req)
+}
+
+// Delete ServiceAccount
+// @Tags serviceAccounts
+// @Summary Delete a service account
+// @Description 删除服务账号及其 API Token
+// @Accept  json
+// @Produce  json
+// @Param name path string true "服务账号名称"
+// @Security ApiKeyAuth
+// @Router /serviceaccounts/{name}/ [delete]
+func (s ServiceAccountController) DeleteBy(name string) error {
+	operator := s.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.DELETE_SERVICE_ACCOUNT, name)
+	return s.ServiceAccountService.Delete(name)
+}
+
+type ServiceAccountTokenController struct {
+	Ctx                   context.Context
+	ServiceAccountService service.ServiceAccountService
+	ApiTokenService       service.ApiTokenService
+}
+
+func NewServiceAccountTokenController() *ServiceAccountTokenController {
+	return &ServiceAccountTokenController{
+		ServiceAccountService: service.NewServiceAccountService(),
+		ApiTokenService:       service.NewApiTokenService(),
+	}
+}
+
+// List ServiceAccount Token
+// @Tags serviceAccounts
+// @Summary Show api tokens of a service account
+// @Description 获取服务账号的 API Token 列表
+// @Accept  json
+// @Produce  json
+// @Param name path string true "服务账号名称"
+// @Success 200 {Array} dto.ApiToken
+// @Security ApiKeyAuth
+// @Router /serviceaccounts/{name}/tokens/ [get]
+func (s ServiceAccountTokenController) Get() ([]dto.ApiToken, error) {
+	name := s.Ctx.Params().GetString("name")
+	if _, err := s.ServiceAccountService.Get(name); err != nil {
+		return nil, err
+	}
+	return s.ApiTokenService.List(name)
+}
+
+// Create ServiceAccount Token
+// @Tags serviceAccounts
+// @Summary Create an api token for a service account
+// @Description 为服务账号创建 API Token，Token 明文只在创建时返回一次
+// @Accept  json
+// @Produce  json
+// @Param name path string true "服务账号名称"
+// @Param request body dto.ApiTokenCreate true "request"
+// @Success 200 {object} dto.ApiTokenCreated
+// @Security ApiKeyAuth
+// @Router /serviceaccounts/{name}/tokens/ [post]
+func (s ServiceAccountTokenController) Post() (*dto.ApiTokenCreated, error) {
+	name := s.Ctx.Params().GetString("name")
+	var req dto.ApiTokenCreate
+	if err := s.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+	if _, err := s.ServiceAccountService.Get(name); err != nil {
+		return nil, err
+	}
+	operator := s.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.CREATE_API_TOKEN, name+"-"+req.Name)
+	return s.ApiTokenService.Create(name, req)
+}
+
+// Delete ServiceAccount Token
+// @Tags serviceAccounts
+// @Summary Revoke an api token of a service account
+// @Description 吊销服务账号的 API Token
+// @Accept  json
+// @Produce  json
+// @Param name path string true "服务账号名称"
+// @Param token path string true "Token 名称"
+// @Security ApiKeyAuth
+// @Router /serviceaccounts/{name}/tokens/{token}/ [delete]
+func (s ServiceAccountTokenController) DeleteBy(token string) error {
+	name := s.Ctx.Params().GetString("name")
+	if _, err := s.ServiceAccountService.Get(name); err != nil {
+		return err
+	}
+	operator := s.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.DELETE_API_TOKEN, name+"-"+token)
+	return s.ApiTokenService.Delete(name, token)
+}
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"github.com/kmpp/pkg/db"
 	"github.com/kmpp/pkg/util/encrypt"
-	"github.com/jinzhu/gorm"
+	"net/http"
 	"time"
 
 	"github.com/kmpp/pkg/constant"
@@ -15,6 +15,7 @@ import (
 	"github.com/kmpp/pkg/service"
 	"github.com/kmpp/pkg/util/captcha"
 	"github.com/dgrijalva/jwt-go"
+	"github.com/go-playground/validator/v10"
 	"github.com/kataras/iris/v12/context"
 	"github.com/spf13/viper"
 )
@@ -22,11 +23,15 @@ import (
 type SessionController struct {
 	Ctx         context.Context
 	UserService service.UserService
+	SsoService  service.SsoService
+	MfaService  service.MfaService
 }
 
 func NewSessionController() *SessionController {
 	return &SessionController{
 		UserService: service.NewUserService(),
+		SsoService:  service.NewSsoService(),
+		MfaService:  service.NewMfaService(),
 	}
 }
 
@@ -55,16 +60,106 @@ func (s *SessionController) Post() (*dto.Profile, error) {
 			return nil, err
 		}
 	}
+	u, err := s.UserService.UserAuth(aul.Username, aul.Password)
+	if err != nil {
+		return nil, err
+	}
+	challenge, err := s.MfaService.Challenge(u, aul.AuthMethod)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return &dto.Profile{Mfa: challenge}, nil
+	}
+	return s.login(u, aul.AuthMethod)
+}
+
+// Mfa Login
+// @Tags auth
+// @Summary Complete login with a totp or recovery code
+// @Description 使用动态码或恢复码完成二次认证；首次绑定时校验通过即启用，并返回恢复码
+// @Param request body dto.MfaLogin true "request"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.Profile
+// @Router /auth/session/mfa [post]
+func (s *SessionController) PostMfa() (*dto.Profile, error) {
+	var req dto.MfaLogin
+	if err := s.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+	u, authMethod, err := s.challengeUser(req.Token)
+	if err != nil {
+		return nil, err
+	}
+	codes, err := s.MfaService.Verify(u, req.Token, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.login(u, authMethod)
+	if err != nil {
+		return nil, err
+	}
+	resp := *p
+	resp.RecoveryCodes = codes
+	return &resp, nil
+}
+
+// Mfa Enroll
+// @Tags auth
+// @Summary Enroll totp authenticator during login
+// @Description 管理员被要求启用二次认证但尚未绑定时，在登录过程中绑定认证器
+// @Param request body dto.MfaEnrollRequest true "request"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.MfaEnroll
+// @Router /auth/session/mfa/enroll [post]
+func (s *SessionController) PostMfaEnroll() (*dto.MfaEnroll, error) {
+	var req dto.MfaEnrollRequest
+	if err := s.Ctx.ReadJSON(&req); err != nil {
+		return nil, err
+	}
+	validate := validator.New()
+	if err := validate.Struct(req); err != nil {
+		return nil, err
+	}
+	u, _, err := s.challengeUser(req.Token)
+	if err != nil {
+		return nil, err
+	}
+	return s.MfaService.Enroll(u)
+}
+
+func (s *SessionController) challengeUser(token string) (*model.User, string, error) {
+	userID, authMethod, err := s.MfaService.ParseChallenge(token)
+	if err != nil {
+		return nil, "", err
+	}
+	var u model.User
+	if err := db.DB.Model(model.User{}).Where(&model.User{ID: userID}).Preload("CurrentProject").First(&u).Error; err != nil {
+		return nil, "", err
+	}
+	if !u.IsActive {
+		return nil, "", errors.New("USER_IS_NOT_ACTIVE")
+	}
+	return &u, authMethod, nil
+}
+
+func (s *SessionController) login(u *model.User, authMethod string) (*dto.Profile, error) {
 	var profile *dto.Profile
-	switch aul.AuthMethod {
+	switch authMethod {
 	case constant.AuthMethodJWT:
-		p, err := s.checkSessionLogin(aul.Username, aul.Password, true)
+		p, err := s.checkSessionLogin(u, true)
 		if err != nil {
 			return nil, err
 		}
 		profile = p
 	default:
-		p, err := s.checkSessionLogin(aul.Username, aul.Password, false)
+		p, err := s.checkSessionLogin(u, false)
 		if err != nil {
 			return nil, err
 		}
@@ -77,7 +172,7 @@ func (s *SessionController) Post() (*dto.Profile, error) {
 		session.Set(constant.SessionUserKey, profile)
 	}
 
-	go kolog.Save(aul.Username, constant.LOGIN, "-")
+	go kolog.Save(u.Name, constant.LOGIN, "-")
 
 	return profile, nil
 }
@@ -121,12 +216,11 @@ func (s *SessionController) Get() (*dto.Profile, error) {
 		return nil, err
 	}
 
-	roles, err := getUserRole(&mo)
+	sessionUser, err := s.UserService.GetSessionUser(&mo)
 	if err != nil {
 		return nil, err
 	}
-	p.User = toSessionUser(mo)
-	p.User.Roles = roles
+	p.User = *sessionUser
 	session.Set(constant.SessionUserKey, p)
 	return p, nil
 }
@@ -137,18 +231,106 @@ func (s *SessionController) GetStatus() (*dto.SessionStatus, error) {
 	return &dto.SessionStatus{IsLogin: user != nil}, nil
 }
 
-func (s *SessionController) checkSessionLogin(username string, password string, jwt bool) (*dto.Profile, error) {
-	u, err := s.UserService.UserAuth(username, password)
+// Sso Status
+// @Tags auth
+// @Summary Show whether sso login is enabled
+// @Description 获取单点登录是否启用
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.SsoStatus
+// @Router /auth/session/sso/status [get]
+func (s *SessionController) GetSsoStatus() (*dto.SsoStatus, error) {
+	return s.SsoService.Status()
+}
+
+// Sso Login
+// @Tags auth
+// @Summary Redirect to the oidc provider
+// @Description 跳转到 OIDC 认证服务登录
+// @Router /auth/session/sso [get]
+func (s *SessionController) GetSso() error {
+	req, err := s.SsoService.Login()
 	if err != nil {
-		return nil, err
+		return err
+	}
+	session := constant.Sess.Start(s.Ctx)
+	session.Set(constant.SessionSsoStateKey, req.State)
+	session.Set(constant.SessionSsoNonceKey, req.Nonce)
+	session.Set(constant.SessionSsoVerifierKey, req.Verifier)
+	s.Ctx.Redirect(req.URL, http.StatusFound)
+	return nil
+}
+
+// Sso Callback
+// @Tags auth
+// @Summary Oidc login callback
+// @Description OIDC 认证服务登录后的回调地址，首次登录时自动创建用户
+// @Param code query string true "授权码"
+// @Param state query string true "state"
+// @Router /auth/session/sso/callback [get]
+func (s *SessionController) GetSsoCallback() error {
+	session := constant.Sess.Start(s.Ctx)
+	state := session.GetString(constant.SessionSsoStateKey)
+	nonce := session.GetString(constant.SessionSsoNonceKey)
+	verifier := session.GetString(constant.SessionSsoVerifierKey)
+	session.Delete(constant.SessionSsoStateKey)
+	session.Delete(constant.SessionSsoNonceKey)
+	session.Delete(constant.SessionSsoVerifierKey)
+	if state == "" || s.Ctx.URLParam("state") != state {
+		return errors.New("SSO_STATE_MISMATCH")
+	}
+	if e := s.Ctx.URLParam("error"); e != "" {
+		return errors.New(e)
+	}
+	u, err := s.SsoService.Callback(s.Ctx.URLParam("code"), verifier, nonce)
+	if err != nil {
+		return err
+	}
+	// 需要二次认证时由登录页取回待验证 Token 后完成认证
+	challenge, err := s.MfaService.Challenge(u, constant.AuthMethodSession)
+	if err != nil {
+		return err
+	}
+	if challenge != nil {
+		session.Set(constant.SessionSsoMfaKey, challenge)
+		s.Ctx.Redirect("/login?mfa=sso", http.StatusFound)
+		return nil
+	}
+	sessionUser, err := s.UserService.GetSessionUser(u)
+	if err != nil {
+		return err
+	}
+	session.Set(constant.SessionUserKey, &dto.Profile{User: *sessionUser})
+	go kolog.Save(u.Name, constant.LOGIN, "SSO")
+	s.Ctx.Redirect("/", http.StatusFound)
+	return nil
+}
+
+// Sso Mfa Challenge
+// @Tags auth
+// @Summary Show the pending mfa challenge of an sso login
+// @Description 单点登录需要二次认证时，登录页获取待验证 Token 后调用 /auth/session/mfa 完成登录
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.MfaChallenge
+// @Router /auth/session/sso/mfa [get]
+func (s *SessionController) GetSsoMfa() (*dto.MfaChallenge, error) {
+	session := constant.Sess.Start(s.Ctx)
+	challenge, ok := session.Get(constant.SessionSsoMfaKey).(*dto.MfaChallenge)
+	if !ok {
+		return nil, errors.New("MFA_TOKEN_INVALID")
 	}
+	session.Delete(constant.SessionSsoMfaKey)
+	return challenge, nil
+}
+
+func (s *SessionController) checkSessionLogin(u *model.User, jwt bool) (*dto.Profile, error) {
 	resp := &dto.Profile{}
-	roles, err := getUserRole(u)
+	sessionUser, err := s.UserService.GetSessionUser(u)
 	if err != nil {
 		return nil, err
 	}
-	resp.User = toSessionUser(*u)
-	resp.User.Roles = roles
+	resp.User = *sessionUser
 	if jwt {
 		token, err := createToken(resp.User)
 		if err != nil {
@@ -159,18 +341,6 @@ func (s *SessionController) checkSessionLogin(username string, password string,
 	return resp, err
 }
 
-func toSessionUser(u model.User) dto.SessionUser {
-	return dto.SessionUser{
-		UserId:         u.ID,
-		Name:           u.Name,
-		Email:          u.Email,
-		Language:       u.Language,
-		IsActive:       u.IsActive,
-		IsAdmin:        u.IsAdmin,
-		CurrentProject: u.CurrentProject.Name,
-	}
-}
-
 func createToken(user dto.SessionUser) (string, error) {
 	exp := viper.GetInt("jwt.exp")
 	secretKey := []byte(viper.GetString("jwt.secret"))
@@ -193,71 +363,3 @@ func createToken(user dto.SessionUser) (string, error) {
 	return tokenString, err
 }
 
-func getUserRole(user *model.User) ([]string, error) {
-
-	if user.IsAdmin {
-		return []string{constant.RoleAdmin}, nil
-	}
-	if user.CurrentProject.Name == "" {
-		var projectMember model.ProjectMember
-		err := db.DB.Model(&model.ProjectMember{}).Where("user_id = ?", user.ID).Preload("Project").First(&projectMember).Error
-		if err != nil && !gorm.IsRecordNotFoundError(err) {
-			return nil, err
-		}
-		if gorm.IsRecordNotFoundError(err) {
-			var clusterMember model.ClusterMember
-			err := db.DB.Model(&model.ClusterMember{}).Where("user_id = ?", user.ID).First(&clusterMember).Error
-			if err != nil && !gorm.IsRecordNotFoundError(err) {
-				return nil, err
-			}
-			if gorm.IsRecordNotFoundError(err) {
-				return nil, errors.New("USER_HAS_NO_RESOURCE")
-			}
-			var projectResource model.ProjectResource
-			err = db.DB.Model(&model.ProjectResource{}).Where("resource_id = ?", clusterMember.ClusterID).Preload("Project").First(&projectResource).Error
-			if err != nil {
-				return nil, err
-			}
-			if projectResource.Project.Name != "" {
-				user.CurrentProject = projectResource.Project
-				user.CurrentProjectID = projectResource.Project.ID
-				db.DB.Save(user)
-			}
-			return []string{constant.RoleClusterManager}, nil
-		}
-		if projectMember.Project.Name != "" {
-			user.CurrentProject = projectMember.Project
-			user.CurrentProjectID = projectMember.Project.ID
-			db.DB.Save(user)
-		}
-		return []string{constant.RoleProjectManager}, nil
-	} else {
-		var project model.Project
-		err := db.DB.Model(model.Project{}).Where("name = ?", user.CurrentProject.Name).Find(&project).Error
-		if err != nil && !gorm.IsRecordNotFoundError(err) {
-			return nil, err
-		}
-		if gorm.IsRecordNotFoundError(err) {
-			user.CurrentProjectID = ""
-			db.DB.Save(user)
-			return nil, errors.New("project is not found")
-		}
-		var projectMember model.ProjectMember
-		err = db.DB.Model(&model.ProjectMember{}).Where("user_id = ? AND project_id = ?", user.ID, project.ID).First(&projectMember).Error
-		if err != nil && !gorm.IsRecordNotFoundError(err) {
-			return nil, err
-		}
-		if gorm.IsRecordNotFoundError(err) {
-			var clusterMember model.ClusterMember
-			err := db.DB.Model(&model.ClusterMember{}).Where("user_id = ?", user.ID).First(&clusterMember).Error
-			if err != nil && !gorm.IsRecordNotFoundError(err) {
-				return nil, err
-			}
-			if gorm.IsRecordNotFoundError(err) {
-				return nil, errors.New("USER_HAS_NO_RESOURCE")
-			}
-			return []string{constant.RoleClusterManager}, nil
-		}
-		return []string{constant.RoleProjectManager}, nil
-	}
-}
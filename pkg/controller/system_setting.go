@@ -20,11 +20,13 @@ var (
 type SystemSettingController struct {
 	Ctx                  context.Context
 	SystemSettingService service.SystemSettingService
+	LdapSyncService      service.LdapSyncService
 }
 
 func NewSystemSettingController() *SystemSettingController {
 	return &SystemSettingController{
 		SystemSettingService: service.NewSystemSettingService(),
+		LdapSyncService:      service.NewLdapSyncService(),
 	}
 }
 
@@ -124,6 +126,21 @@ func (s SystemSettingController) PostCheckBy(typeName string) error {
 	return nil
 }
 
+// Sync Ldap
+// @Tags SystemSetting
+// @Summary Sync ldap users and groups
+// @Description 同步 LDAP 用户和用户组映射，停用 LDAP 中已不存在的用户
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.LdapSyncResult
+// @Security ApiKeyAuth
+// @Router /settings/ldap/sync [post]
+func (s SystemSettingController) PostLdapSync() (*dto.LdapSyncResult, error) {
+	operator := s.Ctx.Values().GetString("operator")
+	go kolog.Save(operator, constant.SYNC_LDAP, "-")
+	return s.LdapSyncService.Sync()
+}
+
 // List Registry
 // @Tags SystemSetting
 // @Summary Show all Registry
@@ -186,9 +203,11 @@ func (s SystemSettingController) PostRegistry() (*dto.SystemRegistry, error) {
 	if err != nil {
 		return nil, err
 	}
-	item, _ := s.SystemSettingService.GetRegistryByArch(req.Architecture)
-	if item.ID != "" {
-		return nil, RegistryAlreadyExistsErr
+	items, _ := s.SystemSettingService.ListRegistry(condition.TODO())
+	for _, item := range items {
+		if item.Hostname == req.Hostname {
+			return nil, RegistryAlreadyExistsErr
+		}
 	}
 
 	operator := s.Ctx.Values().GetString("operator")
@@ -283,6 +302,19 @@ func (s SystemSettingController) PostRegistryBatch() error {
 	return err
 }
 
+// Check Registry
+// @Tags SystemSetting
+// @Summary Check all Registry
+// @Description 检查所有仓库的可用性
+// @Accept  json
+// @Produce  json
+// @Success 200 {Array} []dto.SystemRegistry
+// @Security ApiKeyAuth
+// @Router /settings/registry/check [post]
+func (s SystemSettingController) PostRegistryCheck() ([]dto.SystemRegistry, error) {
+	return s.SystemSettingService.CheckRegistries()
+}
+
 // Delete Registry
 // @Tags SystemSetting
 // @Summary Delete a Registry
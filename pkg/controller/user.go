@@ -130,6 +130,9 @@ func (u *UserController) Post() (*dto.User, error) {
 // @Security ApiKeyAuth
 // @Router /users/{name} [delete]
 func (u *UserController) DeleteBy(name string) error {
+	if before, err := u.UserService.Get(name); err == nil {
+		kolog.AuditBefore(u.Ctx, before)
+	}
 	operator := u.Ctx.Values().GetString("operator")
 	kolog.Save(operator, constant.DELETE_USER, name)
 
@@ -158,6 +161,9 @@ func (u *UserController) PatchBy(name string) (*dto.User, error) {
 	if err != nil {
 		return nil, err
 	}
+	if before, err := u.UserService.Get(name); err == nil {
+		kolog.AuditBefore(u.Ctx, before)
+	}
 	user, err := u.UserService.Update(name, req)
 	if err != nil {
 		return nil, err
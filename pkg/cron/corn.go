@@ -3,6 +3,8 @@ package cron
 import (
 	"fmt"
 	"github.com/kmpp/pkg/cron/job"
+	"github.com/kmpp/pkg/redis"
+	"github.com/kmpp/pkg/service"
 	"github.com/robfig/cron/v3"
 )
 
@@ -12,32 +14,71 @@ const phaseName = "cron"
 
 type InitCronPhase struct {
 	Enable bool
+	// 每分钟检查所有集群的健康状态，集群较多时会增加 apiserver 的负载，默认关闭
+	ClusterHealthCheck bool
 }
 
 func (c *InitCronPhase) Init() error {
-	Cron = cron.New()
+	Cron = cron.New(cron.WithChain(leaderOnly()))
 	if c.Enable {
 		_, err := Cron.AddJob("@hourly", job.NewRefreshHostInfo())
 		if err != nil {
 			return fmt.Errorf("can not add corn job: %s", err.Error())
 		}
-		_, err = Cron.AddJob("@daily", job.NewClusterBackup())
+		_, err = Cron.AddJob("@every 10m", job.NewClusterBackup())
 		if err != nil {
 			return fmt.Errorf("can not add backup corn job: %s", err.Error())
 		}
+		if err := service.InitClusterBackupSchedule(Cron); err != nil {
+			return fmt.Errorf("can not add cluster backup schedule: %s", err.Error())
+		}
 		_, err = Cron.AddJob("@every 10m", job.NewClusterEvent())
 		if err != nil {
 			return fmt.Errorf("can not add cluster event corn job: %s", err.Error())
 		}
-		//_, err = Cron.AddJob("@every 1m", job.NewClusterHealthCheck())
-		//if err != nil {
-		//	return fmt.Errorf("can not add cluster health check corn job: %s", err.Error())
-		//}
+		_, err = Cron.AddJob("@every 1m", job.NewMultiClusterSync())
+		if err != nil {
+			return fmt.Errorf("can not add multi cluster sync corn job: %s", err.Error())
+		}
+		_, err = Cron.AddJob("@every 1m", job.NewRegistryHealthCheck())
+		if err != nil {
+			return fmt.Errorf("can not add registry health check corn job: %s", err.Error())
+		}
+		_, err = Cron.AddJob("@every 30m", job.NewLdapSync())
+		if err != nil {
+			return fmt.Errorf("can not add ldap sync corn job: %s", err.Error())
+		}
+		_, err = Cron.AddJob("@every 1m", job.NewAlertEscalation())
+		if err != nil {
+			return fmt.Errorf("can not add alert escalation corn job: %s", err.Error())
+		}
+		_, err = Cron.AddJob("@every 12h", job.NewClusterCertificateCheck())
+		if err != nil {
+			return fmt.Errorf("can not add cluster certificate check corn job: %s", err.Error())
+		}
+		if c.ClusterHealthCheck {
+			// 失联的集群检查可能超过一分钟，上一次未结束时跳过本次
+			_, err = Cron.AddJob("@every 1m", cron.NewChain(cron.SkipIfStillRunning(cron.DiscardLogger)).Then(job.NewClusterHealthCheck()))
+			if err != nil {
+				return fmt.Errorf("can not add cluster health check corn job: %s", err.Error())
+			}
+		}
 		Cron.Start()
 	}
 	return nil
 }
 
+// leaderOnly 多副本部署时定时任务只在主副本上执行
+func leaderOnly() cron.JobWrapper {
+	return func(j cron.Job) cron.Job {
+		return cron.FuncJob(func() {
+			if redis.IsLeader() {
+				j.Run()
+			}
+		})
+	}
+}
+
 func (c *InitCronPhase) PhaseName() string {
 	return phaseName
 }
@@ -0,0 +1,22 @@
+package job
+
+import (
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/service"
+)
+
+type AlertEscalation struct {
+	messageService service.MessageService
+}
+
+func NewAlertEscalation() *AlertEscalation {
+	return &AlertEscalation{
+		messageService: service.NewMessageService(),
+	}
+}
+
+func (a *AlertEscalation) Run() {
+	if err := a.messageService.EscalateAlerts(); err != nil {
+		logger.Log.Errorf("escalate alerts error %s", err.Error())
+	}
+}
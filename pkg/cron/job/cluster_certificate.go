@@ -0,0 +1,35 @@
+package job
+
+import (
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/service"
+)
+
+type ClusterCertificateCheck struct {
+	clusterService            service.ClusterService
+	clusterCertificateService service.ClusterCertificateService
+}
+
+func NewClusterCertificateCheck() *ClusterCertificateCheck {
+	return &ClusterCertificateCheck{
+		clusterService:            service.NewClusterService(),
+		clusterCertificateService: service.NewClusterCertificateService(),
+	}
+}
+
+func (c *ClusterCertificateCheck) Run() {
+	clusters, err := c.clusterService.List()
+	if err != nil {
+		logger.Log.Errorf("list clusters error %s", err.Error())
+		return
+	}
+	for _, cluster := range clusters {
+		if cluster.Source != constant.ClusterSourceLocal || cluster.Status != constant.StatusRunning {
+			continue
+		}
+		if _, err := c.clusterCertificateService.Check(cluster.Name); err != nil {
+			logger.Log.Errorf("check certificates of cluster %s error %s", cluster.Name, err.Error())
+		}
+	}
+}
@@ -48,6 +48,7 @@ func (c *ClusterEvent) Run() {
 			if err != nil {
 				continue
 			}
+			cluster := cluster
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
@@ -5,6 +5,7 @@ import (
 
 	"github.com/kmpp/pkg/constant"
 	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
 	"github.com/kmpp/pkg/logger"
 	"github.com/kmpp/pkg/service"
 	kubeUtil "github.com/kmpp/pkg/util/kubernetes"
@@ -12,18 +13,20 @@ import (
 
 type ClusterHealthCheck struct {
 	clusterService service.ClusterService
+	messageService service.MessageService
 }
 
 func NewClusterHealthCheck() *ClusterHealthCheck {
 	return &ClusterHealthCheck{
 		clusterService: service.NewClusterService(),
+		messageService: service.NewMessageService(),
 	}
 }
 
 func (c *ClusterHealthCheck) Run() {
 	cs, err := c.clusterService.List()
 	if err != nil {
-		logger.Log.Error("list clusters error %s", err.Error())
+		logger.Log.Errorf("list clusters error %s", err.Error())
 		return
 	}
 	var wg sync.WaitGroup
@@ -41,22 +44,18 @@ func (c *ClusterHealthCheck) Run() {
 			logger.Log.Infof("test cluster  %s api  ", cs[i].Name)
 			endpoints, err := c.clusterService.GetApiServerEndpoints(cs[i].Name)
 			if err != nil {
-				logger.Log.Error("get cluster %s endpoint error %s", cs[i].Name, err.Error())
+				logger.Log.Errorf("get cluster %s endpoint error %s", cs[i].Name, err.Error())
 				return
 			}
 			secret, err := c.clusterService.GetSecrets(cs[i].Name)
 			if err != nil {
-				logger.Log.Error("get cluster %s secret error %s", cs[i].Name, err.Error())
+				logger.Log.Errorf("get cluster %s secret error %s", cs[i].Name, err.Error())
 				return
 			}
 			_, err = kubeUtil.SelectAliveHost(endpoints)
 			if err != nil {
-				logger.Log.Error("ping cluster %s api failed: %+v", cs[i].Name, err)
-				cs[i].Cluster.Status.Phase = constant.StatusLost
-				if err := db.DB.Save(&cs[i].Cluster.Status).Error; err != nil {
-					logger.Log.Error("save cluster %s status error %s", cs[i].Name, err.Error())
-					return
-				}
+				logger.Log.Errorf("ping cluster %s api failed: %+v", cs[i].Name, err)
+				c.markLost(&cs[i], err)
 				return
 			}
 			client, err := kubeUtil.NewKubernetesClient(&kubeUtil.Config{
@@ -64,27 +63,37 @@ func (c *ClusterHealthCheck) Run() {
 				Token: secret.KubernetesToken,
 			})
 			if err != nil {
-				logger.Log.Error("get cluster %s api client error %+v", cs[i].Name, err)
+				logger.Log.Errorf("get cluster %s api client error %+v", cs[i].Name, err)
 				return
 			}
 			_, err = client.ServerVersion()
 			if err != nil {
-				logger.Log.Error("ping cluster %s api error %s", cs[i].Name, err.Error())
-				cs[i].Cluster.Status.Phase = constant.StatusLost
-				if err := db.DB.Save(&cs[i].Cluster.Status).Error; err != nil {
-					logger.Log.Error("save cluster %s status error %s", cs[i].Name, err.Error())
-					return
-				}
+				logger.Log.Errorf("ping cluster %s api error %s", cs[i].Name, err.Error())
+				c.markLost(&cs[i], err)
 				return
 			}
 			if cs[i].Cluster.Status.Phase == constant.StatusLost {
 				cs[i].Cluster.Status.Phase = constant.StatusRunning
 				if err := db.DB.Save(&cs[i].Cluster.Status).Error; err != nil {
-					logger.Log.Error("save cluster %s status error %s", cs[i].Name, err.Error())
+					logger.Log.Errorf("save cluster %s status error %s", cs[i].Name, err.Error())
 					return
 				}
+				_ = c.messageService.SendMessage(constant.System, true, service.GetContent(constant.ClusterHealth, true, ""), cs[i].Name, constant.ClusterHealth)
 			}
 		}()
 	}
 	wg.Wait()
 }
+
+// markLost 只在集群从运行中变为失联时发送消息，避免每次检查都重复通知
+func (c *ClusterHealthCheck) markLost(cluster *dto.Cluster, err error) {
+	if cluster.Cluster.Status.Phase == constant.StatusLost {
+		return
+	}
+	cluster.Cluster.Status.Phase = constant.StatusLost
+	if err := db.DB.Save(&cluster.Cluster.Status).Error; err != nil {
+		logger.Log.Errorf("save cluster %s status error %s", cluster.Name, err.Error())
+		return
+	}
+	_ = c.messageService.SendMessage(constant.System, false, service.GetContent(constant.ClusterHealth, false, err.Error()), cluster.Name, constant.ClusterHealth)
+}
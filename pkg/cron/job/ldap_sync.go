@@ -0,0 +1,34 @@
+package job
+
+import (
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/service"
+)
+
+type LdapSync struct {
+	systemSettingService service.SystemSettingService
+	ldapSyncService      service.LdapSyncService
+}
+
+func NewLdapSync() *LdapSync {
+	return &LdapSync{
+		systemSettingService: service.NewSystemSettingService(),
+		ldapSyncService:      service.NewLdapSyncService(),
+	}
+}
+
+func (l *LdapSync) Run() {
+	status, err := l.systemSettingService.Get("ldap_status")
+	if err != nil || status.Value != constant.Enable {
+		return
+	}
+	result, err := l.ldapSyncService.Sync()
+	if err != nil {
+		logger.Log.Errorf("ldap sync error %s", err.Error())
+		return
+	}
+	for _, e := range result.Errors {
+		logger.Log.Warnf("ldap sync: %s", e)
+	}
+}
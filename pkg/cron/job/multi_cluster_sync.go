@@ -0,0 +1,50 @@
+package job
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/service"
+)
+
+type MultiClusterSync struct {
+	multiClusterRepositoryService service.MultiClusterRepositoryService
+}
+
+func NewMultiClusterSync() *MultiClusterSync {
+	return &MultiClusterSync{
+		multiClusterRepositoryService: service.NewMultiClusterRepositoryService(),
+	}
+}
+
+func (m *MultiClusterSync) Run() {
+	repos, err := m.multiClusterRepositoryService.List()
+	if err != nil {
+		logger.Log.Errorf("list multi cluster repositories error %s", err.Error())
+		return
+	}
+	var wg sync.WaitGroup
+	for i := range repos {
+		repo := repos[i]
+		if !repo.SyncEnable || repo.Status != constant.StatusRunning || repo.SyncRunning(time.Now()) {
+			continue
+		}
+		interval := repo.SyncInterval
+		if interval <= 0 {
+			interval = constant.DefaultMultiClusterSyncInterval
+		}
+		if time.Since(repo.LastSyncTime) < time.Duration(interval)*time.Minute {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := m.multiClusterRepositoryService.Sync(repo.Name, false); err != nil {
+				logger.Log.Errorf("sync multi cluster repository %s error %s", repo.Name, err.Error())
+			}
+		}()
+	}
+	wg.Wait()
+}
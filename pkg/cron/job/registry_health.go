@@ -0,0 +1,30 @@
+package job
+
+import (
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/service"
+)
+
+type RegistryHealthCheck struct {
+	systemSettingService service.SystemSettingService
+}
+
+func NewRegistryHealthCheck() *RegistryHealthCheck {
+	return &RegistryHealthCheck{
+		systemSettingService: service.NewSystemSettingService(),
+	}
+}
+
+func (r *RegistryHealthCheck) Run() {
+	registries, err := r.systemSettingService.CheckRegistries()
+	if err != nil {
+		logger.Log.Errorf("check registries error %s", err.Error())
+		return
+	}
+	for _, item := range registries {
+		if item.Status == constant.RegistryStatusUnhealthy {
+			logger.Log.Warnf("registry %s(%s) is unhealthy: %s", item.Hostname, item.Architecture, item.Message)
+		}
+	}
+}
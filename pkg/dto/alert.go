@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/kmpp/pkg/model"
+)
+
+type AlertRoute struct {
+	model.AlertRoute
+	SendTypes     []string `json:"sendTypes"`
+	Users         []string `json:"users"`
+	EscalateUsers []string `json:"escalateUsers"`
+}
+
+// AlertRouteCreate Users 和 EscalateUsers 为用户名，EscalateAfter 和 RepeatInterval 单位为分钟
+type AlertRouteCreate struct {
+	Name           string   `json:"name" validate:"required,max=255"`
+	Priority       int      `json:"priority"`
+	ClusterName    string   `json:"clusterName"`
+	ProjectName    string   `json:"projectName"`
+	MessageType    string   `json:"messageType"`
+	Level          string   `json:"level" validate:"omitempty,oneof=Info Warning"`
+	SendTypes      []string `json:"sendTypes" validate:"required,dive,oneof=EMAIL DING_TALK WORK_WEIXIN WEBHOOK LOCAL"`
+	Users          []string `json:"users"`
+	EscalateUsers  []string `json:"escalateUsers"`
+	EscalateAfter  int      `json:"escalateAfter" validate:"min=0"`
+	RepeatInterval int      `json:"repeatInterval" validate:"min=0"`
+}
+
+type AlertRouteUpdate struct {
+	Priority       int      `json:"priority"`
+	ClusterName    string   `json:"clusterName"`
+	ProjectName    string   `json:"projectName"`
+	MessageType    string   `json:"messageType"`
+	Level          string   `json:"level" validate:"omitempty,oneof=Info Warning"`
+	SendTypes      []string `json:"sendTypes" validate:"required,dive,oneof=EMAIL DING_TALK WORK_WEIXIN WEBHOOK LOCAL"`
+	Users          []string `json:"users"`
+	EscalateUsers  []string `json:"escalateUsers"`
+	EscalateAfter  int      `json:"escalateAfter" validate:"min=0"`
+	RepeatInterval int      `json:"repeatInterval" validate:"min=0"`
+}
+
+type AlertSilence struct {
+	model.AlertSilence
+	Active bool `json:"active"`
+}
+
+type AlertSilenceCreate struct {
+	ClusterName string    `json:"clusterName"`
+	ProjectName string    `json:"projectName"`
+	MessageType string    `json:"messageType"`
+	Level       string    `json:"level" validate:"omitempty,oneof=Info Warning"`
+	StartsAt    time.Time `json:"startsAt"`
+	EndsAt      time.Time `json:"endsAt" validate:"required"`
+	Comment     string    `json:"comment" validate:"max=255"`
+}
+
+type Alert struct {
+	model.Alert
+	RouteName string `json:"routeName"`
+}
+
+// AlertmanagerWebhook Alertmanager webhook_configs 发送的请求体
+type AlertmanagerWebhook struct {
+	Status       string              `json:"status"`
+	Receiver     string              `json:"receiver"`
+	CommonLabels map[string]string   `json:"commonLabels"`
+	Alerts       []AlertmanagerAlert `json:"alerts"`
+}
+
+type AlertmanagerAlert struct {
+	Status      string            `json:"status"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    time.Time         `json:"startsAt"`
+	EndsAt      time.Time         `json:"endsAt"`
+	Fingerprint string            `json:"fingerprint"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "github.com/kmpp/pkg/model"
+
+type ApiToken struct {
+	model.ApiToken
+	Username string   `json:"username"`
+	Scopes   []string `json:"scopes"`
+}
+
+type ApiTokenCreate struct {
+	Name       string   `json:"name" validate:"required"`
+	Scopes     []string `json:"scopes" validate:"required"`
+	ExpireDays int      `json:"expireDays" validate:"min=0"`
+}
+
+// ApiTokenCreated 创建成功时返回的 Token 明文只会出现这一次
+type ApiTokenCreated struct {
+	ApiToken
+	Token string `json:"token"`
+}
+
+type ServiceAccountCreate struct {
+	Name string `json:"name" validate:"required"`
+	Role string `json:"role"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+import "github.com/kmpp/pkg/model"
+
+type AuditEvent struct {
+	model.AuditEvent
+}
+
+type AuditEventCreate struct {
+	Actor        string
+	SourceIP     string
+	ResourceType string
+	ResourceName string
+	Action       string
+	Method       string
+	Path         string
+	RequestID    string
+	StatusCode   int
+	Before       string
+	After        string
+}
+
+// AuditFilter 除 ResourceName 为模糊匹配外其余字段均为精确匹配，Start/End 为毫秒时间戳
+type AuditFilter struct {
+	Actor        string `json:"actor"`
+	SourceIP     string `json:"sourceIp"`
+	ResourceType string `json:"resourceType"`
+	ResourceName string `json:"resourceName"`
+	Action       string `json:"action"`
+	RequestID    string `json:"requestId"`
+	Outcome      string `json:"outcome"`
+	Start        int64  `json:"start"`
+	End          int64  `json:"end"`
+}
+
+type AuditVerifyResult struct {
+	Valid     bool   `json:"valid"`
+	Total     int64  `json:"total"`
+	BrokenSeq int64  `json:"brokenSeq"`
+	Reason    string `json:"reason"`
+}
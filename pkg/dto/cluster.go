@@ -1,6 +1,10 @@
 package dto
 
-import "github.com/kmpp/pkg/model"
+import (
+	"time"
+
+	"github.com/kmpp/pkg/model"
+)
 
 type Cluster struct {
 	model.Cluster
@@ -126,3 +130,20 @@ type ClusterInfo struct {
 	Name     string `json:"name"`
 	Provider string `json:"provider"`
 }
+
+type ClusterPhases struct {
+	Type   string         `json:"type"`
+	Phases []ClusterPhase `json:"phases"`
+}
+
+type ClusterPhase struct {
+	Name          string    `json:"name"`
+	Status        string    `json:"status"`
+	Message       string    `json:"message"`
+	LastProbeTime time.Time `json:"lastProbeTime"`
+}
+
+type ClusterPhaseOperation struct {
+	Phase     string `json:"phase"`
+	Operation string `json:"operation"`
+}
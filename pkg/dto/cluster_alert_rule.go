@@ -0,0 +1,28 @@
+package dto
+
+import "github.com/kmpp/pkg/model"
+
+// AlertRule Prometheus 告警规则，For 为持续时间，如 5m
+type AlertRule struct {
+	Alert       string            `json:"alert" validate:"required"`
+	Expr        string            `json:"expr" validate:"required"`
+	For         string            `json:"for,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type ClusterAlertRuleGroup struct {
+	model.ClusterAlertRuleGroup
+	Rules []AlertRule `json:"rules"`
+}
+
+type ClusterAlertRuleGroupCreate struct {
+	Name     string      `json:"name" validate:"required,max=255"`
+	Interval string      `json:"interval"`
+	Rules    []AlertRule `json:"rules" validate:"required,min=1,dive"`
+}
+
+type ClusterAlertRuleGroupUpdate struct {
+	Interval string      `json:"interval"`
+	Rules    []AlertRule `json:"rules" validate:"required,min=1,dive"`
+}
@@ -0,0 +1,58 @@
+package dto
+
+// ClusterApply 声明式的集群描述，未填写的部分保持不变
+type ClusterApply struct {
+	Name           string                      `json:"name" validate:"required"`
+	Spec           map[string]interface{}      `json:"spec"`
+	Nodes          []ClusterApplyNode          `json:"nodes"`
+	Tools          []ClusterApplyComponent     `json:"tools"`
+	Istios         []ClusterApplyComponent     `json:"istios"`
+	Provisioners   []ClusterApplyProvisioner   `json:"provisioners"`
+	BackupStrategy *ClusterApplyBackupStrategy `json:"backupStrategy"`
+	// Prune 为 true 时删除文档中未声明的存储供应商
+	Prune bool `json:"prune"`
+}
+
+type ClusterApplyNode struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+	Host string `json:"host"`
+}
+
+type ClusterApplyComponent struct {
+	Name   string                 `json:"name"`
+	Enable bool                   `json:"enable"`
+	Vars   map[string]interface{} `json:"vars"`
+}
+
+type ClusterApplyProvisioner struct {
+	Name string                 `json:"name"`
+	Type string                 `json:"type"`
+	Vars map[string]interface{} `json:"vars"`
+}
+
+type ClusterApplyBackupStrategy struct {
+	Cron              string `json:"cron"`
+	SaveNum           int    `json:"saveNum"`
+	RetainHourly      int    `json:"retainHourly"`
+	RetainDaily       int    `json:"retainDaily"`
+	RetainWeekly      int    `json:"retainWeekly"`
+	VerifyCron        string `json:"verifyCron"`
+	BackupAccountName string `json:"backupAccountName"`
+	Status            string `json:"status"`
+}
+
+type ClusterApplyDiff struct {
+	Cluster string               `json:"cluster"`
+	Changes []ClusterApplyChange `json:"changes"`
+}
+
+type ClusterApplyChange struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Action  string `json:"action"`
+	Current string `json:"current"`
+	Desired string `json:"desired"`
+	// Status 执行时的进度，Diff 不返回
+	Status string `json:"status,omitempty"`
+}
@@ -11,6 +11,7 @@ type ClusterBackupFileCreate struct {
 	Name                    string `json:"name"`
 	ClusterBackupStrategyID string `json:"clusterBackupStrategyId" validate:"required"`
 	Folder                  string `json:"folder"`
+	Checksum                string `json:"checksum"`
 }
 
 type ClusterBackupFileOp struct {
@@ -24,3 +25,8 @@ type ClusterBackupFileRestore struct {
 	File          model.ClusterBackupFile `json:"file"`
 	BackupAccount model.BackupAccount     `json:"backupAccount"`
 }
+
+type ClusterBackupFileVerify struct {
+	ClusterName string `json:"clusterName" validate:"required"`
+	Name        string `json:"name" validate:"required"`
+}
@@ -10,8 +10,12 @@ type ClusterBackupStrategy struct {
 
 type ClusterBackupStrategyRequest struct {
 	ID                string `json:"id"`
-	Cron              int    `json:"cron"  validate:"min=1,max=300" en:"Backup Interval" zh:"备份间隔"`
+	Cron              string `json:"cron"  validate:"required" en:"Backup Schedule" zh:"备份计划"`
 	SaveNum           int    `json:"saveNum"  validate:"min=1,max=100" en:"Keep Copies" zh:"保留份数"`
+	RetainHourly      int    `json:"retainHourly"  validate:"min=0,max=168" en:"Hourly Copies" zh:"保留小时备份数"`
+	RetainDaily       int    `json:"retainDaily"  validate:"min=0,max=90" en:"Daily Copies" zh:"保留每日备份数"`
+	RetainWeekly      int    `json:"retainWeekly"  validate:"min=0,max=104" en:"Weekly Copies" zh:"保留每周备份数"`
+	VerifyCron        string `json:"verifyCron"`
 	BackupAccountName string `json:"backupAccountName" validate:"required"`
 	ClusterName       string `json:"clusterName" validate:"required"`
 	Status            string `json:"status"`
@@ -0,0 +1,8 @@
+package dto
+
+import "github.com/kmpp/pkg/model"
+
+type ClusterCertificate struct {
+	model.ClusterCertificate
+	DaysLeft int `json:"daysLeft"`
+}
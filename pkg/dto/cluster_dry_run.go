@@ -0,0 +1,27 @@
+package dto
+
+import "github.com/KubeOperator/kobe/api"
+
+type ClusterDryRun struct {
+	Operation string              `json:"operation"`
+	Cluster   string              `json:"cluster"`
+	Inventory *api.Inventory      `json:"inventory"`
+	Vars      map[string]string   `json:"vars"`
+	Steps     []ClusterDryRunStep `json:"steps"`
+	Hosts     []ClusterDryRunHost `json:"hosts"`
+}
+
+type ClusterDryRunStep struct {
+	Name     string `json:"name"`
+	Playbook string `json:"playbook"`
+	Tag      string `json:"tag"`
+}
+
+type ClusterDryRunHost struct {
+	Name   string `json:"name"`
+	Role   string `json:"role"`
+	Zone   string `json:"zone"`
+	Cpu    int    `json:"cpu"`
+	Memory int    `json:"memory"`
+	Action string `json:"action"`
+}
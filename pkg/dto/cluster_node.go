@@ -17,6 +17,14 @@ type NodeBatch struct {
 	Increase   int      `json:"increase"`
 	Operation  string   `json:"operation"`
 	SupportGpu string   `json:"supportGpu"`
+	Role       string   `json:"role"`
+}
+
+// NodeReplace 使用新主机替换故障的 master 节点
+type NodeReplace struct {
+	Node       string `json:"node" validate:"required"`
+	Host       string `json:"host" validate:"required"`
+	BackupFile string `json:"backupFile"`
 }
 
 type NodePage struct {
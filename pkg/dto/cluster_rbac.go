@@ -0,0 +1,7 @@
+package dto
+
+// KubernetesIdentity 用户通过 API 代理访问集群时模拟的 Kubernetes 身份
+type KubernetesIdentity struct {
+	User   string   `json:"user"`
+	Groups []string `json:"groups"`
+}
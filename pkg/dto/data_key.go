@@ -0,0 +1,15 @@
+package dto
+
+import "github.com/kmpp/pkg/model"
+
+// DataKey Rows 为仍使用该版本加密的值的数量，为 0 的旧版本可以安全地从备份中移除
+type DataKey struct {
+	model.DataKey
+	Rows int `json:"rows"`
+}
+
+type DataKeyList struct {
+	Items        []DataKey `json:"items"`
+	Plaintext    int       `json:"plaintext"`
+	Reencrypting bool      `json:"reencrypting"`
+}
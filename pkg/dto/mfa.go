@@ -0,0 +1,35 @@
+package dto
+
+// MfaChallenge 密码校验通过后需要继续完成二次认证，Enroll 为 true 时需要先绑定认证器
+type MfaChallenge struct {
+	Token  string `json:"token"`
+	Enroll bool   `json:"enroll"`
+}
+
+type MfaLogin struct {
+	Token string `json:"token" validate:"required"`
+	Code  string `json:"code" validate:"required"`
+}
+
+type MfaEnrollRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type MfaStatus struct {
+	Enabled  bool `json:"enabled"`
+	Required bool `json:"required"`
+}
+
+type MfaEnroll struct {
+	Secret string `json:"secret"`
+	URL    string `json:"url"`
+	QRCode string `json:"qrCode"`
+}
+
+type MfaCode struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type MfaRecoveryCodes struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
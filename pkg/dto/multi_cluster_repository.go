@@ -7,8 +7,8 @@ type MultiClusterRepository struct {
 }
 
 type MultiClusterRepositoryCreateRequest struct {
-	Name     string `json:"name"`
-	Source   string `json:"source"`
+	Name     string `json:"name" validate:"required"`
+	Source   string `json:"source" validate:"required"`
 	Branch   string `json:"branch"`
 	Username string `json:"username"`
 	Password string `json:"password"`
@@ -17,7 +17,8 @@ type MultiClusterRepositoryCreateRequest struct {
 type MultiClusterRepositoryUpdateRequest struct {
 	GitTimeout   int64 `json:"gitTimeout"`
 	SyncInterval int64 `json:"syncInterval"`
-	SyncEnable   bool  `json:"syncEnable"`
+	// 未传时保持原值
+	SyncEnable *bool `json:"syncEnable"`
 }
 
 type UpdateRelationRequest struct {
@@ -16,6 +16,7 @@ type ProjectMemberOP struct {
 
 type ProjectMemberCreate struct {
 	Usernames []string `json:"usernames" validate:"required"`
+	Role      string   `json:"role"`
 }
 
 type AddMemberResponse struct {
@@ -0,0 +1,39 @@
+package dto
+
+import "github.com/kmpp/pkg/model"
+
+// RoleRule 与 grbac 的 AdvancedRule 写法一致，Path 支持 {**} 等通配符
+type RoleRule struct {
+	Path   []string `json:"path" validate:"required"`
+	Method []string `json:"method" validate:"required"`
+}
+
+type Role struct {
+	model.Role
+	Rules []RoleRule `json:"rules"`
+}
+
+type RoleCreate struct {
+	Name        string     `json:"name" validate:"required,max=255"`
+	Description string     `json:"description" validate:"max=255"`
+	Rules       []RoleRule `json:"rules" validate:"required,dive"`
+}
+
+type RoleUpdate struct {
+	Description string     `json:"description" validate:"max=255"`
+	Rules       []RoleRule `json:"rules" validate:"required,dive"`
+}
+
+type RoleBinding struct {
+	model.RoleBinding
+	RoleName     string `json:"roleName"`
+	Username     string `json:"username"`
+	ResourceName string `json:"resourceName"`
+}
+
+// RoleBindingCreate 项目或集群级绑定需要指定 ResourceName，系统级绑定作用于全部资源
+type RoleBindingCreate struct {
+	Username     string `json:"username" validate:"required"`
+	Scope        string `json:"scope" validate:"required,oneof=SYSTEM PROJECT CLUSTER"`
+	ResourceName string `json:"resourceName"`
+}
@@ -21,8 +21,10 @@ type SessionUser struct {
 }
 
 type Profile struct {
-	User  SessionUser `json:"user"`
-	Token string      `json:"token,omitempty"`
+	User          SessionUser   `json:"user"`
+	Token         string        `json:"token,omitempty"`
+	Mfa           *MfaChallenge `json:"mfa,omitempty"`
+	RecoveryCodes []string      `json:"recoveryCodes,omitempty"`
 }
 
 type Captcha struct {
@@ -44,3 +46,7 @@ func (s SessionUser) IsRole(userRole string) bool {
 	}
 	return result
 }
+
+type SsoStatus struct {
+	Enable bool `json:"enable"`
+}
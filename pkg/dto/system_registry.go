@@ -14,6 +14,7 @@ type SystemRegistryCreate struct {
 	RepoPort           int    `json:"repoPort" validate:"required"`
 	RegistryPort       int    `json:"registryPort" validate:"required"`
 	RegistryHostedPort int    `json:"registryHostedPort" validate:"required"`
+	Priority           int    `json:"priority"`
 }
 
 type SystemRegistryUpdate struct {
@@ -23,6 +24,7 @@ type SystemRegistryUpdate struct {
 	RepoPort           int    `json:"repoPort" validate:"required"`
 	RegistryPort       int    `json:"registryPort" validate:"required"`
 	RegistryHostedPort int    `json:"registryHostedPort" validate:"required"`
+	Priority           int    `json:"priority"`
 }
 
 type SystemRegistryDelete struct {
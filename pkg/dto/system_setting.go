@@ -20,3 +20,12 @@ type SystemSettingResult struct {
 	Vars map[string]string `json:"vars" validate:"required"`
 	Tab  string            `json:"tab" validate:"required"`
 }
+
+type LdapSyncResult struct {
+	Created        int      `json:"created"`
+	Deactivated    []string `json:"deactivated"`
+	Reactivated    []string `json:"reactivated"`
+	MembersAdded   int      `json:"membersAdded"`
+	MembersRemoved int      `json:"membersRemoved"`
+	Errors         []string `json:"errors"`
+}
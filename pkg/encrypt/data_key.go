@@ -0,0 +1,16 @@
+package encrypt
+
+import "github.com/kmpp/pkg/service"
+
+const dataKeyPhaseName = "data key"
+
+// InitDataKeyPhase 在数据库迁移和 redis 初始化之后注册信封加密的数据密钥
+type InitDataKeyPhase struct{}
+
+func (i *InitDataKeyPhase) Init() error {
+	return service.InitDataKeyStore()
+}
+
+func (i *InitDataKeyPhase) PhaseName() string {
+	return dataKeyPhaseName
+}
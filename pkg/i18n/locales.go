@@ -25,7 +25,7 @@ func bindata_read(data []byte, name string) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-var _locales_en_us_home_yml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x58\xcd\x72\xa3\x3a\x16\xde\xf3\x14\x0a\xa9\xde\xf5\xdc\xba\xeb\xec\x68\x4c\x12\xa6\x6d\xa0\x00\xe7\xde\xcc\x86\x92\xe1\xd8\xd6\x04\x4b\x94\x24\x92\xf1\xdd\xcd\x7b\xcd\x3b\xcd\x2b\x4c\x1d\x49\x60\xb0\x9d\x6e\x77\xcd\x2a\xb1\xad\xf3\xa3\xf3\xf3\x9d\xef\xe8\xbe\x16\x87\x83\xe0\x5e\x12\xac\xa2\x2a\xfa\x33\x2e\xca\xe2\x81\xf8\x09\x3d\x00\xa1\xad\x04\xda\x1c\x09\xfc\x8b\x29\xad\x7c\x2f\xce\xaa\x24\x2d\x4f\x87\xb2\x16\xa8\x02\xb2\x65\x6d\x4b\x18\x27\x7a\x0f\x44\xc2\x8e\x29\x2d\x8f\x24\xce\x88\xb0\x5f\xa9\xa3\xd2\x70\x20\x0a\xb4\x66\x7c\x47\x3a\xba\x03\xdf\xf3\xbc\xfb\xba\xed\x95\x06\xe9\x85\xcb\x75\x51\x46\x79\xb5\x88\x96\x51\x19\x55\x8f\x41\xbc\x8c\x16\x0f\xc4\xaf\x29\x27\x5c\x68\xd2\x40\x0b\x1a\x88\x3b\x8e\x86\xea\x5e\x4a\xe0\x9a\x28\x4d\x35\xf8\xa3\x82\xb8\x30\xee\xe5\xeb\x24\x89\x93\xa7\x07\xe2\x97\xfb\x89\x98\x32\xca\x64\xcf\x39\xe3\xbb\x0b\xa1\x65\x1a\x06\xcb\x07\xe2\xc7\x87\x4e\x48\x3d\x4a\xd5\x94\xa3\xd4\x06\x48\xdf\xed\x24\x6d\xa0\xf1\xd1\x73\x09\x0d\x70\xcd\x68\xeb\xcd\x9c\xae\xf2\xa8\x48\xd7\x79\x18\x3d\x10\xff\x91\xb2\x16\x1a\xa2\x85\xf3\xff\x8e\x94\x7b\x90\x80\x7e\x50\x4e\xa8\x52\xa2\x66\x54\x43\x43\xf6\x42\x69\xd2\xf3\x06\x24\xd1\x7b\xa6\xc8\x1b\x1c\xfd\x4f\xd4\x56\xff\x48\x93\x5f\xd2\xfd\x97\xe0\x70\x45\xf7\x63\xb0\x5e\x96\x55\x98\x47\x8b\x28\x29\xe3\x60\x59\x85\x41\x62\xa2\x60\xcd\x3e\x10\x7f\x01\x5b\xda\xb7\x9a\x9c\x6e\x3a\x09\x85\x35\xda\xf8\xb6\x64\xc2\xe7\x28\xfc\x7e\xca\x9a\x89\xf9\x49\x8a\x63\x1d\x9d\x44\x4d\x3d\x98\xd2\x52\xe6\xff\x5e\x81\x34\x67\x7c\x2f\x0b\x8a\xe2\x8f\x34\x5f\x8c\xce\x24\xeb\x25\x66\xa4\xa3\x4a\x7d\x08\xd9\x90\xa1\x1e\x36\x40\x36\x2d\xe5\x6f\xff\xfd\xcf\xbf\x7d\x2f\xcb\xe3\x97\xa0\x8c\xaa\xef\xd1\xeb\xb9\x20\x7a\xd2\x49\xf6\x4e\x35\xe0\xc5\x27\x5e\x9c\xc4\xbd\x7b\x0c\xbf\xf7\x9c\x16\x65\x15\x2c\xf3\x28\x58\xbc\x9e\xca\xfb\x19\x33\x73\xde\x03\x2e\x33\x46\x62\xbc\xf4\xd5\x84\xd8\xcc\x62\x4e\x9c\x8a\x49\x62\x3e\x98\xde\x9b\x00\xb8\x42\xbb\xa6\xb7\xfa\xf6\x5a\x65\x79\xfa\xf7\x28\x2c\xff\x2f\x13\x9d\x14\xff\x84\x5a\xfb\x5e\xf1\x5a\x94\xd1\xaa\x72\x5d\xfc\x98\xae\x93\xc5\xf5\x26\x6e\x45\x4d\x5b\xec\xe0\x2d\x93\x4a\x9b\x40\x25\x29\xca\x05\x2f\x41\xbc\x0c\xbe\x2d\xb1\x44\x12\x41\xe2\x8e\xd0\x77\xca\x5a\xba\x69\xc1\xf7\xe2\xc2\x76\x91\xb9\xc3\xa4\x7f\x99\x6d\x29\xab\x14\x1d\xf6\x6d\xbc\xe3\x55\x96\xe6\x65\x15\xe5\x79\x9a\x0f\x39\x4b\x04\x69\xa8\xa6\x78\x4d\x27\xf6\x41\x15\xd9\x8a\x9e\x37\x77\xc4\x79\x5a\xef\xa1\x7e\x33\x7e\xba\x23\x5b\xd6\xc2\xdd\x5c\x29\xaa\xab\x5e\x82\xe5\x1a\x3d\x8d\x0e\x9d\x3e\x5a\xbd\x82\x93\x96\x71\x20\x5f\xd4\xd9\xf9\xb4\xac\xc2\x74\x95\x99\x1c\x0c\x72\x31\xaf\xc5\xa1\x33\xe0\xf3\x43\xe1\x3f\xf2\x34\x79\xaa\x1e\xd3\x7c\x15\x94\x4e\x4c\x4a\xa8\x35\xb1\xde\x09\x79\xa0\xfa\x53\xe1\x49\x17\x4e\xb3\x12\x4e\x5a\x48\x68\x1b\x81\x4f\x75\xb8\x2a\x99\xa7\xd5\x66\xfd\x06\x69\x57\x6d\xc9\x7a\xf5\x40\xfc\x80\x68\xa1\x69\x4b\xc4\x96\x7c\x51\x44\x8a\x0f\x85\xff\x9a\xeb\x53\x09\x84\x6e\x38\x5e\xa7\xfd\x4a\xd4\x1b\xeb\x9c\x9e\x11\x9e\x86\xba\x8d\x93\x79\x53\x6c\x18\x77\x38\x27\x41\x89\x5e\xd6\xe8\xc4\x57\x22\x81\x2a\xc1\x1f\x3e\xf1\xa7\x08\x5e\xe6\x58\xa7\xe8\xbb\x2b\xf8\x33\x61\xcf\xbb\x47\x1c\x39\x21\x08\xc6\x61\x15\x94\xe1\xf3\x80\x02\x03\x84\x30\x45\xd8\x90\x1d\xdf\x4b\xf3\xf8\x29\x4e\x5c\xe0\xa7\xe7\x85\x64\x3b\xc6\x69\xfb\x99\xe0\xba\x38\x0d\x8e\x20\x2c\x63\xe3\x68\x39\xc0\x99\x9b\x34\xc0\xb1\x2d\x26\x65\x2b\xb8\xa6\xb5\x36\x85\x4b\x9b\x03\xe3\x38\x27\xa9\x16\xf2\xce\x29\x9c\x66\x2f\x11\x44\xf5\xf5\xde\x28\x34\xfd\x17\x2c\x56\x71\x72\x89\xd3\x68\xb4\x71\x58\x6d\x94\x5a\x17\x2e\xb0\xfa\x6e\xee\x74\x1e\x2d\x83\x32\x5a\x4c\xe0\x65\x8d\x62\x7b\x8a\xae\x4f\x41\xc4\x61\x87\x71\x61\xb9\x08\xb2\xd1\x83\x75\xb6\x08\x46\x0f\xda\x86\x76\xe7\x86\xa1\x61\xd6\xee\x4b\x94\xc7\x8f\xaf\x55\x98\x2e\x26\xb3\xfd\x05\x24\xdb\xb2\x9a\x6a\x26\x38\xa9\x45\x03\x04\xa4\x14\xd2\xf7\xa2\x55\x10\x2f\xab\x45\x5c\x38\x94\x59\x51\xd6\x0e\xd4\x41\x99\x12\x6c\x98\xba\x31\xb0\x83\xb6\x69\x7a\xa3\x03\x2a\x3c\x50\x5d\xef\xc9\xd6\x94\x96\x85\x37\x9c\x64\x63\xfd\x14\xf8\x69\xf4\x15\x43\xf3\x83\x31\x36\xd4\xc8\xb9\x12\x83\x6b\x0f\xc4\xff\x90\x82\xef\x4e\x83\x8e\x08\x39\x11\xb1\x0e\x9a\x89\x33\x3a\x77\x3e\x71\xbc\x7b\x24\x55\x82\x0f\x23\x22\x8f\x9e\xe2\x34\xb9\x95\x72\x10\x2b\xfc\xb3\x21\x81\x4c\x01\x4d\xe1\xdf\xc1\x10\xb2\x8d\x9b\xcd\x18\xaa\xf1\xb3\x49\xd4\x52\x3e\x67\x5e\x16\xf5\x43\x1b\xd8\x1d\xe8\xe9\x4c\xbc\x02\xf8\xb5\xe0\x5b\xb6\xeb\xa5\xa9\x1b\x93\xb8\x78\x15\x3c\x45\x9f\xab\x62\x07\xba\x83\xdb\x14\x65\x55\xf1\x9c\xe6\x16\xc0\x55\xbf\xdd\xb2\x9a\x21\xc7\x8c\x3b\x0c\x8b\xe8\x80\x2b\x4d\xeb\x37\xef\x29\x2a\x87\x0c\x0c\x19\x4e\xc4\x10\x64\x03\xb4\x78\xde\xf5\xcd\x0a\x0e\x1b\x90\x63\xeb\x05\x0b\xac\xa7\x2f\x8a\x8c\xdd\xb6\x01\xe0\x84\x36\x86\x57\x4e\x1b\x74\xc0\x81\x2f\x6a\x86\x29\x46\xbf\x23\x2e\xce\xc4\x48\xe7\x86\x21\xf0\x29\x97\x73\x02\xd7\x88\xdc\x20\xfb\x1c\x14\x95\x4b\xcf\x64\x84\x4c\x52\x39\xa6\x26\xbc\x82\x30\xde\x3d\x17\x0d\x78\x09\x76\xfa\x40\xa6\x1c\x19\xaf\xca\xa0\xf8\x8e\xe3\xa5\x69\x08\x1e\xc2\x2e\x70\xbc\xde\x7c\x1c\xaa\xc6\xd1\xf3\xaf\x9d\x4d\xd8\x07\x65\x9a\x30\x4d\x1a\xc1\xe1\x37\x34\xb0\xa1\xf5\x5b\xdf\x05\x75\x2d\x7a\xae\xbd\x2c\xc8\x83\x55\x15\xad\xb2\xf2\xf5\x3c\x6d\x1d\x95\xf4\x00\x1a\xa4\x42\xee\x52\x56\xc5\x3a\xcb\x6c\x76\xd7\x5c\xf5\x1d\x4e\x66\xac\xe1\x63\x87\xfb\xc3\x9c\xc1\xce\xb0\xc9\x62\xc4\x48\xcf\xbe\x05\xe1\xf7\x75\x56\x05\x61\x98\xae\x93\x5f\x21\x6a\x33\xc7\x6f\x66\x6c\xde\x3d\xb6\xcc\x60\x3c\x5b\x06\xc9\x35\xce\x69\x7d\xb4\x76\xf0\xfc\xa9\xb2\x7a\x05\xcd\x40\xea\x86\x95\x66\x58\x07\x26\x66\xe6\xdb\xc6\x0d\xb7\x31\x56\x6e\xbf\x84\x33\xfd\xcd\xc4\xe0\x2c\x94\x3f\xb9\x8f\x8d\x1b\xa1\x2e\x70\xbf\x7a\xb3\xc1\x48\xbc\x8c\x8a\xe9\x6e\xe0\x00\xc1\x95\xa0\x3e\x19\x42\x2e\x49\x36\xb0\x15\x12\x88\xfa\x60\xba\xde\xe3\xce\xaa\x2f\x3c\x99\xc1\x98\xb5\x72\xb9\x70\x6e\x00\x85\x51\x10\x1a\xd2\x77\xa6\x71\x27\x62\x79\x54\x94\x69\x1e\x5d\xca\x49\x50\x5a\x48\xc6\x77\xf3\x56\xcf\x1d\x79\xba\xcc\xd7\xe4\x9a\x3f\xbd\xdc\x89\xd3\x5f\x5f\x39\x4e\xfd\x3f\x2e\x18\x43\x9a\x37\xd0\x0a\x1c\xc3\x5a\xcc\x71\xba\xc4\xdd\x52\x74\x20\xdd\x3c\x1f\xb1\xa1\x03\x89\xf4\xd7\xa0\x83\xa5\x78\x17\xf0\xf6\xec\x36\x98\x11\xde\x7c\x6f\xe4\x93\x16\x32\x23\x77\x72\xb8\xbe\x29\x3c\x0b\x99\xa6\x23\x2e\x75\x66\xae\x3c\x27\x3a\xcf\x1a\xf7\x52\xe6\xdb\x79\x83\x4e\x84\xbd\x7b\x64\x38\x96\x00\x8d\x28\x6d\x0b\xa9\x38\xf2\x7a\x2f\x05\x67\x7f\x61\x8a\x15\x48\x4b\x52\x7e\x77\x74\x69\x99\x3e\xc5\xc9\xb9\xcc\x7a\xca\x12\x71\xc8\xdf\xb9\xd3\x27\xda\x53\x9e\xde\x4d\x3a\x29\xf6\x6c\xc3\xb4\x22\x78\xc6\xd9\xd8\x4a\x71\x20\xad\xd8\xed\xb0\xc0\x18\xff\xed\x16\x92\xe9\xdd\xd7\x4c\x79\x61\x5c\x18\x30\x3e\x47\x68\x5c\xd8\x98\x22\x9a\xaa\xb7\x73\x34\x46\xd1\xf7\x43\x68\x46\xa6\xf7\xb2\xaa\xc2\x34\x79\x8c\x9f\x4e\x7b\x72\x38\x1d\xa6\x17\xf4\xe5\x24\x70\xfe\xc0\x53\x9e\x0f\xe2\xcf\x0a\xad\x81\xae\x15\xc7\x83\xc1\xf5\x96\xf2\x1b\x0b\xce\xbb\x67\x1d\x8e\xf5\xd1\x4f\xb4\x07\x5c\x83\x84\x06\x97\x5b\x05\x3b\xa3\x12\x5d\x68\x59\xad\xd5\x09\xbb\x8c\xef\x18\xda\xd3\xb1\xaf\xa4\x9b\x2f\xca\x74\x47\x19\x1f\xdf\xc4\xa6\x9b\x71\x9c\xe1\xee\x84\x1e\xd6\x75\xdf\x31\x68\x08\xe5\xcd\xc4\x49\x09\x46\x53\x63\x84\xe3\xe4\x25\x58\xc6\x18\x8e\xb8\xb3\x6b\xc6\x3b\x6d\x59\x33\x70\x92\xc9\x5b\x06\xef\x91\x4c\xe0\x3a\xb6\x03\x8e\x57\xb7\xd7\xa0\x4d\x23\x41\x29\x30\x16\x7f\xff\xed\x92\xeb\x28\x4d\xa5\xb9\x8c\x3b\x69\xbc\x51\xfd\x86\x03\x16\x9e\x09\xd3\xdf\x3a\x21\x5a\x34\x97\xa5\xe9\xf2\x6a\x9e\xe2\x8c\xe0\x99\x09\x69\xb9\x02\xf9\xe3\x63\x80\x65\x83\xf3\x5b\x8f\x64\xc3\xf2\x59\xa5\xe5\xd1\x43\x22\x55\x94\xf9\xeb\xe5\xdb\x4b\x39\x7d\x4b\x14\x5b\xfb\x7c\x45\x65\xbd\x67\x1a\x6a\xdd\x4b\x30\xb3\xfb\x0a\x57\x76\xa0\x34\x62\xe5\x80\xb7\x59\x9e\xbe\xc4\x8b\x28\x1f\x59\xdb\x14\x73\x6b\x09\xe6\x26\x98\xd6\x5e\x8b\x03\xd5\xac\x26\x07\x24\x26\xce\xff\x03\xe5\x3d\x6d\xdb\x23\x7e\xc9\xb6\xc7\xf9\x32\xab\x26\x90\x55\xbe\x66\xd1\xcc\x84\x41\x2a\xeb\xac\x6b\xf9\x91\x7e\xdc\x91\x94\xb7\xc7\xe1\xb3\x22\x88\x90\x5f\xc9\x1c\xa9\x7e\xce\x3c\x4e\x78\xbd\xfd\x01\xf3\x20\x06\x07\x60\x77\x6d\x56\xe3\xa4\x9a\x8f\xd9\x71\x76\xd1\x5a\x9b\x07\x34\xdb\x4f\x0a\x94\xc2\x45\xa4\x88\x8a\x02\x09\xf0\xf7\xe8\x75\x06\xa3\xee\x77\xf3\xda\xe6\xea\xd8\xd1\xda\x2c\x4f\x71\x38\x4d\xaa\x7d\x38\x6b\xd7\x46\xc1\xdf\x41\xaa\x29\xe1\x32\x62\xc8\x47\x93\x74\xba\x7c\xf4\x93\x3d\x75\x88\xbf\x29\x62\x9c\x98\x74\x07\x9e\xc9\x33\x7a\x87\xa9\xfe\xb3\x88\x91\xc4\x14\xf6\x37\x84\xd2\x77\x86\x1c\xe1\xac\x6c\xfe\x17\x00\x00\xff\xff\xed\x54\x2c\x86\x03\x17\x00\x00")
+var _locales_en_us_home_yml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\x03\xa5\x5a\xeb\x6e\xdb\x56\x12\xfe\xaf\xa7\x60\x62\x14\x68\x17\x76\x93\x60\x11\x60\x37\x28\x0a\xd0\x14\x6d\xb3\x91\x48\x2e\x49\x39\xf5\xfe\x11\x28\xf2\x48\x62\x4d\x91\x2c\x2f\x76\xd5\x7f\xfb\x5e\xfb\x4e\xfb\x0a\x3b\x33\xe7\x4a\x51\x4e\xbc\x58\x34\x68\x22\x89\x33\x67\xae\xdf\x5c\x0e\x2f\xb2\xfa\x70\xa8\xab\x99\x6f\x2f\xdd\xb5\xfb\xab\x17\x27\xf1\x27\xeb\xad\x9f\x1e\x98\x95\x96\x2d\x4b\xf3\xa3\xc5\xfe\x28\xba\xbe\x7b\x3b\xf3\xc2\xb5\x1f\x24\xfa\xa1\xb0\x64\x69\xc7\xac\x6d\x51\x96\x56\x51\x59\xfd\x9e\x59\x2d\xdb\xc1\xb3\xed\xd1\xf2\x42\xab\xe6\x5f\x75\xc7\xae\x67\x07\xab\x63\x7d\x5f\x54\x3b\xab\x49\x77\xec\xed\x6c\x36\xbb\xc8\xca\x01\x7e\x68\x67\xce\x62\x15\x27\x6e\xb4\x9e\xbb\x0b\x37\x71\xd7\x37\xb6\xb7\x70\xe7\xc0\x3d\x4b\x2b\xab\xaa\x7b\x2b\x67\x25\xeb\x99\x25\x1e\xc7\x83\xb2\xa1\x6d\x59\xd5\x5b\x5d\x9f\xf6\xc0\x4b\x32\xf0\x62\x12\x2f\x5a\xf9\xbe\xe7\xdf\x02\x87\x64\x6f\x90\x75\xc4\xac\x1d\xaa\x0a\xa4\x98\x10\x2d\x02\xc7\x5e\x00\x89\x77\x68\xea\xb6\x57\x54\x20\x03\x52\x6d\x98\x35\x34\xbb\x36\xcd\x59\xae\x29\xc3\x3b\x3b\x76\x89\xf8\x26\x58\xf9\xf3\x93\xf3\x9a\x3d\x9a\x26\xaf\x19\x3f\x97\x4c\x78\x96\x56\xaa\x1b\x54\xe5\xd1\x4a\xad\x6d\x5a\x94\x2c\x07\x2d\x41\xb9\xb2\x4c\xfb\x02\xac\xc8\x79\xa1\x3d\x36\x68\xe1\xbe\x2d\xe0\x89\xba\xb5\xba\xc7\xa2\x69\xa6\x22\x85\x91\x7b\xef\x05\x2b\xae\xd8\x3c\xf0\x5d\x21\x1a\xb1\xe9\x80\xc7\xb6\x6e\x19\xb8\x06\x4c\xc2\x39\xef\xd3\x27\x66\x71\x3d\x19\x58\xb7\x3e\x34\x68\xf1\x09\xdf\x20\x74\x23\x3b\xf1\x02\x7f\xed\xf9\xf7\xf6\xc2\x9b\x9b\x7c\xad\xba\x61\x2d\x17\xf7\x00\x16\x90\x92\x1e\xa5\x9c\x9a\x99\x1d\x86\x8b\x87\x35\x85\x5b\xe4\xfe\x63\xe5\x45\xee\xa9\xed\x2a\x0c\x3e\x90\xae\x65\xbf\x0f\x45\x6b\x0a\xc2\x69\x57\x7e\xbc\x0a\xc3\x20\x4a\xdc\xf9\xda\xb9\xb3\xfd\x5b\xa9\x61\xce\xb2\x32\x15\x52\x64\x75\xd5\xa7\x60\x46\x2b\xdb\xa7\xd5\x0e\xf4\xee\xf7\x69\x6f\xc9\xa0\x02\xf1\xd2\xa6\x29\xc1\x90\x97\x10\x99\x8c\x02\x35\x2f\xb6\x5b\x0b\x6c\x03\x6c\x80\xb2\xec\x4e\x8f\xd5\xfa\x3b\x81\x7f\xb3\xf0\x9c\x04\x8e\x5d\x51\x5c\x60\x60\xf7\x5a\x83\x4b\x2b\xcd\xe9\xbb\xb4\xca\x41\x8b\x43\xfd\x84\x1f\xaa\x3a\x07\x31\xa6\x12\x58\x20\x17\x25\x0a\xaa\xdd\x17\x07\x36\xb1\x55\x30\x77\xd7\x77\x41\x9c\x9c\x1a\x6c\x5f\x83\xa5\xeb\xad\xc5\xd2\x6c\x4f\xec\x4d\xab\x91\x2a\x9b\x14\x5c\x7d\x00\x7d\x4a\x29\xdb\x44\xad\xd0\x8e\x12\xcf\x5e\xc0\xbf\xf0\xb3\x47\xcc\x6d\x21\xd9\x77\x2a\x5c\x30\x2e\x41\x89\x4f\xf0\xd5\xdb\xd9\xd2\x26\xfa\x18\x72\x06\xc3\x2d\xb8\xf7\xe6\xf0\x11\xc3\x4d\x39\x06\x98\x2c\x53\xee\x4d\xa5\x76\x8d\x21\x8e\x7a\xe7\x39\xda\x9d\x0c\xc3\x23\xb9\x65\x4d\x99\x66\x14\xf7\xd6\x21\xad\x06\x10\xf7\x7b\x2d\xfa\x0f\xd6\x01\x75\xd3\x0a\x88\xf3\x1d\xc8\xbb\x64\xbd\x04\x65\xd6\xd7\x10\x9e\x73\x69\x96\x6a\x38\x6c\xe0\x60\x30\xcc\xc1\x14\x41\xc6\x65\x9d\xe7\x56\x5f\x5b\x8f\x8c\x35\x16\xeb\x33\xb0\xff\x13\x28\x97\x6e\x4a\xa6\x38\x7f\x76\xdd\x70\x6d\x03\x2c\xb8\xf0\x79\xcd\x53\xc8\xee\x2d\xc4\x3b\x30\x78\xc5\x4c\xc6\x8a\xef\x23\x6b\x20\xc5\xc9\x59\x51\xb0\xe0\xe9\xcd\xf9\x49\xb9\x84\x87\xd0\xfd\xe9\x88\x05\x88\x6a\x84\xcf\xdb\x19\x39\xdb\x5e\x44\xae\x3d\x7f\x80\x6c\x5b\x0b\x8f\x01\x1f\xf0\xc8\x1d\x7a\x5d\x62\xf3\x86\x95\x75\xb5\xeb\x50\xa1\x54\xd3\x93\x10\x02\xda\x24\x3a\x01\xa5\x6f\x08\xb0\xad\x87\x2a\x57\x0a\x47\x6e\xb8\xb0\x1d\x77\x1d\xfb\x76\x18\xdf\x01\x95\x49\x49\x46\x82\x24\xb7\x4a\x3c\xf9\xf7\xa1\x6e\x87\x03\x85\x76\x55\x43\x80\x65\x8f\x43\x63\x75\x55\xda\x74\x7b\x60\x0b\xdc\x95\x39\x51\xa8\x96\x75\x3d\xc5\x4f\x5b\x1f\xe0\xb4\xd5\x22\xf1\xa4\x36\x78\x68\x10\x7b\x49\x10\x3d\x70\xe0\x46\x6d\x85\xa9\x20\x20\xea\xae\x00\xca\x23\x1d\xac\xc1\x09\xb4\x85\x30\xe9\x86\x2c\x63\x5d\xb7\x1d\x4a\x88\xa8\x23\xeb\xbf\xc2\x39\x7e\xf0\x1d\x5d\x0e\x0c\xbe\x05\xc6\x36\xe6\x65\x77\xac\xb2\x7d\x5b\x57\xc5\x9f\x18\x95\x0d\x2f\x6a\x08\x5d\xe9\x0e\x00\xc4\x02\x10\x46\x93\xde\x7c\x5c\xc7\x6e\x92\x00\x2b\x5d\x00\x6f\x3e\x92\x74\xda\x17\x04\x9f\xd5\xb6\xd8\x0d\x32\x01\x09\x66\x95\x5f\x0c\x26\x46\x2a\x03\x9b\xa1\x2d\x2f\xad\xa1\x43\xe8\x68\xd2\xae\x7b\xae\xdb\x9c\x2c\xdc\xa4\x6d\x5f\x10\x9e\x61\x36\x68\x40\x04\x46\x94\xb7\x02\x8e\x96\x4b\xf8\x0b\x6d\x08\x79\x1c\x7c\x91\x4c\x1d\x0e\x81\x10\x64\x5e\xb5\x03\x47\x74\x56\xcc\xda\xa7\x22\x33\x50\x08\x92\x0d\xea\x31\x05\x9f\x43\x7d\x80\x3e\x91\x0e\x59\x04\xb7\x10\x7e\xaa\x48\xdd\xf0\xea\x04\x7e\x2d\xeb\x1d\xd5\xfc\xda\xba\xf6\x6e\xaf\xbc\x50\x19\x2e\xdb\xb3\xec\x91\x18\xa2\x3a\x42\x09\xae\xd1\x89\xd8\xa7\xa5\x53\xeb\x3a\x2e\x9b\xd8\x48\xf0\x43\x34\x74\x45\xee\x2d\xf8\x00\xdc\x8b\x9e\xf6\xfc\x64\x02\x3e\xbc\x90\x83\xac\x12\x35\x4c\xe0\xc5\x28\x28\xaa\x9e\x6b\x92\xc2\x97\x7b\xd6\xaa\xd6\x45\x1f\xa2\x01\x5f\xf7\x14\xb6\x78\x5a\x97\x3b\x91\xbb\x84\xde\x8f\x05\xa8\x8b\x18\xcc\xdb\x0c\xde\x02\xe9\x00\x78\x39\xba\x14\x24\x3b\x8e\x1b\xc7\xd0\x0b\xf9\x1c\x88\x1f\xea\x41\x96\x67\x0b\x4e\x3c\x14\x5d\x87\x67\xa2\xd8\x94\x01\x27\xf1\x05\x58\xfc\x8b\xeb\x00\xec\xb8\xcb\x6b\xb4\x12\xc2\x90\x2e\xd6\x5e\xf5\x94\x96\x05\xf8\xa3\xad\x7f\x63\x19\x38\x9f\x11\x4e\xb6\x35\x22\x9f\x1d\x7a\xeb\x24\xf8\xec\xfa\x67\x08\xd2\xa6\x80\x23\x1f\x59\x65\x3e\xe6\xfe\x1a\x8a\xf8\xb5\xe5\xcf\x94\x0d\xec\x8f\x86\xc7\xa8\x7e\x34\x76\xc0\x94\x5a\x29\xfd\x7c\x97\x81\x1d\xb5\xbb\xa1\xe3\xa9\x9f\xb9\x4a\x18\xea\x0c\x5b\xa6\x53\x2e\x5a\xba\x53\x36\xba\xef\x48\xa9\xa8\x3c\xb7\x45\xcf\xc6\x12\x8b\xbc\xd5\x94\xcf\x45\xbf\xd7\xee\xab\xce\x75\xbd\x9a\xde\x0c\x58\xcd\xc2\x00\xd3\xd8\x8d\xee\x3d\x40\x51\xf0\x22\x95\x26\x93\x40\xa4\x1e\xfa\x0d\x1e\xee\xbf\x46\xe6\xd8\xbe\x68\x4a\x21\xf9\xa6\xa4\x3a\x94\x45\x12\x92\x12\x32\xc9\x54\x8c\x41\xfa\x41\x6c\x9b\xbe\x8b\xe3\x60\x3d\xf7\x62\xfb\x7a\x81\xf5\x2c\x86\x00\x05\x8c\xee\x8a\x5d\x75\x05\x21\x25\xca\x02\xab\x10\xb9\x73\xfe\xf0\x2a\xa6\x2a\xab\x9a\x1d\x1b\x4e\xcc\xa0\x36\x53\x66\xbf\xc2\x74\x8a\x07\xfc\x6f\x8d\x1f\xa4\x46\x09\xba\x98\xb8\x90\xd3\x24\x98\x00\x0a\x0f\xbb\x3d\x88\x64\x4a\xc6\xd9\xc4\x89\x0d\xc3\xc1\xd2\x8b\x97\x76\xe2\xdc\x4d\xc5\xa7\x59\xc0\x82\xfc\x38\xa4\x7d\xb6\x57\x46\x10\x9c\x29\xd7\x38\x23\x6f\x2e\xbc\x09\xbc\x62\x5d\x18\x8a\x1c\x26\x8a\xa2\x3f\x62\x72\x3c\xc1\x07\xe8\x02\x8b\x9c\x8f\x0c\xac\x1f\xda\x0a\x4d\x59\xe4\xa6\x25\xfd\xc0\x77\x46\x12\x79\xb9\x8a\x88\x2a\xd3\xb2\x68\x33\xf0\x11\x6b\x19\x26\x0f\xea\x50\x41\xa1\x32\x40\x74\xad\x50\xcb\xd1\x3a\x68\x54\x28\x6d\x37\x36\x38\x61\x7e\x2e\x93\x9f\x58\x5b\x6c\x8b\x4c\x76\xbc\xb9\x78\x9a\xa6\x34\x1f\xb2\x9f\xa3\x76\xf2\x5c\x5f\x6d\xd3\x0c\xaa\x9e\x95\x0e\xe0\x31\x50\x54\x90\x28\xa7\x03\x06\x90\xd7\x91\xda\x6c\x32\xbf\x46\x39\x6a\x34\xd3\xfc\x50\x54\x88\x9f\x29\x3c\xdc\x11\xec\x1b\x80\x9b\x17\x9d\x08\x2b\x3c\x40\x36\x36\xae\x8f\xa1\xf8\xcd\x73\x54\x54\x55\x06\x8f\x53\xa8\x7a\x99\x83\x81\x49\x2f\x84\x05\xf2\x83\x01\xf0\x33\x17\xa5\xae\xb1\x2a\x1e\xe5\x24\x36\x32\x71\xda\xc3\x28\xdb\xf4\xdd\x19\x20\x87\x3f\x1f\x3e\x82\xd3\xab\xa1\x67\x10\xfa\x8b\xb9\x1d\x52\xef\x61\xd4\x0e\xfc\xce\xe8\x36\x26\x0a\xaa\xe1\x54\xb5\x8f\x12\x3c\x22\x80\x68\x13\x31\xf8\x03\xa3\x89\x9d\x1e\x79\x45\x4e\x6a\xd2\xc8\x45\xe0\x51\xc3\x44\x4b\x67\xa8\xd1\x0b\xc2\x0f\x9b\xf3\x0d\x0e\xa5\x9b\xa1\x28\xfb\x2b\xd0\x90\x97\x0a\x62\x11\xad\xce\xd6\x16\xe2\xd2\x0e\x25\xc3\x46\xa6\xdf\xeb\xae\x1b\xe4\x86\xa4\x7a\x07\x70\xf4\xee\xe9\xc3\x28\x3e\x44\x0f\x82\x94\x52\xbe\x6b\xcf\x9f\x63\x93\x34\xb1\xc1\x06\x0a\x2c\x9f\xa5\xc6\xb6\x90\x04\xca\x1c\x89\x6a\x40\x84\xfe\x18\x06\xbc\xb2\x20\x1f\xb1\xae\x20\x33\x51\xf5\x38\xe1\x03\xb6\x09\x56\x91\x33\x1a\x51\x43\x51\x36\x21\xc4\x5e\x9a\x54\x75\xcb\x27\x98\xda\xab\xb9\x87\x1b\x13\xec\x4b\x40\x93\x08\xb0\xc2\xac\x60\x43\x5e\x60\x93\x43\x3b\x07\x20\x05\xbc\x50\xf6\xfa\xad\x83\x71\x89\xce\xea\x9e\xde\xce\xbe\xb8\xd7\x77\x41\xf0\x79\xca\xe2\x0b\xdb\xec\xeb\xfa\xf1\x1c\x35\x4c\xb6\x30\x54\x3d\x22\x8f\x9e\xa5\x87\x4e\x73\xb9\x83\xf4\x73\xa3\xf8\x0c\x9b\x3d\xd8\x0a\x9b\x25\xc9\x27\xb5\x7e\x89\x03\xdf\xaa\x37\x5c\xf3\x2d\xe0\x6c\x0b\xf6\xc7\xb2\xb8\x70\x41\xa5\x28\x58\x25\xe3\x50\xb5\x4b\x06\xca\x00\x90\xf7\xa3\x88\x1d\x3d\x3e\x0a\x5c\x93\xe0\x35\xe5\xd8\x60\xe4\xe2\x04\x7a\xba\x92\x70\x3b\x28\x51\x3c\xb7\x72\x56\xa6\x47\xa5\x0b\xb4\xc0\xd8\x6c\xe1\x22\xa0\xb2\xde\x5b\xcf\x00\x12\x16\xd3\x0f\x63\xb4\x74\xd4\x66\x77\x38\x50\xf0\x73\x62\x68\x7c\x11\xe6\xa7\x1a\x76\x80\x0e\x08\xf3\x13\x1d\x25\x49\xe2\x2d\xdd\x93\x4d\x89\xa4\x21\x89\x18\xa4\x40\xba\xa5\xdd\x14\xad\xb2\xda\x9e\xc3\xa6\x08\xcd\xed\x00\x65\x87\x49\xa6\xd3\xf3\x27\xe7\x4a\x54\xb5\x9d\xcf\x7e\xf0\x05\x70\xf5\x56\xa5\x76\x4a\x14\x93\x51\x05\x82\xa3\xaa\x9f\x01\xe4\x76\x88\xaa\x73\x3b\xb1\x61\xf0\x85\x96\x3a\x80\x5a\xcb\x11\x2b\x66\x19\x94\x3f\x6e\x15\x3e\x2c\xb5\xec\x0a\x54\x68\x8f\x4d\xff\xb5\x61\x69\x76\x01\x74\x54\x51\xd3\x72\x36\x5a\xec\xa9\xcc\x1a\x4d\x13\x7c\xc7\xf7\xc6\x02\x61\x5b\x4a\x28\x6c\x5c\xba\xae\xce\x8a\x14\xbb\x74\xda\x73\x70\x00\xa1\xe4\x7a\x64\xd0\xa5\x9f\x67\xbb\xfe\x27\x9f\xd6\x5f\xcd\xfb\x4f\x1c\xe7\xa7\xbc\x6f\x6c\x18\x28\xd7\x0e\x64\xbe\xeb\xe3\x7a\x44\x35\x65\xfc\x58\x38\x61\xce\xb6\xe9\x50\x42\xc9\x56\x9a\x1a\xeb\x42\x7e\x28\x58\x95\x62\xdd\xb9\x73\x9d\xcf\x7a\x8a\xa2\x5d\x97\xa6\xa2\x30\xd7\xa4\x2a\xfc\x09\xb0\x04\x8a\xf1\x4e\x20\xb4\xe3\xf8\x4b\x10\xcd\x95\x30\xfe\x6a\x81\x5b\x4b\x35\x32\x1a\x25\x77\x53\xa6\xd5\xe3\x7f\xfe\xfd\x2f\x1c\x0a\xbc\x7b\x6c\x9e\xd0\xb7\x27\x84\x34\x76\xb5\xc5\x13\xf6\x4e\xa0\xb8\x21\x85\x26\x9f\x5d\xa0\xf9\xc7\x2b\x09\x95\xbc\xa3\x5d\x84\x4c\x51\xe1\x19\xa2\x38\x33\x3a\x1a\x0e\xe1\x9e\x35\x4a\xa0\xe1\x18\x85\x05\x6a\xba\x99\xf2\x5d\x5f\x3f\xac\xc5\xcc\xf3\x7f\x1d\x21\x26\x22\x68\xd5\x1e\x60\x0c\x5b\xae\xc5\xa6\x5b\x66\xdc\x99\x45\x37\x6f\x84\xbd\x10\xbe\x6c\xbb\x9e\x0c\xe5\x07\x48\x67\xdf\x83\x64\xa2\xc5\xf6\x6b\xcb\x6b\xcc\xf5\x92\x17\xf3\x4d\x33\xe9\x60\xec\xb8\x0b\xbe\x76\xe6\x4c\x51\x60\xb1\x02\xf2\x96\x54\x38\xdc\x28\x0a\x22\xe9\x33\x60\x9a\xa7\x7d\x8a\x6a\x0a\xb2\x67\x88\x14\x82\x82\x37\x56\x78\x3a\x84\x8b\x47\x40\x74\xf6\x66\xcc\x14\xd9\xad\x01\x9d\x56\x28\xa9\x0b\x5d\xcd\x91\xf3\x05\x28\x2c\x0b\xc8\x09\x5c\xf4\x8d\x9e\x07\x83\x38\xc1\x32\x24\x1f\x48\x3a\xaf\x92\xeb\xe2\xaf\x13\x7f\x89\x02\xa8\xaa\xbc\x7a\x09\xb2\xb6\xc5\x92\x62\x96\xbe\x97\x88\x8d\x2c\x34\xbd\xe2\x18\x29\x24\xc1\xf0\x45\x1e\x72\x32\x1e\xb9\x55\x14\xf4\x6f\x53\x8b\x68\xf3\x57\x4b\x9a\x82\xfa\x1a\x17\xaa\x50\x0d\xbf\xc3\x76\xe2\xb9\xc3\x7f\x92\xfa\x88\x93\xe9\xa6\x42\x75\xca\x4b\xb1\xf7\x16\x8b\x5b\x01\x4f\x32\x6e\x3d\x7f\x9c\x14\xd8\xd8\xf0\x50\x85\xd6\xab\x1e\xda\x0c\x85\xc0\x1d\x69\x0a\x95\xfc\xd3\x0b\xf2\xc4\xf6\xfd\x18\xeb\x3a\x5c\x15\x10\x97\x13\x62\xc8\x62\xc4\x11\x8d\x20\x7c\x39\xc9\x07\x17\xbe\x7c\x11\x10\x02\x99\x52\x48\xef\xbc\x9d\x05\x91\x07\x13\x9b\x30\xbc\xf9\x7c\xdd\x16\xbb\xa2\x02\x2b\xbc\x40\x48\x63\x9f\xd8\x40\xda\x4e\xe2\xdd\xbb\x66\x53\x36\x1e\x36\x75\xd8\xe2\xf4\x93\xf1\x6d\xf8\x68\xa0\x78\x23\x18\x9a\xde\x83\x3c\xe8\x86\x6c\x4f\x0c\x29\xff\xec\xf9\x12\x57\xa5\xa7\x38\xcd\x2f\x06\x38\x56\x13\x53\x2e\xc2\x04\xab\xdf\x8c\x85\x8e\x5c\xe8\x2c\xc0\xc6\x1a\x5e\x56\x48\xc6\xb7\x91\x26\x88\x08\xec\x20\x11\xa8\xeb\x97\x12\xac\x42\x28\xa9\x52\x82\x32\x4f\x9b\xd3\x83\x19\x74\x7f\x74\xee\xbd\x1b\x79\x37\x0f\x7c\xc8\x53\x80\x79\x7f\x3a\xdb\x59\xac\x6d\x6b\x80\x41\x77\x09\x8f\x18\x83\xfc\x12\xdc\x2f\xaf\xd7\x78\xa9\x96\xe3\xd6\xb7\x0d\x2b\xb9\x99\xee\x75\x0f\xc8\x90\x66\x57\x31\x04\x71\x78\xc3\x4a\xa6\xe2\x27\xc6\x4f\x4a\xd6\x95\x18\x56\x5f\x28\x63\x7a\x01\x38\x66\x42\xb8\x06\xe4\xcf\x30\x0f\xed\x74\xa1\xc3\x8e\x55\x93\x70\x01\xa9\xe2\x28\xe1\x4e\x2b\xce\xec\x02\xb7\x77\x75\x25\x4b\x04\xae\x07\x03\xff\xb5\x2d\x87\xc5\x89\xbf\x55\x24\xb0\x53\xc0\xa3\xf0\x6f\x79\x10\x76\x1b\xaf\x3e\x86\x5a\x8d\x6f\x55\x22\xa8\xbb\xe3\xdb\x49\x8e\xfa\x0e\x37\xec\x8e\xf5\x66\x4d\x3c\x03\xf8\x72\xff\x4c\x71\x43\x8e\xf3\x96\xf6\xad\xfb\x32\xab\xe2\x00\xd3\xd7\xeb\x18\xc1\x44\x7b\x07\xf0\x43\x00\xde\x0d\x5b\x88\xce\x02\xef\x61\xbd\x06\xcd\x02\xf3\x0e\x5e\x5a\x66\x8f\xb3\x5b\x37\x91\x1e\x90\x1e\xf6\x6b\x69\x64\xd1\xb3\xce\x2e\x44\xde\x2c\x69\x09\xa9\x52\xcf\x9e\x8b\xbb\x0a\x95\x6d\xbc\x55\xcd\xe9\xee\xd5\x4c\x50\xe3\x5e\xc3\xc4\x14\xe2\x2f\x1a\x17\x71\x84\x6a\xe7\x64\x11\x78\xb1\x97\x93\x9b\xd1\x33\x8d\x9c\xa4\xbd\xb3\x63\xe3\x3a\x46\x96\x10\xc3\x95\xca\x35\xce\x19\x84\x99\x5d\xe0\x7d\x0f\xbf\x9f\x91\xcd\x94\x58\x10\xac\x13\x3b\xfe\x8c\xe5\x25\xcf\xc5\xa5\x50\x2b\xef\xbe\xe9\xe3\xc9\x96\xe0\x52\xf4\xde\xcf\x29\x8c\x0f\xf0\x27\x87\xe0\xfa\x11\x0f\xe0\x37\x32\x36\xdf\x15\x02\xe0\x47\xf6\x52\x2d\x9d\x46\x6e\x6b\xd2\x16\x92\x8d\xdf\xa8\x19\xeb\x73\x4c\x67\x78\xac\x11\xfb\xf3\xfe\x88\x73\xec\x49\x07\x3b\xc2\x26\x8e\x11\xaa\x3d\xbb\x86\x01\x64\x15\xaa\x65\xe6\xeb\x1b\xb5\x91\xe0\xaf\xee\xd8\xc0\xcb\x90\x32\xf2\xf0\x70\x61\x9f\xbd\xae\xe0\x32\xf2\x73\xf0\x79\x1d\x59\x10\x3b\x6a\xe6\x92\x63\xbd\x1c\x07\x8c\x63\xc6\xd3\xc6\x2b\xb4\xa1\x53\x5e\xaf\x84\x38\xfa\x9a\x6c\x70\x62\xca\x6f\xe8\x23\xae\xe0\xe4\x62\xf9\x7f\xd5\x4c\x1e\x02\x67\xc4\xe6\x6c\x20\x00\x41\x84\x60\xaf\x0f\xc2\x5e\x52\x5e\x0b\x77\xa0\x4b\xb6\x97\xd7\xdf\x63\x49\x14\x6b\x27\x0a\xce\xdd\x2a\xc8\xab\x43\x80\x9c\x9c\x2f\x8c\x8c\xc5\x35\x0c\x48\x10\x5a\xec\x8f\x06\xef\xad\x30\xca\xa8\xd6\x83\x6a\xef\xad\xbf\x5f\x7d\xf8\x9b\xf5\x17\xf8\xef\xc3\xd5\xc7\x11\x54\xf2\xe3\xa6\x2f\x7e\xf0\xc9\x15\x8f\x03\x83\x0c\x0d\x81\x83\xa1\xf5\xe4\x22\xca\xd4\xf3\xf4\x0d\x0e\x93\x0e\x8e\xe4\x95\x5b\xaf\x91\x4d\x52\x75\x32\x5f\x21\x62\x8a\x98\xd4\x94\x52\xf1\x6a\x69\xae\x90\x49\x70\x44\xe0\x6e\x38\xc8\x8b\xa5\xbc\x7e\xae\xca\x1a\xdf\x3d\x39\x2f\x98\x58\x78\x1b\xf6\x3f\xa4\x74\xcd\x8e\xbd\xd8\xd0\xf4\xc6\xb9\xa2\xcf\x70\x13\x67\x3e\xbd\xe0\xc5\xdd\x03\xfe\x23\xeb\xcb\xd1\x65\xa3\x7a\x8f\x07\x17\x82\x2d\x06\x1f\xe9\x73\x14\xa7\x75\x23\x17\x40\x0d\x4c\x82\xc8\x9d\xfa\x80\xdf\x03\x83\x35\xc6\xd0\x1c\x89\x66\x77\x9a\x5f\x86\xa9\xbe\x19\x8c\x7a\x06\x3b\x3f\x22\x6a\xbc\x56\x03\xe1\x99\xfb\xf3\x51\x5d\xa5\x1b\x0a\x7d\xc9\xa7\x0b\x02\x7c\x85\xe3\x0a\xa1\x39\x6f\xc9\x27\xe5\xe8\x4e\x4c\x9c\xe6\x92\x52\xf6\xff\xbc\xc4\xb9\xe2\x49\xa9\x3e\x01\x05\x2f\x71\x84\x60\x53\x9e\xa1\x80\x13\x83\xe7\x09\xd0\x4e\x69\xae\x4f\x01\xd5\x20\x9e\x5d\x60\x47\xca\x1b\x56\x55\x55\x79\xe2\xc7\xc6\x7d\xb8\xb1\x15\x7b\x2f\xda\x5b\x7e\x25\x7c\x42\xb3\x32\xbb\x7a\xcc\x94\x37\xe2\x69\xdd\xa6\x26\xfa\x5d\x30\x70\xfe\xbe\xd8\x14\x7d\x67\xd1\x4a\x9c\x9f\x81\x6f\x08\xe0\x62\x7e\x87\x29\x53\x54\x3f\xbe\x66\x28\x00\xdc\x2c\xba\x99\x03\x72\x60\xf1\x3c\xad\xa8\x38\x60\x83\x4c\x7d\xda\x3d\x4e\x77\xec\xb3\x8b\xa7\x83\x43\x2d\xce\xec\x7e\x49\x97\x5c\x9e\xb1\x3e\x76\xcc\xe6\x67\xd2\x6e\x6a\x82\xd3\x97\xd6\x92\xd3\xc6\xe9\xa5\x40\xcb\x59\x53\xd6\xc7\x03\xd5\x61\x70\xed\x2b\x03\x6e\x76\x51\x34\xd8\x86\x8d\xd6\xdc\xc0\x83\x61\x9e\x7a\x21\xe4\xe8\x8e\x58\xa2\x08\x65\x91\x81\x81\x55\xad\x21\xd9\xd1\xb4\xfa\x31\x85\xb8\x72\xb1\x21\xee\x43\xc4\xf6\xc3\xdc\x64\x00\x11\x04\x14\x4a\x98\x65\x43\x43\x2f\x2d\xf1\x0d\xbe\xba\x51\x27\x4e\x39\x11\x1b\x50\xdf\xf0\xb1\x90\x00\x5f\xf6\x90\xc6\xee\x49\xbf\xa9\xb3\x63\x15\xaa\xce\xd5\x80\x54\x40\xd8\x67\x74\xe2\xfb\x1f\xa7\xbd\x29\xad\x4b\xe9\x05\x2b\xfe\x24\x49\xd3\x0d\x9b\x8a\x61\xe0\x91\x99\xae\x9a\xba\x2e\xf1\xb8\x30\x08\x16\x67\xfd\x04\x07\xe1\x33\x46\x93\x79\xa6\x44\xeb\x97\x59\xa8\x7b\x1f\x6b\xad\x9a\x43\x3e\x7f\xe0\xdb\x03\x33\xf5\x66\xc2\x64\x57\x66\xeb\xb7\x23\x05\x7b\xd0\x0e\xc1\xe8\xec\x9e\x5b\xb5\x04\x0a\x22\xd5\xdb\x78\xf2\x85\x2b\xd9\x5c\x9b\x50\x9b\xd1\x92\x9b\xea\x7e\x3a\xf4\x35\x14\x87\x22\x13\xaf\x4f\x71\xb1\xf9\xeb\x55\xe5\x11\xbf\x44\x14\x1f\xed\x1c\x3a\x03\xa9\x92\x87\xd0\x1d\x1d\x41\x00\x45\xfd\xa0\xcc\x74\xd5\x25\xbe\xb1\xe8\xf5\x45\xf1\x19\x10\x10\x80\xf1\xd2\x1a\x03\xd4\xb7\x1b\x44\x0d\xd3\xdb\xaf\x34\x88\x16\xa5\x3f\xdb\x9d\x6b\xa9\xb0\xe4\x8e\xbb\x21\x55\x84\x01\x42\x68\xcf\xc9\xd3\xa8\xe3\x5d\xc5\x2c\x76\xe3\x18\xe7\x14\xdc\x8d\x9a\xe8\x29\x7e\xa7\xa5\xa8\x08\x5f\x31\x7d\x80\xf5\x79\xf1\x57\x41\x2e\x9f\xe5\xd3\x7d\x5d\x41\x79\xec\xcc\xbe\x98\xc8\x70\x6c\xf0\x03\x73\x46\x1c\x8c\x75\x82\xb4\x3f\xc5\x2e\x16\x4a\x18\xc9\x66\xe4\x67\x94\x0e\x5d\xfd\x6b\xec\x61\xaf\x19\xf3\xdf\xf4\x2d\xf5\xe4\xca\xdd\x75\x22\x1a\xbf\x6e\xdc\x88\xae\x21\xa6\x7d\x57\x47\x4b\x7d\x38\x73\x0b\xb0\x51\x65\x8c\x5e\x3c\xb2\x9e\x70\x00\xfa\xf4\xee\xdd\x4f\x78\x6d\xf7\xf3\xc5\x4f\xa0\xf9\xcf\x38\x82\x60\x81\x95\x5f\x1b\xed\xe3\x01\xad\xcb\x14\xb3\xbc\xc0\xb5\x4f\xdd\x1e\x7f\xd6\xed\x80\xe3\x46\x89\x77\xe3\x39\xb6\xb8\x21\x92\xef\xd8\x3a\x0c\x72\x97\xa6\x07\x46\x9b\xb3\xe2\xdc\xab\x3a\x22\xc3\x28\xe5\xe5\x3b\x82\x15\x7b\x66\xfa\x2a\x09\xef\x1f\x6f\xa3\x60\x15\x9e\xbb\x7e\x82\x7e\xd2\xda\xb5\x35\x04\xc2\xf4\x0e\x4a\x13\x9e\xde\x41\x69\xaa\x17\x6e\x9d\x90\x74\xbe\x9a\xbc\x05\x2b\x2d\x9b\x0b\xd0\xbf\x14\x3d\x2c\x4c\x87\x30\x60\xed\xd9\xd0\xa9\x9f\x54\x1f\xfb\xd7\xf7\xdd\xa5\xf5\xf1\x80\x9a\x7d\xd8\xd3\x78\xb9\x74\x93\x3b\x77\x85\x3d\xd4\x32\x00\x93\x7d\x89\xbc\xe4\xec\x0d\x2b\x3b\xd4\x78\x55\x86\x6f\xb7\x88\x5a\x73\x69\x40\x23\xe5\x3a\xc2\x14\x7e\xd8\xf7\x7d\xf3\x7d\xf7\x83\x78\x18\xbf\xfd\x7d\x60\x78\x59\x23\x01\x76\x74\x70\x9c\x40\x66\x1a\x27\x1a\xe2\xd3\x60\xaf\x6e\xd2\x1a\xfd\x03\x88\xff\x38\x6c\xd8\x95\xfe\xea\x8a\x9e\x3d\xc7\x78\xb9\x5c\x25\xa2\x9e\x4c\x58\x9b\x2e\xc7\xf7\x75\xe9\xe6\x59\xbc\x74\x7d\x29\x17\x5a\xa4\x53\x8f\x98\x4d\xcd\x2a\x6d\x0f\x71\xf6\x15\x75\xeb\xbf\x99\x65\x5c\x04\xcb\x2e\x00\x00")
 
 func locales_en_us_home_yml() ([]byte, error) {
 	return bindata_read(
@@ -34,7 +34,7 @@ func locales_en_us_home_yml() ([]byte, error) {
 	)
 }
 
-var _locales_zh_cn_home_yml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x58\x4b\x53\xdb\x4a\x16\xde\xeb\x57\xb8\xec\xba\xbb\x3b\x53\xb3\xce\x4e\xb1\x05\x68\x90\x25\x95\x24\x73\x2f\xb3\x51\x71\x89\x27\xc3\x04\x30\x85\x61\xaa\xee\xac\x62\x5e\xb6\xc1\xc6\xbe\x09\x66\x02\x38\x01\x13\x08\xbe\x10\x3f\x80\x04\x3b\x7e\xc0\x9f\x51\xb7\xe4\x15\x7f\x61\xaa\xbb\x25\xb9\x2d\x07\xc2\x86\x2a\xec\xf3\x9d\x3e\x7d\x1e\xdf\xf9\xda\x81\xe9\xd8\xdc\x5c\x6c\x9e\x11\xd9\x30\xa7\x73\xbf\xf2\xaa\xa6\x3e\xf3\xf9\x41\x3e\x6b\x9e\xd5\x41\xe3\x0a\x54\xde\x81\x62\xd9\xcf\xf0\xb2\x2e\x4a\x5a\xdf\xc0\xaa\x35\x40\xb1\x6c\x5e\xb7\xcd\xf6\xa1\x55\xbd\x35\xbb\xd5\x5e\xe9\x6b\xef\xfd\x31\x28\x5d\x80\x8d\x3d\xa3\xfd\x16\xb4\xde\xf2\xb2\x9f\x61\x02\xd3\xb3\xcb\xf1\xa5\xe8\x22\x13\x14\x22\xaa\xc6\x29\x7a\x88\x13\x38\x8d\xd3\x47\x58\x5e\xe0\x42\xcf\x7c\x7e\xf8\xbf\x23\x78\x5d\x00\xa9\xa3\xde\xde\x09\xe8\xbe\x05\xe9\xac\xb9\x79\x03\x5f\x27\xcc\xfd\xb5\xde\xc1\x86\x79\x7b\xe2\x77\xa1\xbc\x8a\x83\x50\x22\xa2\xc8\x8b\xa3\xcf\x7c\x7e\x62\x60\x34\xb3\xa0\x58\xb6\xee\xf2\x56\x29\x63\x34\x2b\xf7\x9d\xc4\x10\x44\x90\x82\xac\x80\xee\x55\xeb\x80\xf5\x53\x02\xb3\x0f\xce\x26\xcd\xd6\x19\x0e\x74\x31\xfa\x22\x3a\xbf\x34\x33\x35\xcb\x0c\xc4\xa8\x2b\x9c\x2a\x45\x94\x20\x87\xf0\x24\xcc\x93\x4b\xeb\xcb\xe9\x7d\x27\x61\xd5\x4e\xcd\xb3\x77\xbd\x37\xa7\x46\x73\x0b\x16\xd3\x60\xfd\xda\x4a\xec\x18\xcd\x36\x2c\xb6\xfc\x0f\x38\xd1\xff\x21\x89\x4f\xf5\x04\x72\x35\x73\xa7\x0c\x32\xd8\xd9\x08\x1b\x11\x34\x3d\xa8\x70\x21\x4e\xd4\x78\x56\xd0\x83\xac\x88\xef\x46\xce\x41\xd9\x68\xbf\xb3\xaa\x27\x20\x59\x81\xd9\xaa\xd1\xcc\x5a\xab\x5d\x72\x08\x4e\x08\xae\x6f\x70\x8c\x0b\x8e\xf7\x53\x4f\x4e\x24\xb5\x26\x00\xa3\xb9\x6d\xee\x94\x61\xaa\x81\x3e\x3c\x68\x82\x7c\xc6\xcf\xc8\xac\xaa\xfe\x22\x29\x21\xf7\x40\x31\x22\x90\x5c\x6e\x98\x47\x09\x07\xd7\x32\xff\x6c\xe1\x83\x64\x85\x9f\x60\x35\x4e\x1f\xe7\x26\xbd\x08\xe7\x86\x1e\x04\xc3\x04\xfe\x15\x8b\x2f\x31\x63\x92\xaa\xe9\xac\xa0\x70\x6c\x68\xb2\xdf\x69\x24\x9d\x54\x2b\xda\x79\xc5\xd6\xee\x55\x86\xd3\xe9\xe2\xcc\x76\x8e\xa4\xd3\x69\xa7\x61\x07\xfa\xf3\x49\x5d\x56\xa4\xbf\x73\x41\xed\xa9\xbe\x4a\xdf\xcc\x83\x2a\x0e\x5f\x9d\x54\x35\x2e\xac\xdb\x13\x32\x22\x45\xc4\x90\x3d\x20\xeb\x29\x32\x0e\xb0\xf8\x19\x16\x5b\xbc\x4c\x0a\x21\x21\x53\x76\x82\xe5\x05\xf6\xb9\x80\xea\xc6\xcb\x3e\xeb\xeb\x1a\x6c\xe5\x51\x66\x6e\xae\xfd\x0c\xaf\x92\x86\xc5\x21\x62\x5f\x76\x04\x46\x73\x9b\x8c\x96\x8f\x97\x7d\x60\xe3\xca\x3c\x4f\xdc\x77\x32\xb0\x78\x6e\xad\x76\x61\x2a\x0f\x36\x0f\x61\xa3\x0d\x36\x8f\xfc\x24\x97\x7c\x58\x96\x14\x4d\xe7\x14\x45\x52\x9c\x1a\xc0\xe2\x39\x4c\xdf\x82\x54\x1d\xe4\x6a\x64\x1a\xcc\xfd\x35\x58\xa8\xc3\x6c\x15\xdf\xb5\x01\x3f\xbe\x86\x87\xa7\xe4\x2b\xb8\x9b\x34\xda\x37\x38\x6c\xda\x21\x72\xa5\x4f\xb0\x42\x04\x45\xff\x53\xdc\x67\x95\x32\xb0\x98\x36\xff\x6c\x11\x3f\x1e\x63\x49\xd3\x83\x52\x58\xc6\x39\xf7\x80\xb0\x39\x1a\xdf\x6a\x06\x16\xbe\x0c\xe2\x7e\x51\x24\x71\x54\x1f\x91\x94\x30\xab\xb9\x08\xf3\xa2\x06\x72\x1f\xe1\x51\x07\x74\x72\x46\x33\x0b\x2b\x1f\xcd\x92\xe7\x3c\x6a\x42\xe8\x7a\xd8\x27\xa6\x6f\xd1\x71\xa9\x3a\xa8\x6d\xf4\xde\x9c\x0e\x22\xed\x0e\x78\x0c\x46\xca\x3e\x08\xb3\x5b\x48\x8c\x84\x51\xef\xac\x5f\xfa\x3c\x97\x83\x95\x8f\xa0\xd9\xbc\xef\x64\xac\xc6\xb5\x75\x97\xb4\xc1\x2e\x1f\x38\x1d\xc8\xe3\xf3\x48\x9d\x49\x3b\x20\x47\xc6\xdd\x7b\xd4\xf7\x76\x2f\x66\xc0\xf6\x21\x38\x38\xba\xef\xec\xff\x14\xff\x6e\x10\x2a\x3b\xc1\xb9\x5e\x7e\x84\x67\x98\xc0\x72\x3c\xba\xd8\x1f\x70\x74\xf1\x30\xab\x05\xc7\xdc\xe9\xee\xed\xec\x59\xb5\x9a\x9f\x91\x14\x7e\x94\x17\xed\x94\xba\x26\xdb\x87\x83\x56\x11\xb5\xcf\xb8\x6c\x50\xe3\x71\x2c\x84\x4f\x60\xf1\x1c\xe4\x11\x9b\x91\x26\xb3\x12\x3b\x68\x77\x54\x4b\x66\x7e\x03\xfc\xf1\x0e\x77\x18\x46\xd3\xb9\x47\x04\x5d\x39\x21\x78\x6c\xc1\x86\xc2\xbc\xf8\x10\xef\xf9\xa6\x5e\xcc\xcd\xcc\xfb\x88\x39\xe1\x18\xeb\xf8\x82\x62\x40\x3a\x3a\x85\x13\x58\x8d\x0b\x51\x43\x6f\x87\x79\x55\x72\xd9\xd7\xa9\xb5\x10\x62\x65\xf7\xd0\x88\x1c\x62\xf1\xa1\xe8\xd3\x81\xc3\x8c\xbb\x2a\xdc\xf9\x86\x4f\x9a\xe0\x14\x7e\x64\x52\x0f\x4a\x21\x6a\xcf\xf5\xce\x33\x56\x2d\x41\x65\x8b\x0b\xb3\xbc\xa0\x87\x78\xd5\xa6\x81\xde\x4a\xd5\x68\xdf\x90\x65\x6a\x1d\x5f\x98\x9f\x12\x0f\xa5\xcb\xc1\xd2\xc5\x20\x68\x90\xf9\xd6\x5b\xcf\xba\xd4\x65\xd3\xbe\x5b\x60\x15\xfd\xd7\xa7\x7f\x87\xe9\x5d\xee\x27\xe5\x74\x88\x7f\x10\x8b\x59\x84\x46\xc1\xd4\xee\x60\xf9\x49\x50\x98\xb8\x49\x40\x66\xf5\x92\xe2\x6d\x86\x09\x2c\x46\x5f\xce\xc4\xe6\x1d\x02\x56\xb8\x51\x5e\x12\x9f\xb4\x65\x41\xa6\x05\x0e\x0f\x69\x02\xa6\x76\x23\x13\xf8\x6f\x6c\x3e\xea\x78\x45\xfb\xf5\x69\x3e\x1d\x0f\x03\xbc\xbe\x5a\x36\xbb\x57\x56\xb5\x04\x52\x6f\x06\x55\x04\xa1\x4f\x6b\xbb\x01\x72\xbb\x36\x19\xe0\x7d\x42\xb3\x66\x6f\x3d\x6b\x76\xc9\x56\xe0\xc3\xec\x28\xf7\x10\xb0\x50\x04\xab\xb9\x87\x80\xb2\xae\x8e\x49\x0a\x4a\x21\xbf\xe0\x73\x56\x02\xc3\x04\x62\x0b\xd1\xf9\xf8\xd2\xd4\xf4\x2b\x66\x94\xd3\x9c\xe4\x39\x55\xe9\x13\x1b\xce\x14\x4a\xca\xc2\x62\xec\xdf\xd1\xe9\xa5\x70\x74\xee\xb7\xe8\xa2\xdb\xfd\x6c\xc8\xa6\x35\xbb\x8e\xf8\xee\xce\xd6\xa0\x47\x84\x62\x40\x77\x84\xc9\xe6\x20\x4b\xdb\xf1\xef\x4a\x13\x87\x3e\x1f\x98\x4f\x32\x4e\x43\xba\xc4\x41\x8d\xb1\xaa\x6e\xa7\x1b\x41\xb0\x31\xbd\xb6\xef\x3b\x89\x21\x2c\x13\x98\x8f\xbd\x88\x32\x22\x9a\x33\x47\x37\xd8\xe2\x50\xd7\x58\x75\x1c\xf3\xf1\x8d\xd1\xde\xb5\x36\x57\xcc\x95\x6f\xb0\x50\xef\x25\x73\xf0\x6d\xd6\xe8\x16\x11\x29\x17\xcb\x30\x7d\x66\x95\x32\x3f\xfb\xac\x5a\xc3\xac\xa4\xc1\xed\x3a\xa8\xae\x1a\xed\xcf\xe4\x63\xb4\x94\x6a\x85\xbf\xa2\x63\x7e\x9b\x9a\x7e\xb5\xbc\xc0\x4e\x4f\xc7\x96\xe7\x97\x18\x99\x55\xd8\xb0\xce\x85\x65\x6d\x12\x9d\x90\x5b\x81\x85\xba\x53\x27\x74\x71\x35\x22\xcb\xa4\x80\x88\xfd\x77\x6a\x30\x83\xc4\xac\x79\xd9\x06\x1f\xb6\xfc\x8c\x47\x83\xc1\xa3\x52\xef\x3c\x43\x4d\xac\xdd\xc6\xcf\xd9\xe0\x78\x44\xd6\xd9\x60\x50\x8a\x88\x4f\xd5\x29\xe0\x24\x69\xb4\xbb\xd6\x97\x4f\x20\xd7\x78\x40\xad\x30\x81\x85\xd9\x29\x77\x04\x65\x81\x15\x1f\x13\x51\xf4\x38\xa0\x51\x2e\x96\x9d\x32\x6e\x39\xda\xbb\x62\x74\xef\xcc\x9d\xb2\x57\xef\xfe\x20\x52\x8f\x63\x4f\xa4\xd4\xbb\xe1\x39\x4e\xbd\x27\x2b\x8f\x44\xec\x49\xc1\xa3\x11\x3b\xde\x78\x81\x53\x69\x9d\x6a\x6b\x37\xdb\x37\xf2\x47\x84\x10\xd8\xc8\x82\x54\x12\x66\x8f\xe9\x43\x06\x58\x82\x78\x74\x5f\x26\xa4\xcb\x88\xf5\x77\x5e\x26\x0a\xa7\x6a\x92\xc2\x79\xcc\x61\xe2\x18\x9c\x64\x1d\x73\x77\xce\x94\x68\x3c\xb6\xbc\x38\x1d\x1d\xce\x33\x75\x8d\x47\x82\xa7\x9b\xcb\x23\x7d\xfb\x73\x37\x20\x74\x2f\x3f\x18\xad\x6d\xcf\xf4\x59\x77\x07\x48\xdb\x54\x4e\xc8\x18\x51\xd2\x70\x88\x35\x1c\xc9\x9a\x75\x16\x80\x2b\x79\x08\x0d\x71\x8e\xbe\xc2\x62\x07\x34\xae\x1c\x16\xc2\x4d\x39\xec\x8f\x6e\x19\xca\xab\x67\x50\x86\x71\x74\xb1\x28\x1c\x13\x98\x7d\x31\xb5\x40\xb6\xbb\xcb\x7b\xf6\xcb\x26\x9f\x81\x95\x53\x90\xaa\xa3\xb1\xb5\x57\x7c\xeb\x6f\xb6\x12\x10\xa4\x51\x5e\xf4\x22\x5c\x21\x40\xbc\xe3\xb4\x60\xeb\xfe\x7a\x27\x6f\x65\xf3\x53\x02\x56\x8e\xd1\x57\x04\x62\xee\xb5\x7b\x7b\x1b\xbe\x07\x24\x11\x13\x98\x9e\x89\x33\x41\x5e\xc5\x74\xe6\xe5\x38\x14\xa9\xb3\x5e\x61\xfa\x02\xe6\x72\x46\xb3\x62\xee\xaf\x19\xed\x36\xd8\x2c\x21\xf4\x7f\xe6\x82\xb1\xf9\x7f\xce\xbc\x64\x26\xc2\x7a\x50\x12\x47\xf8\xd1\xfe\xbb\x8a\xac\x1c\x6a\x3f\xf7\x6d\xbc\x4f\x74\xd7\xb4\xdf\x15\x54\x2d\x1e\xed\x0d\x26\x30\xb3\x80\x56\x5a\xff\x97\x05\xfc\x22\x32\xf7\xd7\x78\x19\x56\xbf\x22\xcd\xd1\xb8\x82\xc5\x34\xfe\xcf\x7d\xd1\x58\xb5\x46\x2f\x99\x85\xbb\x75\x62\xed\xfe\xfc\xe0\x79\x35\xa1\xe6\x39\xbe\x00\xd9\x23\x2c\x94\x32\xae\xe8\xeb\x25\x37\xe1\xce\x2d\x46\xf1\xe2\x04\x2b\xf0\x21\x6c\x0f\x8a\x75\xf0\xfe\xb5\xfb\x6c\x70\xb6\xad\x5b\xc2\x43\x98\xca\xe3\xc0\x88\x21\x59\x17\xa8\xf2\x3e\x77\x4f\x5b\x5f\x1b\xe0\x6c\x8b\x7c\x0f\xde\x64\x40\x25\x6f\x76\xff\x70\x2f\xfa\x97\x85\x58\x6c\x16\xb9\x94\x25\x49\x18\xca\x22\xbf\xe0\x83\x97\x47\xdf\xd5\x30\xe8\x11\x47\xfd\x0c\xe2\xb7\xa5\x52\x7c\x69\xf1\x77\x06\x2d\x7a\x55\x53\x26\x87\x5f\xc6\x56\xed\x14\x7e\xb8\x81\x1f\xec\xd5\x82\x2b\x8f\x1e\x87\xf6\x11\x6e\x9f\xdb\x4c\xea\x12\x88\xc3\x40\xb2\x22\x4d\xf0\x21\x4e\x71\x45\x84\x95\x3c\x07\x9b\x65\x58\x2e\x81\x4e\x0e\xa4\x0e\x40\xbb\xe5\xfe\x08\x43\x72\x0b\xd3\x5b\x60\xb3\x4c\x94\x2e\xfd\x52\xa1\x66\x5b\x9b\x94\x39\xd7\xa1\xbb\xff\xc8\x68\xbb\x5b\x10\xb5\x4c\x7b\x9d\x7c\xe5\x43\xf4\xf1\xf3\xe0\x14\xff\x78\x0b\xf6\x29\xeb\x07\x2b\x00\x27\xda\xdc\x5f\x23\x1f\x9a\x95\x5d\xb3\x70\x8a\x38\x19\x3f\x46\xfa\x24\x1b\x8f\xc6\xe3\x48\x9a\xaa\x9c\xaa\x22\x5d\x35\xce\x4d\x0e\xf0\x88\xfd\xbd\xef\x55\xf4\x77\x1f\x2a\x55\x21\x65\xab\x25\x59\x91\x10\xf1\x52\x8d\xe6\x98\x92\x01\xb7\xba\x9f\x41\x66\x97\x84\x67\x43\x90\xd4\x11\x25\x5a\x9c\xd2\x8f\x0f\x27\xa3\x28\xa8\xa5\xd8\xe2\xd4\xcb\x28\x83\x0b\x85\xc2\x42\xb5\xfa\x55\xe5\x35\x7a\xf2\xd1\xdf\x95\x32\xcc\xe5\x8d\xdb\x03\x50\xd8\xf0\x33\xff\x0f\x00\x00\xff\xff\x64\x6c\x4d\x3f\xd2\x13\x00\x00")
+var _locales_zh_cn_home_yml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\x03\x8d\x5a\x6d\x4f\x1b\x49\xb6\xfe\xee\x5f\xd1\x22\x1a\xe9\xee\xd5\xe6\xce\x44\xab\x48\x7b\x57\xab\x95\x1a\xbb\x81\xde\xd8\x6e\xdf\x6e\x9b\x0c\xf7\x8b\xc5\x10\xef\x84\x0d\x60\x84\xc9\xac\xf6\x7e\x02\x12\xde\x5f\x4c\x12\x20\x10\xc8\x00\x09\x04\x36\x09\x76\x48\x32\x81\x60\x5e\x7e\xcc\xba\xba\xed\x4f\xf3\x17\xee\x39\x75\xaa\xab\xab\x6d\xc8\x44\x2b\xcd\xc6\xb8\xce\xa9\xaa\xf3\xf2\x9c\xe7\x9c\xf2\xb5\x9e\x7c\x7f\x7f\x7e\x20\x92\xd4\x13\x46\xd6\xf8\xde\x74\xd2\xce\x9f\xb4\x16\xb6\x38\xef\xed\xbd\x63\x47\xef\xd9\xc1\x2a\xdb\xd8\x6f\x89\x98\xa9\x6c\xd2\x4a\x07\x0b\x6a\xe5\x23\xf8\xbb\xf7\xa1\xe2\x55\x36\x6b\xa5\x73\xef\xac\x54\xdf\xfe\xa5\xfe\xfc\x05\xdb\x7e\xc3\x26\xd6\xaa\x95\x27\xec\xe4\x89\x99\x6a\x89\x44\xae\xf5\xf4\xdd\x2f\x0c\xe7\x86\x22\xd1\x78\xc6\x49\x1b\x76\x36\x66\xc4\x8d\xb4\x91\x6d\xd3\xcd\xb8\x11\x03\x4d\xee\xd3\x2d\xf7\xc3\x32\x9b\xda\xaa\xaf\xed\xb0\xb3\x27\x6c\x7a\xde\x9b\xf9\xe4\x8e\x8c\x7a\xcf\x1e\xd6\xd7\x27\xbc\xf3\x9d\x16\x29\x6a\x3a\xfc\x10\x76\x26\x99\x34\x93\xed\x20\x4b\x0b\xaa\xc7\xf3\x70\x96\xda\xc5\x62\x6d\x7b\xae\x7a\x7c\xf0\xeb\xe9\x68\x93\x48\xdc\x8a\xea\x71\xbc\x57\xf9\x94\x8d\xef\x92\x98\xd8\x78\x7e\xd2\x3b\xd9\x0b\x04\x52\x1d\xba\x63\x70\x99\x36\x2b\x93\x8c\xc9\x4d\xea\xab\x9f\xdc\xd2\x2f\xb8\x95\xb0\xc8\x25\x02\xfe\x95\x58\xf1\x75\xed\xc1\x59\x7d\x72\xbe\x56\x5e\x76\xa7\x56\x6a\x47\x1f\x6a\x17\x93\xac\x34\x5d\x7b\x39\xce\x76\x0e\x6b\x1f\x77\xf1\x6e\x5c\x5f\xa3\x9a\x94\x6d\x74\x9a\x56\x86\x0e\x1d\xb3\x92\x06\x37\xf5\xae\xd8\xfc\xf3\x2c\x5a\xc7\x97\x65\xef\x9e\xb9\x1b\xaf\x59\x69\xce\x9d\x5a\x6c\xd4\x63\xa5\x0c\x5b\x4f\x9b\x56\x32\x6b\x26\x3b\xf5\xb8\xc9\xef\xc1\xa5\xdc\x27\xf3\xd5\xb3\x0d\x3a\xa1\xbb\x5a\x6e\x38\x64\xa0\x47\x4f\xa5\xe2\x5d\x59\x1e\x15\xb6\xf1\x3f\x19\xd3\x36\x02\x5b\x50\x74\x80\x2d\x40\x47\xf5\xf8\xc4\xfb\xd7\x49\xa3\x5c\x26\xe9\x64\x52\x29\xcb\x4e\x1b\xb1\x6c\xb4\x43\x4f\xb6\x1b\x52\xd8\x2d\x16\x6b\x17\x20\x7c\xc0\xe6\xc6\xd9\xe2\x1b\xf2\x82\x3b\xbd\x07\xce\x83\xab\xb1\xe2\xaa\xbb\xfe\xf1\xd7\xd3\x39\x08\x30\x77\x73\xd7\xdb\x98\x65\x47\x25\x76\x3a\x56\x2b\xbf\x72\x1f\x8c\x37\x6e\x13\x5c\x33\x6a\x25\xdb\xe2\x66\x34\x8d\xd6\xe7\x1e\xa5\xcd\xfe\x3d\x32\xea\x1e\x55\xd8\xcc\x56\x6d\x66\xcc\x1b\xfb\xcc\x1e\xcf\x51\xa0\xd1\x47\xba\x01\x5b\x9c\x73\x9f\x7e\xa2\x13\x34\xdd\xdf\x8a\x19\xd9\x0e\xcb\x49\xab\x46\x70\xa7\x67\xd9\xcc\xbe\xbb\xbf\xcd\x4e\x8b\x32\x4a\xe1\x42\x6e\xb9\x58\x3d\x7e\x4d\xaa\xeb\xa0\xf7\x62\xbc\xbe\x5d\x71\xe7\xc0\xf3\xcf\xaa\xc7\x15\x77\xa3\xc9\x4a\x29\xdd\x4e\x9b\x7a\x1c\xfe\x85\x9f\x4d\x8a\x9d\xa3\xf7\x74\x14\xed\x9b\x02\x98\x01\x7d\xbe\xb8\x20\x0d\x43\xe1\xf3\xeb\xe9\xb3\x6f\x0a\x2d\x91\x84\xce\x75\x39\x10\xda\x18\x39\x56\xa7\x19\x83\x8f\x18\x39\xd2\xf8\x14\x8c\xee\xc6\xf4\xa5\x67\x76\x97\xca\xee\x9c\x30\x10\x58\x4a\xe4\xe0\xe3\x39\x77\xfd\xc2\x9d\x7f\xa1\xf5\x77\x63\xe2\x6a\x74\x21\xb9\x5d\x14\xd2\x22\x9d\x4d\xc0\x3d\xb2\xad\x10\x68\x31\xdc\x23\xb4\xd2\x5d\x7e\x57\x9f\x2c\xc2\xd5\x21\x34\xd8\xee\x24\x7c\xac\x56\x76\xab\x17\xcf\x6b\xe5\x51\x2d\x37\xdc\x73\x47\x63\xc5\xb2\xb7\xb4\x2f\x15\xde\x32\x8c\x54\x56\x87\x1c\x35\xe0\x73\x56\xc4\xfc\xe4\x07\xf6\xee\x51\x7d\x63\xa4\xf6\x6a\x14\x44\xbd\xe5\xb5\xea\xf1\x08\x18\xb7\xf1\x4c\xdc\x3f\xb6\x15\xa7\x04\x24\x85\x94\x32\xd2\xc3\x18\xe4\xfc\xb6\x70\xed\x46\x71\xee\x59\x3d\x6e\x1b\x7a\xac\x0b\x32\x25\x2b\xdc\x03\x1a\xbe\x29\x68\xe4\x33\xf0\x87\x57\x29\xb2\xc3\x9f\xab\x27\x0b\x3e\x1e\xf1\x5d\x05\xb2\xf8\x28\x01\x02\x72\x4b\x1f\x22\xc4\x05\x6d\x23\x15\xd7\xa3\x46\xd6\x49\xea\x29\xa7\x03\x64\x54\x39\xb2\x08\x00\xed\xce\x21\x5b\xa8\xb0\x9d\x67\x60\x2f\x48\x68\xf6\x68\x15\xbd\x7f\xbc\xe4\xbe\xdf\x06\xf7\x91\xc9\xe0\x08\xee\xe8\x0b\xb6\x83\x18\xc0\x76\x26\xab\x15\x88\xb1\x37\xde\x38\xa0\x57\x22\x13\x4f\x9b\xfe\xf1\x71\x43\xcb\x31\xd3\x96\xdd\x45\x78\x89\xd7\x83\xad\x08\x95\x05\x6c\x8c\xcf\xd6\x9f\x4d\xe0\x46\x33\x9b\x5f\x10\x77\xba\x92\x51\x82\x5a\x12\x76\x0f\x5e\xc2\xcd\x30\x63\x0e\x76\x39\xd4\x62\x9e\x7a\xfb\xf3\x10\xa3\x6c\x02\x91\xa4\x25\xd2\x76\x33\xeb\x18\xe9\x34\x48\xa9\xd5\x42\x60\x2e\x5c\xb4\x3e\x3e\x0f\xd5\x42\x6b\xbb\x19\x5a\xaa\xa4\x57\xdb\x4d\x8d\x6d\xbc\x63\xcf\x47\x20\x24\xe1\xd2\xee\xd4\x11\xe0\x0d\x86\x67\x79\xc2\xdb\x1a\xe5\x29\x3c\xc1\xe6\x4e\xc2\xf0\x03\xaa\x78\x36\x09\x30\x48\x24\xe0\xff\xf0\xee\x90\x5d\xd6\x6d\x5f\x6d\xd4\x74\x34\x12\xf3\x4a\xdb\xde\xe2\x84\x16\xe5\xb5\x4f\x23\x85\x5c\x49\xdc\x6a\x87\x38\x90\x48\xee\xad\x55\xd8\xd9\xb2\xd6\x6a\xb6\x5f\x37\x53\x9a\x9f\x7d\x1c\x9c\x5e\x8e\x20\x3e\xf9\xe7\xc3\x63\xf1\xf3\x35\x1c\x45\x75\xb5\xd0\x52\x3d\x9e\x91\x31\x02\x86\xf1\x37\x0f\xac\xdf\x0e\x56\x03\xdb\xa3\x1b\xcc\x64\xba\x39\xa1\x79\x0d\x53\x80\x67\x9e\xf2\x98\x4d\x4d\x42\xd6\x92\x9f\x02\x75\x01\x44\x06\x65\x53\xba\x83\xdc\x49\x78\x03\x4e\xf5\x0e\x2b\x54\x1e\x54\xbf\x52\x85\x50\xa0\x2b\x1a\x35\x1c\x07\xca\x78\x92\x00\x8b\x02\xb4\x56\xba\xa8\x3f\x2d\x49\xc5\x70\x3a\xf7\xf9\x83\xfa\x1a\x14\x26\x00\xa6\xbf\x1a\x51\x48\x4e\x23\xd1\x8a\xd7\xc3\x64\x0d\x8a\x12\xc2\xff\xf2\x14\x5e\x66\xfb\xb3\xb7\x5e\xa2\xc8\xaf\xed\x3d\xae\x4d\xbf\x6f\x89\xe8\x29\x33\x9b\xb6\x6e\x19\xc9\xcb\x04\x34\xf8\x56\x4b\xe7\xef\xe5\x06\xd4\x85\xc6\xf7\x29\x11\x46\xf2\x6b\xcc\x2e\x28\x6d\xee\xc6\xa6\xba\xd0\x89\x82\x65\x82\x5b\x04\xab\xe5\xd1\x6b\x73\x0f\xd8\xfa\x47\x74\xd5\xf8\x68\xad\x74\x8c\x98\x02\x6e\x3f\x1c\x6b\xd6\x12\x9c\x2e\x50\xa3\xea\x90\xd5\x56\x1b\xca\x75\xdf\xd1\xa0\xda\x6a\xff\x18\xea\x1d\xce\x85\x0f\x2e\xa9\x17\xb8\x62\x5e\x0b\x1d\xdf\x07\x94\x60\xb9\x1a\x57\xc1\x52\x05\x7b\x1c\xc3\xee\x34\x01\x74\xc0\x5d\x1c\xb0\x55\x01\x77\x63\x9e\xcd\x6c\xd7\x3e\xbe\x62\xc5\xa3\x2f\x88\x44\xf5\xa4\x20\x4f\x90\x13\xcd\x62\x48\x71\x46\x9e\x21\xb3\xe1\x81\x4f\xa9\x42\x91\x53\x3d\xbb\x80\xcc\x50\x3d\xe4\x38\x56\x36\x66\x3a\x7a\x6b\xdc\xe0\x9a\x5e\xb3\x45\x44\x34\x36\xbf\x0c\x90\x49\xa2\xb4\x28\xe3\xf0\x1a\x13\x54\x73\xff\xf6\x64\x17\xf4\xce\xc6\x5b\xc0\x07\xca\x3c\x45\x06\xfe\x93\xc5\x0f\xfe\x69\xc1\x5f\xb4\x86\xaa\x88\x38\x6a\xd3\x7e\x4e\x5a\x07\x36\x9a\x30\x9d\x84\x9e\x8e\x76\x70\xfa\x10\x2c\x21\x26\x8a\x26\x9a\xfb\x0c\xb8\x45\x97\x83\x84\x70\x57\xde\xa9\x3a\xcc\x98\xf0\x0a\xa8\x71\x44\x8a\x95\x76\xa0\xde\x91\xc5\x44\x86\x5c\x2c\xb1\xf5\x9f\x35\x33\xa6\x9a\x24\x69\x25\xa3\xa1\xdd\xfd\xaf\x31\x0c\xb5\x81\xfc\x40\x4f\x4e\x93\xdb\x07\x97\x25\xb2\x9e\x48\xa5\xbb\x54\x11\xa4\x20\x7c\x2b\x89\x4a\x80\xec\x6d\x3a\x58\x33\xa6\xc6\x68\xfd\x35\xdc\x62\x14\x3c\x56\x5f\x5a\xab\x95\xcb\xb4\x86\x93\xfb\x24\x24\xa7\x60\xb0\xbc\x48\x78\x95\x47\xc8\x5d\x4e\xe6\xdc\xb7\xdb\x74\x21\x5a\xac\x80\x35\x01\x29\xe4\x2c\xb1\x1d\xf2\xaa\x2a\xc1\xab\x18\xa7\x59\xe3\x1f\xea\x4f\x0f\x48\x81\x5f\x72\x8d\x24\x06\x04\xea\x51\x45\xd0\xe5\x8b\x82\x22\xc0\xe2\x46\x04\x68\x58\x5a\xfb\x34\x0e\xf4\xed\x32\xd7\xa0\x30\x10\xff\x5b\x46\x70\x6b\x42\x70\x10\x87\x2a\x8b\xe1\xb0\xf3\x8c\x04\xb5\x1b\x37\xb1\x0a\xd4\x1f\x6f\x2a\x95\x2c\x1e\xd3\x53\xbc\x02\x2a\xd0\x89\x7f\xd3\xa8\xfe\x11\x78\xd6\x2e\xd6\xa9\xed\x68\x89\x48\x1e\xe2\xe7\x19\xe1\x98\x92\x61\xb4\x22\xd4\x69\xd1\x9a\xa6\x7e\x2b\x58\x69\x1b\x98\x97\x46\xac\x61\x6d\xf5\x18\xce\x39\x0e\x95\x54\xfc\x71\xe2\xbd\xf7\x7a\x54\xc8\xd9\x99\x10\xc6\xd2\x8a\xda\xde\x43\x36\xb5\x46\xf0\x89\x97\x3e\x2a\xb3\xf3\x87\xe4\xb5\xea\xd9\x3c\x50\x0b\xed\xdb\xee\xc1\xde\x6f\x7f\xba\x01\x71\x34\x0b\xc4\x43\x2d\x94\x3e\x20\x73\xed\xad\x66\x32\x86\x25\xbb\xf9\xa6\x22\x5e\x1a\xee\xeb\xaf\x97\x57\x16\xe1\xc9\xab\x9f\x00\xc8\x89\x71\xe4\xbd\xb3\xbb\xc8\x54\x0f\x76\x2e\xdd\x0e\xec\x60\x65\xec\x68\xa8\x1d\x91\x0a\x28\xc7\x89\x6b\xfb\x95\x64\x45\x6d\x56\x00\x3b\x33\x31\x13\x1b\x58\xac\xa3\x70\x6e\x1b\x32\x4e\xb1\x11\x2b\x41\x44\x6d\x63\x69\x9d\x3c\x71\xb7\x4e\x81\x25\x0b\xd3\x1c\x9f\x68\x7f\x2f\xe4\x07\xfa\x38\x6a\xf7\x14\x7e\x6a\x89\xdc\x36\x5a\x3b\x2c\xeb\x56\xb3\x8e\xdb\xb9\x1f\xee\xe6\xf3\xf7\xb4\xcb\xe4\x81\xbf\x14\xfa\xba\x7b\xee\x71\x35\xc3\xb9\xee\xfe\x42\xa0\xa8\x03\x72\xc1\xb0\x9d\x4b\x34\x51\xc1\x61\x3b\x1f\x45\x2f\xb1\x5a\x66\x23\xa7\x48\xab\x0f\x9e\x7a\x6f\x5f\x55\x8f\xdf\x23\x4a\xfc\xd5\xb1\x92\x1a\x2b\x7f\xae\x1d\x6e\xc3\x2d\xe3\x06\x5c\xcf\xb6\x32\xe9\x70\x28\xb2\x47\x33\xb5\x83\x57\xe0\x74\x6f\xe9\x50\x71\x50\x68\x79\xc3\x04\x00\xe1\x56\x15\x53\xeb\x90\x22\x66\x60\xff\xd1\xd8\x6b\xd2\x48\x80\x3a\x31\x77\x61\xd7\x5d\xfa\x54\x3d\x39\x81\x34\xa5\xbf\x78\x07\xd3\xec\x1c\xb3\xb6\xfe\x54\xdc\x8c\xed\xec\x61\x04\x7e\xe7\xab\x76\x80\x7f\x21\x32\x36\x5f\xa1\xfe\x7c\xad\x5e\x59\x6d\xba\x82\x2f\x90\x36\x13\x21\xac\xe3\xab\xc1\x4a\x5e\xe5\x89\xfb\x7c\x53\xdd\xd2\x5d\x03\x64\x5b\x60\xa7\x23\x6c\x6f\x56\xfc\x1d\x38\x1c\x1f\x40\xd0\x47\x5f\x75\xf3\x19\x9a\x76\xf7\xf1\x4c\x8f\xde\x4a\x5a\xb7\x01\xd1\xda\x8d\x60\x35\x62\xd4\x8b\x37\xde\x76\x09\x20\xab\x25\x12\xd3\xd3\x3a\xb4\x3c\x40\xef\x2c\xa8\x3c\x04\x2a\xee\x72\xd1\x7d\xb8\x89\xc4\x7f\xbe\x44\xa8\x42\x40\x06\x6d\x19\xd4\xd6\xcb\x48\x76\xe4\x5a\xcf\x50\xee\x4e\x6e\x60\xb8\xb7\xbb\x2f\x12\x9a\xa8\xc8\x3c\xc1\xfd\xa9\xa1\x13\xac\x75\x14\x2b\xe2\xde\x6a\xfd\x31\x30\xf7\x59\xec\x29\xc6\x3f\xd4\x46\x97\xfc\xce\xf4\x72\x25\xd9\xff\xa5\x7e\xec\x6b\x34\x51\x8f\xc2\xb9\x6c\xcc\x68\xd3\xa1\xa5\xc8\x46\x21\x4d\x8d\x24\xf6\xb8\x92\x4c\xd0\x3e\xe8\x9a\xca\x2a\x18\x84\x4d\x1e\xc0\xa5\x45\x85\xe0\x9b\xf0\xf1\x0d\x8f\xc5\x68\x87\x11\xbd\xa5\x70\x71\xbe\x63\x78\xf6\xb0\x20\x4b\x9d\xb7\x7e\x0c\x21\x0b\x74\x53\x77\x9c\xdb\x96\x1d\x93\x1b\x26\x33\x71\x9a\xfc\x20\x49\x51\x9b\x06\xbe\x51\xca\x36\x3b\x91\x00\xa0\x47\x1a\x24\xfc\x1b\x36\x48\x80\xed\xef\xe6\x0b\xc3\xe1\xde\x51\xa6\x8d\x6c\x1a\xfd\x00\x11\x76\xe5\xab\x83\x01\x51\x93\x39\x43\xcd\x26\x37\xa7\xdf\x6c\x36\x2b\xc8\xb6\x76\x65\x05\xab\xfe\x5a\x5d\x1c\x12\xf9\xf1\x9d\x2e\x60\xf1\x89\xac\x98\xe7\x49\xfc\x2e\x1f\xb1\xf1\x29\x1a\xde\x01\xbf\x02\x71\x33\x45\x8e\xb0\x70\xa9\xde\x09\x1b\x0b\xee\x06\x1d\x4c\xed\x97\x87\xee\xc9\x22\x5a\xe6\xd3\x87\x96\x08\x34\xc2\x7c\xbc\xc6\x8f\x48\xa0\x4c\x27\x00\xf7\x50\x37\xa2\x61\xef\xc4\x0b\x14\xc4\x32\xf0\x0a\x24\xc3\xbc\xf3\xa4\xd1\x83\xe8\xc3\xcd\x04\x87\x66\xc3\xb6\x2d\xdb\xf7\x01\x2c\x76\xa7\xcf\xd9\xd4\x3b\x08\x2e\xd9\xf7\x50\xa2\xf0\xbb\x8a\x36\x8c\xbe\x72\x57\xa0\x25\xfe\xc4\x8f\xad\x2a\x44\x55\x59\x00\x84\x8c\x21\xda\xf4\xed\x39\xe4\x48\xff\x3a\x21\x3d\x0d\x8b\xc1\x28\xd0\x3e\xa6\xb8\xcd\x1b\x84\xf8\x72\xcc\xfd\x12\xfc\xfb\x63\x58\xee\xb6\x6d\x41\x8d\xa2\x92\x20\x25\xbc\x37\x65\x56\x7c\x49\xe5\x00\xdb\xb4\x83\x97\x80\x02\x61\x39\x25\x43\x1a\xa7\x09\xb0\xe3\xf4\x39\x6e\x07\xb7\x2f\x03\x35\xd9\x0d\x4b\xfa\x7d\xd5\x17\xc4\xc8\xed\x61\x31\x11\x42\xc9\x4c\x02\x63\x67\xfc\x50\x6b\xb8\x1c\xe2\xcf\xf1\x31\x27\x08\x34\x10\x14\xd3\x2f\x81\x07\x7e\x04\x9a\x7c\x3f\xf2\x33\x85\x03\x2a\xaa\x5e\x3c\xc7\xb8\xf7\xdb\x64\xb6\xb0\xc9\xd6\xb7\xc4\xb4\xea\x92\x43\x38\x7a\xa7\x21\xb5\xfc\x96\x3c\xe4\xdd\xfd\x42\x6e\x28\x48\x70\x9a\xf8\x08\xd2\xce\xb3\xdb\x27\xb4\x96\x6d\x42\x0f\x20\x4c\x2a\x97\x80\xb2\xd0\x2a\xde\x35\x88\x29\x8e\x1e\x4d\x9b\xfc\x2c\x84\x27\xb2\x3f\xa1\x20\x83\x0c\xc2\x49\xb7\x4f\x75\x79\x84\x71\xe9\x50\x53\x05\xcc\xea\x60\x87\xe4\xf9\x0a\x3d\x96\xc0\x91\xd2\xe5\xb8\xa7\x75\xdf\xe9\xef\x05\x96\xcf\x97\x13\xc6\x40\x91\x50\x10\x50\x3d\x9d\x6d\x40\x81\x05\x7b\x05\x49\x2f\x8e\x49\xb3\x21\x25\xc5\x05\x69\xf5\x37\xcd\xa4\xa0\xdc\x18\x3e\x6d\x55\x37\xab\x5e\x94\xdc\xa5\xcf\x7c\xa7\x4e\xc3\x36\xdb\xba\xa8\x4b\x90\x08\xd5\xd4\x24\x18\x09\xf8\x4a\x69\xe1\xea\x63\x25\x48\x36\xaa\xf3\x58\xde\x5e\x8d\x5e\x65\x2e\x5f\x56\x75\x06\x49\x53\x5b\x23\xa1\x4b\xc0\xbe\x74\xb0\x83\x9f\x02\xf8\xf7\x91\x5e\x62\xbf\xe8\x3b\x05\xf0\x87\x65\x39\x8a\xa8\x52\xc0\xbb\xc2\xee\xa7\x43\x71\xe0\xa6\x03\x79\x25\x95\xe2\x40\xb8\x0d\xe5\x7e\xec\xcd\x0f\xf8\x00\x8c\x73\x19\x2b\xf9\x55\x55\x16\x6a\x20\xdb\xdc\x54\x01\x58\xa9\x8d\x91\x6b\xff\x97\x1f\xc8\xf9\x5a\xb1\xbe\x7e\x9d\x4e\x5f\x43\x08\xd7\x1f\xec\x7b\x67\xef\x91\xb8\x4e\x3d\x0e\xbf\x79\x10\x7c\xd6\x16\x8e\x58\x71\x45\x80\x01\xaf\x27\x2a\x6a\xd2\x24\x8e\x1b\xde\x4c\xe8\xed\xc6\x55\x82\xcb\x1b\xec\x41\xf1\x2a\x41\xe8\x90\x3a\x20\xa5\xb1\x30\x0c\x6a\x7e\x49\x80\x4b\xe6\x07\x73\x03\x85\x61\x60\xbc\x91\x76\x23\xed\x1b\xcf\xf7\x4a\x00\x6c\xdc\x52\x68\x94\xc1\xa1\xfc\xdf\x73\x3d\xc3\x89\x5c\xff\x0f\x90\xe4\x7e\xf4\xeb\x31\x01\x6b\xc2\x8f\xfc\xee\x7e\xd5\x50\x53\x44\x41\x40\x99\xc2\x54\x39\xa8\x68\xfb\xfa\x25\x35\xf1\xe1\xf3\x8a\xfc\xa4\x74\x6a\xe2\x25\xbe\x54\x87\xee\x28\xc3\x62\x5a\xac\x96\x6d\x58\xdc\x24\x1b\xb9\x36\x90\xbf\x93\xa3\xe1\xb1\xcf\x1b\x44\x63\x99\x4d\xeb\xce\x2d\x8e\xc7\x9f\xaa\x95\x15\x75\x8c\x4e\xf3\x38\x75\x4e\xf7\x7b\xec\x0a\x88\x41\xb3\xd2\x83\x6a\xe5\xad\x18\xdf\x41\x51\x2a\x2f\xff\x17\x6e\xf3\x03\x58\xfd\xfe\xa0\xde\xd3\x93\xbf\x3f\x30\x0c\x78\x69\xeb\x09\x39\x2c\x60\xc5\x31\x1c\xc8\x0b\x3f\x29\xa3\x45\x8e\xc3\x62\x92\x88\x7c\xf9\xb0\xc2\x7e\x9e\x85\x98\x0a\x73\x30\x77\x6b\x1b\x90\x41\xc9\x58\x11\xc6\xad\xc0\x7c\x33\x29\x39\x39\xfa\x3a\x9e\x42\x23\x6c\x9a\x27\x5d\xc1\x56\xc0\x6f\x7d\xdd\x32\x05\x53\x71\x3d\xf9\x25\x12\xa5\xa6\x03\xa6\x32\xf0\x68\xe1\xc6\x59\xf9\x48\x43\xa3\xa9\x46\xbe\xfb\x1b\x27\x6d\x50\xdc\x70\x52\xe5\x95\xb3\x95\x9b\xbe\xc1\x2a\x5f\x38\x71\x83\x09\xbe\x78\x62\x5f\x1b\x28\x73\x54\x9e\x2a\xb8\x9b\xd0\x8d\xfa\x88\x08\x41\xaf\x40\x23\x61\x75\x13\xa9\x26\x6a\x87\x1a\x36\xb1\x86\xdf\x30\x98\x11\x80\x66\x4e\x09\xb5\x9e\xa1\xfc\x00\xd0\x84\xfd\xda\xf9\x39\x70\x19\x9c\xe9\x9c\xcf\xb2\x57\x63\xda\x77\xda\x7f\x5f\xbf\xf1\x47\xed\x3f\xe1\x7f\x37\xae\xdf\x0c\x41\x10\xed\x13\x3c\xfb\xd1\xcb\x01\xdf\xc6\x7f\xa4\x55\x6e\x14\xee\xb2\x94\x5b\x28\xbd\x96\xba\x1c\x36\xa0\xba\x45\x1d\x94\x2a\x21\x92\x85\x47\x2a\x9f\xcb\xa8\x72\x3c\x9e\x9d\x4c\x42\x9d\xb9\x81\xb1\x6b\x67\x67\xf2\x4d\x45\x68\xa1\x48\x7f\x8c\xd3\xab\xea\xf1\x48\x6d\x12\x1f\x25\xc5\xa3\x4b\xb1\x8c\x99\x7d\xf4\xde\x9d\xdf\x64\xcf\x8b\x72\x03\x51\x48\x8d\x74\x34\x76\xc9\xb8\x95\xad\xed\x57\x8f\x67\x68\x50\x41\x6f\xc1\xe2\x35\xec\xf1\x1c\xff\x47\xcf\x70\x9f\xe6\x4e\x23\x66\xf8\x49\x86\x7b\x85\x4c\x0a\x05\x22\x6d\xd9\x46\x83\x4d\xe9\x49\xc8\xb7\xa9\x44\x3a\x3b\x57\xc8\xdf\x1f\xea\xc9\x35\x47\xba\x62\x8f\x2f\x84\x8f\x9a\xde\x0d\xcd\x47\x80\x7c\x57\xbd\x91\x49\xfc\xa3\xf1\x18\x10\x23\xff\x61\x41\x92\xf3\x26\xdc\xf6\x9b\x86\x60\x7a\xe4\x93\x4e\x2a\x04\x86\xcf\x70\x39\xdd\x44\xf3\x8b\x3a\xc0\x61\xa1\x59\x9f\x9a\xb4\xcd\x51\xe4\x43\x55\xb3\x9c\x9a\x2e\x8a\x5c\xe4\x5a\xdf\x9d\xee\x41\xe2\x57\xb2\xf2\x88\xde\x92\xcf\x03\xa1\xd6\x20\x70\x0a\x92\x75\xf2\x9d\xe0\x62\xf4\xa2\xd4\x20\x21\xa9\x18\x69\xe7\x66\xe1\xab\x03\x82\x45\xbf\xad\x00\x5e\xe5\x1e\xbc\xc0\xaf\x48\xc4\x5b\xab\xd4\xd7\x26\xb4\x2b\x48\x29\xe0\x50\x6f\x21\x12\x85\x7d\xb0\xa0\x34\x56\x19\x75\x9a\xee\x4e\xbf\x71\x8b\xd0\x94\x1c\xc0\x91\xab\x15\xb0\xe3\x36\x4a\xff\xd4\x1f\xcd\x0f\xfc\xad\xf7\xc7\x48\x67\x82\xcf\xe0\x4d\x65\x6a\x47\x45\x5f\x61\x48\xc1\x9a\xc6\x9f\x74\xc8\xa5\x41\x54\x28\xbe\xf8\x62\x6c\x44\xae\xf5\x0e\x22\xa9\x08\xe6\x50\x1c\x80\xe0\x94\x66\x8a\xff\xfe\x62\x01\x1d\xbf\x31\xcd\x3f\xc9\x9e\x52\x8e\x7e\x69\xb5\xfc\xb9\x4a\x43\xdf\x8a\xc1\x03\x24\x7b\x7e\x8b\x53\xd5\x39\x49\xbb\xeb\x93\x33\xee\xd2\x39\x97\x0a\x10\xd1\x4c\xd1\x2b\xa4\x6c\xdc\x7c\xbe\x23\x5d\xb8\x09\x4d\x2c\x3f\x18\x2d\xa4\x82\x8d\x9e\xd7\x24\x53\xaa\xfd\x72\xc4\xf6\x66\xe9\x7b\x1c\x2f\x1d\x2c\x7a\x67\x8f\xe4\x45\xaf\x0f\xe6\xf3\x7d\xa8\x32\x65\x59\xf1\x26\x2b\x02\x9f\x72\x0f\xb7\x2e\x65\x91\xd8\x46\x2b\x3f\x9b\x69\x11\x64\xb5\x30\x3c\xf4\xcf\x88\x7c\x3f\x6c\x9a\x4d\x04\xa3\x01\xfe\x8e\x22\x9e\x83\x49\xbf\x0c\x72\x51\xc8\x24\x7a\xc8\xdf\x95\xf8\xbf\x2f\xf0\x39\x5c\x6d\xf2\xb5\xfc\x45\x01\x9b\x5a\x67\x95\x13\xf5\x49\x12\x61\x8c\xff\xe4\x80\x1a\x0d\xb5\x51\x54\x12\x3b\xdd\x95\x32\xa4\x42\x49\x3f\x28\xaf\x25\x09\xc1\x78\xa9\x8c\xd3\x57\x1a\x62\xc7\xef\xc3\x29\xfc\xdb\x24\x24\xc0\xab\xdf\xa8\xc0\xdc\xca\xb2\x10\x78\x07\x2b\xde\xf2\x2e\x7f\x7a\xe2\xaf\x1a\x12\x61\x0b\xb9\x42\x01\x3b\x03\xc7\x70\x1c\xa4\xb5\x38\x3c\x52\x41\x44\x7c\xaf\xdd\xcb\xfd\x53\xa3\x62\x2a\xc8\x2a\x18\x91\xaa\x97\x8c\x32\x7f\x29\x65\x77\xed\xec\x2d\x9b\x5b\xa1\xe3\x09\x11\x64\x9a\x49\x4b\xed\x0d\xd4\xde\xcf\xb7\x28\x1e\x6a\x38\x3f\xd4\xfd\x63\x2e\xc2\x1d\x85\xc7\x42\x5f\x7d\xef\x98\xe1\x47\x34\xf8\xef\xd8\xbe\x5b\x5c\xac\x9e\xaf\xb3\xe5\x09\x7c\xf3\x8b\xda\x9c\x9f\xb7\x19\x36\x1f\xa0\x2a\x94\x80\x0f\x1d\xd8\xe9\x32\x6e\xc8\x67\x18\xd4\x3a\x61\x1d\x3c\x59\xc2\xc1\xf6\x4f\xdd\xf7\xfb\x86\xff\xf4\xed\xb7\x7f\xa6\xc7\x84\xbf\x5c\xfb\x73\x7d\xa9\xf4\x17\x3e\xe2\xfe\x5b\x6f\x5f\x0e\xbf\x71\xe7\xc6\xa0\xb6\x92\x2a\xe4\xc8\x67\xcb\x50\x6d\xc1\xc2\x42\x22\x28\x6f\x51\xc3\x4e\x9b\x6d\x66\x54\x17\x83\xeb\xab\x7f\xb1\x45\x99\x05\x9b\xb8\xeb\x1f\x21\xe5\xa1\x4d\xad\x7e\x7e\x25\x67\xd2\xf8\xf4\xd1\x6e\x5b\x99\xd4\x65\xe3\x6f\xfe\x04\xe2\x55\x1e\x36\x4f\xc0\x03\xb1\x00\x79\xc2\x32\xcd\xe3\x6f\x94\x89\x65\x9a\x7e\x67\x45\xd3\xe3\xcb\x2d\x96\x1a\xca\xf7\xe7\x86\xef\xe6\xee\x17\x34\x75\x19\x2e\x00\x36\xf5\x87\xef\x0a\xff\x1e\x19\xbd\xd9\x0f\xff\xb9\x71\x97\x37\x1b\x09\x23\xdd\x61\x64\xb0\xf2\x27\x2c\x30\xcc\x6d\xdb\x4c\x87\x5e\x76\x2e\x36\x70\xc6\x34\xb1\x86\x26\xe2\xa0\x2b\x37\x0c\x66\x63\xfc\x5b\x77\xe5\x33\x0e\xa1\x2a\xd0\x47\xce\x68\x77\x87\x87\x07\xff\xa3\xf0\x3b\x8d\xbe\xc2\x1f\xfc\x00\x52\x95\x5f\x10\x4c\x85\xf6\x75\xd2\x90\x53\xca\x86\xca\xf9\x09\xd2\x49\x6f\xf0\xc0\x3d\xa8\x5c\x10\xc2\xe0\xde\xfd\x1f\x72\xd7\x83\xbf\x5d\xe7\x1d\xe1\x65\x3b\x24\x12\x99\xb4\x00\xea\x2b\xf6\x08\xbd\x38\xf3\x67\x04\x62\xb7\x82\xa7\x02\x85\x19\xdd\x40\x74\x9c\x98\x17\xa5\x40\x3c\x21\xfe\x3f\xbc\x52\x57\x32\xe1\x28\x00\x00")
 
 func locales_zh_cn_home_yml() ([]byte, error) {
 	return bindata_read(
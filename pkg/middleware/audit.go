@@ -0,0 +1,169 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/controller/kolog"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kataras/iris/v12/context"
+	uuid "github.com/satori/go.uuid"
+)
+
+// auditSnapshotHeader 标记 AuditSnapshotMiddleware 发起的内部读取请求，值为进程内随机生成的令牌，这类请求不记录审计事件
+const auditSnapshotHeader = "X-Kmpp-Audit-Snapshot"
+
+var auditSnapshotToken = uuid.NewV4().String()
+
+// AuditMiddleware 为每个请求分配请求 ID，并在处理完成后为写操作和被拒绝的请求记录审计事件
+// 需要注册在认证中间件之前，认证失败的请求同样会被记录
+func AuditMiddleware(ctx context.Context) {
+	if ctx.GetHeader(auditSnapshotHeader) == auditSnapshotToken {
+		ctx.Next()
+		return
+	}
+	requestId := ctx.GetHeader(constant.RequestIDHeader)
+	if requestId == "" {
+		requestId = uuid.NewV4().String()
+	}
+	ctx.Values().Set("requestId", requestId)
+	ctx.Header(constant.RequestIDHeader, requestId)
+
+	method := ctx.Method()
+	readOnly := method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+	var body []byte
+	if !readOnly && ctx.Request().Body != nil {
+		body, _ = ioutil.ReadAll(ctx.Request().Body)
+		ctx.Request().Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	ctx.Next()
+
+	status := ctx.GetStatusCode()
+	if readOnly && status != http.StatusUnauthorized && status != http.StatusForbidden {
+		return
+	}
+	resourceType, resourceName := auditResource(ctx.Path(), body)
+	creation := dto.AuditEventCreate{
+		Actor:        auditActor(ctx, body),
+		SourceIP:     ctx.RemoteAddr(),
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		Action:       auditAction(method, ctx.Path()),
+		Method:       method,
+		Path:         ctx.Path(),
+		RequestID:    requestId,
+		StatusCode:   status,
+		Before:       ctx.Values().GetString(kolog.AuditBeforeKey),
+	}
+	if len(body) > 0 {
+		creation.After = kolog.Redact(body)
+	}
+	go kolog.Audit(creation)
+}
+
+// AuditSnapshotMiddleware 在修改和删除资源前，以当前请求的身份读取同一路径作为审计事件的 before
+// 控制器通过 AuditBefore 记录的状态会覆盖该快照
+func AuditSnapshotMiddleware(ctx context.Context) {
+	switch ctx.Method() {
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		if before := auditSnapshot(ctx); before != "" {
+			ctx.Values().Set(kolog.AuditBeforeKey, before)
+		}
+	}
+	ctx.Next()
+}
+
+func auditSnapshot(ctx context.Context) string {
+	req, err := http.NewRequest(http.MethodGet, ctx.Request().URL.Path, nil)
+	if err != nil {
+		return ""
+	}
+	req = req.WithContext(ctx.Request().Context())
+	req.RemoteAddr = ctx.Request().RemoteAddr
+	for _, h := range []string{"Authorization", "Cookie", constant.RequestIDHeader} {
+		if v := ctx.GetHeader(h); v != "" {
+			req.Header.Set(h, v)
+		}
+	}
+	req.Header.Set(auditSnapshotHeader, auditSnapshotToken)
+	rec := httptest.NewRecorder()
+	ctx.Application().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Body.Len() == 0 {
+		return ""
+	}
+	return kolog.Redact(rec.Body.Bytes())
+}
+
+// auditActor 认证通过的请求取当前用户，登录等未认证请求取请求体中提交的用户名
+func auditActor(ctx context.Context, body []byte) string {
+	if operator := ctx.Values().GetString("operator"); operator != "" {
+		return operator
+	}
+	var fields struct {
+		Username string `json:"username"`
+	}
+	if len(body) > 0 && json.Unmarshal(body, &fields) == nil {
+		return fields.Username
+	}
+	return ""
+}
+
+// auditSessionPath 登录、MFA 校验和登出请求的路径前缀
+const auditSessionPath = "/api/v1/auth/session"
+
+func auditAction(method, path string) string {
+	if strings.HasPrefix(path, auditSessionPath) {
+		if method == http.MethodDelete {
+			return constant.AuditActionLogout
+		}
+		if method == http.MethodPost {
+			return constant.AuditActionLogin
+		}
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return constant.AuditActionRead
+	case http.MethodPost:
+		return constant.AuditActionCreate
+	case http.MethodDelete:
+		return constant.AuditActionDelete
+	}
+	return constant.AuditActionUpdate
+}
+
+// auditResource 资源类型取 /api/v1 后的第一段路径，名称优先取请求体中的 name/clusterName，否则取路径最后一段
+func auditResource(path string, body []byte) (string, string) {
+	var segments []string
+	for _, seg := range strings.Split(strings.TrimPrefix(path, "/api/v1/"), "/") {
+		if seg != "" {
+			segments = append(segments, seg)
+		}
+	}
+	if len(segments) == 0 {
+		return "", ""
+	}
+	var fields struct {
+		Name        string `json:"name"`
+		ClusterName string `json:"clusterName"`
+	}
+	if len(body) > 0 && json.Unmarshal(body, &fields) == nil {
+		if fields.ClusterName != "" && fields.Name != "" {
+			return segments[0], fields.ClusterName + "/" + fields.Name
+		}
+		if fields.ClusterName != "" {
+			return segments[0], fields.ClusterName
+		}
+		if fields.Name != "" {
+			return segments[0], fields.Name
+		}
+	}
+	if len(segments) == 1 {
+		return segments[0], ""
+	}
+	return segments[0], segments[len(segments)-1]
+}
@@ -1,8 +1,12 @@
 package middleware
 
 import (
+	"encoding/json"
+	"strings"
+
 	"github.com/kmpp/pkg/constant"
 	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/service"
 	"github.com/dgrijalva/jwt-go"
 	jwtmiddleware "github.com/iris-contrib/middleware/jwt"
 	"github.com/kataras/iris/v12/context"
@@ -16,9 +20,14 @@ var (
 
 type JwtMiddleware struct {
 	*jwtmiddleware.Middleware
+	apiTokenService service.ApiTokenService
 }
 
 func (m *JwtMiddleware) Serve(ctx context.Context) {
+	if token, _ := jwtmiddleware.FromAuthHeader(ctx); strings.HasPrefix(token, constant.ApiTokenPrefix) {
+		m.serveApiToken(ctx, token)
+		return
+	}
 	session := constant.Sess.Start(ctx)
 	u := session.Get(constant.SessionUserKey)
 	if u != nil {
@@ -32,6 +41,26 @@ func (m *JwtMiddleware) Serve(ctx context.Context) {
 	ctx.Next()
 }
 
+// serveApiToken 校验 API Token，并以与 JWT 相同的 claims 形式保存用户信息
+func (m *JwtMiddleware) serveApiToken(ctx context.Context, token string) {
+	user, err := m.apiTokenService.Authenticate(token, ctx.Method(), ctx.RemoteAddr())
+	if err != nil {
+		if err == service.ErrApiTokenScopeDenied {
+			ctx.StopExecution()
+			ctx.StatusCode(http.StatusForbidden)
+			_, _ = ctx.JSON(&dto.Response{Msg: err.Error()})
+			return
+		}
+		m.Config.ErrorHandler(ctx, err)
+		return
+	}
+	var claims jwt.MapClaims
+	js, _ := json.Marshal(user)
+	_ = json.Unmarshal(js, &claims)
+	ctx.Values().Set(m.Config.ContextKey, &jwt.Token{Claims: claims, Valid: true})
+	ctx.Next()
+}
+
 func JWTMiddleware() *JwtMiddleware {
 	secretKey := []byte(viper.GetString("jwt.secret"))
 	m := JwtMiddleware{jwtmiddleware.New(
@@ -43,7 +72,7 @@ func JWTMiddleware() *JwtMiddleware {
 			SigningMethod: jwt.SigningMethodHS256,
 			ErrorHandler:  ErrorHandler,
 		},
-	)}
+	), service.NewApiTokenService()}
 	return &m
 }
 
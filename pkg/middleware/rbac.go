@@ -3,6 +3,7 @@ package middleware
 import (
 	"github.com/kmpp/pkg/constant"
 	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/service"
 	"github.com/kataras/iris/v12"
 	"github.com/kataras/iris/v12/context"
 	"github.com/storyicon/grbac"
@@ -14,6 +15,7 @@ func RBACMiddleware() iris.Handler {
 	if err != nil {
 		panic(err)
 	}
+	roleService := service.NewRoleService()
 	return func(c context.Context) {
 		roles := querySystemRoles(c)
 		state, err := r.IsRequestGranted(c.Request(), roles)
@@ -23,9 +25,20 @@ func RBACMiddleware() iris.Handler {
 			return
 		}
 		if !state.IsGranted() {
-			c.StatusCode(http.StatusForbidden)
-			c.StopExecution()
-			return
+			// 内置角色未授权时再检查用户绑定的自定义角色
+			u := c.Values().Get("user").(dto.SessionUser)
+			scope := service.RouteRequestScope(c.GetCurrentRoute().Tmpl().Src, c.Params().Get)
+			granted, err := roleService.IsRequestGranted(u.UserId, c.Request(), scope)
+			if err != nil {
+				c.StatusCode(http.StatusInternalServerError)
+				c.StopExecution()
+				return
+			}
+			if !granted {
+				c.StatusCode(http.StatusForbidden)
+				c.StopExecution()
+				return
+			}
 		}
 		c.Next()
 	}
@@ -6,10 +6,14 @@ import (
 
 	"github.com/kmpp/pkg/constant"
 	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/service"
 	"github.com/dgrijalva/jwt-go"
 	"github.com/kataras/iris/v12/context"
 )
 
+var userService = service.NewUserService()
+
+// UserMiddleware 从 JWT 或会话中取出当前用户，用户已被停用时拒绝请求并销毁会话
 func UserMiddleware(ctx context.Context) {
 	var u dto.SessionUser
 	j := ctx.Values().Get("jwt")
@@ -28,6 +32,15 @@ func UserMiddleware(ctx context.Context) {
 		}
 		u = sessionUser.(*dto.Profile).User
 	}
+	if err := userService.CheckActive(u.Name); err != nil {
+		if j == nil {
+			constant.Sess.Destroy(ctx)
+		}
+		ctx.StopExecution()
+		ctx.StatusCode(http.StatusUnauthorized)
+		_, _ = ctx.JSON(&dto.Response{Msg: err.Error()})
+		return
+	}
 	// set roles
 	ctx.Values().Set("user", u)
 	ctx.Values().Set("operator", u.Name)
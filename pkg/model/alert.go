@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"github.com/kmpp/pkg/model/common"
+	uuid "github.com/satori/go.uuid"
+)
+
+// AlertRoute 按集群、项目、消息类型和级别匹配消息，字段为空时匹配任意值；
+// SendTypes、Users 和 EscalateUsers 为 JSON 格式的字符串数组
+type AlertRoute struct {
+	common.BaseModel
+	ID             string `json:"id" gorm:"type:varchar(64)"`
+	Name           string `json:"name" gorm:"type:varchar(255)"`
+	Priority       int    `json:"priority"`
+	ClusterName    string `json:"clusterName" gorm:"type:varchar(255)"`
+	ProjectName    string `json:"projectName" gorm:"type:varchar(255)"`
+	MessageType    string `json:"messageType" gorm:"type:varchar(64)"`
+	Level          string `json:"level" gorm:"type:varchar(64)"`
+	SendTypes      string `json:"-" gorm:"type:varchar(255)"`
+	Users          string `json:"-" gorm:"type:text"`
+	EscalateUsers  string `json:"-" gorm:"type:text"`
+	EscalateAfter  int    `json:"escalateAfter"`
+	RepeatInterval int    `json:"repeatInterval"`
+}
+
+func (a *AlertRoute) BeforeCreate() (err error) {
+	a.ID = uuid.NewV4().String()
+	return err
+}
+
+// AlertSilence 在 [StartsAt, EndsAt) 时间窗口内屏蔽匹配的消息，匹配规则与 AlertRoute 相同
+type AlertSilence struct {
+	common.BaseModel
+	ID          string    `json:"id" gorm:"type:varchar(64)"`
+	ClusterName string    `json:"clusterName" gorm:"type:varchar(255)"`
+	ProjectName string    `json:"projectName" gorm:"type:varchar(255)"`
+	MessageType string    `json:"messageType" gorm:"type:varchar(64)"`
+	Level       string    `json:"level" gorm:"type:varchar(64)"`
+	StartsAt    time.Time `json:"startsAt"`
+	EndsAt      time.Time `json:"endsAt"`
+	Comment     string    `json:"comment" gorm:"type:varchar(255)"`
+	CreatedBy   string    `json:"createdBy" gorm:"type:varchar(255)"`
+}
+
+func (a *AlertSilence) BeforeCreate() (err error) {
+	a.ID = uuid.NewV4().String()
+	return err
+}
+
+// Alert 记录同一指纹的告警级消息，用于去重、确认和升级
+type Alert struct {
+	common.BaseModel
+	ID          string     `json:"id" gorm:"type:varchar(64)"`
+	Fingerprint string     `json:"fingerprint" gorm:"type:varchar(64)"`
+	RouteID     string     `json:"routeId" gorm:"type:varchar(64)"`
+	MessageID   string     `json:"messageId" gorm:"type:varchar(64)"`
+	ClusterName string     `json:"clusterName" gorm:"type:varchar(255)"`
+	ProjectName string     `json:"projectName" gorm:"type:varchar(255)"`
+	MessageType string     `json:"messageType" gorm:"type:varchar(64)"`
+	Level       string     `json:"level" gorm:"type:varchar(64)"`
+	Status      string     `json:"status" gorm:"type:varchar(64)"`
+	Count       int        `json:"count"`
+	FirstAt     time.Time  `json:"firstAt"`
+	LastAt      time.Time  `json:"lastAt"`
+	LastSentAt  time.Time  `json:"lastSentAt"`
+	EscalateAt  *time.Time `json:"escalateAt"`
+	AckedBy     string     `json:"ackedBy" gorm:"type:varchar(255)"`
+	AckedAt     *time.Time `json:"ackedAt"`
+}
+
+func (a *Alert) BeforeCreate() (err error) {
+	a.ID = uuid.NewV4().String()
+	return err
+}
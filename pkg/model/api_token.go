@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"github.com/kmpp/pkg/model/common"
+	uuid "github.com/satori/go.uuid"
+)
+
+type ApiToken struct {
+	common.BaseModel
+	ID         string     `json:"id" gorm:"type:varchar(64)"`
+	UserID     string     `json:"-" gorm:"type:varchar(64)"`
+	Name       string     `json:"name" gorm:"type:varchar(255)"`
+	Prefix     string     `json:"prefix" gorm:"type:varchar(64)"`
+	TokenHash  string     `json:"-" gorm:"type:varchar(128)"`
+	Scopes     string     `json:"-" gorm:"type:varchar(255)"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	LastUsedIP string     `json:"lastUsedIp" gorm:"type:varchar(64)"`
+	User       User       `json:"-" gorm:"save_associations:false"`
+}
+
+func (a *ApiToken) BeforeCreate() (err error) {
+	a.ID = uuid.NewV4().String()
+	return err
+}
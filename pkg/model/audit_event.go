@@ -0,0 +1,40 @@
+package model
+
+import (
+	"github.com/kmpp/pkg/model/common"
+	uuid "github.com/satori/go.uuid"
+)
+
+// AuditEvent 结构化审计事件，Hash 由上一条事件的 Hash 与本条内容计算，修改或删除任意一条都会使校验失败
+type AuditEvent struct {
+	common.BaseModel
+	ID           string `json:"id" gorm:"type:varchar(64)"`
+	Seq          int64  `json:"seq"`
+	Timestamp    int64  `json:"timestamp"`
+	Actor        string `json:"actor" gorm:"type:varchar(255)"`
+	SourceIP     string `json:"sourceIp" gorm:"type:varchar(64)"`
+	ResourceType string `json:"resourceType" gorm:"type:varchar(64)"`
+	ResourceName string `json:"resourceName" gorm:"type:varchar(255)"`
+	Action       string `json:"action" gorm:"type:varchar(64)"`
+	Method       string `json:"method" gorm:"type:varchar(16)"`
+	Path         string `json:"path" gorm:"type:varchar(1024)"`
+	RequestID    string `json:"requestId" gorm:"type:varchar(64)"`
+	Outcome      string `json:"outcome" gorm:"type:varchar(64)"`
+	StatusCode   int    `json:"statusCode"`
+	Before       string `json:"before" gorm:"type:mediumtext"`
+	After        string `json:"after" gorm:"type:mediumtext"`
+	PrevHash     string `json:"prevHash" gorm:"type:varchar(64)"`
+	Hash         string `json:"hash" gorm:"type:varchar(64)"`
+}
+
+func (a *AuditEvent) BeforeCreate() (err error) {
+	a.ID = uuid.NewV4().String()
+	return err
+}
+
+// AuditChain 只有一行，记录链尾的序号和 Hash，写入事件时加行锁保证多副本下链不分叉
+type AuditChain struct {
+	ID   string `gorm:"type:varchar(64)"`
+	Seq  int64
+	Hash string `gorm:"type:varchar(64)"`
+}
@@ -27,6 +27,18 @@ func (b *BackupAccount) BeforeCreate() (err error) {
 	return err
 }
 
+func (b *BackupAccount) BeforeSave() error {
+	return sealFields(&b.Credential)
+}
+
+func (b *BackupAccount) AfterSave() error {
+	return openFields(&b.Credential)
+}
+
+func (b *BackupAccount) AfterFind() error {
+	return openFields(&b.Credential)
+}
+
 func (b *BackupAccount) BeforeDelete() (err error) {
 	var backupAccounts []ProjectResource
 	err = db.DB.Where(ProjectResource{ResourceID: b.ID}).Find(&backupAccounts).Error
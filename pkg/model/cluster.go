@@ -158,6 +158,11 @@ func (c Cluster) BeforeDelete() error {
 		return err
 	}
 
+	if err := tx.Where("cluster_id = ?", c.ID).Delete(&F5Setting{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	var (
 		messages   []Message
 		messageIDs []string
@@ -490,6 +495,18 @@ func (c Cluster) ParseInventory() *api.Inventory {
 				Children: []string{},
 				Vars:     map[string]string{},
 			},
+			{
+				Name:     "new-master",
+				Hosts:    []string{},
+				Children: []string{},
+				Vars:     map[string]string{},
+			},
+			{
+				Name:     "del-master",
+				Hosts:    []string{},
+				Children: []string{},
+				Vars:     map[string]string{},
+			},
 		},
 	}
 }
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"github.com/kmpp/pkg/model/common"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ClusterAlertRuleGroup 集群的 Prometheus 告警规则组，Rules 为 JSON 格式的规则数组
+type ClusterAlertRuleGroup struct {
+	common.BaseModel
+	ID        string `json:"id" gorm:"type:varchar(64)"`
+	ClusterID string `json:"clusterId" gorm:"type:varchar(64)"`
+	Name      string `json:"name" gorm:"type:varchar(255)"`
+	Interval  string `json:"interval" gorm:"column:eval_interval;type:varchar(64)"`
+	Rules     string `json:"-" gorm:"type:mediumtext"`
+	Builtin   bool   `json:"builtin"`
+}
+
+func (c *ClusterAlertRuleGroup) BeforeCreate() (err error) {
+	c.ID = uuid.NewV4().String()
+	return err
+}
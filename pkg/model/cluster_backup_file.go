@@ -1,6 +1,8 @@
 package model
 
 import (
+	"time"
+
 	"github.com/kmpp/pkg/model/common"
 	uuid "github.com/satori/go.uuid"
 )
@@ -12,6 +14,12 @@ type ClusterBackupFile struct {
 	ClusterID               string                `json:"clusterId"`
 	ClusterBackupStrategyID string                `json:"clusterBackupStrategyId"`
 	Folder                  string                `json:"folder"`
+	Checksum                string                `json:"checksum"`
+	VerifyStatus            string                `json:"verifyStatus"`
+	VerifyMessage           string                `json:"verifyMessage"`
+	VerifyTime              *time.Time            `json:"verifyTime"`
+	KeyCount                int64                 `json:"keyCount"`
+	Revision                int64                 `json:"revision"`
 	ClusterBackupStrategy   ClusterBackupStrategy `json:"-"`
 	CLuster                 Cluster               `json:"-"`
 }
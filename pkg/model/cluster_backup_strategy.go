@@ -8,8 +8,12 @@ import (
 type ClusterBackupStrategy struct {
 	common.BaseModel
 	ID              string        `json:"id"`
-	Cron            int           `json:"cron"`
+	Cron            string        `json:"cron"`
 	SaveNum         int           `json:"saveNum"`
+	RetainHourly    int           `json:"retainHourly"`
+	RetainDaily     int           `json:"retainDaily"`
+	RetainWeekly    int           `json:"retainWeekly"`
+	VerifyCron      string        `json:"verifyCron"`
 	BackupAccountID string        `json:"backupAccountId"`
 	ClusterID       string        `json:"clusterId"`
 	Status          string        `json:"status"`
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/kmpp/pkg/model/common"
+	uuid "github.com/satori/go.uuid"
+)
+
+// ClusterCertificate 集群节点上 apiserver、etcd 和 kubelet 端点的服务端证书有效期，
+// NotifiedDays 为最近一次已发送提醒的剩余天数阈值，证书更新后归零
+type ClusterCertificate struct {
+	common.BaseModel
+	ID           string     `json:"id" gorm:"type:varchar(64)"`
+	ClusterID    string     `json:"clusterId" gorm:"type:varchar(64)"`
+	NodeName     string     `json:"nodeName" gorm:"type:varchar(255)"`
+	Name         string     `json:"name" gorm:"type:varchar(64)"`
+	Endpoint     string     `json:"endpoint" gorm:"type:varchar(255)"`
+	Subject      string     `json:"subject" gorm:"type:varchar(255)"`
+	Issuer       string     `json:"issuer" gorm:"type:varchar(255)"`
+	NotBefore    *time.Time `json:"notBefore"`
+	NotAfter     *time.Time `json:"notAfter"`
+	NotifiedDays int        `json:"notifiedDays"`
+	Message      string     `json:"message" gorm:"type:text"`
+}
+
+func (c *ClusterCertificate) BeforeCreate() (err error) {
+	c.ID = uuid.NewV4().String()
+	return err
+}
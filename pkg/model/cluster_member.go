@@ -11,6 +11,7 @@ type ClusterMember struct {
 	ClusterID string `json:"clusterId" gorm:"type:varchar(64)"`
 	UserID    string `json:"userId" gorm:"type:varchar(64)"`
 	Role      string `json:"role" gorm:"type:varchar(64)"`
+	Source    string `json:"source" gorm:"type:varchar(64)"`
 	User      User   `json:"user" gorm:"save_associations:false"`
 }
 
@@ -42,9 +42,8 @@ func (n *ClusterNode) BeforeCreate() (err error) {
 }
 
 func (n ClusterNode) GetRegistry(arch string) (*Registry, error) {
-	var systemRegistry SystemRegistry
 	var registry Registry
-	err := db.DB.Where("architecture = ?", arch).First(&systemRegistry).Error
+	systemRegistry, err := GetActiveRegistry(arch)
 	if err != nil {
 		return &registry, err
 	}
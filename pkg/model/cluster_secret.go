@@ -12,3 +12,15 @@ func (n *ClusterSecret) BeforeCreate() (err error) {
 	n.ID = uuid.NewV4().String()
 	return nil
 }
+
+func (n *ClusterSecret) BeforeSave() error {
+	return sealFields(&n.KubeadmToken, &n.KubernetesToken)
+}
+
+func (n *ClusterSecret) AfterSave() error {
+	return openFields(&n.KubeadmToken, &n.KubernetesToken)
+}
+
+func (n *ClusterSecret) AfterFind() error {
+	return openFields(&n.KubeadmToken, &n.KubernetesToken)
+}
@@ -22,6 +22,8 @@ type ClusterTool struct {
 	Message       string `json:"message" gorm:"type:text(65535)"`
 	Logo          string `json:"logo" `
 	Vars          string `json:"-"  gorm:"type:text(65535)"`
+	// Secret 不随 Vars 返回的敏感参数，目前为自定义远端写入的密码或密钥引用
+	Secret        string `json:"-"  gorm:"type:text(65535)"`
 	Frame         bool   `json:"frame"`
 	Url           string `json:"url"`
 	Architecture  string `json:"architecture"`
@@ -32,3 +34,15 @@ func (c *ClusterTool) BeforeCreate() (err error) {
 	c.ID = uuid.NewV4().String()
 	return nil
 }
+
+func (c *ClusterTool) BeforeSave() error {
+	return sealFields(&c.Secret)
+}
+
+func (c *ClusterTool) AfterSave() error {
+	return openFields(&c.Secret)
+}
+
+func (c *ClusterTool) AfterFind() error {
+	return openFields(&c.Secret)
+}
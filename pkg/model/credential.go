@@ -17,7 +17,7 @@ type Credential struct {
 	ID         string `json:"id" gorm:"type:varchar(64)"`
 	Name       string `json:"name" gorm:"type:varchar(256);not null;unique"`
 	Username   string `json:"username" gorm:"type:varchar(64)"`
-	Password   string `json:"password" gorm:"type:varchar(256)"`
+	Password   string `json:"password" gorm:"type:varchar(1024)"`
 	PrivateKey string `json:"privateKey" gorm:"type: text(0)"`
 	Type       string `json:"type" gorm:"type:varchar(64)"`
 }
@@ -27,6 +27,18 @@ func (c *Credential) BeforeCreate() (err error) {
 	return err
 }
 
+func (c *Credential) BeforeSave() error {
+	return sealFields(&c.PrivateKey, &c.Password)
+}
+
+func (c *Credential) AfterSave() error {
+	return openFields(&c.PrivateKey, &c.Password)
+}
+
+func (c *Credential) AfterFind() error {
+	return openFields(&c.PrivateKey, &c.Password)
+}
+
 func (c *Credential) BeforeDelete() (err error) {
 	if c.Name == constant.DefaultResourceName {
 		return errors.New(DefaultCredentialCanNotDelete)
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"github.com/kmpp/pkg/model/common"
+	uuid "github.com/satori/go.uuid"
+)
+
+// DataKey 信封加密的数据密钥，WrappedKey 为主密钥加密后的 base64，Status 为 ACTIVE 的版本用于加密新数据
+type DataKey struct {
+	common.BaseModel
+	ID         string `json:"id" gorm:"type:varchar(64)"`
+	Version    int    `json:"version"`
+	WrappedKey string `json:"-" gorm:"type:text"`
+	Status     string `json:"status" gorm:"type:varchar(64)"`
+}
+
+func (d *DataKey) BeforeCreate() (err error) {
+	d.ID = uuid.NewV4().String()
+	return err
+}
@@ -11,12 +11,26 @@ type F5Setting struct {
 	ClusterID string `json:"clusterID" gorm:"type:varchar(64)"`
 	URL       string `json:"url" gorm:"type:varchar(64)"`
 	User      string `json:"user" gorm:"type:varchar(64)"`
+	Password  string `json:"-" gorm:"type:varchar(1024)"`
 	Partition string `json:"partition" gorm:"type:varchar(64)" `
 	PublicIP  string `json:"publicIP" gorm:"type:varchar(64)"`
 	Status    string `json:"status" gorm:"type:varchar(64)"`
+	Message   string `json:"message" gorm:"type:text"`
 }
 
 func (s *F5Setting) BeforeCreate() (err error) {
 	s.ID = uuid.NewV4().String()
 	return err
 }
+
+func (s *F5Setting) BeforeSave() error {
+	return sealFields(&s.Password)
+}
+
+func (s *F5Setting) AfterSave() error {
+	return openFields(&s.Password)
+}
+
+func (s *F5Setting) AfterFind() error {
+	return openFields(&s.Password)
+}
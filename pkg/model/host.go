@@ -10,6 +10,7 @@ import (
 	"github.com/kmpp/pkg/model/common"
 	"github.com/kmpp/pkg/util/encrypt"
 	"github.com/kmpp/pkg/util/kobe"
+	"github.com/kmpp/pkg/util/secret"
 	"github.com/KubeOperator/kobe/api"
 	"github.com/jinzhu/gorm"
 	uuid "github.com/satori/go.uuid"
@@ -56,9 +57,17 @@ func (h Host) GetHostPasswordAndPrivateKey() (string, []byte, error) {
 		if err != nil {
 			return "", nil, err
 		}
-		password = p
+		// 凭据可能是 Vault 或挂载文件的引用，在使用时解析
+		password, err = secret.Resolve(p)
+		if err != nil {
+			return "", nil, err
+		}
 	case "privateKey":
-		privateKey = []byte(h.Credential.PrivateKey)
+		k, err := secret.Resolve(h.Credential.PrivateKey)
+		if err != nil {
+			return "", nil, err
+		}
+		privateKey = []byte(k)
 	}
 	return password, privateKey, nil
 }
@@ -1,6 +1,8 @@
 package model
 
 import (
+	"context"
+
 	"github.com/kmpp/pkg/constant"
 	"github.com/kmpp/pkg/db"
 	"github.com/kmpp/pkg/model/common"
@@ -35,6 +37,18 @@ func (m *MultiClusterRepository) BeforeCreate() error {
 	return nil
 }
 
+func (m *MultiClusterRepository) BeforeSave() error {
+	return sealFields(&m.Password)
+}
+
+func (m *MultiClusterRepository) AfterSave() error {
+	return openFields(&m.Password)
+}
+
+func (m *MultiClusterRepository) AfterFind() error {
+	return openFields(&m.Password)
+}
+
 func (m *MultiClusterRepository) BeforeDelete() error {
 	var mls []MultiClusterSyncLog
 	if err := db.DB.Where(MultiClusterSyncLog{
@@ -54,14 +68,49 @@ func (m *MultiClusterRepository) BeforeDelete() error {
 }
 
 func (m *MultiClusterRepository) AfterDelete() error {
-	_ = os.RemoveAll(path.Join(constant.DefaultRepositoryDir, m.Name))
+	_ = os.RemoveAll(m.LocalPath())
 	return nil
 }
 
+func (m *MultiClusterRepository) Clone() error {
+	ctx, cancel := m.gitContext()
+	defer cancel()
+	return git.CloneRepository(ctx, m.Source, m.LocalPath(), m.Branch,
+		&http.BasicAuth{Username: m.Username, Password: m.Password})
+}
+
 func (m *MultiClusterRepository) Pull() error {
-	if err := git.UpdateRepository(path.Join(constant.DefaultRepositoryDir, m.Name), m.Branch,
+	ctx, cancel := m.gitContext()
+	defer cancel()
+	if err := git.UpdateRepository(ctx, m.LocalPath(), m.Branch,
 		&http.BasicAuth{Username: m.Username, Password: m.Password}); err != nil {
 		return err
 	}
 	return nil
 }
+
+func (m *MultiClusterRepository) LocalPath() string {
+	return path.Join(constant.DefaultRepositoryDir, m.Name)
+}
+
+func (m *MultiClusterRepository) gitTimeout() time.Duration {
+	timeout := m.GitTimeout
+	if timeout <= 0 {
+		timeout = constant.DefaultMultiClusterGitTimeout
+	}
+	return time.Duration(timeout) * time.Second
+}
+
+func (m *MultiClusterRepository) gitContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), m.gitTimeout())
+}
+
+// SyncStaleBefore 早于该时间开始、仍为 Running 的同步视为已中断，可以重新同步
+func (m *MultiClusterRepository) SyncStaleBefore(now time.Time) time.Time {
+	return now.Add(-m.gitTimeout() - constant.MultiClusterSyncStaleMargin*time.Minute)
+}
+
+// SyncRunning 仓库正在同步，进程异常退出遗留的 Running 状态不算
+func (m *MultiClusterRepository) SyncRunning(now time.Time) bool {
+	return m.SyncStatus == constant.StatusRunning && m.UpdatedAt.After(m.SyncStaleBefore(now))
+}
@@ -11,6 +11,7 @@ type ProjectMember struct {
 	ProjectID string  `json:"-" gorm:"type:varchar(64)"`
 	UserID    string  `json:"-" gorm:"type:varchar(64)"`
 	Role      string  `json:"role" gorm:"type:varchar(64)"`
+	Source    string  `json:"source" gorm:"type:varchar(64)"`
 	User      User    `json:"-" gorm:"save_associations:false"`
 	Project   Project `json:"-" gorm:"save_associations:false"`
 }
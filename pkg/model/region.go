@@ -18,3 +18,15 @@ func (c *Region) BeforeCreate() (err error) {
 	c.ID = uuid.NewV4().String()
 	return err
 }
+
+func (c *Region) BeforeSave() error {
+	return sealFields(&c.Vars)
+}
+
+func (c *Region) AfterSave() error {
+	return openFields(&c.Vars)
+}
+
+func (c *Region) AfterFind() error {
+	return openFields(&c.Vars)
+}
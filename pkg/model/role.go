@@ -0,0 +1,37 @@
+package model
+
+import (
+	"github.com/kmpp/pkg/model/common"
+	uuid "github.com/satori/go.uuid"
+)
+
+// Role 管理员自定义的角色，Rules 为 JSON 格式的 []dto.RoleRule
+type Role struct {
+	common.BaseModel
+	ID          string `json:"id" gorm:"type:varchar(64)"`
+	Name        string `json:"name" gorm:"type:varchar(255)"`
+	Description string `json:"description" gorm:"type:varchar(255)"`
+	Rules       string `json:"-" gorm:"type:mediumtext"`
+}
+
+func (r *Role) BeforeCreate() (err error) {
+	r.ID = uuid.NewV4().String()
+	return err
+}
+
+// RoleBinding 将自定义角色授予用户，ResourceID 为项目或集群的 ID，系统级绑定时为空
+type RoleBinding struct {
+	common.BaseModel
+	ID         string `json:"id" gorm:"type:varchar(64)"`
+	RoleID     string `json:"-" gorm:"type:varchar(64)"`
+	UserID     string `json:"-" gorm:"type:varchar(64)"`
+	Scope      string `json:"scope" gorm:"type:varchar(64)"`
+	ResourceID string `json:"-" gorm:"type:varchar(64)"`
+	Role       Role   `json:"-" gorm:"save_associations:false"`
+	User       User   `json:"-" gorm:"save_associations:false"`
+}
+
+func (r *RoleBinding) BeforeCreate() (err error) {
+	r.ID = uuid.NewV4().String()
+	return err
+}
@@ -0,0 +1,30 @@
+package model
+
+import "github.com/kmpp/pkg/util/encrypt"
+
+// sealFields 在保存前以信封加密处理敏感字段，已是密文的字段不重复加密
+func sealFields(fields ...*string) error {
+	for _, f := range fields {
+		if encrypt.SealedVersion(*f) > 0 {
+			continue
+		}
+		sealed, err := encrypt.Seal(*f)
+		if err != nil {
+			return err
+		}
+		*f = sealed
+	}
+	return nil
+}
+
+// openFields 在查询或保存后还原敏感字段，调用方始终拿到明文
+func openFields(fields ...*string) error {
+	for _, f := range fields {
+		plain, err := encrypt.Open(*f)
+		if err != nil {
+			return err
+		}
+		*f = plain
+	}
+	return nil
+}
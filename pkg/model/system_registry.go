@@ -1,22 +1,56 @@
 package model
 
 import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
 	"github.com/kmpp/pkg/model/common"
 	uuid "github.com/satori/go.uuid"
 )
 
 type SystemRegistry struct {
 	common.BaseModel
-	ID                 string `json:"id" gorm:"type:varchar(64)"`
-	Hostname           string `json:"hostname" gorm:"type:varchar(256);not null;unique"`
-	Protocol           string `json:"protocol" gorm:"type:varchar(256);not null;"`
-	Architecture       string `json:"architecture" gorm:"type:varchar(256);not null;"`
-	RepoPort           int    `json:"repoPort" gorm:"type:int(64)"`
-	RegistryPort       int    `json:"registryPort" gorm:"type:int(64)"`
-	RegistryHostedPort int    `json:"registryHostedPort" gorm:"type:int(64)"`
+	ID                 string    `json:"id" gorm:"type:varchar(64)"`
+	Hostname           string    `json:"hostname" gorm:"type:varchar(256);not null;unique"`
+	Protocol           string    `json:"protocol" gorm:"type:varchar(256);not null;"`
+	Architecture       string    `json:"architecture" gorm:"type:varchar(256);not null;"`
+	RepoPort           int       `json:"repoPort" gorm:"type:int(64)"`
+	RegistryPort       int       `json:"registryPort" gorm:"type:int(64)"`
+	RegistryHostedPort int       `json:"registryHostedPort" gorm:"type:int(64)"`
+	Priority           int       `json:"priority"`
+	Status             string    `json:"status" gorm:"type:varchar(64)"`
+	Message            string    `json:"message" gorm:"type:text"`
+	LastCheckTime      time.Time `json:"lastCheckTime"`
 }
 
 func (s *SystemRegistry) BeforeCreate() (err error) {
 	s.ID = uuid.NewV4().String()
 	return err
 }
+
+// GetActiveRegistry 返回该架构下优先级最高的健康仓库，全部不健康时仍返回优先级最高的仓库
+func GetActiveRegistry(architecture string) (SystemRegistry, error) {
+	var registries []SystemRegistry
+	if err := db.DB.Where("architecture = ?", architecture).Order("priority").Order("created_at").Find(&registries).Error; err != nil {
+		return SystemRegistry{}, err
+	}
+	if len(registries) == 0 {
+		return SystemRegistry{}, gorm.ErrRecordNotFound
+	}
+	for _, r := range registries {
+		if r.Status != constant.RegistryStatusUnhealthy {
+			return r, nil
+		}
+	}
+	return registries[0], nil
+}
+
+// RegistryArchitecture 将集群的架构转换为仓库的架构，混合架构的集群使用 arm64 仓库
+func RegistryArchitecture(clusterArch string) string {
+	if clusterArch == constant.ArchAMD64 {
+		return constant.ArchitectureOfAMD64
+	}
+	return constant.ArchitectureOfARM64
+}
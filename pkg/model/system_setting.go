@@ -5,11 +5,14 @@ import (
 	uuid "github.com/satori/go.uuid"
 )
 
+// SecretSettingKeys 以信封加密保存的系统设置
+var SecretSettingKeys = []string{"ldap_password", "oidc_client_secret", "WEBHOOK_SECRET", "SMTP_PASSWORD", "WORK_WEIXIN_CORP_SECRET"}
+
 type SystemSetting struct {
 	common.BaseModel
 	ID    string `json:"id" gorm:"type:varchar(64)"`
 	Key   string `json:"key" gorm:"type:varchar(256);not null;unique"`
-	Value string `json:"value" gorm:"type:varchar(256);not null;"`
+	Value string `json:"value" gorm:"type:varchar(1024);not null;"`
 	Tab   string `json:"tab"`
 }
 
@@ -17,3 +20,20 @@ func (s *SystemSetting) BeforeCreate() (err error) {
 	s.ID = uuid.NewV4().String()
 	return err
 }
+
+func (s *SystemSetting) BeforeSave() error {
+	for _, key := range SecretSettingKeys {
+		if s.Key == key {
+			return sealFields(&s.Value)
+		}
+	}
+	return nil
+}
+
+func (s *SystemSetting) AfterSave() error {
+	return openFields(&s.Value)
+}
+
+func (s *SystemSetting) AfterFind() error {
+	return openFields(&s.Value)
+}
@@ -25,12 +25,16 @@ type User struct {
 	CurrentProjectID string  `json:"-" gorm:"type:varchar(64)"`
 	CurrentProject   Project `json:"-" gorm:"save_associations:false"`
 	Name             string  `json:"name" gorm:"type:varchar(256);not null;unique"`
-	Password         string  `json:"password" gorm:"type:varchar(256)"`
+	Password         string  `json:"password" gorm:"type:varchar(1024)"`
 	Email            string  `json:"email" gorm:"type:varchar(256);not null;unique"`
 	Language         string  `json:"language" gorm:"type:varchar(64)"`
 	IsAdmin          bool    `json:"-" gorm:"type:boolean;default:false"`
 	IsActive         bool    `json:"-" gorm:"type:boolean;default:true"`
 	Type             string  `json:"type" gorm:"type:varchar(64)"`
+	// SSO 用户在 IdP 中的唯一标识(ID Token 的 sub)
+	Subject string `json:"-" gorm:"type:varchar(256)"`
+	// 由 LDAP 同步停用，用户重新出现在 LDAP 中时自动启用
+	DeactivatedBySync bool `json:"-" gorm:"type:boolean;default:false"`
 }
 
 type Token struct {
@@ -42,6 +46,18 @@ func (u *User) BeforeCreate() (err error) {
 	return err
 }
 
+func (u *User) BeforeSave() error {
+	return sealFields(&u.Password)
+}
+
+func (u *User) AfterSave() error {
+	return openFields(&u.Password)
+}
+
+func (u *User) AfterFind() error {
+	return openFields(&u.Password)
+}
+
 func (u *User) BeforeDelete() (err error) {
 	if u.Name == "admin" {
 		return errors.New(AdminCanNotDelete)
@@ -66,6 +82,14 @@ func (u *User) BeforeDelete() (err error) {
 	if err != nil {
 		return err
 	}
+	err = db.DB.Model(ApiToken{}).Where("user_id =?", u.ID).Delete(&ApiToken{}).Error
+	if err != nil {
+		return err
+	}
+	err = db.DB.Model(UserMfa{}).Where("user_id =?", u.ID).Delete(&UserMfa{}).Error
+	if err != nil {
+		return err
+	}
 	return err
 }
 
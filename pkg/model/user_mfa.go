@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"github.com/kmpp/pkg/model/common"
+	uuid "github.com/satori/go.uuid"
+)
+
+// UserMfa 用户的 TOTP 二次认证配置，Secret 加密保存，恢复码只保存摘要
+type UserMfa struct {
+	common.BaseModel
+	ID            string `json:"-" gorm:"type:varchar(64)"`
+	UserID        string `json:"-" gorm:"type:varchar(64)"`
+	Secret        string `json:"-" gorm:"type:varchar(1024)"`
+	Enabled       bool   `json:"enabled"`
+	RecoveryCodes string `json:"-" gorm:"type:text"`
+	LastCounter   int64  `json:"-"`
+	// 连续验证失败的次数，达到上限后锁定到 LockedUntil
+	FailedCount int        `json:"-"`
+	LockedUntil *time.Time `json:"-"`
+}
+
+func (u *UserMfa) BeforeCreate() (err error) {
+	u.ID = uuid.NewV4().String()
+	return err
+}
+
+func (u *UserMfa) BeforeSave() error {
+	return sealFields(&u.Secret)
+}
+
+func (u *UserMfa) AfterSave() error {
+	return openFields(&u.Secret)
+}
+
+func (u *UserMfa) AfterFind() error {
+	return openFields(&u.Secret)
+}
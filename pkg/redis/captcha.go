@@ -0,0 +1,44 @@
+package redis
+
+import (
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/kmpp/pkg/logger"
+)
+
+const (
+	captchaKeyPrefix  = "kmpp:captcha:"
+	captchaExpiration = 10 * time.Minute
+)
+
+// CaptchaStore 多副本共享的验证码存储，实现 base64Captcha.Store
+type CaptchaStore struct {
+	client *redis.Client
+}
+
+func NewCaptchaStore(client *redis.Client) *CaptchaStore {
+	return &CaptchaStore{client: client}
+}
+
+func (c *CaptchaStore) Set(id string, value string) {
+	if err := c.client.Set(captchaKeyPrefix+id, value, captchaExpiration).Err(); err != nil {
+		logger.Log.Errorf("set captcha error %s", err.Error())
+	}
+}
+
+func (c *CaptchaStore) Get(id string, clear bool) string {
+	value, err := c.client.Get(captchaKeyPrefix + id).Result()
+	if err != nil {
+		return ""
+	}
+	if clear {
+		c.client.Del(captchaKeyPrefix + id)
+	}
+	return value
+}
+
+func (c *CaptchaStore) Verify(id, answer string, clear bool) bool {
+	value := c.Get(id, clear)
+	return value != "" && value == answer
+}
@@ -0,0 +1,99 @@
+package redis
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+const challengeKeyPrefix = "kmpp:challenge:"
+
+// localChallenges 未启用 redis 时单副本运行，一次性凭据的状态记录在进程内，过期后清理
+var localChallenges = struct {
+	sync.Mutex
+	items map[string]*challenge
+}{items: map[string]*challenge{}}
+
+type challenge struct {
+	failures int64
+	used     bool
+	expires  time.Time
+}
+
+// ChallengeState 返回一次性凭据的失败次数和是否已使用
+func ChallengeState(id string) (int64, bool, error) {
+	if Client == nil {
+		localChallenges.Lock()
+		defer localChallenges.Unlock()
+		c, ok := localChallenges.items[id]
+		if !ok || !time.Now().Before(c.expires) {
+			return 0, false, nil
+		}
+		return c.failures, c.used, nil
+	}
+	values, err := Client.HMGet(challengeKeyPrefix+id, "failures", "used").Result()
+	if err != nil {
+		return 0, false, err
+	}
+	var failures int64
+	if s, ok := values[0].(string); ok {
+		failures, _ = strconv.ParseInt(s, 10, 64)
+	}
+	return failures, values[1] != nil, nil
+}
+
+// ChallengeFail 失败次数加一并返回累计次数，凭据过期后记录自动删除
+func ChallengeFail(id string, expires time.Time) (int64, error) {
+	if Client == nil {
+		localChallenges.Lock()
+		defer localChallenges.Unlock()
+		c := localChallenge(id, expires)
+		c.failures++
+		return c.failures, nil
+	}
+	key := challengeKeyPrefix + id
+	pipe := Client.TxPipeline()
+	incr := pipe.HIncrBy(key, "failures", 1)
+	pipe.ExpireAt(key, expires)
+	if _, err := pipe.Exec(); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+// ChallengeUse 标记凭据已使用，多个副本同时使用同一凭据时只有一个返回 true
+func ChallengeUse(id string, expires time.Time) (bool, error) {
+	if Client == nil {
+		localChallenges.Lock()
+		defer localChallenges.Unlock()
+		c := localChallenge(id, expires)
+		if c.used {
+			return false, nil
+		}
+		c.used = true
+		return true, nil
+	}
+	key := challengeKeyPrefix + id
+	pipe := Client.TxPipeline()
+	set := pipe.HSetNX(key, "used", 1)
+	pipe.ExpireAt(key, expires)
+	if _, err := pipe.Exec(); err != nil {
+		return false, err
+	}
+	return set.Val(), nil
+}
+
+func localChallenge(id string, expires time.Time) *challenge {
+	now := time.Now()
+	for k, c := range localChallenges.items {
+		if !now.Before(c.expires) {
+			delete(localChallenges.items, k)
+		}
+	}
+	c, ok := localChallenges.items[id]
+	if !ok {
+		c = &challenge{expires: expires}
+		localChallenges.items[id] = c
+	}
+	return c
+}
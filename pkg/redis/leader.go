@@ -0,0 +1,64 @@
+package redis
+
+import (
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/kmpp/pkg/logger"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	leaderKey           = "kmpp:leader"
+	leaderLeaseDuration = 15 * time.Second
+	leaderRenewInterval = 5 * time.Second
+)
+
+var (
+	leader   int32
+	identity = newIdentity()
+)
+
+func newIdentity() string {
+	hostname, _ := os.Hostname()
+	return hostname + "-" + uuid.NewV4().String()
+}
+
+// IsLeader 当前副本是否为主副本，定时任务只在主副本上执行；未启用 redis 时始终为 true
+func IsLeader() bool {
+	if Client == nil {
+		return true
+	}
+	return atomic.LoadInt32(&leader) == 1
+}
+
+// runElection 以带过期时间的 key 作为租约竞选主副本，主副本定期续约，宕机后租约到期由其他副本接替
+func runElection() {
+	campaign()
+	ticker := time.NewTicker(leaderRenewInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		campaign()
+	}
+}
+
+func campaign() {
+	ok, err := Client.SetNX(leaderKey, identity, leaderLeaseDuration).Result()
+	if err == nil && !ok {
+		var renewed int64
+		renewed, err = Client.Eval(renewScript, []string{leaderKey}, identity, int64(leaderLeaseDuration/time.Millisecond)).Int64()
+		ok = renewed == 1
+	}
+	if err != nil {
+		logger.Log.Errorf("leader election error %s", err.Error())
+		ok = false
+	}
+	var v int32
+	if ok {
+		v = 1
+	}
+	if old := atomic.SwapInt32(&leader, v); old != v {
+		logger.Log.Infof("leader changed, %s is leader: %v", identity, ok)
+	}
+}
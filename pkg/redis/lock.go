@@ -0,0 +1,103 @@
+package redis
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/kmpp/pkg/logger"
+	uuid "github.com/satori/go.uuid"
+)
+
+const lockKeyPrefix = "kmpp:lock:"
+
+var ErrLocked = errors.New("OPERATION_LOCKED")
+
+// 仅当锁仍属于自己时续期或释放，避免误删其他副本持有的锁
+const (
+	renewScript  = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("pexpire", KEYS[1], ARGV[2]) else return 0 end`
+	unlockScript = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) else return 0 end`
+)
+
+// localLocks 未启用 redis 时单副本运行，退化为进程内的锁
+var localLocks = struct {
+	sync.Mutex
+	keys map[string]bool
+}{keys: map[string]bool{}}
+
+// Lock 分布式锁，持有期间自动续期，进程退出后在 ttl 内过期
+type Lock struct {
+	key   string
+	token string
+	stop  chan struct{}
+	once  sync.Once
+}
+
+// TryLock 尝试获取锁，已被其他副本或任务持有时返回 ErrLocked
+func TryLock(name string, ttl time.Duration) (*Lock, error) {
+	l := &Lock{key: lockKeyPrefix + name, token: uuid.NewV4().String(), stop: make(chan struct{})}
+	if Client == nil {
+		localLocks.Lock()
+		defer localLocks.Unlock()
+		if localLocks.keys[l.key] {
+			return nil, ErrLocked
+		}
+		localLocks.keys[l.key] = true
+		return l, nil
+	}
+	ok, err := Client.SetNX(l.key, l.token, ttl).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrLocked
+	}
+	go l.renew(ttl)
+	return l, nil
+}
+
+// IsLocked 只检查锁是否被持有，不获取锁
+func IsLocked(name string) (bool, error) {
+	key := lockKeyPrefix + name
+	if Client == nil {
+		localLocks.Lock()
+		defer localLocks.Unlock()
+		return localLocks.keys[key], nil
+	}
+	n, err := Client.Exists(key).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (l *Lock) renew(ttl time.Duration) {
+	ticker := time.NewTicker(ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			if err := Client.Eval(renewScript, []string{l.key}, l.token, int64(ttl/time.Millisecond)).Err(); err != nil {
+				logger.Log.Errorf("renew lock %s error %s", l.key, err.Error())
+			}
+		}
+	}
+}
+
+// Unlock 释放锁，可重复调用
+func (l *Lock) Unlock() {
+	l.once.Do(func() {
+		close(l.stop)
+		if Client == nil {
+			localLocks.Lock()
+			delete(localLocks.keys, l.key)
+			localLocks.Unlock()
+			return
+		}
+		if err := Client.Eval(unlockScript, []string{l.key}, l.token).Err(); err != nil {
+			logger.Log.Errorf("release lock %s error %s", l.key, err.Error())
+		}
+	})
+}
@@ -2,7 +2,10 @@ package redis
 
 import (
 	"fmt"
+
 	"github.com/go-redis/redis"
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/util/captcha"
 )
 
 var Client *redis.Client
@@ -10,20 +13,33 @@ var Client *redis.Client
 const phaseName = "redis"
 
 type InitRedisPhase struct {
+	Enable     bool
 	Host       string
 	Port       int
+	Password   string
 	DB         int
 	MaxRetries int
 }
 
+// Init 启用后会话、验证码保存到 redis，并参与主副本选举，以支持多副本部署
 func (i *InitRedisPhase) Init() error {
-	Client = redis.NewClient(&redis.Options{
+	if !i.Enable {
+		return nil
+	}
+	client := redis.NewClient(&redis.Options{
 		Addr:       fmt.Sprintf("%s:%d", i.Host, i.Port),
+		Password:   i.Password,
 		DB:         i.DB,
 		MaxRetries: i.MaxRetries,
 	})
-	_, err := Client.Ping().Result()
-	return err
+	if _, err := client.Ping().Result(); err != nil {
+		return err
+	}
+	Client = client
+	constant.Sess.UseDatabase(NewSessionDatabase(Client))
+	captcha.SetStore(NewCaptchaStore(Client))
+	go runElection()
+	return nil
 }
 
 func (i *InitRedisPhase) PhaseName() string {
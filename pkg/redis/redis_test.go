@@ -0,0 +1,182 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+	"github.com/kataras/iris/v12/sessions"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/logger"
+)
+
+func newTestClient(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	logger.Init()
+	m, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, redis.NewClient(&redis.Options{Addr: m.Addr()})
+}
+
+func TestSessionDatabase(t *testing.T) {
+	m, client := newTestClient(t)
+	defer m.Close()
+	db := NewSessionDatabase(client)
+
+	lt := db.Acquire("sid", time.Hour)
+	if !lt.Time.IsZero() {
+		t.Error("expected new session without lifetime")
+	}
+	if db.Len("sid") != 0 {
+		t.Error("expected empty session")
+	}
+	lt = db.Acquire("sid", time.Hour)
+	if lt.DurationUntilExpiration() <= 0 {
+		t.Error("expected existing session to keep its expiration")
+	}
+
+	profile := &dto.Profile{User: dto.SessionUser{Name: "admin", IsAdmin: true, Roles: []string{"admin"}}}
+	db.Set("sid", sessions.LifeTime{Time: time.Now().Add(time.Hour)}, "user", profile, false)
+	// 另一个副本读取同一个会话
+	other := NewSessionDatabase(redis.NewClient(&redis.Options{Addr: m.Addr()}))
+	p, ok := other.Get("sid", "user").(*dto.Profile)
+	if !ok || p.User.Name != "admin" || !p.User.IsAdmin {
+		t.Fatalf("unexpected session value %#v", other.Get("sid", "user"))
+	}
+	if other.Len("sid") != 1 {
+		t.Errorf("expected 1 key, got %d", other.Len("sid"))
+	}
+	visited := 0
+	other.Visit("sid", func(key string, value interface{}) {
+		visited++
+		if _, ok := value.(*dto.Profile); !ok || key != "user" {
+			t.Errorf("unexpected value %s %#v", key, value)
+		}
+	})
+	if visited != 1 {
+		t.Errorf("expected 1 visited key, got %d", visited)
+	}
+
+	if !other.Delete("sid", "user") || db.Get("sid", "user") != nil {
+		t.Error("expected session value to be deleted")
+	}
+	db.Release("sid")
+	if m.Exists(sessionKeyPrefix + "sid") {
+		t.Error("expected session to be released")
+	}
+}
+
+func TestCaptchaStore(t *testing.T) {
+	m, client := newTestClient(t)
+	defer m.Close()
+	store := NewCaptchaStore(client)
+	store.Set("id", "ab12")
+	if NewCaptchaStore(client).Verify("id", "ab13", false) {
+		t.Error("expected wrong answer to fail")
+	}
+	if !NewCaptchaStore(client).Verify("id", "ab12", true) {
+		t.Error("expected answer to be verified")
+	}
+	if store.Verify("id", "ab12", true) {
+		t.Error("expected captcha to be cleared after verify")
+	}
+}
+
+func TestLockAndLeader(t *testing.T) {
+	m, client := newTestClient(t)
+	defer m.Close()
+	Client = client
+	defer func() { Client = nil }()
+
+	l, err := TryLock("cluster/test", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := TryLock("cluster/test", time.Minute); err != ErrLocked {
+		t.Errorf("expected ErrLocked, got %v", err)
+	}
+	// 锁被其他副本抢占后不能误删
+	m.Set(lockKeyPrefix+"cluster/test", "other")
+	l.Unlock()
+	if !m.Exists(lockKeyPrefix + "cluster/test") {
+		t.Error("expected lock held by another replica to be kept")
+	}
+	m.Del(lockKeyPrefix + "cluster/test")
+	l, err = TryLock("cluster/test", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Unlock()
+	l.Unlock()
+
+	campaign()
+	if !IsLeader() {
+		t.Fatal("expected to be elected as leader")
+	}
+	m.Set(leaderKey, "other")
+	campaign()
+	if IsLeader() {
+		t.Error("expected to lose leadership")
+	}
+	m.Del(leaderKey)
+	campaign()
+	if !IsLeader() {
+		t.Error("expected to take over leadership")
+	}
+}
+
+func TestLocalLock(t *testing.T) {
+	l, err := TryLock("cluster/local", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := TryLock("cluster/local", time.Minute); err != ErrLocked {
+		t.Errorf("expected ErrLocked, got %v", err)
+	}
+	if locked, _ := IsLocked("cluster/local"); !locked {
+		t.Error("expected lock to be held")
+	}
+	l.Unlock()
+	if locked, _ := IsLocked("cluster/local"); locked {
+		t.Error("expected lock to be released")
+	}
+	if !IsLeader() {
+		t.Error("expected single replica to be leader")
+	}
+}
+
+func TestChallenge(t *testing.T) {
+	check := func(name string) {
+		expires := time.Now().Add(time.Minute)
+		if n, _ := ChallengeFail(name, expires); n != 1 {
+			t.Errorf("%s: expected 1 failure, got %d", name, n)
+		}
+		if n, _ := ChallengeFail(name, expires); n != 2 {
+			t.Errorf("%s: expected 2 failures, got %d", name, n)
+		}
+		if ok, err := ChallengeUse(name, expires); !ok || err != nil {
+			t.Errorf("%s: first use should succeed, got %v %v", name, ok, err)
+		}
+		if ok, _ := ChallengeUse(name, expires); ok {
+			t.Errorf("%s: challenge should be used only once", name)
+		}
+		if failures, used, err := ChallengeState(name); failures != 2 || !used || err != nil {
+			t.Errorf("%s: unexpected state %d %v %v", name, failures, used, err)
+		}
+		if failures, used, _ := ChallengeState(name + "-missing"); failures != 0 || used {
+			t.Errorf("%s: unknown challenge should be empty", name)
+		}
+	}
+	check("local")
+
+	m, client := newTestClient(t)
+	defer m.Close()
+	Client = client
+	defer func() { Client = nil }()
+	check("shared")
+	if ttl := m.TTL(challengeKeyPrefix + "shared"); ttl <= 0 || ttl > time.Minute {
+		t.Errorf("challenge should expire with the token, ttl %s", ttl)
+	}
+}
@@ -0,0 +1,142 @@
+package redis
+
+import (
+	"bytes"
+	"encoding/gob"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/kataras/iris/v12/sessions"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/logger"
+)
+
+const (
+	sessionKeyPrefix = "kmpp:session:"
+	// sessionMarker 保证没有任何值的会话在 redis 中也存在，用于计算过期时间
+	sessionMarker = "_"
+)
+
+func init() {
+	gob.Register(&dto.Profile{})
+}
+
+// SessionDatabase 多副本共享的会话存储，值使用 gob 编码以保留具体类型
+type SessionDatabase struct {
+	client *redis.Client
+}
+
+var _ sessions.Database = (*SessionDatabase)(nil)
+
+func NewSessionDatabase(client *redis.Client) *SessionDatabase {
+	return &SessionDatabase{client: client}
+}
+
+func (s *SessionDatabase) key(sid string) string {
+	return sessionKeyPrefix + sid
+}
+
+func (s *SessionDatabase) Acquire(sid string, expires time.Duration) sessions.LifeTime {
+	ttl, err := s.client.TTL(s.key(sid)).Result()
+	if err != nil {
+		logger.Log.Errorf("get session ttl error %s", err.Error())
+		return sessions.LifeTime{}
+	}
+	switch {
+	case ttl == -2*time.Second:
+		pipe := s.client.TxPipeline()
+		pipe.HSet(s.key(sid), sessionMarker, sid)
+		if expires > 0 {
+			pipe.Expire(s.key(sid), expires)
+		}
+		if _, err := pipe.Exec(); err != nil {
+			logger.Log.Errorf("create session error %s", err.Error())
+		}
+		return sessions.LifeTime{}
+	case ttl < 0:
+		return sessions.LifeTime{}
+	}
+	return sessions.LifeTime{Time: time.Now().Add(ttl)}
+}
+
+func (s *SessionDatabase) OnUpdateExpiration(sid string, newExpires time.Duration) error {
+	return s.client.Expire(s.key(sid), newExpires).Err()
+}
+
+func (s *SessionDatabase) Set(sid string, lifetime sessions.LifeTime, key string, value interface{}, immutable bool) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&value); err != nil {
+		logger.Log.Errorf("encode session value %s error %s", key, err.Error())
+		return
+	}
+	pipe := s.client.TxPipeline()
+	pipe.HSet(s.key(sid), key, buf.Bytes())
+	if d := lifetime.DurationUntilExpiration(); d > 0 {
+		pipe.Expire(s.key(sid), d)
+	}
+	if _, err := pipe.Exec(); err != nil {
+		logger.Log.Errorf("set session value %s error %s", key, err.Error())
+	}
+}
+
+func (s *SessionDatabase) Get(sid string, key string) interface{} {
+	data, err := s.client.HGet(s.key(sid), key).Bytes()
+	if err != nil {
+		return nil
+	}
+	return decodeSessionValue(data)
+}
+
+func (s *SessionDatabase) Visit(sid string, cb func(key string, value interface{})) {
+	values, err := s.client.HGetAll(s.key(sid)).Result()
+	if err != nil {
+		return
+	}
+	for k, v := range values {
+		if k == sessionMarker {
+			continue
+		}
+		cb(k, decodeSessionValue([]byte(v)))
+	}
+}
+
+func (s *SessionDatabase) Len(sid string) int {
+	n, err := s.client.HLen(s.key(sid)).Result()
+	if err != nil || n == 0 {
+		return 0
+	}
+	return int(n) - 1
+}
+
+func (s *SessionDatabase) Delete(sid string, key string) bool {
+	n, err := s.client.HDel(s.key(sid), key).Result()
+	return err == nil && n > 0
+}
+
+func (s *SessionDatabase) Clear(sid string) {
+	keys, err := s.client.HKeys(s.key(sid)).Result()
+	if err != nil {
+		return
+	}
+	var fields []string
+	for _, k := range keys {
+		if k != sessionMarker {
+			fields = append(fields, k)
+		}
+	}
+	if len(fields) > 0 {
+		s.client.HDel(s.key(sid), fields...)
+	}
+}
+
+func (s *SessionDatabase) Release(sid string) {
+	s.client.Del(s.key(sid))
+}
+
+func decodeSessionValue(data []byte) interface{} {
+	var value interface{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value); err != nil {
+		return nil
+	}
+	return value
+}
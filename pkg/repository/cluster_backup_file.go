@@ -11,6 +11,8 @@ type ClusterBackupFileRepository interface {
 	Save(file *model.ClusterBackupFile) error
 	Batch(operation string, items []model.ClusterBackupFile) error
 	Get(name string) (model.ClusterBackupFile, error)
+	List(clusterId string) ([]model.ClusterBackupFile, error)
+	UpdateVerifyResult(file *model.ClusterBackupFile) error
 	Delete(name string) error
 }
 
@@ -44,6 +46,22 @@ func (c clusterBackupFileRepository) Get(name string) (model.ClusterBackupFile,
 	return file, nil
 }
 
+func (c clusterBackupFileRepository) List(clusterId string) ([]model.ClusterBackupFile, error) {
+	var files []model.ClusterBackupFile
+	err := db.DB.Where("cluster_id = ?", clusterId).Order("created_at desc").Find(&files).Error
+	return files, err
+}
+
+func (c clusterBackupFileRepository) UpdateVerifyResult(file *model.ClusterBackupFile) error {
+	return db.DB.Model(&model.ClusterBackupFile{}).Where("id = ?", file.ID).Updates(map[string]interface{}{
+		"verify_status":  file.VerifyStatus,
+		"verify_message": file.VerifyMessage,
+		"verify_time":    file.VerifyTime,
+		"key_count":      file.KeyCount,
+		"revision":       file.Revision,
+	}).Error
+}
+
 func (c clusterBackupFileRepository) Save(file *model.ClusterBackupFile) error {
 	if db.DB.NewRecord(file) {
 		return db.DB.Create(&file).Error
@@ -8,6 +8,7 @@ import (
 type F5Repository interface {
 	Save(f5 *model.F5Setting) error
 	Get(name string) (*model.F5Setting, error)
+	Delete(clusterID string) error
 }
 
 func NewF5Repository() F5Repository {
@@ -19,9 +20,9 @@ type f5Repository struct {
 
 func (f f5Repository) Save(f5 *model.F5Setting) error {
 	if db.DB.NewRecord(f5) {
-		return db.DB.Create(&f5).Error
+		return db.DB.Create(f5).Error
 	} else {
-		return db.DB.Save(&f5).Error
+		return db.DB.Save(f5).Error
 	}
 }
 
@@ -32,3 +33,7 @@ func (f f5Repository) Get(clusterID string) (*model.F5Setting, error) {
 	}
 	return &f5, nil
 }
+
+func (f f5Repository) Delete(clusterID string) error {
+	return db.DB.Where("cluster_id = ?", clusterID).Delete(model.F5Setting{}).Error
+}
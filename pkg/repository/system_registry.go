@@ -32,11 +32,7 @@ func (s systemRegistryRepository) Get(id string) (model.SystemRegistry, error) {
 }
 
 func (s systemRegistryRepository) GetByArch(arch string) (model.SystemRegistry, error) {
-	var registry model.SystemRegistry
-	if err := db.DB.Where("architecture = ?", arch).First(&registry).Error; err != nil {
-		return registry, err
-	}
-	return registry, nil
+	return model.GetActiveRegistry(arch)
 }
 
 func (s systemRegistryRepository) List() ([]model.SystemRegistry, error) {
@@ -10,6 +10,7 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/kmpp/pkg/controller/kolog"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/dgrijalva/jwt-go"
 	"github.com/kataras/iris/v12"
 	"github.com/kataras/iris/v12/context"
+	uuid "github.com/satori/go.uuid"
 )
 
 func KubernetesClientProxy(ctx context.Context) {
@@ -45,22 +47,60 @@ func KubernetesClientProxy(ctx context.Context) {
 		_, _ = ctx.JSON(iris.StatusInternalServerError)
 		return
 	}
-	token := fmt.Sprintf("%s %s", keyPrefix, secret.KubernetesToken)
-	ctx.Request().Header.Add(AuthorizationHeader, token)
+	identity, err := getKubernetesIdentity(ctx, clusterName)
+	if err != nil {
+		ctx.StatusCode(iris.StatusForbidden)
+		_, _ = ctx.JSON(dto.Response{Msg: err.Error()})
+		return
+	}
+	if rbacSyncNeeded(clusterName, time.Now()) {
+		if err := clusterRbacService.Sync(clusterName); err != nil {
+			_, _ = ctx.JSON(iris.StatusInternalServerError)
+			return
+		}
+		rbacSyncedClusters.Store(clusterName, time.Now())
+	}
+	impersonate(ctx.Request().Header, secret.KubernetesToken, identity)
 	ctx.Request().URL.Path = proxyPath
+	var audit *dto.AuditEventCreate
+	if ctx.Method() != "GET" {
+		saveSystemLogs(ctx, clusterName)
+		audit = kubernetesAuditEvent(ctx, clusterName, proxyPath)
+	}
 	proxy.ModifyResponse = func(response *http.Response) error {
 		if response.StatusCode == http.StatusUnauthorized {
 			response.StatusCode = http.StatusInternalServerError
 		}
+		if audit != nil {
+			audit.StatusCode = response.StatusCode
+			go kolog.Audit(*audit)
+		}
 		return nil
 	}
-	if ctx.Method() != "GET" {
-		saveSystemLogs(ctx, clusterName)
-	}
 
 	proxy.ServeHTTP(ctx.ResponseWriter(), ctx.Request())
 }
 
+// rbacSyncNeeded 集群未同步过或距上次同步超过 rbacSyncTTL 时需要同步
+func rbacSyncNeeded(clusterName string, now time.Time) bool {
+	v, ok := rbacSyncedClusters.Load(clusterName)
+	return !ok || now.Sub(v.(time.Time)) > rbacSyncTTL
+}
+
+// impersonate 以 kmpp 的集群凭据访问，并模拟当前用户的身份，客户端自带的 Impersonate-* 头会被移除
+func impersonate(header http.Header, kubernetesToken string, identity *dto.KubernetesIdentity) {
+	for key := range header {
+		if strings.HasPrefix(http.CanonicalHeaderKey(key), "Impersonate-") {
+			header.Del(key)
+		}
+	}
+	header.Set(AuthorizationHeader, fmt.Sprintf("%s %s", keyPrefix, kubernetesToken))
+	header.Set(ImpersonateUserHeader, identity.User)
+	for _, group := range identity.Groups {
+		header.Add(ImpersonateGroupHeader, group)
+	}
+}
+
 func saveSystemLogs(ctx context.Context, clusterName string) {
 	var (
 		bodyStruct interface{}
@@ -100,6 +140,42 @@ func saveSystemLogs(ctx context.Context, clusterName string) {
 	}
 }
 
+// kubernetesAuditEvent 通过代理对集群资源的写操作同样记录审计事件，资源名称为 "集群/路径"
+func kubernetesAuditEvent(ctx context.Context, clusterName, proxyPath string) *dto.AuditEventCreate {
+	var body []byte
+	if ctx.Request().Body != nil {
+		body, _ = ioutil.ReadAll(ctx.Request().Body)
+		ctx.Request().Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	requestId := ctx.GetHeader(constant.RequestIDHeader)
+	if requestId == "" {
+		requestId = uuid.NewV4().String()
+	}
+	ctx.Header(constant.RequestIDHeader, requestId)
+	action := constant.AuditActionUpdate
+	switch ctx.Method() {
+	case http.MethodPost:
+		action = constant.AuditActionCreate
+	case http.MethodDelete:
+		action = constant.AuditActionDelete
+	}
+	creation := &dto.AuditEventCreate{
+		Actor:        getOperator(ctx),
+		SourceIP:     ctx.RemoteAddr(),
+		ResourceType: "kubernetes",
+		ResourceName: clusterName + "/" + strings.TrimPrefix(proxyPath, "/"),
+		Action:       action,
+		Method:       ctx.Method(),
+		Path:         ctx.Path(),
+		RequestID:    requestId,
+	}
+	// Secret 的 data 字段名不固定，无法按字段脱敏，因此不保存请求体
+	if len(body) > 0 && !strings.Contains(proxyPath, "/secrets") {
+		creation.After = kolog.Redact(body)
+	}
+	return creation
+}
+
 func goSaveLogs(askParam, clusterName, operator, deleteConstant, createConstant string, valueMap map[string]interface{}) {
 	if len(askParam) != 0 {
 		logStr := clusterName + "-" + askParam
@@ -120,6 +196,25 @@ func goSaveLogs(askParam, clusterName, operator, deleteConstant, createConstant
 	return
 }
 
+// getKubernetesIdentity 根据当前登录用户在集群和项目中的角色计算模拟的 Kubernetes 身份
+func getKubernetesIdentity(ctx context.Context, clusterName string) (*dto.KubernetesIdentity, error) {
+	var u dto.SessionUser
+	j := ctx.Values().Get("jwt")
+	if j != nil {
+		j := j.(*jwt.Token)
+		foobar := j.Claims.(jwt.MapClaims)
+		js, _ := json.Marshal(foobar)
+		_ = json.Unmarshal(js, &u)
+	} else {
+		session := constant.Sess.Start(ctx)
+		sessionUser := session.Get(constant.SessionUserKey)
+		if sessionUser != nil {
+			u = sessionUser.(*dto.Profile).User
+		}
+	}
+	return clusterRbacService.GetIdentity(clusterName, u)
+}
+
 func getOperator(ctx context.Context) string {
 	var u dto.SessionUser
 	j := ctx.Values().Get("jwt")
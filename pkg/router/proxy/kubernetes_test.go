@@ -0,0 +1,49 @@
+package proxy
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kmpp/pkg/dto"
+)
+
+func TestImpersonate(t *testing.T) {
+	header := http.Header{}
+	header.Set(AuthorizationHeader, "Bearer user-token")
+	header.Set("Impersonate-User", "system:admin")
+	header.Add("impersonate-group", "system:masters")
+	header.Set("Impersonate-Extra-Scopes", "all")
+	header.Set("Accept", "application/json")
+
+	impersonate(header, "cluster-token", &dto.KubernetesIdentity{User: "kmpp:alice", Groups: []string{"kmpp:project:demo:viewer", "system:authenticated"}})
+
+	if got := header.Get(AuthorizationHeader); got != "Bearer cluster-token" {
+		t.Errorf("unexpected authorization %q", got)
+	}
+	if got := header.Values(ImpersonateUserHeader); !reflect.DeepEqual(got, []string{"kmpp:alice"}) {
+		t.Errorf("unexpected user %v", got)
+	}
+	if got := header.Values(ImpersonateGroupHeader); !reflect.DeepEqual(got, []string{"kmpp:project:demo:viewer", "system:authenticated"}) {
+		t.Errorf("client groups should be replaced, got %v", got)
+	}
+	if header.Get("Impersonate-Extra-Scopes") != "" || header.Get("Accept") != "application/json" {
+		t.Errorf("only impersonation headers should be removed: %v", header)
+	}
+}
+
+func TestRbacSyncNeeded(t *testing.T) {
+	now := time.Now()
+	if !rbacSyncNeeded("test-sync", now) {
+		t.Error("cluster never synced should be synced")
+	}
+	rbacSyncedClusters.Store("test-sync", now)
+	defer rbacSyncedClusters.Delete("test-sync")
+	if rbacSyncNeeded("test-sync", now.Add(time.Minute)) {
+		t.Error("recently synced cluster should not be synced again")
+	}
+	if !rbacSyncNeeded("test-sync", now.Add(rbacSyncTTL+time.Second)) {
+		t.Error("cluster should be synced again after the ttl")
+	}
+}
@@ -1,16 +1,27 @@
 package proxy
 
 import (
+	"sync"
+	"time"
+
 	"github.com/kmpp/pkg/service"
 	"github.com/kataras/iris/v12"
 )
 
 var (
-	keyPrefix           = "Bearer"
-	AuthorizationHeader = "Authorization"
-	clusterService      = service.NewClusterService()
+	keyPrefix              = "Bearer"
+	AuthorizationHeader    = "Authorization"
+	ImpersonateUserHeader  = "Impersonate-User"
+	ImpersonateGroupHeader = "Impersonate-Group"
+	clusterService         = service.NewClusterService()
+	clusterRbacService     = service.NewClusterRbacService()
+	// 集群最近一次同步 kmpp ClusterRoleBinding 的时间
+	rbacSyncedClusters sync.Map
 )
 
+// rbacSyncTTL 超过该时间重新同步一次，集群中的绑定被删除或修改后可以自动恢复
+const rbacSyncTTL = 10 * time.Minute
+
 func RegisterProxy(parent iris.Party) {
 	proxy := parent.Party("/proxy")
 	proxy.Any("/kubernetes/{cluster_name}/{p:path}", KubernetesClientProxy)
@@ -20,12 +20,15 @@ var WhiteScope iris.Party
 func V1(parent iris.Party) {
 	v1 := parent.Party("/v1")
 	authParty := v1.Party("/auth")
+	authParty.Use(middleware.AuditMiddleware)
 	mvc.New(authParty.Party("/session")).HandleError(ErrorHandler).Handle(controller.NewSessionController())
 	mvc.New(v1.Party("/user")).HandleError(ErrorHandler).Handle(controller.NewForgotPasswordController())
 	AuthScope = v1.Party("/")
+	AuthScope.Use(middleware.AuditMiddleware)
 	AuthScope.Use(middleware.JWTMiddleware().Serve)
 	AuthScope.Use(middleware.UserMiddleware)
 	AuthScope.Use(middleware.RBACMiddleware())
+	AuthScope.Use(middleware.AuditSnapshotMiddleware)
 	AuthScope.Use(middleware.PagerMiddleware)
 	AuthScope.Use(middleware.ForceMiddleware)
 	mvc.New(AuthScope.Party("/clusters")).HandleError(ErrorHandler).Handle(controller.NewClusterController())
@@ -33,13 +36,25 @@ func V1(parent iris.Party) {
 	mvc.New(AuthScope.Party("/grpc")).HandleError(ErrorHandler).Handle(controller.NewGrpcproxyController())
 	mvc.New(AuthScope.Party("/hosts")).HandleError(ErrorHandler).Handle(controller.NewHostController())
 	mvc.New(AuthScope.Party("/users")).HandleError(ErrorHandler).Handle(controller.NewUserController())
+	mvc.New(AuthScope.Party("/tokens")).HandleError(ErrorHandler).Handle(controller.NewApiTokenController())
+	mvc.New(AuthScope.Party("/mfa")).HandleError(ErrorHandler).Handle(controller.NewMfaController())
+	mvc.New(AuthScope.Party("/serviceaccounts")).HandleError(ErrorHandler).Handle(controller.NewServiceAccountController())
+	mvc.New(AuthScope.Party("/serviceaccounts/{name}/tokens")).HandleError(ErrorHandler).Handle(controller.NewServiceAccountTokenController())
+	mvc.New(AuthScope.Party("/roles")).HandleError(ErrorHandler).Handle(controller.NewRoleController())
+	mvc.New(AuthScope.Party("/roles/{name}/bindings")).HandleError(ErrorHandler).Handle(controller.NewRoleBindingController())
 	mvc.New(AuthScope.Party("/regions")).HandleError(ErrorHandler).Handle(controller.NewRegionController())
 	mvc.New(AuthScope.Party("/zones")).HandleError(ErrorHandler).Handle(controller.NewZoneController())
 	mvc.New(AuthScope.Party("/plans")).HandleError(ErrorHandler).Handle(controller.NewPlanController())
 	mvc.New(AuthScope.Party("/settings")).HandleError(ErrorHandler).Handle(controller.NewSystemSettingController())
 	mvc.New(AuthScope.Party("/logs")).HandleError(ErrorHandler).Handle(controller.NewSystemLogController())
+	mvc.New(AuthScope.Party("/audits")).HandleError(ErrorHandler).Handle(controller.NewAuditController())
+	mvc.New(AuthScope.Party("/alerts")).HandleError(ErrorHandler).Handle(controller.NewAlertController())
+	mvc.New(AuthScope.Party("/alerts/routes")).HandleError(ErrorHandler).Handle(controller.NewAlertRouteController())
+	mvc.New(AuthScope.Party("/alerts/silences")).HandleError(ErrorHandler).Handle(controller.NewAlertSilenceController())
+	mvc.New(AuthScope.Party("/datakeys")).HandleError(ErrorHandler).Handle(controller.NewDataKeyController())
 	mvc.New(AuthScope.Party("/projects")).HandleError(ErrorHandler).Handle(controller.NewProjectController())
 	mvc.New(AuthScope.Party("/clusters/istio")).HandleError(ErrorHandler).Handle(controller.NewClusterIstioController())
+	mvc.New(AuthScope.Party("/clusters/f5")).HandleError(ErrorHandler).Handle(controller.NewClusterF5Controller())
 	mvc.New(AuthScope.Party("/backupaccounts")).HandleError(ErrorHandler).Handle(controller.NewBackupAccountController())
 	mvc.New(AuthScope.Party("/clusters/backup")).HandleError(ErrorHandler).Handle(controller.NewClusterBackupStrategyController())
 	mvc.New(AuthScope.Party("/license")).Handle(ErrorHandler).Handle(controller.NewLicenseController())
@@ -47,15 +62,19 @@ func V1(parent iris.Party) {
 	mvc.New(AuthScope.Party("/manifests")).HandleError(ErrorHandler).Handle(controller.NewManifestController())
 	mvc.New(AuthScope.Party("/vmconfigs")).HandleError(ErrorHandler).Handle(controller.NewVmConfigController())
 	mvc.New(AuthScope.Party("/clusters/events")).HandleError(ErrorHandler).Handle(controller.NewClusterEventController())
+	mvc.New(AuthScope.Party("/clusters/{cluster}/alertrules")).HandleError(ErrorHandler).Handle(controller.NewClusterAlertRuleController())
 	mvc.New(AuthScope.Party("/ippools")).HandleError(ErrorHandler).Handle(controller.NewIpPoolController())
 	mvc.New(AuthScope.Party("/ippools/{name}/ips")).HandleError(ErrorHandler).Handle(controller.NewIpController())
+	mvc.New(AuthScope.Party("/multicluster/repositories")).HandleError(ErrorHandler).Handle(controller.NewMultiClusterRepositoryController())
 	mvc.New(AuthScope.Party("/projects/{project}/resources")).HandleError(ErrorHandler).Handle(controller.NewProjectResourceController())
 	mvc.New(AuthScope.Party("/projects/{project}/members")).HandleError(ErrorHandler).Handle(controller.NewProjectMemberController())
 	mvc.New(AuthScope.Party("/projects/{project}/clusters/{cluster}/members")).HandleError(ErrorHandler).Handle(controller.NewClusterMemberController())
 	mvc.New(AuthScope.Party("/projects/{project}/clusters/{cluster}/resources")).HandleError(ErrorHandler).Handle(controller.NewClusterResourceController())
+	// kubeconfig 包含集群管理员凭据，需要登录并经过 RBAC 校验
+	AuthScope.Get("/clusters/kubeconfig/{name}", downloadKubeconfig)
 	WhiteScope = v1.Party("/")
-	WhiteScope.Get("/clusters/kubeconfig/{name}", downloadKubeconfig)
 	WhiteScope.Get("/captcha", generateCaptcha)
+	WhiteScope.Post("/alertmanager/receive", receiveAlertmanager)
 	mvc.New(WhiteScope.Party("/theme")).HandleError(ErrorHandler).Handle(controller.NewThemeController())
 
 }
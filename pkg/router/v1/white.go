@@ -2,6 +2,9 @@ package v1
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/kmpp/pkg/dto"
 	"github.com/kmpp/pkg/service"
 	"github.com/kmpp/pkg/util/captcha"
 	"github.com/kataras/iris/v12/context"
@@ -31,3 +34,18 @@ func generateCaptcha(ctx context.Context) {
 	_, _ = ctx.JSON(&c)
 
 }
+
+// receiveAlertmanager 集群 Alertmanager 的 webhook 接收地址，使用按集群生成的 bearer token 认证
+func receiveAlertmanager(ctx context.Context) {
+	var payload dto.AlertmanagerWebhook
+	if err := ctx.ReadJSON(&payload); err != nil {
+		ctx.StatusCode(http.StatusBadRequest)
+		return
+	}
+	token := strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+	if err := service.ReceiveAlertmanagerWebhook(ctx.URLParam("cluster"), token, payload); err != nil {
+		ctx.StatusCode(http.StatusUnauthorized)
+		return
+	}
+	ctx.StatusCode(http.StatusOK)
+}
@@ -12,8 +12,10 @@ import (
 	"github.com/kmpp/pkg/migrate"
 	"github.com/kmpp/pkg/plugin"
 	"github.com/kmpp/pkg/plugin/xpack"
+	"github.com/kmpp/pkg/redis"
 	"github.com/kmpp/pkg/router"
 	"github.com/kmpp/pkg/server/hook"
+	"github.com/kmpp/pkg/service/cluster/adm"
 	"github.com/kataras/iris/v12"
 	"github.com/spf13/viper"
 )
@@ -46,8 +48,19 @@ func Phases() []Phase {
 		},
 		&data.InitDataPhase{},
 		&plugin.InitPluginDBPhase{},
+		&adm.InitPhaseRegistryPhase{},
+		&redis.InitRedisPhase{
+			Enable:     viper.GetBool("redis.enable"),
+			Host:       viper.GetString("redis.host"),
+			Port:       viper.GetInt("redis.port"),
+			Password:   viper.GetString("redis.password"),
+			DB:         viper.GetInt("redis.db"),
+			MaxRetries: viper.GetInt("redis.max_retries"),
+		},
+		&encrypt.InitDataKeyPhase{},
 		&cron.InitCronPhase{
-			Enable: viper.GetBool("cron.enable"),
+			Enable:             viper.GetBool("cron.enable"),
+			ClusterHealthCheck: viper.GetBool("cron.cluster_health_check"),
 		},
 	}
 }
@@ -0,0 +1,372 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/controller/page"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/repository"
+	"github.com/jinzhu/gorm"
+)
+
+var (
+	errAlertRouteNotFound       = errors.New("ALERT_ROUTE_NOT_FOUND")
+	errAlertRouteNameExists     = errors.New("ALERT_ROUTE_NAME_EXISTS")
+	errAlertRouteEscalation     = errors.New("ALERT_ROUTE_ESCALATION_INVALID")
+	errAlertSilenceNotFound     = errors.New("ALERT_SILENCE_NOT_FOUND")
+	errAlertSilenceTime         = errors.New("ALERT_SILENCE_TIME_INVALID")
+	errAlertNotFound            = errors.New("ALERT_NOT_FOUND")
+	errAlertAlreadyAcknowledged = errors.New("ALERT_ALREADY_ACKNOWLEDGED")
+)
+
+// AlertService 管理告警路由、静默和告警确认，消息发送时的匹配逻辑见 messageService
+type AlertService interface {
+	ListRoutes() ([]dto.AlertRoute, error)
+	GetRoute(name string) (*dto.AlertRoute, error)
+	CreateRoute(req dto.AlertRouteCreate) (*dto.AlertRoute, error)
+	UpdateRoute(name string, req dto.AlertRouteUpdate) (*dto.AlertRoute, error)
+	DeleteRoute(name string) error
+	ListSilences(all bool) ([]dto.AlertSilence, error)
+	CreateSilence(req dto.AlertSilenceCreate, operator string) (*dto.AlertSilence, error)
+	DeleteSilence(id string) error
+	Page(num, size int, status, clusterName string) (*page.Page, error)
+	Ack(id, operator string) (*dto.Alert, error)
+}
+
+func NewAlertService() AlertService {
+	return &alertService{
+		userRepo: repository.NewUserRepository(),
+	}
+}
+
+type alertService struct {
+	userRepo repository.UserRepository
+}
+
+func (a alertService) ListRoutes() ([]dto.AlertRoute, error) {
+	var routes []model.AlertRoute
+	if err := db.DB.Order("priority asc, created_at asc").Find(&routes).Error; err != nil {
+		return nil, err
+	}
+	var result []dto.AlertRoute
+	for _, r := range routes {
+		result = append(result, toAlertRouteDTO(r))
+	}
+	return result, nil
+}
+
+func (a alertService) GetRoute(name string) (*dto.AlertRoute, error) {
+	route, err := a.getRoute(name)
+	if err != nil {
+		return nil, err
+	}
+	d := toAlertRouteDTO(route)
+	return &d, nil
+}
+
+func (a alertService) CreateRoute(req dto.AlertRouteCreate) (*dto.AlertRoute, error) {
+	var old model.AlertRoute
+	db.DB.Where("name = ?", req.Name).First(&old)
+	if old.ID != "" {
+		return nil, errAlertRouteNameExists
+	}
+	route := model.AlertRoute{Name: req.Name}
+	if err := a.fillRoute(&route, dto.AlertRouteUpdate{
+		Priority:       req.Priority,
+		ClusterName:    req.ClusterName,
+		ProjectName:    req.ProjectName,
+		MessageType:    req.MessageType,
+		Level:          req.Level,
+		SendTypes:      req.SendTypes,
+		Users:          req.Users,
+		EscalateUsers:  req.EscalateUsers,
+		EscalateAfter:  req.EscalateAfter,
+		RepeatInterval: req.RepeatInterval,
+	}); err != nil {
+		return nil, err
+	}
+	if err := db.DB.Create(&route).Error; err != nil {
+		return nil, err
+	}
+	go refreshAlertmanagerConfigs()
+	d := toAlertRouteDTO(route)
+	return &d, nil
+}
+
+func (a alertService) UpdateRoute(name string, req dto.AlertRouteUpdate) (*dto.AlertRoute, error) {
+	route, err := a.getRoute(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.fillRoute(&route, req); err != nil {
+		return nil, err
+	}
+	if err := db.DB.Save(&route).Error; err != nil {
+		return nil, err
+	}
+	go refreshAlertmanagerConfigs()
+	d := toAlertRouteDTO(route)
+	return &d, nil
+}
+
+func (a alertService) DeleteRoute(name string) error {
+	route, err := a.getRoute(name)
+	if err != nil {
+		return err
+	}
+	if err := db.DB.Delete(&route).Error; err != nil {
+		return err
+	}
+	go refreshAlertmanagerConfigs()
+	return nil
+}
+
+func (a alertService) ListSilences(all bool) ([]dto.AlertSilence, error) {
+	var silences []model.AlertSilence
+	d := db.DB.Order("created_at desc")
+	if !all {
+		d = d.Where("ends_at > ?", time.Now())
+	}
+	if err := d.Find(&silences).Error; err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var result []dto.AlertSilence
+	for _, s := range silences {
+		result = append(result, dto.AlertSilence{AlertSilence: s, Active: !now.Before(s.StartsAt) && now.Before(s.EndsAt)})
+	}
+	return result, nil
+}
+
+func (a alertService) CreateSilence(req dto.AlertSilenceCreate, operator string) (*dto.AlertSilence, error) {
+	now := time.Now()
+	if req.StartsAt.IsZero() {
+		req.StartsAt = now
+	}
+	if !req.EndsAt.After(req.StartsAt) || !req.EndsAt.After(now) {
+		return nil, errAlertSilenceTime
+	}
+	silence := model.AlertSilence{
+		ClusterName: req.ClusterName,
+		ProjectName: req.ProjectName,
+		MessageType: req.MessageType,
+		Level:       req.Level,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		Comment:     req.Comment,
+		CreatedBy:   operator,
+	}
+	if err := db.DB.Create(&silence).Error; err != nil {
+		return nil, err
+	}
+	return &dto.AlertSilence{AlertSilence: silence, Active: !now.Before(silence.StartsAt)}, nil
+}
+
+func (a alertService) DeleteSilence(id string) error {
+	var silence model.AlertSilence
+	if err := db.DB.Where("id = ?", id).First(&silence).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return errAlertSilenceNotFound
+		}
+		return err
+	}
+	return db.DB.Delete(&silence).Error
+}
+
+func (a alertService) Page(num, size int, status, clusterName string) (*page.Page, error) {
+	var (
+		p      page.Page
+		alerts []model.Alert
+	)
+	d := db.DB.Model(&model.Alert{})
+	if status != "" {
+		d = d.Where("status = ?", status)
+	}
+	if clusterName != "" {
+		d = d.Where("cluster_name = ?", clusterName)
+	}
+	if err := d.Count(&p.Total).Order("last_at desc").Offset((num - 1) * size).Limit(size).Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+	routeNames := make(map[string]string)
+	var routes []model.AlertRoute
+	if err := db.DB.Find(&routes).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range routes {
+		routeNames[r.ID] = r.Name
+	}
+	var result []dto.Alert
+	for _, alert := range alerts {
+		result = append(result, dto.Alert{Alert: alert, RouteName: routeNames[alert.RouteID]})
+	}
+	p.Items = result
+	return &p, nil
+}
+
+// Ack 确认后同一告警在持续期间不再重复通知，也不会升级
+func (a alertService) Ack(id, operator string) (*dto.Alert, error) {
+	var alert model.Alert
+	if err := db.DB.Where("id = ?", id).First(&alert).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, errAlertNotFound
+		}
+		return nil, err
+	}
+	if alert.Status == constant.AlertAcknowledged {
+		return nil, errAlertAlreadyAcknowledged
+	}
+	now := time.Now()
+	alert.Status = constant.AlertAcknowledged
+	alert.AckedBy = operator
+	alert.AckedAt = &now
+	alert.EscalateAt = nil
+	if err := db.DB.Save(&alert).Error; err != nil {
+		return nil, err
+	}
+	return &dto.Alert{Alert: alert}, nil
+}
+
+func (a alertService) getRoute(name string) (model.AlertRoute, error) {
+	var route model.AlertRoute
+	if err := db.DB.Where("name = ?", name).First(&route).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return route, errAlertRouteNotFound
+		}
+		return route, err
+	}
+	return route, nil
+}
+
+func (a alertService) fillRoute(route *model.AlertRoute, req dto.AlertRouteUpdate) error {
+	if len(req.EscalateUsers) > 0 && req.EscalateAfter <= 0 {
+		return errAlertRouteEscalation
+	}
+	for _, name := range append(append([]string{}, req.Users...), req.EscalateUsers...) {
+		if _, err := a.userRepo.Get(name); err != nil {
+			return errUserNotFound
+		}
+	}
+	sendTypes, _ := json.Marshal(req.SendTypes)
+	users, _ := json.Marshal(req.Users)
+	escalateUsers, _ := json.Marshal(req.EscalateUsers)
+	route.Priority = req.Priority
+	route.ClusterName = req.ClusterName
+	route.ProjectName = req.ProjectName
+	route.MessageType = req.MessageType
+	route.Level = req.Level
+	route.SendTypes = string(sendTypes)
+	route.Users = string(users)
+	route.EscalateUsers = string(escalateUsers)
+	route.EscalateAfter = req.EscalateAfter
+	route.RepeatInterval = req.RepeatInterval
+	return nil
+}
+
+func toAlertRouteDTO(route model.AlertRoute) dto.AlertRoute {
+	d := dto.AlertRoute{AlertRoute: route}
+	_ = json.Unmarshal([]byte(route.SendTypes), &d.SendTypes)
+	_ = json.Unmarshal([]byte(route.Users), &d.Users)
+	_ = json.Unmarshal([]byte(route.EscalateUsers), &d.EscalateUsers)
+	return d
+}
+
+// alertLabels 为一条消息参与路由和静默匹配的属性
+type alertLabels struct {
+	ClusterName string
+	ProjectName string
+	MessageType string
+	Level       string
+}
+
+// alertMatch 匹配条件为空时匹配任意值
+func alertMatch(l alertLabels, clusterName, projectName, messageType, level string) bool {
+	return (clusterName == "" || clusterName == l.ClusterName) &&
+		(projectName == "" || projectName == l.ProjectName) &&
+		(messageType == "" || messageType == l.MessageType) &&
+		(level == "" || level == l.Level)
+}
+
+func matchAlertSilence(l alertLabels, now time.Time) (*model.AlertSilence, error) {
+	var silences []model.AlertSilence
+	if err := db.DB.Where("starts_at <= ? AND ends_at > ?", now, now).Find(&silences).Error; err != nil {
+		return nil, err
+	}
+	for i := range silences {
+		if alertMatch(l, silences[i].ClusterName, silences[i].ProjectName, silences[i].MessageType, silences[i].Level) {
+			return &silences[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// matchAlertRoute 按优先级返回第一个匹配的路由，没有匹配时返回 nil，消息按用户通知配置发送
+func matchAlertRoute(l alertLabels) (*dto.AlertRoute, error) {
+	var routes []model.AlertRoute
+	if err := db.DB.Order("priority asc, created_at asc").Find(&routes).Error; err != nil {
+		return nil, err
+	}
+	for _, r := range routes {
+		if alertMatch(l, r.ClusterName, r.ProjectName, r.MessageType, r.Level) {
+			d := toAlertRouteDTO(r)
+			return &d, nil
+		}
+	}
+	return nil, nil
+}
+
+// alertFingerprint 集群事件按对象和原因区分，同一对象反复产生的事件视为同一告警；
+// 其他消息按集群和类型区分
+func alertFingerprint(l alertLabels, content string) string {
+	fields := []string{l.ClusterName, l.MessageType, l.Level}
+	if l.MessageType == constant.ClusterEventWarning {
+		detail := make(map[string]string)
+		_ = json.Unmarshal([]byte(content), &detail)
+		object := detail["name"]
+		if i := strings.LastIndex(object, "."); i > 0 {
+			object = object[:i]
+		}
+		fields = append(fields, detail["namespace"], detail["kind"], object, detail["reason"], detail["component"], detail["host"])
+	}
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+// updateAlert 更新同一指纹的告警状态并返回本次是否需要通知：
+// 距上次出现超过重复间隔视为新的告警；持续中的告警已确认则不再通知，否则每个重复间隔提醒一次
+func updateAlert(alert *model.Alert, route *dto.AlertRoute, now time.Time) bool {
+	repeat := time.Duration(constant.AlertDefaultRepeatInterval) * time.Minute
+	if route != nil && route.RepeatInterval > 0 {
+		repeat = time.Duration(route.RepeatInterval) * time.Minute
+	}
+	send := false
+	if alert.ID == "" || now.Sub(alert.LastAt) > repeat {
+		alert.Status = constant.AlertFiring
+		alert.Count = 0
+		alert.FirstAt = now
+		alert.AckedBy = ""
+		alert.AckedAt = nil
+		alert.EscalateAt = nil
+		if route != nil && len(route.EscalateUsers) > 0 && route.EscalateAfter > 0 {
+			escalateAt := now.Add(time.Duration(route.EscalateAfter) * time.Minute)
+			alert.EscalateAt = &escalateAt
+		}
+		send = true
+	} else if alert.Status != constant.AlertAcknowledged && now.Sub(alert.LastSentAt) >= repeat {
+		send = true
+	}
+	alert.Count++
+	alert.LastAt = now
+	if send {
+		alert.LastSentAt = now
+	}
+	return send
+}
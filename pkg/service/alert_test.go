@@ -0,0 +1,75 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/model"
+)
+
+func TestAlertMatch(t *testing.T) {
+	l := alertLabels{ClusterName: "c1", ProjectName: "p1", MessageType: constant.ClusterEventWarning, Level: constant.MsgWarning}
+	if !alertMatch(l, "", "", "", "") {
+		t.Error("empty matchers should match any message")
+	}
+	if !alertMatch(l, "c1", "p1", constant.ClusterEventWarning, constant.MsgWarning) {
+		t.Error("all matchers equal should match")
+	}
+	if alertMatch(l, "c2", "", "", "") || alertMatch(l, "", "", "", constant.MsgInfo) {
+		t.Error("different cluster or level should not match")
+	}
+}
+
+func TestAlertFingerprint(t *testing.T) {
+	l := alertLabels{ClusterName: "c1", MessageType: constant.ClusterEventWarning, Level: constant.MsgWarning}
+	a := alertFingerprint(l, `{"name":"nginx-7d9c.16a1b","namespace":"default","kind":"Pod","reason":"BackOff","message":"restarting 1"}`)
+	b := alertFingerprint(l, `{"name":"nginx-7d9c.16a2c","namespace":"default","kind":"Pod","reason":"BackOff","message":"restarting 2"}`)
+	c := alertFingerprint(l, `{"name":"nginx-7d9c.16a2c","namespace":"default","kind":"Pod","reason":"Unhealthy"}`)
+	if a != b {
+		t.Error("repeated events of the same object should have the same fingerprint")
+	}
+	if a == c {
+		t.Error("events with different reasons should have different fingerprints")
+	}
+	other := alertLabels{ClusterName: "c2", MessageType: constant.ClusterEventWarning, Level: constant.MsgWarning}
+	if alertFingerprint(other, `{"name":"nginx-7d9c.16a1b","namespace":"default","kind":"Pod","reason":"BackOff"}`) == a {
+		t.Error("events of different clusters should have different fingerprints")
+	}
+}
+
+func TestUpdateAlert(t *testing.T) {
+	now := time.Now()
+	route := &dto.AlertRoute{
+		AlertRoute:    model.AlertRoute{EscalateAfter: 15, RepeatInterval: 30},
+		EscalateUsers: []string{"oncall"},
+	}
+	var alert model.Alert
+	if !updateAlert(&alert, route, now) {
+		t.Fatal("new alert should be sent")
+	}
+	if alert.Status != constant.AlertFiring || alert.EscalateAt == nil || !alert.EscalateAt.Equal(now.Add(15*time.Minute)) {
+		t.Fatalf("unexpected new alert %+v", alert)
+	}
+	alert.ID = "1"
+	if updateAlert(&alert, route, now.Add(10*time.Minute)) {
+		t.Error("repeated alert within repeat interval should be deduplicated")
+	}
+	if alert.Count != 2 {
+		t.Errorf("expect count 2, got %d", alert.Count)
+	}
+	if !updateAlert(&alert, route, now.Add(31*time.Minute)) {
+		t.Error("firing alert should be sent again after repeat interval")
+	}
+	alert.Status = constant.AlertAcknowledged
+	if updateAlert(&alert, route, now.Add(55*time.Minute)) {
+		t.Error("acknowledged alert should not be sent while it keeps firing")
+	}
+	if !updateAlert(&alert, route, now.Add(90*time.Minute)) {
+		t.Error("alert quiet for longer than repeat interval should fire again")
+	}
+	if alert.Status != constant.AlertFiring || alert.Count != 1 || alert.AckedAt != nil {
+		t.Errorf("refired alert should be reset %+v", alert)
+	}
+}
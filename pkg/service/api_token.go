@@ -0,0 +1,176 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/repository"
+	"github.com/jinzhu/gorm"
+)
+
+var (
+	ErrApiTokenInvalid     = errors.New("API_TOKEN_INVALID")
+	ErrApiTokenExpired     = errors.New("API_TOKEN_EXPIRED")
+	ErrApiTokenScopeDenied = errors.New("API_TOKEN_SCOPE_DENIED")
+	errApiTokenExists      = errors.New("API_TOKEN_EXISTS")
+	errApiTokenNotFound    = errors.New("API_TOKEN_NOT_FOUND")
+	errApiTokenScope       = errors.New("API_TOKEN_SCOPE_INVALID")
+)
+
+// 最近使用时间的刷新间隔，避免每个请求都写数据库
+const apiTokenTouchInterval = time.Minute
+
+// ApiTokenService 管理用户的 API Token，数据库中只保存 Token 的 SHA256 摘要
+type ApiTokenService interface {
+	List(userName string) ([]dto.ApiToken, error)
+	Create(userName string, req dto.ApiTokenCreate) (*dto.ApiTokenCreated, error)
+	Delete(userName, tokenName string) error
+	Authenticate(token, method, ip string) (*dto.SessionUser, error)
+}
+
+func NewApiTokenService() ApiTokenService {
+	return &apiTokenService{
+		userRepo:    repository.NewUserRepository(),
+		userService: NewUserService(),
+	}
+}
+
+type apiTokenService struct {
+	userRepo    repository.UserRepository
+	userService UserService
+}
+
+func (a apiTokenService) List(userName string) ([]dto.ApiToken, error) {
+	user, err := a.userRepo.Get(userName)
+	if err != nil {
+		return nil, err
+	}
+	var tokens []model.ApiToken
+	if err := db.DB.Where("user_id = ?", user.ID).Order("created_at desc").Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	var result []dto.ApiToken
+	for _, t := range tokens {
+		result = append(result, toApiTokenDTO(t, user.Name))
+	}
+	return result, nil
+}
+
+func (a apiTokenService) Create(userName string, req dto.ApiTokenCreate) (*dto.ApiTokenCreated, error) {
+	for _, s := range req.Scopes {
+		if s != constant.ApiTokenScopeRead && s != constant.ApiTokenScopeWrite {
+			return nil, errApiTokenScope
+		}
+	}
+	user, err := a.userRepo.Get(userName)
+	if err != nil {
+		return nil, err
+	}
+	var old model.ApiToken
+	if err := db.DB.Where("user_id = ? AND name = ?", user.ID, req.Name).First(&old).Error; err == nil {
+		return nil, errApiTokenExists
+	} else if !gorm.IsRecordNotFoundError(err) {
+		return nil, err
+	}
+	plain, err := generateApiToken()
+	if err != nil {
+		return nil, err
+	}
+	token := model.ApiToken{
+		UserID:    user.ID,
+		Name:      req.Name,
+		Prefix:    plain[:len(constant.ApiTokenPrefix)+6],
+		TokenHash: hashApiToken(plain),
+		Scopes:    strings.Join(req.Scopes, ","),
+	}
+	if req.ExpireDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.ExpireDays)
+		token.ExpiresAt = &expiresAt
+	}
+	if err := db.DB.Create(&token).Error; err != nil {
+		return nil, err
+	}
+	return &dto.ApiTokenCreated{ApiToken: toApiTokenDTO(token, user.Name), Token: plain}, nil
+}
+
+func (a apiTokenService) Delete(userName, tokenName string) error {
+	user, err := a.userRepo.Get(userName)
+	if err != nil {
+		return err
+	}
+	var token model.ApiToken
+	if err := db.DB.Where("user_id = ? AND name = ?", user.ID, tokenName).First(&token).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return errApiTokenNotFound
+		}
+		return err
+	}
+	return db.DB.Delete(&token).Error
+}
+
+func (a apiTokenService) Authenticate(plain, method, ip string) (*dto.SessionUser, error) {
+	var token model.ApiToken
+	if err := db.DB.Where("token_hash = ?", hashApiToken(plain)).Preload("User").Preload("User.CurrentProject").First(&token).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, ErrApiTokenInvalid
+		}
+		return nil, err
+	}
+	now := time.Now()
+	if token.ExpiresAt != nil && token.ExpiresAt.Before(now) {
+		return nil, ErrApiTokenExpired
+	}
+	if !token.User.IsActive {
+		return nil, errUserIsNotActive
+	}
+	if !apiTokenAllows(strings.Split(token.Scopes, ","), method) {
+		return nil, ErrApiTokenScopeDenied
+	}
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) > apiTokenTouchInterval {
+		db.DB.Model(&model.ApiToken{}).Where("id = ?", token.ID).Updates(map[string]interface{}{
+			"last_used_at": now,
+			"last_used_ip": ip,
+		})
+	}
+	return a.userService.GetSessionUser(&token.User)
+}
+
+// apiTokenAllows 只读 Token 仅允许安全方法，读写 Token 允许所有方法
+func apiTokenAllows(scopes []string, method string) bool {
+	readOnly := method == "GET" || method == "HEAD" || method == "OPTIONS"
+	for _, s := range scopes {
+		if s == constant.ApiTokenScopeWrite || (readOnly && s == constant.ApiTokenScopeRead) {
+			return true
+		}
+	}
+	return false
+}
+
+func generateApiToken() (string, error) {
+	buf := make([]byte, 20)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return constant.ApiTokenPrefix + hex.EncodeToString(buf), nil
+}
+
+func hashApiToken(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+func toApiTokenDTO(token model.ApiToken, userName string) dto.ApiToken {
+	return dto.ApiToken{
+		ApiToken: token,
+		Username: userName,
+		Scopes:   strings.Split(token.Scopes, ","),
+	}
+}
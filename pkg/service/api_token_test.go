@@ -0,0 +1,39 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kmpp/pkg/constant"
+)
+
+func TestApiTokenAllows(t *testing.T) {
+	read := []string{constant.ApiTokenScopeRead}
+	write := []string{constant.ApiTokenScopeWrite}
+	if !apiTokenAllows(read, "GET") {
+		t.Error("read token should allow GET")
+	}
+	if apiTokenAllows(read, "DELETE") {
+		t.Error("read token should not allow DELETE")
+	}
+	if !apiTokenAllows(write, "POST") || !apiTokenAllows(write, "GET") {
+		t.Error("write token should allow all methods")
+	}
+	if apiTokenAllows([]string{""}, "GET") {
+		t.Error("token without scope should be denied")
+	}
+}
+
+func TestGenerateApiToken(t *testing.T) {
+	a, err := generateApiToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := generateApiToken()
+	if !strings.HasPrefix(a, constant.ApiTokenPrefix) || a == b {
+		t.Errorf("unexpected tokens %q %q", a, b)
+	}
+	if hashApiToken(a) == hashApiToken(b) || len(hashApiToken(a)) != 64 {
+		t.Error("unexpected token hash")
+	}
+}
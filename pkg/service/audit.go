@@ -0,0 +1,347 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/syslog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/controller/page"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/model"
+	"github.com/jinzhu/gorm"
+	"github.com/spf13/viper"
+)
+
+var ErrAuditExportFormat = errors.New("AUDIT_EXPORT_FORMAT_INVALID")
+
+const (
+	auditChainHead   = "head"
+	auditExportBatch = 500
+)
+
+var auditCsvHeader = []string{"seq", "timestamp", "actor", "sourceIp", "resourceType", "resourceName", "action", "method", "path", "requestId", "outcome", "statusCode", "before", "after", "prevHash", "hash"}
+
+// AuditService 以哈希链的形式保存审计事件，支持过滤、导出和完整性校验
+type AuditService interface {
+	Record(creation dto.AuditEventCreate) error
+	Page(num, size int, filter dto.AuditFilter) (*page.Page, error)
+	Export(filter dto.AuditFilter, format string, w io.Writer) error
+	Verify() (*dto.AuditVerifyResult, error)
+}
+
+func NewAuditService() AuditService {
+	return &auditService{
+		systemSettingService: NewSystemSettingService(),
+	}
+}
+
+type auditService struct {
+	systemSettingService SystemSettingService
+}
+
+func (a auditService) Record(creation dto.AuditEventCreate) error {
+	event := model.AuditEvent{
+		Timestamp:    time.Now().UnixNano() / int64(time.Millisecond),
+		Actor:        creation.Actor,
+		SourceIP:     creation.SourceIP,
+		ResourceType: creation.ResourceType,
+		ResourceName: creation.ResourceName,
+		Action:       creation.Action,
+		Method:       creation.Method,
+		Path:         creation.Path,
+		RequestID:    creation.RequestID,
+		Outcome:      auditOutcome(creation.StatusCode),
+		StatusCode:   creation.StatusCode,
+		Before:       creation.Before,
+		After:        creation.After,
+	}
+	tx := db.DB.Begin()
+	var head model.AuditChain
+	if err := tx.Set("gorm:query_option", "FOR UPDATE").Where("id = ?", auditChainHead).First(&head).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	event.Seq = head.Seq + 1
+	event.PrevHash = head.Hash
+	event.Hash = auditHash(event)
+	if err := tx.Create(&event).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Model(&model.AuditChain{}).Where("id = ?", auditChainHead).Updates(map[string]interface{}{"seq": event.Seq, "hash": event.Hash}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	a.forward(event)
+	return nil
+}
+
+func (a auditService) Page(num, size int, filter dto.AuditFilter) (*page.Page, error) {
+	var (
+		p      page.Page
+		events []model.AuditEvent
+		result []dto.AuditEvent
+	)
+	if err := auditQuery(filter).
+		Count(&p.Total).
+		Order("seq DESC").
+		Offset((num - 1) * size).
+		Limit(size).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	for _, e := range events {
+		result = append(result, dto.AuditEvent{AuditEvent: e})
+	}
+	p.Items = result
+	return &p, nil
+}
+
+// Export 按批读取并写出，避免一次性加载全部审计事件
+func (a auditService) Export(filter dto.AuditFilter, format string, w io.Writer) error {
+	var write func(e model.AuditEvent) error
+	var csvWriter *csv.Writer
+	switch format {
+	case constant.AuditExportJSONLines:
+		encoder := json.NewEncoder(w)
+		write = func(e model.AuditEvent) error {
+			return encoder.Encode(&e)
+		}
+	case constant.AuditExportCSV:
+		csvWriter = csv.NewWriter(w)
+		if err := csvWriter.Write(auditCsvHeader); err != nil {
+			return err
+		}
+		write = func(e model.AuditEvent) error {
+			return csvWriter.Write(auditCsvRecord(e))
+		}
+	default:
+		return ErrAuditExportFormat
+	}
+	var lastSeq int64
+	for {
+		var events []model.AuditEvent
+		if err := auditQuery(filter).Where("seq > ?", lastSeq).Order("seq ASC").Limit(auditExportBatch).Find(&events).Error; err != nil {
+			return err
+		}
+		for _, e := range events {
+			if err := write(e); err != nil {
+				return err
+			}
+		}
+		if csvWriter != nil {
+			csvWriter.Flush()
+			if err := csvWriter.Error(); err != nil {
+				return err
+			}
+		}
+		if len(events) < auditExportBatch {
+			return nil
+		}
+		lastSeq = events[len(events)-1].Seq
+	}
+}
+
+// Verify 从第一条事件开始重新计算哈希链，发现缺失、篡改或链尾被截断时返回第一个异常的序号
+func (a auditService) Verify() (*dto.AuditVerifyResult, error) {
+	var head model.AuditChain
+	if err := db.DB.Where("id = ?", auditChainHead).First(&head).Error; err != nil {
+		return nil, err
+	}
+	result := &dto.AuditVerifyResult{Valid: true}
+	var (
+		lastSeq  int64
+		lastHash string
+	)
+	// 只校验到读取链头时的序号，校验期间新写入的事件留到下次校验
+	for {
+		var events []model.AuditEvent
+		if err := db.DB.Where("seq > ? AND seq <= ?", lastSeq, head.Seq).Order("seq ASC").Limit(auditExportBatch).Find(&events).Error; err != nil {
+			return nil, err
+		}
+		for _, e := range events {
+			if reason := verifyAuditEvent(e, lastSeq, lastHash); reason != "" {
+				result.Valid = false
+				result.BrokenSeq = lastSeq + 1
+				result.Reason = reason
+				return result, nil
+			}
+			lastSeq = e.Seq
+			lastHash = e.Hash
+			result.Total++
+		}
+		if len(events) < auditExportBatch {
+			break
+		}
+	}
+	if lastSeq != head.Seq || lastHash != head.Hash {
+		result.Valid = false
+		result.BrokenSeq = lastSeq + 1
+		result.Reason = "events after seq " + strconv.FormatInt(lastSeq, 10) + " are missing"
+	}
+	return result, nil
+}
+
+func verifyAuditEvent(e model.AuditEvent, prevSeq int64, prevHash string) string {
+	if e.Seq != prevSeq+1 {
+		return "event is missing"
+	}
+	if e.PrevHash != prevHash {
+		return "previous hash mismatch"
+	}
+	if auditHash(e) != e.Hash {
+		return "event content has been modified"
+	}
+	return ""
+}
+
+// auditHash 对上一条的 Hash 和本条所有内容字段计算 HMAC-SHA256，不包含由数据库维护的 created_at 等字段
+// 密钥不保存在数据库中，仅能修改数据库的人无法重新计算整条链
+func auditHash(e model.AuditEvent) string {
+	fields := []string{
+		e.PrevHash,
+		strconv.FormatInt(e.Seq, 10),
+		strconv.FormatInt(e.Timestamp, 10),
+		e.Actor,
+		e.SourceIP,
+		e.ResourceType,
+		e.ResourceName,
+		e.Action,
+		e.Method,
+		e.Path,
+		e.RequestID,
+		e.Outcome,
+		strconv.Itoa(e.StatusCode),
+		e.Before,
+		e.After,
+	}
+	h := hmac.New(sha256.New, auditKey())
+	h.Write([]byte(strings.Join(fields, "\x1f")))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// auditKey 优先使用配置的 audit.key，未配置时由 jwt.secret 派生
+func auditKey() []byte {
+	if key := viper.GetString("audit.key"); key != "" {
+		return []byte(key)
+	}
+	return []byte(viper.GetString("jwt.secret") + ":audit")
+}
+
+func auditOutcome(statusCode int) string {
+	switch {
+	case statusCode == 401 || statusCode == 403:
+		return constant.AuditOutcomeDenied
+	case statusCode >= 400:
+		return constant.AuditOutcomeFailure
+	}
+	return constant.AuditOutcomeSuccess
+}
+
+func auditCsvRecord(e model.AuditEvent) []string {
+	return []string{
+		strconv.FormatInt(e.Seq, 10),
+		time.Unix(0, e.Timestamp*int64(time.Millisecond)).UTC().Format(time.RFC3339Nano),
+		e.Actor,
+		e.SourceIP,
+		e.ResourceType,
+		e.ResourceName,
+		e.Action,
+		e.Method,
+		e.Path,
+		e.RequestID,
+		e.Outcome,
+		strconv.Itoa(e.StatusCode),
+		e.Before,
+		e.After,
+		e.PrevHash,
+		e.Hash,
+	}
+}
+
+func auditQuery(filter dto.AuditFilter) *gorm.DB {
+	d := db.DB.Model(&model.AuditEvent{})
+	for column, value := range map[string]string{
+		"actor":         filter.Actor,
+		"source_ip":     filter.SourceIP,
+		"resource_type": filter.ResourceType,
+		"action":        filter.Action,
+		"request_id":    filter.RequestID,
+		"outcome":       filter.Outcome,
+	} {
+		if value != "" {
+			d = d.Where(column+" = ?", value)
+		}
+	}
+	if filter.ResourceName != "" {
+		d = d.Where("resource_name LIKE ?", "%"+filter.ResourceName+"%")
+	}
+	if filter.Start > 0 {
+		d = d.Where("`timestamp` >= ?", filter.Start)
+	}
+	if filter.End > 0 {
+		d = d.Where("`timestamp` <= ?", filter.End)
+	}
+	return d
+}
+
+// auditSyslog 缓存与 syslog 服务器的连接，配置变化或发送失败时重新建立
+var auditSyslog = struct {
+	sync.Mutex
+	config string
+	writer *syslog.Writer
+}{}
+
+// forward 在系统设置中启用后将审计事件以 JSON 形式转发到 syslog，转发失败不影响事件入库
+func (a auditService) forward(event model.AuditEvent) {
+	setting, err := a.systemSettingService.ListByTab(constant.AuditSettingTab)
+	if err != nil || setting.Vars["syslog_status"] != constant.Enable {
+		return
+	}
+	network := setting.Vars["syslog_network"]
+	if network == "" {
+		network = "udp"
+	}
+	tag := setting.Vars["syslog_tag"]
+	if tag == "" {
+		tag = "kmpp-audit"
+	}
+	config := network + "|" + setting.Vars["syslog_address"] + "|" + tag
+	msg, _ := json.Marshal(&event)
+
+	auditSyslog.Lock()
+	defer auditSyslog.Unlock()
+	if auditSyslog.writer != nil && auditSyslog.config != config {
+		_ = auditSyslog.writer.Close()
+		auditSyslog.writer = nil
+	}
+	if auditSyslog.writer == nil {
+		w, err := syslog.Dial(network, setting.Vars["syslog_address"], syslog.LOG_INFO|syslog.LOG_AUTH, tag)
+		if err != nil {
+			logger.Log.Errorf("dial audit syslog %s error %s", setting.Vars["syslog_address"], err.Error())
+			return
+		}
+		auditSyslog.writer = w
+		auditSyslog.config = config
+	}
+	if err := auditSyslog.writer.Info(string(msg)); err != nil {
+		logger.Log.Errorf("forward audit event %d to syslog error %s", event.Seq, err.Error())
+		_ = auditSyslog.writer.Close()
+		auditSyslog.writer = nil
+	}
+}
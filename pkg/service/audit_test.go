@@ -0,0 +1,53 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/model"
+)
+
+func TestAuditHashChain(t *testing.T) {
+	var events []model.AuditEvent
+	prev := ""
+	for i, actor := range []string{"admin", "alice", "bob"} {
+		e := model.AuditEvent{Seq: int64(i + 1), Timestamp: int64(1000 + i), Actor: actor, Action: constant.AuditActionCreate, PrevHash: prev, StatusCode: 200}
+		e.Hash = auditHash(e)
+		prev = e.Hash
+		events = append(events, e)
+	}
+	var lastSeq int64
+	lastHash := ""
+	for _, e := range events {
+		if reason := verifyAuditEvent(e, lastSeq, lastHash); reason != "" {
+			t.Fatalf("seq %d: %s", e.Seq, reason)
+		}
+		lastSeq, lastHash = e.Seq, e.Hash
+	}
+
+	tampered := events[1]
+	tampered.Actor = "mallory"
+	if verifyAuditEvent(tampered, 1, events[0].Hash) == "" {
+		t.Error("modified event should be detected")
+	}
+	if verifyAuditEvent(events[2], 1, events[0].Hash) == "" {
+		t.Error("deleted event should be detected")
+	}
+}
+
+func TestAuditOutcomeAndCsv(t *testing.T) {
+	for code, want := range map[int]string{200: constant.AuditOutcomeSuccess, 403: constant.AuditOutcomeDenied, 400: constant.AuditOutcomeFailure} {
+		if got := auditOutcome(code); got != want {
+			t.Errorf("%d: got %s, want %s", code, got, want)
+		}
+	}
+	record := auditCsvRecord(model.AuditEvent{Seq: 7, Timestamp: 0, Actor: "admin", StatusCode: 200})
+	if len(record) != len(auditCsvHeader) || record[0] != "7" || record[1] != "1970-01-01T00:00:00Z" {
+		t.Errorf("unexpected csv record %v", record)
+	}
+	if err := (auditService{}).Export(dto.AuditFilter{}, "xml", &bytes.Buffer{}); err != ErrAuditExportFormat {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -104,6 +104,10 @@ func (b backupAccountService) ListByClusterName(clusterName string) ([]dto.Backu
 		return nil, err
 	}
 	for _, mo := range backupAccounts {
+		// Raw 查询不会触发 AfterFind，需要手动解密
+		if err := mo.AfterFind(); err != nil {
+			return nil, err
+		}
 		backupAccountDTOs = append(backupAccountDTOs, dto.BackupAccount{BackupAccount: mo})
 	}
 	return backupAccountDTOs, nil
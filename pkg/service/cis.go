@@ -114,15 +114,9 @@ func (c *cisService) Create(clusterName string) (*dto.CisTask, error) {
 	if err != nil {
 		return nil, err
 	}
-	var registery model.SystemRegistry
-	if cluster.Spec.Architectures == constant.ArchAMD64 {
-		if err := db.DB.Where("architecture = ?", constant.ArchitectureOfAMD64).First(&registery).Error; err != nil {
-			return nil, errors.New("load image pull port of arm failed")
-		}
-	} else {
-		if err := db.DB.Where("architecture = ?", constant.ArchitectureOfARM64).First(&registery).Error; err != nil {
-			return nil, errors.New("load image pull port of arm failed")
-		}
+	registery, err := model.GetActiveRegistry(model.RegistryArchitecture(cluster.Spec.Architectures))
+	if err != nil {
+		return nil, errors.New("load image pull port of arm failed")
 	}
 	localRepoPort := registery.RegistryPort
 
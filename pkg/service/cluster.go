@@ -193,7 +193,7 @@ func (c clusterService) Page(num, size int, user dto.SessionUser, conditions con
 				}
 			}
 		}
-		if user.IsRole(constant.RoleProjectManager) {
+		if (user.IsRole(constant.RoleProjectManager) || user.IsRole(constant.RoleProjectViewer)) {
 			for _, pm := range resources {
 				clusterIds = append(clusterIds, pm.ResourceID)
 			}
@@ -325,43 +325,11 @@ func (c clusterService) Create(creation dto.ClusterCreate) (*dto.Cluster, error)
 		Name:   creation.Name,
 		Source: constant.ClusterSourceLocal,
 	}
-	spec := model.ClusterSpec{
-		RuntimeType:             creation.RuntimeType,
-		DockerStorageDir:        creation.DockerStorageDIr,
-		ContainerdStorageDir:    creation.ContainerdStorageDIr,
-		NetworkType:             creation.NetworkType,
-		CiliumVersion:           creation.CiliumVersion,
-		CiliumTunnelMode:        creation.CiliumTunnelMode,
-		CiliumNativeRoutingCidr: creation.CiliumNativeRoutingCidr,
-		Version:                 creation.Version,
-		Provider:                creation.Provider,
-		FlannelBackend:          creation.FlannelBackend,
-		CalicoIpv4poolIpip:      creation.CalicoIpv4poolIpip,
-		KubeProxyMode:           creation.KubeProxyMode,
-		EnableDnsCache:          creation.EnableDnsCache,
-		DnsCacheVersion:         creation.DnsCacheVersion,
-		IngressControllerType:   creation.IngressControllerType,
-		Architectures:           creation.Architectures,
-		KubernetesAudit:         creation.KubernetesAudit,
-		DockerSubnet:            creation.DockerSubnet,
-		KubeApiServerPort:       constant.DefaultApiServerPort,
-		HelmVersion:             creation.HelmVersion,
-		NetworkInterface:        creation.NetworkInterface,
-		NetworkCidr:             creation.NetworkCidr,
-		SupportGpu:              creation.SupportGpu,
-		YumOperate:              creation.YumOperate,
-	}
-
-	spec.KubePodSubnet = creation.ClusterCIDR
-	serviceCIDR, nodeMask, err := getServiceCIDRAndNodeCIDRMaskSize(creation.ClusterCIDR, creation.MaxClusterServiceNum, creation.MaxNodePodNum)
+	spec, err := newClusterSpec(creation)
 	if err != nil {
 		return nil, err
 	}
 
-	spec.KubeServiceSubnet = serviceCIDR
-	spec.KubeMaxPods = maxNodePodNumMap[nodeMask]
-	spec.KubeNetworkNodePrefix = nodeMask
-
 	status := model.ClusterStatus{Phase: constant.ClusterWaiting}
 	secret := model.ClusterSecret{
 		KubeadmToken: clusterUtil.GenerateKubeadmToken(),
@@ -525,6 +493,46 @@ func (c clusterService) Create(creation dto.ClusterCreate) (*dto.Cluster, error)
 	return &dto.Cluster{Cluster: cluster}, nil
 }
 
+func newClusterSpec(creation dto.ClusterCreate) (model.ClusterSpec, error) {
+	spec := model.ClusterSpec{
+		RuntimeType:             creation.RuntimeType,
+		DockerStorageDir:        creation.DockerStorageDIr,
+		ContainerdStorageDir:    creation.ContainerdStorageDIr,
+		NetworkType:             creation.NetworkType,
+		CiliumVersion:           creation.CiliumVersion,
+		CiliumTunnelMode:        creation.CiliumTunnelMode,
+		CiliumNativeRoutingCidr: creation.CiliumNativeRoutingCidr,
+		Version:                 creation.Version,
+		Provider:                creation.Provider,
+		FlannelBackend:          creation.FlannelBackend,
+		CalicoIpv4poolIpip:      creation.CalicoIpv4poolIpip,
+		KubeProxyMode:           creation.KubeProxyMode,
+		EnableDnsCache:          creation.EnableDnsCache,
+		DnsCacheVersion:         creation.DnsCacheVersion,
+		IngressControllerType:   creation.IngressControllerType,
+		Architectures:           creation.Architectures,
+		KubernetesAudit:         creation.KubernetesAudit,
+		DockerSubnet:            creation.DockerSubnet,
+		KubeApiServerPort:       constant.DefaultApiServerPort,
+		HelmVersion:             creation.HelmVersion,
+		NetworkInterface:        creation.NetworkInterface,
+		NetworkCidr:             creation.NetworkCidr,
+		SupportGpu:              creation.SupportGpu,
+		YumOperate:              creation.YumOperate,
+	}
+
+	spec.KubePodSubnet = creation.ClusterCIDR
+	serviceCIDR, nodeMask, err := getServiceCIDRAndNodeCIDRMaskSize(creation.ClusterCIDR, creation.MaxClusterServiceNum, creation.MaxNodePodNum)
+	if err != nil {
+		return spec, err
+	}
+
+	spec.KubeServiceSubnet = serviceCIDR
+	spec.KubeMaxPods = maxNodePodNumMap[nodeMask]
+	spec.KubeNetworkNodePrefix = nodeMask
+	return spec, nil
+}
+
 func getServiceCIDRAndNodeCIDRMaskSize(clusterCIDR string, maxClusterServiceNum int, maxNodePodNum int) (string, int, error) {
 	if maxClusterServiceNum <= 0 || maxNodePodNum <= 0 {
 		return "", 0, errors.New("maxClusterServiceNum or maxNodePodNum must more than 0")
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kmpp/pkg/constant"
 	"github.com/kmpp/pkg/db"
 	"github.com/kmpp/pkg/dto"
 	"github.com/kmpp/pkg/logger"
@@ -99,14 +100,35 @@ func NewCluster(cluster model.Cluster, writer ...io.Writer) *Cluster {
 	return c
 }
 
+// namedHandler 为 Handler 指定阶段名称，自定义阶段是闭包，无法通过函数名获取名称
+type namedHandler struct {
+	Handler
+	phaseName string
+	step      PlanStep
+}
+
+func (h namedHandler) name() string {
+	return h.phaseName
+}
+
+func newNamedHandlers(handlers ...Handler) []namedHandler {
+	var hs []namedHandler
+	for _, h := range handlers {
+		step := builtinSteps[h.name()]
+		step.Name = h.name()
+		hs = append(hs, namedHandler{Handler: h, phaseName: h.name(), step: step})
+	}
+	return hs
+}
+
 type ClusterAdm struct {
-	createHandlers  []Handler
-	upgradeHandlers []Handler
+	createHandlers  []namedHandler
+	upgradeHandlers []namedHandler
 }
 
 func NewClusterAdm() *ClusterAdm {
 	ca := new(ClusterAdm)
-	ca.createHandlers = []Handler{
+	ca.createHandlers = newNamedHandlers(
 		ca.EnsureInitTaskStart,
 		ca.EnsurePrepareBaseSystemConfig,
 		ca.EnsurePrepareContainerRuntime,
@@ -121,18 +143,38 @@ func NewClusterAdm() *ClusterAdm {
 		ca.EnsureInitMetricsServer,
 		ca.EnsureInitIngressController,
 		ca.EnsurePostInit,
-	}
-	ca.upgradeHandlers = []Handler{
+	)
+	ca.upgradeHandlers = newNamedHandlers(
 		ca.EnsureUpgradeTaskStart,
 		ca.EnsureBackupETCD,
 		ca.EnsureUpgradeRuntime,
 		ca.EnsureUpgradeETCD,
 		ca.EnsureUpgradeKubernetes,
 		ca.EnsureUpdateCertificates,
-	}
+	)
+	ca.createHandlers = insertCustomPhases(ca.createHandlers, constant.ClusterPhaseTypeCreate)
+	ca.upgradeHandlers = insertCustomPhases(ca.upgradeHandlers, constant.ClusterPhaseTypeUpgrade)
 	return ca
 }
 
+// CreatePhases returns the condition names of the create handlers in execution order.
+func (ca *ClusterAdm) CreatePhases() []string {
+	return handlerNames(ca.createHandlers)
+}
+
+// UpgradePhases returns the condition names of the upgrade handlers in execution order.
+func (ca *ClusterAdm) UpgradePhases() []string {
+	return handlerNames(ca.upgradeHandlers)
+}
+
+func handlerNames(handlers []namedHandler) []string {
+	var names []string
+	for _, h := range handlers {
+		names = append(names, h.name())
+	}
+	return names
+}
+
 func (ca *ClusterAdm) OnInitialize(c Cluster) (Cluster, error) {
 	err := ca.Create(&c)
 	return c, err
@@ -1,9 +1,6 @@
 package adm
 
 import (
-	"reflect"
-	"runtime"
-	"strings"
 	"time"
 
 	"github.com/kmpp/pkg/constant"
@@ -47,7 +44,10 @@ func (ca *ClusterAdm) Create(c *Cluster) error {
 				Message:       "",
 			})
 		}
+		return nil
 	}
+	// 所有阶段均已完成(例如跳过了最后一个阶段)
+	c.Status.Phase = constant.ClusterRunning
 	return nil
 }
 
@@ -71,7 +71,7 @@ func (ca *ClusterAdm) getCreateCurrentCondition(c *Cluster) *model.ClusterStatus
 func (ca *ClusterAdm) getCreateHandler(conditionName string) Handler {
 	for _, f := range ca.createHandlers {
 		if conditionName == f.name() {
-			return f
+			return f.Handler
 		}
 	}
 	return nil
@@ -79,11 +79,10 @@ func (ca *ClusterAdm) getCreateHandler(conditionName string) Handler {
 func (ca *ClusterAdm) getNextCreateConditionName(conditionName string) string {
 	var (
 		i int
-		f Handler
+		f namedHandler
 	)
 	for i, f = range ca.createHandlers {
-		name := runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
-		if strings.Contains(name, conditionName) {
+		if f.name() == conditionName {
 			break
 		}
 	}
@@ -7,7 +7,7 @@ import (
 )
 
 const (
-	backupCluster = "94-backup-cluster.yml"
+	BackupClusterPlaybook = "94-backup-cluster.yml"
 )
 
 type BackupClusterPhase struct {
@@ -18,5 +18,5 @@ func (backup BackupClusterPhase) Name() string {
 }
 
 func (backup BackupClusterPhase) Run(b kobe.Interface, writer io.Writer) error {
-	return phases.RunPlaybookAndGetResult(b, backupCluster, "", writer)
+	return phases.RunPlaybookAndGetResult(b, BackupClusterPlaybook, "", writer)
 }
@@ -7,7 +7,7 @@ import (
 )
 
 const (
-	restoreCluster = "95-restore-cluster.yml"
+	RestoreClusterPlaybook = "95-restore-cluster.yml"
 )
 
 type RestoreClusterPhase struct {
@@ -18,5 +18,5 @@ func (restore RestoreClusterPhase) Name() string {
 }
 
 func (restore RestoreClusterPhase) Run(b kobe.Interface, writer io.Writer) error {
-	return phases.RunPlaybookAndGetResult(b, restoreCluster, "", writer)
+	return phases.RunPlaybookAndGetResult(b, RestoreClusterPlaybook, "", writer)
 }
@@ -7,7 +7,7 @@ import (
 )
 
 const (
-	initMetricsServer = "13-metrics-server.yml"
+	MetricsServerPlaybook = "13-metrics-server.yml"
 )
 
 type MetricsServerPhase struct {
@@ -18,5 +18,5 @@ func (m MetricsServerPhase) Name() string {
 }
 
 func (m MetricsServerPhase) Run(b kobe.Interface, writer io.Writer) error {
-	return phases.RunPlaybookAndGetResult(b, initMetricsServer, "", writer)
+	return phases.RunPlaybookAndGetResult(b, MetricsServerPlaybook, "", writer)
 }
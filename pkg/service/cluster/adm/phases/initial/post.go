@@ -7,7 +7,7 @@ import (
 )
 
 const (
-	initPost = "15-post.yml"
+	PostPlaybook = "15-post.yml"
 )
 
 type PostPhase struct {
@@ -18,5 +18,5 @@ func (s PostPhase) Name() string {
 }
 
 func (s PostPhase) Run(b kobe.Interface, writer io.Writer) error {
-	return phases.RunPlaybookAndGetResult(b, initPost, "", writer)
+	return phases.RunPlaybookAndGetResult(b, PostPlaybook, "", writer)
 }
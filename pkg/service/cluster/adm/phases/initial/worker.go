@@ -7,7 +7,7 @@ import (
 )
 
 const (
-	initWorker = "08-kubernetes-worker.yml"
+	WorkerPlaybook = "08-kubernetes-worker.yml"
 )
 
 type WorkerPhase struct {
@@ -18,5 +18,5 @@ func (WorkerPhase) Name() string {
 }
 
 func (s WorkerPhase) Run(b kobe.Interface, writer io.Writer) error {
-	return phases.RunPlaybookAndGetResult(b, initWorker, "", writer)
+	return phases.RunPlaybookAndGetResult(b, WorkerPlaybook, "", writer)
 }
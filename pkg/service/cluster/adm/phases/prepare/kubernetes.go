@@ -7,7 +7,7 @@ import (
 )
 
 const (
-	prepareKubernetesComponents = "03-kubernetes-component.yml"
+	KubernetesComponentPlaybook = "03-kubernetes-component.yml"
 )
 
 type KubernetesComponentPhase struct {
@@ -18,5 +18,5 @@ func (s KubernetesComponentPhase) Name() string {
 }
 
 func (s KubernetesComponentPhase) Run(b kobe.Interface, writer io.Writer) error {
-	return phases.RunPlaybookAndGetResult(b, prepareKubernetesComponents, "", writer)
+	return phases.RunPlaybookAndGetResult(b, KubernetesComponentPlaybook, "", writer)
 }
@@ -0,0 +1,67 @@
+package adm
+
+import (
+	"github.com/KubeOperator/kobe/api"
+	"github.com/kmpp/pkg/service/cluster/adm/phases/backup"
+	"github.com/kmpp/pkg/service/cluster/adm/phases/initial"
+	"github.com/kmpp/pkg/service/cluster/adm/phases/plugin/ingress"
+	"github.com/kmpp/pkg/service/cluster/adm/phases/prepare"
+	"github.com/kmpp/pkg/service/cluster/adm/phases/upgrade"
+	"github.com/kmpp/pkg/util/kobe"
+)
+
+const upgradeTag = "upgrade"
+
+// PlanStep 执行计划中的一个阶段及其执行的 playbook，不执行 playbook 的阶段 Playbook 为空
+type PlanStep struct {
+	Name     string `json:"name"`
+	Playbook string `json:"playbook"`
+	Tag      string `json:"tag"`
+}
+
+var builtinSteps = map[string]PlanStep{
+	"EnsurePrepareBaseSystemConfig":    {Playbook: prepare.BaseSystemConfigPlaybook},
+	"EnsurePrepareContainerRuntime":    {Playbook: prepare.ContainerRuntimePlaybook},
+	"EnsurePrepareKubernetesComponent": {Playbook: prepare.KubernetesComponentPlaybook},
+	"EnsurePrepareLoadBalancer":        {Playbook: prepare.LoadBalancerPlaybook},
+	"EnsurePrepareCertificates":        {Playbook: prepare.CertificatesPlaybook},
+	"EnsureInitEtcd":                   {Playbook: initial.EtcdPlaybook},
+	"EnsureInitMaster":                 {Playbook: initial.MasterPlaybook},
+	"EnsureInitWorker":                 {Playbook: initial.WorkerPlaybook},
+	"EnsureInitNetwork":                {Playbook: initial.NetworkPlaybook},
+	"EnsureInitHelm":                   {Playbook: initial.HelmPlaybook},
+	"EnsureInitMetricsServer":          {Playbook: initial.MetricsServerPlaybook},
+	"EnsureInitIngressController":      {Playbook: ingress.ControllerPlaybook},
+	"EnsurePostInit":                   {Playbook: initial.PostPlaybook},
+	"EnsureBackupETCD":                 {Playbook: backup.BackupClusterPlaybook},
+	"EnsureUpgradeRuntime":             {Playbook: prepare.ContainerRuntimePlaybook, Tag: upgradeTag},
+	"EnsureUpgradeETCD":                {Playbook: initial.EtcdPlaybook, Tag: upgradeTag},
+	"EnsureUpgradeKubernetes":          {Playbook: upgrade.UpgradeClusterPlaybook},
+	"EnsureUpdateCertificates":         {Playbook: prepare.CertificatesPlaybook},
+}
+
+// CreateSteps 返回创建集群时依次执行的阶段
+func (ca *ClusterAdm) CreateSteps() []PlanStep {
+	return handlerSteps(ca.createHandlers)
+}
+
+// UpgradeSteps 返回升级集群时依次执行的阶段
+func (ca *ClusterAdm) UpgradeSteps() []PlanStep {
+	return handlerSteps(ca.upgradeHandlers)
+}
+
+func handlerSteps(handlers []namedHandler) []PlanStep {
+	var steps []PlanStep
+	for _, h := range handlers {
+		steps = append(steps, h.step)
+	}
+	return steps
+}
+
+// Inventory 返回执行 playbook 使用的 inventory，其中 Vars 为合并后的变量
+func (c *Cluster) Inventory() *api.Inventory {
+	if k, ok := c.Kobe.(*kobe.Kobe); ok {
+		return k.Inventory
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package adm
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/service/cluster/adm/phases"
+	"github.com/spf13/viper"
+)
+
+const (
+	PhasePositionBefore = "before"
+	PhasePositionAfter  = "after"
+
+	phaseRegistryPhaseName = "phase registry"
+	customPhasesConfigKey  = "cluster.phases"
+)
+
+// CustomPhase 自定义阶段，在内置阶段(或已注册的自定义阶段)之前或之后执行指定的 playbook
+type CustomPhase struct {
+	Name     string            `json:"name" mapstructure:"name"`
+	Pipeline string            `json:"pipeline" mapstructure:"pipeline"`
+	Anchor   string            `json:"anchor" mapstructure:"anchor"`
+	Position string            `json:"position" mapstructure:"position"`
+	Playbook string            `json:"playbook" mapstructure:"playbook"`
+	Vars     map[string]string `json:"vars" mapstructure:"vars"`
+}
+
+var (
+	customPhases []CustomPhase
+	customLock   sync.RWMutex
+)
+
+// RegisterPhase 注册自定义阶段，之后创建的 ClusterAdm 会在对应流程中执行该阶段
+func RegisterPhase(p CustomPhase) error {
+	if p.Pipeline == "" {
+		p.Pipeline = constant.ClusterPhaseTypeCreate
+	}
+	if p.Name == "" || p.Playbook == "" {
+		return errors.New("custom phase name and playbook are required")
+	}
+	if p.Position != PhasePositionBefore && p.Position != PhasePositionAfter {
+		return fmt.Errorf("custom phase %s position must be %s or %s", p.Name, PhasePositionBefore, PhasePositionAfter)
+	}
+	var names []string
+	switch p.Pipeline {
+	case constant.ClusterPhaseTypeCreate:
+		names = NewClusterAdm().CreatePhases()
+	case constant.ClusterPhaseTypeUpgrade:
+		names = NewClusterAdm().UpgradePhases()
+	default:
+		return fmt.Errorf("custom phase %s pipeline %s is not supported", p.Name, p.Pipeline)
+	}
+	anchorExist := false
+	for _, name := range names {
+		if name == p.Name {
+			return fmt.Errorf("phase %s already exists", p.Name)
+		}
+		if name == p.Anchor {
+			anchorExist = true
+		}
+	}
+	if !anchorExist {
+		return fmt.Errorf("anchor phase %s of custom phase %s not found", p.Anchor, p.Name)
+	}
+	customLock.Lock()
+	defer customLock.Unlock()
+	customPhases = append(customPhases, p)
+	return nil
+}
+
+// RegisteredPhases 返回已注册的自定义阶段
+func RegisteredPhases() []CustomPhase {
+	customLock.RLock()
+	defer customLock.RUnlock()
+	return append([]CustomPhase{}, customPhases...)
+}
+
+// handler 自定义变量会写入集群的 ansible 变量，对之后的阶段同样可见
+func (p CustomPhase) handler() Handler {
+	return func(c *Cluster) error {
+		writeLog(fmt.Sprintf("----%s start----", p.Name), c.writer)
+		for k, v := range p.Vars {
+			c.Kobe.SetVar(k, v)
+		}
+		return phases.RunPlaybookAndGetResult(c.Kobe, p.Playbook, "", c.writer)
+	}
+}
+
+func insertCustomPhases(handlers []namedHandler, pipeline string) []namedHandler {
+	for _, p := range RegisteredPhases() {
+		if p.Pipeline != pipeline {
+			continue
+		}
+		for i := range handlers {
+			if handlers[i].name() != p.Anchor {
+				continue
+			}
+			index := i
+			if p.Position == PhasePositionAfter {
+				index = i + 1
+			}
+			h := namedHandler{
+				Handler:   p.handler(),
+				phaseName: p.Name,
+				step:      PlanStep{Name: p.Name, Playbook: p.Playbook},
+			}
+			handlers = append(handlers[:index], append([]namedHandler{h}, handlers[index:]...)...)
+			break
+		}
+	}
+	return handlers
+}
+
+// InitPhaseRegistryPhase 启动时从配置 cluster.phases 加载自定义阶段
+type InitPhaseRegistryPhase struct{}
+
+func (i *InitPhaseRegistryPhase) Init() error {
+	var ps []CustomPhase
+	if err := viper.UnmarshalKey(customPhasesConfigKey, &ps); err != nil {
+		return fmt.Errorf("can not read custom phases: %s", err.Error())
+	}
+	for _, p := range ps {
+		if err := RegisterPhase(p); err != nil {
+			return err
+		}
+		logger.Log.Infof("custom phase %s registered %s %s", p.Name, p.Position, p.Anchor)
+	}
+	return nil
+}
+
+func (i *InitPhaseRegistryPhase) PhaseName() string {
+	return phaseRegistryPhaseName
+}
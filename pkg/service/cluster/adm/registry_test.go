@@ -0,0 +1,54 @@
+package adm
+
+import (
+	"testing"
+
+	"github.com/kmpp/pkg/constant"
+)
+
+func TestRegisterPhase(t *testing.T) {
+	defer func() { customPhases = nil }()
+	err := RegisterPhase(CustomPhase{
+		Name:     "EnsureSiteHardening",
+		Anchor:   "EnsureInitWorker",
+		Position: PhasePositionAfter,
+		Playbook: "site-hardening.yml",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = RegisterPhase(CustomPhase{
+		Name:     "EnsureSiteAgent",
+		Anchor:   "EnsureSiteHardening",
+		Position: PhasePositionBefore,
+		Playbook: "site-agent.yml",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca := NewClusterAdm()
+	names := ca.CreatePhases()
+	for i, name := range names {
+		if name == "EnsureInitWorker" {
+			if names[i+1] != "EnsureSiteAgent" || names[i+2] != "EnsureSiteHardening" {
+				t.Errorf("unexpected phase order %v", names)
+			}
+		}
+	}
+	if ca.getNextCreateConditionName("EnsureSiteHardening") != "EnsureInitNetwork" {
+		t.Errorf("unexpected next phase of EnsureSiteHardening")
+	}
+	if len(ca.UpgradePhases()) != 6 {
+		t.Errorf("custom create phase should not be inserted into upgrade pipeline")
+	}
+
+	if err := RegisterPhase(CustomPhase{Name: "EnsureSiteHardening", Anchor: "EnsureInitWorker", Position: PhasePositionAfter, Playbook: "a.yml"}); err == nil {
+		t.Error("duplicate phase name should be rejected")
+	}
+	if err := RegisterPhase(CustomPhase{Name: "EnsureOther", Anchor: "EnsureNothing", Position: PhasePositionAfter, Playbook: "a.yml"}); err == nil {
+		t.Error("unknown anchor should be rejected")
+	}
+	if err := RegisterPhase(CustomPhase{Name: "EnsureOther", Pipeline: constant.ClusterPhaseTypeUpgrade, Anchor: "EnsureInitWorker", Position: PhasePositionAfter, Playbook: "a.yml"}); err == nil {
+		t.Error("anchor of another pipeline should be rejected")
+	}
+}
@@ -2,8 +2,6 @@ package adm
 
 import (
 	"fmt"
-	"reflect"
-	"runtime"
 	"strings"
 	"time"
 
@@ -74,7 +72,7 @@ func (ca *ClusterAdm) getUpgradeCurrentCondition(c *Cluster) *model.ClusterStatu
 func (ca *ClusterAdm) getUpgradeHandler(conditionName string) Handler {
 	for _, f := range ca.upgradeHandlers {
 		if conditionName == f.name() {
-			return f
+			return f.Handler
 		}
 	}
 	return nil
@@ -82,11 +80,10 @@ func (ca *ClusterAdm) getUpgradeHandler(conditionName string) Handler {
 func (ca *ClusterAdm) getNextUpgradeConditionName(conditionName string) string {
 	var (
 		i int
-		f Handler
+		f namedHandler
 	)
 	for i, f = range ca.upgradeHandlers {
-		name := runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
-		if strings.Contains(name, conditionName) {
+		if f.name() == conditionName {
 			break
 		}
 	}
@@ -1,11 +1,14 @@
 package tools
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
 	"github.com/kmpp/pkg/constant"
 	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/util/secret"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type Grafana struct {
@@ -13,17 +16,20 @@ type Grafana struct {
 	Tool                *model.ClusterTool
 	LocalHostName       string
 	LocalRepositoryPort int
-	prometheusNs        string
+	prometheusUrl       string
+	central             *RemoteWrite
 	lokiNs              string
 }
 
-func NewGrafana(cluster *Cluster, tool *model.ClusterTool, prometheusNs, lokiNs string) (*Grafana, error) {
+// NewGrafana central 为中心存储，配置了查询地址时添加为跨集群的数据源
+func NewGrafana(cluster *Cluster, tool *model.ClusterTool, prometheusUrl string, central *RemoteWrite, lokiNs string) (*Grafana, error) {
 	p := &Grafana{
 		Tool:                tool,
 		Cluster:             cluster,
 		LocalHostName:       constant.LocalRepositoryDomainName,
 		LocalRepositoryPort: cluster.helmRepoPort,
-		prometheusNs:        prometheusNs,
+		prometheusUrl:       prometheusUrl,
+		central:             central,
 		lokiNs:              lokiNs,
 	}
 	return p, nil
@@ -49,18 +55,35 @@ func (g Grafana) setDefaultValue(toolDetail model.ClusterToolDetail, isInstall b
 
 		values["datasources.'datasources\\.yaml'.apiVersion"] = 1
 
-		if len(g.prometheusNs) != 0 {
-			values["datasources.'datasources\\.yaml'.datasources[0].name"] = "MYDS_Prometheus"
-			values["datasources.'datasources\\.yaml'.datasources[0].type"] = "prometheus"
-			values["datasources.'datasources\\.yaml'.datasources[0].url"] = "http://prometheus-server." + g.prometheusNs
-			values["datasources.'datasources\\.yaml'.datasources[0].access"] = "proxy"
-			values["datasources.'datasources\\.yaml'.datasources[0].isDefault"] = true
+		// strvals 不允许列表中间有空项，数据源按顺序编号
+		i := 0
+		if len(g.prometheusUrl) != 0 {
+			values[fmt.Sprintf("datasources.'datasources\\.yaml'.datasources[%d].name", i)] = "MYDS_Prometheus"
+			values[fmt.Sprintf("datasources.'datasources\\.yaml'.datasources[%d].type", i)] = "prometheus"
+			values[fmt.Sprintf("datasources.'datasources\\.yaml'.datasources[%d].url", i)] = g.prometheusUrl
+			values[fmt.Sprintf("datasources.'datasources\\.yaml'.datasources[%d].access", i)] = "proxy"
+			values[fmt.Sprintf("datasources.'datasources\\.yaml'.datasources[%d].isDefault", i)] = true
+			i++
 		}
 		if len(g.lokiNs) != 0 {
-			values["datasources.'datasources\\.yaml'.datasources[1].name"] = "Loki"
-			values["datasources.'datasources\\.yaml'.datasources[1].type"] = "loki"
-			values["datasources.'datasources\\.yaml'.datasources[1].url"] = "http://loki." + g.lokiNs + ":3100"
-			values["datasources.'datasources\\.yaml'.datasources[1].access"] = "proxy"
+			values[fmt.Sprintf("datasources.'datasources\\.yaml'.datasources[%d].name", i)] = "Loki"
+			values[fmt.Sprintf("datasources.'datasources\\.yaml'.datasources[%d].type", i)] = "loki"
+			values[fmt.Sprintf("datasources.'datasources\\.yaml'.datasources[%d].url", i)] = "http://loki." + g.lokiNs + ":3100"
+			values[fmt.Sprintf("datasources.'datasources\\.yaml'.datasources[%d].access", i)] = "proxy"
+			i++
+		}
+		// 中心存储中的样本带有 cluster 标签，面板可以按集群筛选或汇总所有集群
+		if g.central != nil && len(g.central.QueryURL) != 0 {
+			values[fmt.Sprintf("datasources.'datasources\\.yaml'.datasources[%d].name", i)] = "Central"
+			values[fmt.Sprintf("datasources.'datasources\\.yaml'.datasources[%d].type", i)] = "prometheus"
+			values[fmt.Sprintf("datasources.'datasources\\.yaml'.datasources[%d].url", i)] = g.central.QueryURL
+			values[fmt.Sprintf("datasources.'datasources\\.yaml'.datasources[%d].access", i)] = "proxy"
+			if len(g.central.Username) != 0 {
+				values[fmt.Sprintf("datasources.'datasources\\.yaml'.datasources[%d].basicAuth", i)] = true
+				values[fmt.Sprintf("datasources.'datasources\\.yaml'.datasources[%d].basicAuthUser", i)] = g.central.Username
+				values[fmt.Sprintf("datasources.'datasources\\.yaml'.datasources[%d].secureJsonData.basicAuthPassword", i)] = "$" + constant.GrafanaCentralPasswordEnv
+				values["envFromSecret"] = constant.GrafanaCentralSecretName
+			}
 		}
 
 		values["dashboardProviders.'dashboardproviders\\.yaml'.apiVersion"] = 1
@@ -89,6 +112,9 @@ func (g Grafana) setDefaultValue(toolDetail model.ClusterToolDetail, isInstall b
 
 func (g Grafana) Install(toolDetail model.ClusterToolDetail) error {
 	g.setDefaultValue(toolDetail, true)
+	if err := g.applyCentralSecret(); err != nil {
+		return err
+	}
 	if err := installChart(g.Cluster.HelmClient, g.Tool, constant.GrafanaChartName, toolDetail.ChartVersion); err != nil {
 		return err
 	}
@@ -107,5 +133,23 @@ func (g Grafana) Upgrade(toolDetail model.ClusterToolDetail) error {
 }
 
 func (g Grafana) Uninstall() error {
-	return uninstall(g.Cluster.Namespace, g.Tool, constant.DefaultGrafanaIngressName, g.Cluster.HelmClient, g.Cluster.KubeClient)
+	if err := uninstall(g.Cluster.Namespace, g.Tool, constant.DefaultGrafanaIngressName, g.Cluster.HelmClient, g.Cluster.KubeClient); err != nil {
+		return err
+	}
+	_ = g.Cluster.KubeClient.CoreV1().Secrets(g.Cluster.Namespace).Delete(context.TODO(), constant.GrafanaCentralSecretName, metav1.DeleteOptions{})
+	return nil
+}
+
+// applyCentralSecret 解析中心存储的密码引用并写入 envFromSecret 使用的 Secret
+func (g Grafana) applyCentralSecret() error {
+	if g.central == nil || len(g.central.QueryURL) == 0 || len(g.central.Username) == 0 {
+		return nil
+	}
+	password, err := secret.Resolve(g.central.Password)
+	if err != nil {
+		return err
+	}
+	return applySecret(g.Cluster.KubeClient, g.Cluster.Namespace, constant.GrafanaCentralSecretName, map[string][]byte{
+		constant.GrafanaCentralPasswordEnv: []byte(password),
+	})
 }
@@ -0,0 +1,35 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/model"
+)
+
+func TestGrafanaCentralDatasource(t *testing.T) {
+	cluster := &Cluster{Namespace: "kube-operator", helmRepoPort: 8081}
+	cluster.Name = "demo"
+	central := &RemoteWrite{QueryURL: "http://victoria:8428", Username: "kmpp", Password: "admin123"}
+	g, _ := NewGrafana(cluster, &model.ClusterTool{Name: "grafana", Vars: `{}`}, "", central, "")
+	g.setDefaultValue(model.ClusterToolDetail{Vars: `{}`}, true)
+	values := toolValues(g.Tool)
+	prefix := "datasources.'datasources\\.yaml'.datasources[0]."
+	expected := map[string]interface{}{
+		prefix + "name":                             "Central",
+		prefix + "url":                              "http://victoria:8428",
+		prefix + "basicAuth":                        true,
+		prefix + "basicAuthUser":                    "kmpp",
+		prefix + "secureJsonData.basicAuthPassword": "$" + constant.GrafanaCentralPasswordEnv,
+		"envFromSecret":                             constant.GrafanaCentralSecretName,
+	}
+	for k, v := range expected {
+		if values[k] != v {
+			t.Errorf("%s: expected %v, got %v", k, v, values[k])
+		}
+	}
+	if strings.Contains(g.Tool.Vars, "admin123") {
+		t.Error("central password should not be saved in grafana vars")
+	}
+}
@@ -1,18 +1,65 @@
 package tools
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 
 	"github.com/kmpp/pkg/constant"
 	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/util/secret"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
+var errRemoteWriteInvalid = errors.New("PROMETHEUS_REMOTE_WRITE_INVALID")
+
+// prometheusRuleFiles chart 默认的规则文件加上 kmpp 管理的告警规则组
+var prometheusRuleFiles = []string{
+	"/etc/config/recording_rules.yml",
+	"/etc/config/alerting_rules.yml",
+	"/etc/config/rules",
+	"/etc/config/alerts",
+	constant.PrometheusRulesMountPath + "/*.yml",
+}
+
+// kubePrometheusStackImages kube-prometheus-stack 使用的镜像，tool detail 中未提供 <name>_image_name 时使用默认镜像
+var kubePrometheusStackImages = []struct {
+	value       string
+	name        string
+	defaultName string
+	defaultTag  string
+}{
+	{"prometheusOperator.image", "stack_operator", "prometheus-operator/prometheus-operator", "v0.48.1"},
+	{"prometheusOperator.prometheusConfigReloaderImage", "stack_config_reloader", "prometheus-operator/prometheus-config-reloader", "v0.48.1"},
+	{"prometheus.prometheusSpec.image", "stack_prometheus", "prom/prometheus", "v2.26.0"},
+	{"alertmanager.alertmanagerSpec.image", "stack_alertmanager", constant.DefaultAlertmanagerImageName, constant.DefaultAlertmanagerImageTag},
+	{"kube-state-metrics.image", "stack_metrics", "kube-state-metrics/kube-state-metrics", "v2.0.0"},
+	{"prometheus-node-exporter.image", "stack_exporter", "prom/node-exporter", "v1.1.2"},
+}
+
+// RemoteWrite Prometheus 远端写入的地址，QueryURL 为中心存储的查询地址，Password 可以是密钥引用
+type RemoteWrite struct {
+	URL      string
+	QueryURL string
+	Username string
+	Password string
+}
+
 type Prometheus struct {
 	Tool                *model.ClusterTool
 	Cluster             *Cluster
 	LocalHostName       string
 	LocalRepositoryPort int
+	// AlertmanagerConfig 由 kmpp 通知设置生成的 alertmanager.yml，AlertRules 为集群的告警规则组
+	AlertmanagerConfig []byte
+	AlertRules         []byte
 }
 
 func NewPrometheus(cluster *Cluster, tool *model.ClusterTool) (*Prometheus, error) {
@@ -31,17 +78,6 @@ func (p Prometheus) setDefaultValue(toolDetail model.ClusterToolDetail, isInstal
 
 	values := map[string]interface{}{}
 	_ = json.Unmarshal([]byte(p.Tool.Vars), &values)
-	values["alertmanager.enabled"] = false
-	values["pushgateway.enabled"] = false
-	values["configmapReload.prometheus.image.repository"] = fmt.Sprintf("%s:%d/%s", p.LocalHostName, p.LocalRepositoryPort, imageMap["configmap_image_name"])
-	values["configmapReload.prometheus.image.tag"] = imageMap["configmap_image_tag"]
-	values["kube-state-metrics.image.repository"] = fmt.Sprintf("%s:%d/%s", p.LocalHostName, p.LocalRepositoryPort, imageMap["metrics_image_name"])
-	values["kube-state-metrics.image.tag"] = imageMap["metrics_image_tag"]
-	values["nodeExporter.image.repository"] = fmt.Sprintf("%s:%d/%s", p.LocalHostName, p.LocalRepositoryPort, imageMap["exporter_image_name"])
-	values["nodeExporter.image.tag"] = imageMap["exporter_image_tag"]
-	values["server.image.repository"] = fmt.Sprintf("%s:%d/%s", p.LocalHostName, p.LocalRepositoryPort, imageMap["prometheus_image_name"])
-	values["server.image.tag"] = imageMap["prometheus_image_tag"]
-
 	if isInstall {
 		if _, ok := values["server.retention"]; ok {
 			values["server.retention"] = fmt.Sprintf("%vd", values["server.retention"])
@@ -55,12 +91,147 @@ func (p Prometheus) setDefaultValue(toolDetail model.ClusterToolDetail, isInstal
 			}
 		}
 	}
+	stack := IsKubePrometheusStack(values)
+	if stack {
+		p.setStackValue(values, imageMap)
+	} else {
+		p.setStandaloneValue(values, imageMap)
+	}
+	p.setRemoteWriteValue(values, stack)
 	str, _ := json.Marshal(&values)
 	p.Tool.Vars = string(str)
 }
 
+func (p Prometheus) setStandaloneValue(values, imageMap map[string]interface{}) {
+	values["pushgateway.enabled"] = false
+	alertmanager, _ := values["alertmanager.enabled"].(bool)
+	values["alertmanager.enabled"] = alertmanager
+	if alertmanager {
+		imageName, imageTag := imageMap["alertmanager_image_name"], imageMap["alertmanager_image_tag"]
+		if imageName == nil || imageTag == nil {
+			imageName, imageTag = constant.DefaultAlertmanagerImageName, constant.DefaultAlertmanagerImageTag
+		}
+		values["alertmanager.image.repository"] = fmt.Sprintf("%s:%d/%s", p.LocalHostName, p.LocalRepositoryPort, imageName)
+		values["alertmanager.image.tag"] = imageTag
+		values["configmapReload.alertmanager.image.repository"] = fmt.Sprintf("%s:%d/%s", p.LocalHostName, p.LocalRepositoryPort, imageMap["configmap_image_name"])
+		values["configmapReload.alertmanager.image.tag"] = imageMap["configmap_image_tag"]
+		values["alertmanager.configFromSecret"] = constant.AlertmanagerSecretName
+		if _, ok := values["alertmanager.persistentVolume.enabled"]; !ok {
+			values["alertmanager.persistentVolume.enabled"] = false
+		}
+	}
+	for i, f := range prometheusRuleFiles {
+		values[fmt.Sprintf("serverFiles.prometheus\\.yml.rule_files[%d]", i)] = f
+	}
+	for _, prefix := range []string{"server", "configmapReload.prometheus"} {
+		values[prefix+".extraConfigmapMounts[0].name"] = constant.PrometheusRulesConfigMap
+		values[prefix+".extraConfigmapMounts[0].mountPath"] = constant.PrometheusRulesMountPath
+		values[prefix+".extraConfigmapMounts[0].configMap"] = constant.PrometheusRulesConfigMap
+		values[prefix+".extraConfigmapMounts[0].readOnly"] = true
+	}
+	values["configmapReload.prometheus.extraVolumeDirs[0]"] = constant.PrometheusRulesMountPath
+	values["configmapReload.prometheus.image.repository"] = fmt.Sprintf("%s:%d/%s", p.LocalHostName, p.LocalRepositoryPort, imageMap["configmap_image_name"])
+	values["configmapReload.prometheus.image.tag"] = imageMap["configmap_image_tag"]
+	values["kube-state-metrics.image.repository"] = fmt.Sprintf("%s:%d/%s", p.LocalHostName, p.LocalRepositoryPort, imageMap["metrics_image_name"])
+	values["kube-state-metrics.image.tag"] = imageMap["metrics_image_tag"]
+	values["nodeExporter.image.repository"] = fmt.Sprintf("%s:%d/%s", p.LocalHostName, p.LocalRepositoryPort, imageMap["exporter_image_name"])
+	values["nodeExporter.image.tag"] = imageMap["exporter_image_tag"]
+	values["server.image.repository"] = fmt.Sprintf("%s:%d/%s", p.LocalHostName, p.LocalRepositoryPort, imageMap["prometheus_image_name"])
+	values["server.image.tag"] = imageMap["prometheus_image_tag"]
+}
+
+// setStackValue kube-prometheus-stack 的参数，页面上 server.* 的保留时间、存储和节点选择转换为 prometheusSpec 的对应配置
+func (p Prometheus) setStackValue(values, imageMap map[string]interface{}) {
+	values["fullnameOverride"] = constant.KubePrometheusStackFullname
+	// 使用 kmpp 的 Grafana 工具，不单独部署
+	values["grafana.enabled"] = false
+	// 离线环境没有 certgen 镜像，关闭准入 webhook
+	values["prometheusOperator.admissionWebhooks.enabled"] = false
+	values["prometheusOperator.tls.enabled"] = false
+	for _, image := range kubePrometheusStackImages {
+		imageName, imageTag := imageMap[image.name+"_image_name"], imageMap[image.name+"_image_tag"]
+		if imageName == nil || imageTag == nil {
+			imageName, imageTag = image.defaultName, image.defaultTag
+		}
+		values[image.value+".repository"] = fmt.Sprintf("%s:%d/%s", p.LocalHostName, p.LocalRepositoryPort, imageName)
+		values[image.value+".tag"] = imageTag
+	}
+	// 选择所有的 ServiceMonitor、PodMonitor 和 PrometheusRule，而不只是带 release 标签的
+	for _, selector := range []string{"serviceMonitor", "podMonitor", "rule"} {
+		values["prometheus.prometheusSpec."+selector+"SelectorNilUsesHelmValues"] = false
+	}
+	alertmanager, _ := values["alertmanager.enabled"].(bool)
+	values["alertmanager.enabled"] = alertmanager
+	if alertmanager {
+		values["alertmanager.alertmanagerSpec.useExistingSecret"] = true
+	}
+
+	if retention, ok := values["server.retention"]; ok {
+		values["prometheus.prometheusSpec.retention"] = retention
+	}
+	if enabled, _ := values["server.persistentVolume.enabled"].(bool); enabled {
+		prefix := "prometheus.prometheusSpec.storageSpec.volumeClaimTemplate.spec."
+		values[prefix+"accessModes[0]"] = "ReadWriteOnce"
+		if size, ok := values["server.persistentVolume.size"]; ok {
+			values[prefix+"resources.requests.storage"] = size
+		}
+		if storageClass, ok := values["server.persistentVolume.storageClass"]; ok {
+			values[prefix+"storageClassName"] = storageClass
+		}
+	}
+	if node, ok := values["server.nodeSelector.kubernetes\\.io/hostname"]; ok {
+		values["prometheus.prometheusSpec.nodeSelector.kubernetes\\.io/hostname"] = node
+	}
+}
+
+// setRemoteWriteValue 开启远端写入时带上集群标签，认证信息从 Secret 读取，不写入 chart 的参数
+func (p Prometheus) setRemoteWriteValue(values map[string]interface{}, stack bool) {
+	rw, err := GetRemoteWrite(values)
+	if err != nil || rw == nil {
+		return
+	}
+	if stack {
+		values["prometheus.prometheusSpec.externalLabels."+constant.PrometheusClusterLabel] = p.Cluster.Name
+		values["prometheus.prometheusSpec.remoteWrite[0].url"] = rw.URL
+		if rw.Username != "" {
+			for _, key := range []string{"username", "password"} {
+				values["prometheus.prometheusSpec.remoteWrite[0].basicAuth."+key+".name"] = constant.RemoteWriteSecretName
+				values["prometheus.prometheusSpec.remoteWrite[0].basicAuth."+key+".key"] = key
+			}
+		}
+		return
+	}
+	values["server.global.external_labels."+constant.PrometheusClusterLabel] = p.Cluster.Name
+	values["server.remoteWrite[0].url"] = rw.URL
+	if rw.Username != "" {
+		values["server.remoteWrite[0].basic_auth.username"] = rw.Username
+		values["server.remoteWrite[0].basic_auth.password_file"] = constant.RemoteWriteMountPath + "/password"
+		values["server.extraSecretMounts[0].name"] = constant.RemoteWriteSecretName
+		values["server.extraSecretMounts[0].mountPath"] = constant.RemoteWriteMountPath
+		values["server.extraSecretMounts[0].secretName"] = constant.RemoteWriteSecretName
+		values["server.extraSecretMounts[0].readOnly"] = true
+	}
+}
+
+func (p Prometheus) values() map[string]interface{} {
+	values := map[string]interface{}{}
+	_ = json.Unmarshal([]byte(p.Tool.Vars), &values)
+	return values
+}
+
 func (p Prometheus) Install(toolDetail model.ClusterToolDetail) error {
 	p.setDefaultValue(toolDetail, true)
+	values := p.values()
+	if err := p.applyRemoteWriteSecret(); err != nil {
+		return err
+	}
+	if IsKubePrometheusStack(values) {
+		return p.installStack(toolDetail, values)
+	}
+	// server 挂载的规则 ConfigMap 和 Alertmanager Secret 必须在安装前存在
+	if err := ApplyAlertConfig(p.Cluster.KubeClient, p.Cluster.Namespace, values, p.AlertRules, p.AlertmanagerConfig); err != nil {
+		return err
+	}
 	if err := installChart(p.Cluster.HelmClient, p.Tool, constant.PrometheusChartName, toolDetail.ChartVersion); err != nil {
 		return err
 	}
@@ -74,11 +245,238 @@ func (p Prometheus) Install(toolDetail model.ClusterToolDetail) error {
 	return nil
 }
 
+func (p Prometheus) installStack(toolDetail model.ClusterToolDetail, values map[string]interface{}) error {
+	if err := installChart(p.Cluster.HelmClient, p.Tool, constant.KubePrometheusStackChartName, stackChartVersion(toolDetail)); err != nil {
+		return err
+	}
+	// PrometheusRule 依赖 chart 安装的 CRD
+	if err := ApplyAlertConfig(p.Cluster.KubeClient, p.Cluster.Namespace, values, p.AlertRules, p.AlertmanagerConfig); err != nil {
+		return err
+	}
+	if err := createRoute(p.Cluster.Namespace, constant.DefaultPrometheusIngressName, constant.DefaultPrometheusIngress, constant.KubePrometheusStackServiceName, constant.KubePrometheusStackServicePort, p.Cluster.KubeClient); err != nil {
+		return err
+	}
+	return waitForRunning(p.Cluster.Namespace, constant.KubePrometheusStackOperatorName, 1, p.Cluster.KubeClient)
+}
+
 func (p Prometheus) Upgrade(toolDetail model.ClusterToolDetail) error {
 	p.setDefaultValue(toolDetail, false)
+	values := p.values()
+	if err := p.applyRemoteWriteSecret(); err != nil {
+		return err
+	}
+	if err := ApplyAlertConfig(p.Cluster.KubeClient, p.Cluster.Namespace, values, p.AlertRules, p.AlertmanagerConfig); err != nil {
+		return err
+	}
+	if IsKubePrometheusStack(values) {
+		return upgradeChart(p.Cluster.HelmClient, p.Tool, constant.KubePrometheusStackChartName, stackChartVersion(toolDetail))
+	}
 	return upgradeChart(p.Cluster.HelmClient, p.Tool, constant.PrometheusChartName, toolDetail.ChartVersion)
 }
 
 func (p Prometheus) Uninstall() error {
-	return uninstall(p.Cluster.Namespace, p.Tool, constant.DefaultPrometheusIngressName, p.Cluster.HelmClient, p.Cluster.KubeClient)
+	if err := uninstall(p.Cluster.Namespace, p.Tool, constant.DefaultPrometheusIngressName, p.Cluster.HelmClient, p.Cluster.KubeClient); err != nil {
+		return err
+	}
+	ns := p.Cluster.Namespace
+	for _, name := range []string{constant.AlertmanagerSecretName, constant.KubePrometheusStackAlertmanagerSecret, constant.RemoteWriteSecretName} {
+		_ = p.Cluster.KubeClient.CoreV1().Secrets(ns).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	}
+	_ = p.Cluster.KubeClient.CoreV1().ConfigMaps(ns).Delete(context.TODO(), constant.PrometheusRulesConfigMap, metav1.DeleteOptions{})
+	if IsKubePrometheusStack(p.values()) {
+		_ = p.Cluster.KubeClient.Discovery().RESTClient().Delete().AbsPath(prometheusRulePath(ns), constant.PrometheusRulesConfigMap).Do(context.TODO()).Error()
+	}
+	return nil
+}
+
+// applyRemoteWriteSecret 在使用时解析密码引用并写入 Secret
+func (p Prometheus) applyRemoteWriteSecret() error {
+	rw, err := ToolRemoteWrite(p.Tool)
+	if err != nil {
+		return err
+	}
+	if rw == nil || rw.Username == "" {
+		return nil
+	}
+	password, err := secret.Resolve(rw.Password)
+	if err != nil {
+		return err
+	}
+	return applySecret(p.Cluster.KubeClient, p.Cluster.Namespace, constant.RemoteWriteSecretName, map[string][]byte{
+		"username": []byte(rw.Username),
+		"password": []byte(password),
+	})
+}
+
+func stackChartVersion(toolDetail model.ClusterToolDetail) string {
+	imageMap := map[string]interface{}{}
+	_ = json.Unmarshal([]byte(toolDetail.Vars), &imageMap)
+	if version, ok := imageMap["stack_chart_version"].(string); ok && version != "" {
+		return version
+	}
+	return constant.DefaultKubePrometheusStackChartVersion
+}
+
+// IsKubePrometheusStack 工具参数 stack 为 kube-prometheus-stack 时使用 operator 部署
+func IsKubePrometheusStack(vars map[string]interface{}) bool {
+	stack, _ := vars["stack"].(string)
+	return stack == constant.PrometheusStackKube
+}
+
+// ToolRemoteWrite 读取已保存工具的远端写入配置，自定义地址的密码从工具的 secret 列读取
+func ToolRemoteWrite(tool *model.ClusterTool) (*RemoteWrite, error) {
+	vars := map[string]interface{}{}
+	_ = json.Unmarshal([]byte(tool.Vars), &vars)
+	rw, err := GetRemoteWrite(vars)
+	if err != nil || rw == nil {
+		return rw, err
+	}
+	if vars["remoteWrite.mode"] == constant.RemoteWriteCustom && tool.Secret != "" {
+		rw.Password = tool.Secret
+	}
+	return rw, nil
+}
+
+// GetRemoteWrite 读取工具参数中的远端写入配置，未开启时返回 nil，central 使用 kmpp 配置的中心存储
+func GetRemoteWrite(vars map[string]interface{}) (*RemoteWrite, error) {
+	var rw RemoteWrite
+	mode, _ := vars["remoteWrite.mode"].(string)
+	switch mode {
+	case "":
+		return nil, nil
+	case constant.RemoteWriteCentral:
+		rw = centralRemoteWrite()
+	case constant.RemoteWriteCustom:
+		rw.URL, _ = vars["remoteWrite.url"].(string)
+		rw.QueryURL, _ = vars["remoteWrite.queryUrl"].(string)
+		rw.Username, _ = vars["remoteWrite.username"].(string)
+		rw.Password, _ = vars[constant.RemoteWritePasswordVar].(string)
+	default:
+		return nil, errRemoteWriteInvalid
+	}
+	if !isHTTPURL(rw.URL) || (rw.QueryURL != "" && !isHTTPURL(rw.QueryURL)) {
+		return nil, errRemoteWriteInvalid
+	}
+	if secret.IsReference(rw.Password) {
+		if err := secret.Validate(rw.Password); err != nil {
+			return nil, err
+		}
+	}
+	return &rw, nil
+}
+
+// centralRemoteWrite kmpp 配置的中心存储
+func centralRemoteWrite() RemoteWrite {
+	return RemoteWrite{
+		URL:      viper.GetString("prometheus.remote_write.url"),
+		QueryURL: viper.GetString("prometheus.remote_write.query_url"),
+		Username: viper.GetString("prometheus.remote_write.username"),
+		Password: viper.GetString("prometheus.remote_write.password"),
+	}
+}
+
+func isHTTPURL(address string) bool {
+	u, err := url.Parse(address)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// ApplyAlertConfig 写入告警规则和 Alertmanager 配置，kube-prometheus-stack 的规则使用 PrometheusRule 资源
+func ApplyAlertConfig(kubeClient *kubernetes.Clientset, namespace string, vars map[string]interface{}, rules, config []byte) error {
+	stack := IsKubePrometheusStack(vars)
+	if stack {
+		if err := ApplyPrometheusRuleResource(kubeClient, namespace, rules); err != nil {
+			return err
+		}
+	} else if err := ApplyPrometheusRules(kubeClient, namespace, rules); err != nil {
+		return err
+	}
+	if enabled, _ := vars["alertmanager.enabled"].(bool); !enabled {
+		return nil
+	}
+	if stack {
+		return applySecret(kubeClient, namespace, constant.KubePrometheusStackAlertmanagerSecret, map[string][]byte{constant.KubePrometheusStackAlertmanagerKey: config})
+	}
+	return ApplyAlertmanagerConfig(kubeClient, namespace, config)
+}
+
+// ApplyPrometheusRules 更新告警规则 ConfigMap，configmap-reload 检测到变化后通知 Prometheus 重新加载
+func ApplyPrometheusRules(kubeClient *kubernetes.Clientset, namespace string, rules []byte) error {
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constant.PrometheusRulesConfigMap, Namespace: namespace},
+		Data:       map[string]string{constant.PrometheusRulesKey: string(rules)},
+	}
+	old, err := kubeClient.CoreV1().ConfigMaps(namespace).Get(context.TODO(), cm.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = kubeClient.CoreV1().ConfigMaps(namespace).Create(context.TODO(), cm, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	old.Data = cm.Data
+	_, err = kubeClient.CoreV1().ConfigMaps(namespace).Update(context.TODO(), old, metav1.UpdateOptions{})
+	return err
+}
+
+// ApplyPrometheusRuleResource 将规则文件写入 PrometheusRule，规则文件与 PrometheusRule 的 spec 格式相同
+func ApplyPrometheusRuleResource(kubeClient *kubernetes.Clientset, namespace string, rules []byte) error {
+	if len(rules) == 0 {
+		rules = []byte("groups: []")
+	}
+	spec, err := yaml.YAMLToJSON(rules)
+	if err != nil {
+		return err
+	}
+	resource := map[string]interface{}{
+		"apiVersion": "monitoring.coreos.com/v1",
+		"kind":       "PrometheusRule",
+		"metadata":   map[string]interface{}{"name": constant.PrometheusRulesConfigMap, "namespace": namespace},
+		"spec":       json.RawMessage(spec),
+	}
+	client := kubeClient.Discovery().RESTClient()
+	path := prometheusRulePath(namespace)
+	raw, err := client.Get().AbsPath(path, constant.PrometheusRulesConfigMap).DoRaw(context.TODO())
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	if err != nil {
+		body, _ := json.Marshal(resource)
+		return client.Post().AbsPath(path).SetHeader("Content-Type", "application/json").Body(body).Do(context.TODO()).Error()
+	}
+	var old struct {
+		Metadata struct {
+			ResourceVersion string `json:"resourceVersion"`
+		} `json:"metadata"`
+	}
+	_ = json.Unmarshal(raw, &old)
+	resource["metadata"].(map[string]interface{})["resourceVersion"] = old.Metadata.ResourceVersion
+	body, _ := json.Marshal(resource)
+	return client.Put().AbsPath(path, constant.PrometheusRulesConfigMap).SetHeader("Content-Type", "application/json").Body(body).Do(context.TODO()).Error()
+}
+
+func prometheusRulePath(namespace string) string {
+	return fmt.Sprintf("/apis/monitoring.coreos.com/v1/namespaces/%s/prometheusrules", namespace)
+}
+
+// ApplyAlertmanagerConfig 更新 Alertmanager 配置，配置中含 SMTP 密码等敏感信息所以使用 Secret
+func ApplyAlertmanagerConfig(kubeClient *kubernetes.Clientset, namespace string, config []byte) error {
+	return applySecret(kubeClient, namespace, constant.AlertmanagerSecretName, map[string][]byte{constant.AlertmanagerConfigKey: config})
+}
+
+func applySecret(kubeClient *kubernetes.Clientset, namespace, name string, data map[string][]byte) error {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       data,
+	}
+	old, err := kubeClient.CoreV1().Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = kubeClient.CoreV1().Secrets(namespace).Create(context.TODO(), secret, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	old.Data = data
+	_, err = kubeClient.CoreV1().Secrets(namespace).Update(context.TODO(), old, metav1.UpdateOptions{})
+	return err
 }
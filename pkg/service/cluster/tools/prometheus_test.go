@@ -0,0 +1,112 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/model"
+)
+
+const testToolDetail = `{"prometheus_image_name":"prom/prometheus","prometheus_image_tag":"v2.18.1","configmap_image_name":"jimmidyson/configmap-reload","configmap_image_tag":"v0.3.0"}`
+
+func newTestPrometheus(vars, secret string) *Prometheus {
+	cluster := &Cluster{Namespace: "kube-operator", helmRepoPort: 8081}
+	cluster.Name = "demo"
+	p, _ := NewPrometheus(cluster, &model.ClusterTool{Name: "prometheus", Vars: vars, Secret: secret})
+	return p
+}
+
+func toolValues(tool *model.ClusterTool) map[string]interface{} {
+	values := map[string]interface{}{}
+	_ = json.Unmarshal([]byte(tool.Vars), &values)
+	return values
+}
+
+func TestPrometheusStandaloneValues(t *testing.T) {
+	p := newTestPrometheus(`{"namespace":"kube-operator","server.retention":10,"remoteWrite.mode":"custom","remoteWrite.url":"https://thanos/api/v1/receive","remoteWrite.username":"kmpp"}`, "admin123")
+	p.setDefaultValue(model.ClusterToolDetail{Vars: testToolDetail}, true)
+	values := toolValues(p.Tool)
+	expected := map[string]interface{}{
+		"server.retention":                               "10d",
+		"server.image.repository":                        constant.LocalRepositoryDomainName + ":8081/prom/prometheus",
+		"server.global.external_labels.cluster":          "demo",
+		"server.remoteWrite[0].url":                      "https://thanos/api/v1/receive",
+		"server.remoteWrite[0].basic_auth.username":      "kmpp",
+		"server.remoteWrite[0].basic_auth.password_file": constant.RemoteWriteMountPath + "/password",
+		"server.extraSecretMounts[0].secretName":         constant.RemoteWriteSecretName,
+		"server.extraConfigmapMounts[0].configMap":       constant.PrometheusRulesConfigMap,
+		"alertmanager.enabled":                           false,
+	}
+	for k, v := range expected {
+		if values[k] != v {
+			t.Errorf("%s: expected %v, got %v", k, v, values[k])
+		}
+	}
+	for k, v := range chartValues(values) {
+		if s, ok := v.(string); ok && s == "admin123" {
+			t.Errorf("password should not be passed to the chart in %s", k)
+		}
+	}
+	if _, ok := values["fullnameOverride"]; ok {
+		t.Error("standalone chart should not use stack values")
+	}
+}
+
+func TestPrometheusStackValues(t *testing.T) {
+	p := newTestPrometheus(`{"namespace":"kube-operator","stack":"kube-prometheus-stack","server.retention":10,"server.persistentVolume.enabled":true,"server.persistentVolume.size":20,"server.persistentVolume.storageClass":"nfs","alertmanager.enabled":true,"remoteWrite.mode":"custom","remoteWrite.url":"https://thanos/api/v1/receive","remoteWrite.username":"kmpp"}`, "admin123")
+	p.setDefaultValue(model.ClusterToolDetail{Vars: testToolDetail}, true)
+	values := toolValues(p.Tool)
+	expected := map[string]interface{}{
+		"fullnameOverride": constant.KubePrometheusStackFullname,
+		"grafana.enabled":  false,
+		"prometheus.prometheusSpec.image.repository":                                                constant.LocalRepositoryDomainName + ":8081/prom/prometheus",
+		"prometheus.prometheusSpec.retention":                                                       "10d",
+		"prometheus.prometheusSpec.storageSpec.volumeClaimTemplate.spec.resources.requests.storage": "20Gi",
+		"prometheus.prometheusSpec.storageSpec.volumeClaimTemplate.spec.storageClassName":           "nfs",
+		"prometheus.prometheusSpec.ruleSelectorNilUsesHelmValues":                                   false,
+		"prometheus.prometheusSpec.externalLabels.cluster":                                          "demo",
+		"prometheus.prometheusSpec.remoteWrite[0].url":                                              "https://thanos/api/v1/receive",
+		"prometheus.prometheusSpec.remoteWrite[0].basicAuth.password.name":                          constant.RemoteWriteSecretName,
+		"alertmanager.alertmanagerSpec.useExistingSecret":                                           true,
+	}
+	for k, v := range expected {
+		if values[k] != v {
+			t.Errorf("%s: expected %v, got %v", k, v, values[k])
+		}
+	}
+	if _, ok := values["server.remoteWrite[0].url"]; ok {
+		t.Error("stack chart should not use standalone values")
+	}
+}
+
+func TestChartValues(t *testing.T) {
+	values := chartValues(map[string]interface{}{
+		"server.retention":     "10d",
+		"remoteWrite.mode":     "custom",
+		"remoteWrite.url":      "https://thanos/api/v1/receive",
+		"remoteWrite.password": "secret",
+	})
+	if len(values) != 1 || values["server.retention"] != "10d" {
+		t.Errorf("remote write vars should not be passed to the chart: %v", values)
+	}
+}
+
+func TestToolRemoteWrite(t *testing.T) {
+	tool := &model.ClusterTool{
+		Vars:   `{"remoteWrite.mode":"custom","remoteWrite.url":"https://thanos/api/v1/receive","remoteWrite.username":"kmpp"}`,
+		Secret: "vault://kv/thanos#password",
+	}
+	rw, err := ToolRemoteWrite(tool)
+	if err != nil || rw == nil || rw.Password != tool.Secret || rw.Username != "kmpp" {
+		t.Errorf("unexpected remote write %+v %v", rw, err)
+	}
+	// 升级前保存在 vars 中的密码仍然可用
+	tool = &model.ClusterTool{Vars: `{"remoteWrite.mode":"custom","remoteWrite.url":"https://thanos/api/v1/receive","remoteWrite.password":"admin123"}`}
+	if rw, _ := ToolRemoteWrite(tool); rw == nil || rw.Password != "admin123" {
+		t.Errorf("unexpected remote write %+v", rw)
+	}
+	if rw, err := ToolRemoteWrite(&model.ClusterTool{Vars: `{}`}); rw != nil || err != nil {
+		t.Errorf("remote write should be disabled, got %+v %v", rw, err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/kmpp/pkg/constant"
@@ -41,15 +42,9 @@ func NewCluster(cluster model.Cluster, hosts []kubernetesUtil.Host, secret model
 	c := Cluster{
 		Cluster: cluster,
 	}
-	var registery model.SystemRegistry
-	if cluster.Spec.Architectures == constant.ArchAMD64 {
-		if err := db.DB.Where("architecture = ?", constant.ArchitectureOfAMD64).First(&registery).Error; err != nil {
-			return nil, errors.New("load image pull port failed")
-		}
-	} else {
-		if err := db.DB.Where("architecture = ?", constant.ArchitectureOfARM64).First(&registery).Error; err != nil {
-			return nil, errors.New("load image pull port failed")
-		}
+	registery, err := model.GetActiveRegistry(model.RegistryArchitecture(cluster.Spec.Architectures))
+	if err != nil {
+		return nil, errors.New("load image pull port failed")
 	}
 	c.helmRepoPort = registery.RegistryPort
 	c.Namespace = namespace
@@ -89,14 +84,15 @@ func NewClusterTool(tool *model.ClusterTool, cluster model.Cluster, hosts []kube
 		return NewLoki(c, tool)
 	case "grafana":
 		if enable {
-			prometheusNs, err := getGrafanaSourceNs(cluster, "prometheus")
-			if err != nil {
+			central := getCentralSource(cluster)
+			prometheusUrl, err := getPrometheusSource(cluster)
+			if err != nil && central == nil {
 				return nil, err
 			}
 			lokiNs, _ := getGrafanaSourceNs(cluster, "loki")
-			return NewGrafana(c, tool, prometheusNs, lokiNs)
+			return NewGrafana(c, tool, prometheusUrl, central, lokiNs)
 		} else {
-			return NewGrafana(c, tool, "", "")
+			return NewGrafana(c, tool, "", nil, "")
 		}
 	case "registry":
 		return NewRegistry(c, tool)
@@ -152,7 +148,7 @@ func installChart(h helm.Interface, tool *model.ClusterTool, chartName, chartVer
 	}
 	valueMap := map[string]interface{}{}
 	_ = json.Unmarshal([]byte(tool.Vars), &valueMap)
-	m, err := MergeValueMap(valueMap)
+	m, err := MergeValueMap(chartValues(valueMap))
 	if err != nil {
 		return err
 	}
@@ -165,12 +161,24 @@ func installChart(h helm.Interface, tool *model.ClusterTool, chartName, chartVer
 	return nil
 }
 
+// chartValues 去掉只由 kmpp 使用的工具参数，远端写入的地址和认证信息已转换为 chart 参数和 Secret
+func chartValues(vars map[string]interface{}) map[string]interface{} {
+	values := map[string]interface{}{}
+	for k, v := range vars {
+		if strings.HasPrefix(k, constant.RemoteWriteVarPrefix) {
+			continue
+		}
+		values[k] = v
+	}
+	return values
+}
+
 func upgradeChart(h helm.Interface, tool *model.ClusterTool, chartName, chartVersion string) error {
 	valueMap := map[string]interface{}{}
 	if err := json.Unmarshal([]byte(tool.Vars), &valueMap); err != nil {
 		return err
 	}
-	m, err := MergeValueMap(valueMap)
+	m, err := MergeValueMap(chartValues(valueMap))
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("merge value map failed: %v", err))
 	}
@@ -306,3 +314,37 @@ func getGrafanaSourceNs(cluster model.Cluster, sourceFrom string) (string, error
 	}
 	return sp.(string), nil
 }
+
+// getPrometheusSource 返回集群内 Prometheus 的地址
+func getPrometheusSource(cluster model.Cluster) (string, error) {
+	var sourceData model.ClusterTool
+	if err := db.DB.
+		Where("cluster_id = ? AND status = ? AND name = ?", cluster.ID, "Running", "prometheus").
+		Find(&sourceData).Error; err != nil {
+		return "", err
+	}
+	sourceVars := map[string]interface{}{}
+	_ = json.Unmarshal([]byte(sourceData.Vars), &sourceVars)
+	ns, ok := sourceVars["namespace"].(string)
+	if !ok {
+		return "", fmt.Errorf("load namespace of prometheus failed")
+	}
+	if IsKubePrometheusStack(sourceVars) {
+		return fmt.Sprintf("http://%s.%s:%d", constant.KubePrometheusStackServiceName, ns, constant.KubePrometheusStackServicePort), nil
+	}
+	return "http://" + constant.DefaultPrometheusServiceName + "." + ns, nil
+}
+
+// getCentralSource 集群 Prometheus 写入自定义地址时使用其查询地址，否则使用 kmpp 配置的中心存储，与本地 Prometheus 的部署方式和状态无关
+func getCentralSource(cluster model.Cluster) *RemoteWrite {
+	var sourceData model.ClusterTool
+	if err := db.DB.Where("cluster_id = ? AND name = ?", cluster.ID, "prometheus").First(&sourceData).Error; err == nil {
+		if rw, _ := ToolRemoteWrite(&sourceData); rw != nil && rw.QueryURL != "" {
+			return rw
+		}
+	}
+	if rw := centralRemoteWrite(); isHTTPURL(rw.QueryURL) {
+		return &rw
+	}
+	return nil
+}
@@ -0,0 +1,396 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/service/cluster/tools"
+	kubernetesUtil "github.com/kmpp/pkg/util/kubernetes"
+	"github.com/ghodss/yaml"
+	"github.com/jinzhu/gorm"
+)
+
+var (
+	errAlertRuleGroupNotFound = errors.New("ALERT_RULE_GROUP_NOT_FOUND")
+	errAlertRuleGroupExists   = errors.New("ALERT_RULE_GROUP_EXISTS")
+	errAlertRuleDuration      = errors.New("ALERT_RULE_DURATION_INVALID")
+)
+
+// alertRuleDuration Prometheus 的持续时间格式，如 30s、5m、1h30m
+var alertRuleDuration = regexp.MustCompile(`^([0-9]+(ms|s|m|h|d|w|y))+$`)
+
+type ClusterAlertRuleService interface {
+	List(clusterName string) ([]dto.ClusterAlertRuleGroup, error)
+	Get(clusterName string, name string) (*dto.ClusterAlertRuleGroup, error)
+	Create(clusterName string, req dto.ClusterAlertRuleGroupCreate) (*dto.ClusterAlertRuleGroup, error)
+	Update(clusterName string, name string, req dto.ClusterAlertRuleGroupUpdate) (*dto.ClusterAlertRuleGroup, error)
+	Delete(clusterName string, name string) error
+	InstallBuiltin(clusterName string) ([]dto.ClusterAlertRuleGroup, error)
+	Apply(clusterName string) error
+}
+
+func NewClusterAlertRuleService() ClusterAlertRuleService {
+	return &clusterAlertRuleService{
+		clusterService: NewClusterService(),
+	}
+}
+
+type clusterAlertRuleService struct {
+	clusterService ClusterService
+}
+
+func (c clusterAlertRuleService) List(clusterName string) ([]dto.ClusterAlertRuleGroup, error) {
+	cluster, err := c.clusterService.Get(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := listAlertRuleGroups(cluster.ID)
+	if err != nil {
+		return nil, err
+	}
+	var result []dto.ClusterAlertRuleGroup
+	for _, group := range groups {
+		result = append(result, toAlertRuleGroupDTO(group))
+	}
+	return result, nil
+}
+
+func (c clusterAlertRuleService) Get(clusterName string, name string) (*dto.ClusterAlertRuleGroup, error) {
+	group, err := c.getGroup(clusterName, name)
+	if err != nil {
+		return nil, err
+	}
+	d := toAlertRuleGroupDTO(group)
+	return &d, nil
+}
+
+func (c clusterAlertRuleService) Create(clusterName string, req dto.ClusterAlertRuleGroupCreate) (*dto.ClusterAlertRuleGroup, error) {
+	cluster, err := c.clusterService.Get(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	var old model.ClusterAlertRuleGroup
+	db.DB.Where("cluster_id = ? AND name = ?", cluster.ID, req.Name).First(&old)
+	if old.ID != "" {
+		return nil, errAlertRuleGroupExists
+	}
+	if err := validateAlertRules(req.Interval, req.Rules); err != nil {
+		return nil, err
+	}
+	buf, _ := json.Marshal(req.Rules)
+	group := model.ClusterAlertRuleGroup{ClusterID: cluster.ID, Name: req.Name, Interval: req.Interval, Rules: string(buf)}
+	if err := db.DB.Create(&group).Error; err != nil {
+		return nil, err
+	}
+	if err := c.Apply(clusterName); err != nil {
+		return nil, err
+	}
+	d := toAlertRuleGroupDTO(group)
+	return &d, nil
+}
+
+func (c clusterAlertRuleService) Update(clusterName string, name string, req dto.ClusterAlertRuleGroupUpdate) (*dto.ClusterAlertRuleGroup, error) {
+	group, err := c.getGroup(clusterName, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateAlertRules(req.Interval, req.Rules); err != nil {
+		return nil, err
+	}
+	buf, _ := json.Marshal(req.Rules)
+	group.Interval = req.Interval
+	group.Rules = string(buf)
+	if err := db.DB.Save(&group).Error; err != nil {
+		return nil, err
+	}
+	if err := c.Apply(clusterName); err != nil {
+		return nil, err
+	}
+	d := toAlertRuleGroupDTO(group)
+	return &d, nil
+}
+
+func (c clusterAlertRuleService) Delete(clusterName string, name string) error {
+	group, err := c.getGroup(clusterName, name)
+	if err != nil {
+		return err
+	}
+	if err := db.DB.Delete(&group).Error; err != nil {
+		return err
+	}
+	return c.Apply(clusterName)
+}
+
+// InstallBuiltin 安装或还原内置的 node、etcd 和 apiserver 告警规则组
+func (c clusterAlertRuleService) InstallBuiltin(clusterName string) ([]dto.ClusterAlertRuleGroup, error) {
+	cluster, err := c.clusterService.Get(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if err := installBuiltinAlertRules(cluster.ID); err != nil {
+		return nil, err
+	}
+	if err := c.Apply(clusterName); err != nil {
+		return nil, err
+	}
+	return c.List(clusterName)
+}
+
+// Apply 将集群的告警规则和 Alertmanager 配置写入 Prometheus 所在的命名空间，Prometheus 未启用时只保存
+func (c clusterAlertRuleService) Apply(clusterName string) error {
+	cluster, err := c.clusterService.Get(clusterName)
+	if err != nil {
+		return err
+	}
+	var tool model.ClusterTool
+	if err := db.DB.Where("cluster_id = ? AND name = ?", cluster.ID, "prometheus").First(&tool).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	if tool.Status != constant.ClusterRunning {
+		return nil
+	}
+	vars := map[string]interface{}{}
+	_ = json.Unmarshal([]byte(tool.Vars), &vars)
+	namespace, _ := vars["namespace"].(string)
+	if namespace == "" {
+		namespace = constant.DefaultNamespace
+	}
+
+	hosts, err := c.clusterService.GetApiServerEndpoints(clusterName)
+	if err != nil {
+		return err
+	}
+	secret, err := c.clusterService.GetSecrets(clusterName)
+	if err != nil {
+		return err
+	}
+	kubeClient, err := kubernetesUtil.NewKubernetesClient(&kubernetesUtil.Config{
+		Hosts: hosts,
+		Token: secret.KubernetesToken,
+	})
+	if err != nil {
+		return err
+	}
+	rules, err := renderClusterAlertRules(cluster.ID)
+	if err != nil {
+		return err
+	}
+	var config []byte
+	if enabled, _ := vars["alertmanager.enabled"].(bool); enabled {
+		if config, err = renderAlertmanagerConfig(cluster.Cluster); err != nil {
+			return err
+		}
+	}
+	return tools.ApplyAlertConfig(kubeClient, namespace, vars, rules, config)
+}
+
+func (c clusterAlertRuleService) getGroup(clusterName string, name string) (model.ClusterAlertRuleGroup, error) {
+	var group model.ClusterAlertRuleGroup
+	cluster, err := c.clusterService.Get(clusterName)
+	if err != nil {
+		return group, err
+	}
+	if err := db.DB.Where("cluster_id = ? AND name = ?", cluster.ID, name).First(&group).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return group, errAlertRuleGroupNotFound
+		}
+		return group, err
+	}
+	return group, nil
+}
+
+func listAlertRuleGroups(clusterID string) ([]model.ClusterAlertRuleGroup, error) {
+	var groups []model.ClusterAlertRuleGroup
+	if err := db.DB.Where("cluster_id = ?", clusterID).Order("name").Find(&groups).Error; err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func installBuiltinAlertRules(clusterID string) error {
+	for _, builtin := range builtinAlertRuleGroups {
+		var group model.ClusterAlertRuleGroup
+		db.DB.Where("cluster_id = ? AND name = ?", clusterID, builtin.Name).First(&group)
+		buf, _ := json.Marshal(builtin.Rules)
+		group.ClusterID = clusterID
+		group.Name = builtin.Name
+		group.Interval = builtin.Interval
+		group.Rules = string(buf)
+		group.Builtin = true
+		if err := db.DB.Save(&group).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderClusterAlertRules 生成集群的规则文件，集群还没有规则组时先安装内置规则
+func renderClusterAlertRules(clusterID string) ([]byte, error) {
+	groups, err := listAlertRuleGroups(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		if err := installBuiltinAlertRules(clusterID); err != nil {
+			return nil, err
+		}
+		if groups, err = listAlertRuleGroups(clusterID); err != nil {
+			return nil, err
+		}
+	}
+	var items []dto.ClusterAlertRuleGroup
+	for _, group := range groups {
+		items = append(items, toAlertRuleGroupDTO(group))
+	}
+	return renderAlertRules(items)
+}
+
+// renderAlertRules 按 Prometheus 规则文件格式输出
+func renderAlertRules(groups []dto.ClusterAlertRuleGroup) ([]byte, error) {
+	type ruleGroup struct {
+		Name     string          `json:"name"`
+		Interval string          `json:"interval,omitempty"`
+		Rules    []dto.AlertRule `json:"rules"`
+	}
+	file := struct {
+		Groups []ruleGroup `json:"groups"`
+	}{Groups: []ruleGroup{}}
+	for _, group := range groups {
+		file.Groups = append(file.Groups, ruleGroup{Name: group.Name, Interval: group.Interval, Rules: group.Rules})
+	}
+	return yaml.Marshal(file)
+}
+
+func validateAlertRules(interval string, rules []dto.AlertRule) error {
+	if interval != "" && !alertRuleDuration.MatchString(interval) {
+		return errAlertRuleDuration
+	}
+	for _, rule := range rules {
+		if rule.For != "" && !alertRuleDuration.MatchString(rule.For) {
+			return errAlertRuleDuration
+		}
+	}
+	return nil
+}
+
+func toAlertRuleGroupDTO(group model.ClusterAlertRuleGroup) dto.ClusterAlertRuleGroup {
+	d := dto.ClusterAlertRuleGroup{ClusterAlertRuleGroup: group, Rules: []dto.AlertRule{}}
+	_ = json.Unmarshal([]byte(group.Rules), &d.Rules)
+	return d
+}
+
+// builtinAlertRuleGroups 内置规则，表达式同时兼容 prometheus chart 和 kube-prometheus-stack 默认的抓取任务
+var builtinAlertRuleGroups = []dto.ClusterAlertRuleGroupCreate{
+	{
+		Name: "kmpp-node",
+		Rules: []dto.AlertRule{
+			{
+				Alert:       "NodeExporterDown",
+				Expr:        `up{component="node-exporter"} == 0 or up{job="node-exporter"} == 0`,
+				For:         "5m",
+				Labels:      map[string]string{"severity": "critical"},
+				Annotations: map[string]string{"summary": "node-exporter {{ $labels.instance }} is down"},
+			},
+			{
+				Alert:       "NodeNotReady",
+				Expr:        `kube_node_status_condition{condition="Ready",status="true"} == 0`,
+				For:         "5m",
+				Labels:      map[string]string{"severity": "critical"},
+				Annotations: map[string]string{"summary": "node {{ $labels.node }} is not ready"},
+			},
+			{
+				Alert:       "NodeFilesystemAlmostFull",
+				Expr:        `node_filesystem_avail_bytes{fstype!~"tmpfs|overlay"} / node_filesystem_size_bytes{fstype!~"tmpfs|overlay"} < 0.1`,
+				For:         "10m",
+				Labels:      map[string]string{"severity": "warning"},
+				Annotations: map[string]string{"summary": "filesystem {{ $labels.mountpoint }} on {{ $labels.instance }} has less than 10% space left"},
+			},
+			{
+				Alert:       "NodeMemoryHigh",
+				Expr:        `(1 - node_memory_MemAvailable_bytes / node_memory_MemTotal_bytes) > 0.9`,
+				For:         "10m",
+				Labels:      map[string]string{"severity": "warning"},
+				Annotations: map[string]string{"summary": "memory usage of {{ $labels.instance }} is above 90%"},
+			},
+			{
+				Alert:       "NodeCPUHigh",
+				Expr:        `1 - avg by (instance) (rate(node_cpu_seconds_total{mode="idle"}[5m])) > 0.9`,
+				For:         "15m",
+				Labels:      map[string]string{"severity": "warning"},
+				Annotations: map[string]string{"summary": "cpu usage of {{ $labels.instance }} is above 90%"},
+			},
+		},
+	},
+	{
+		Name: "kmpp-etcd",
+		Rules: []dto.AlertRule{
+			{
+				Alert:       "EtcdNoLeader",
+				Expr:        `etcd_server_has_leader == 0`,
+				For:         "1m",
+				Labels:      map[string]string{"severity": "critical"},
+				Annotations: map[string]string{"summary": "etcd member {{ $labels.instance }} has no leader"},
+			},
+			{
+				Alert:       "EtcdFrequentLeaderChanges",
+				Expr:        `increase(etcd_server_leader_changes_seen_total[1h]) > 3`,
+				Labels:      map[string]string{"severity": "warning"},
+				Annotations: map[string]string{"summary": "etcd member {{ $labels.instance }} has seen {{ $value }} leader changes within the last hour"},
+			},
+			{
+				Alert:       "EtcdSlowWalFsync",
+				Expr:        `histogram_quantile(0.99, rate(etcd_disk_wal_fsync_duration_seconds_bucket[5m])) > 0.5`,
+				For:         "10m",
+				Labels:      map[string]string{"severity": "warning"},
+				Annotations: map[string]string{"summary": "etcd member {{ $labels.instance }} wal fsync p99 is above 500ms"},
+			},
+			{
+				Alert:       "EtcdRequestSlow",
+				Expr:        `histogram_quantile(0.99, sum by (le, operation) (rate(etcd_request_duration_seconds_bucket[5m]))) > 1`,
+				For:         "10m",
+				Labels:      map[string]string{"severity": "warning"},
+				Annotations: map[string]string{"summary": "etcd {{ $labels.operation }} request p99 from apiserver is above 1s"},
+			},
+		},
+	},
+	{
+		Name: "kmpp-apiserver",
+		Rules: []dto.AlertRule{
+			{
+				Alert:       "KubeAPIServerDown",
+				Expr:        `absent(up{job=~"kubernetes-apiservers|apiserver"} == 1)`,
+				For:         "5m",
+				Labels:      map[string]string{"severity": "critical"},
+				Annotations: map[string]string{"summary": "kube-apiserver has disappeared from Prometheus target discovery"},
+			},
+			{
+				Alert:       "KubeAPIServerErrorsHigh",
+				Expr:        `sum(rate(apiserver_request_total{code=~"5.."}[5m])) / sum(rate(apiserver_request_total[5m])) > 0.05`,
+				For:         "10m",
+				Labels:      map[string]string{"severity": "warning"},
+				Annotations: map[string]string{"summary": "more than 5% of kube-apiserver requests are failing"},
+			},
+			{
+				Alert:       "KubeAPIServerLatencyHigh",
+				Expr:        `histogram_quantile(0.99, sum by (le, verb) (rate(apiserver_request_duration_seconds_bucket{verb!~"WATCH|CONNECT"}[5m]))) > 1`,
+				For:         "10m",
+				Labels:      map[string]string{"severity": "warning"},
+				Annotations: map[string]string{"summary": "kube-apiserver {{ $labels.verb }} request p99 is above 1s"},
+			},
+			{
+				Alert:       "KubeClientCertificateExpiration",
+				Expr:        `apiserver_client_certificate_expiration_seconds_count > 0 and histogram_quantile(0.01, sum by (le) (rate(apiserver_client_certificate_expiration_seconds_bucket[5m]))) < 604800`,
+				Labels:      map[string]string{"severity": "warning"},
+				Annotations: map[string]string{"summary": "a client certificate used to authenticate to kube-apiserver expires in less than 7 days"},
+			},
+		},
+	},
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/model"
+	"github.com/ghodss/yaml"
+)
+
+func TestValidateAlertRules(t *testing.T) {
+	rules := []dto.AlertRule{{Alert: "A", Expr: "up == 0", For: "1h30m"}}
+	if err := validateAlertRules("30s", rules); err != nil {
+		t.Fatal(err)
+	}
+	if err := validateAlertRules("30 seconds", rules); err != errAlertRuleDuration {
+		t.Error("invalid interval should fail")
+	}
+	rules[0].For = "5"
+	if err := validateAlertRules("", rules); err != errAlertRuleDuration {
+		t.Error("invalid for should fail")
+	}
+	for _, group := range builtinAlertRuleGroups {
+		if err := validateAlertRules(group.Interval, group.Rules); err != nil {
+			t.Errorf("builtin group %s: %v", group.Name, err)
+		}
+	}
+}
+
+func TestRenderAlertRules(t *testing.T) {
+	var groups []dto.ClusterAlertRuleGroup
+	for _, builtin := range builtinAlertRuleGroups {
+		group := dto.ClusterAlertRuleGroup{Rules: builtin.Rules}
+		group.Name = builtin.Name
+		groups = append(groups, group)
+	}
+	buf, err := renderAlertRules(groups)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file struct {
+		Groups []struct {
+			Name  string                   `json:"name"`
+			Rules []map[string]interface{} `json:"rules"`
+		} `json:"groups"`
+	}
+	if err := yaml.Unmarshal(buf, &file); err != nil {
+		t.Fatal(err)
+	}
+	if len(file.Groups) != 3 || file.Groups[1].Name != "kmpp-etcd" {
+		t.Fatalf("unexpected groups %s", buf)
+	}
+	if _, ok := file.Groups[0].Rules[0]["for"]; !ok {
+		t.Errorf("rule should keep for: %v", file.Groups[0].Rules[0])
+	}
+	if empty, _ := renderAlertRules(nil); strings.TrimSpace(string(empty)) != "groups: []" {
+		t.Errorf("empty rule file should still be valid: %s", empty)
+	}
+}
+
+func TestBuildAlertmanagerConfig(t *testing.T) {
+	settings := map[string]map[string]string{
+		constant.Email:   {"SMTP_ADDRESS": "smtp.example.com", "SMTP_PORT": "465", "SMTP_USERNAME": "kmpp@example.com", "SMTP_PASSWORD": "pwd"},
+		constant.Webhook: {"WEBHOOK_URL": "https://hooks.example.com/kmpp"},
+	}
+	def := alertmanagerTarget{
+		Name:      constant.AlertmanagerReceiverDefault,
+		Receivers: map[string][]string{constant.Email: {"a@example.com", "b@example.com"}, constant.WorkWeiXin: {"a"}},
+		Webhook:   true,
+	}
+	routes := []alertmanagerTarget{{
+		Name:           "kmpp-route-oncall",
+		Severity:       alertmanagerSeverity(constant.MsgWarning),
+		Receivers:      map[string][]string{constant.Email: {"oncall@example.com"}},
+		RepeatInterval: 30,
+	}}
+	hook := alertmanagerWebhook{URL: "http://kmpp/api/v1/alertmanager/receive?cluster=demo", Token: "token"}
+	config := buildAlertmanagerConfig(settings, hook, def, routes)
+	if config.Global["smtp_smarthost"] != "smtp.example.com:465" {
+		t.Errorf("unexpected global %v", config.Global)
+	}
+	if _, ok := config.Global["wechat_api_corp_id"]; ok {
+		t.Error("disabled work weixin should not be configured")
+	}
+	if config.Route.Receiver != constant.AlertmanagerReceiverDefault || config.Route.RepeatInterval != "60m" {
+		t.Errorf("unexpected route %+v", config.Route)
+	}
+	if len(config.Receivers) != 2 {
+		t.Fatalf("unexpected receivers %+v", config.Receivers)
+	}
+	receiver := config.Receivers[0]
+	if len(receiver.EmailConfigs) != 1 || receiver.EmailConfigs[0]["to"] != "a@example.com,b@example.com" ||
+		len(receiver.WechatConfigs) != 0 || len(receiver.WebhookConfigs) != 1 {
+		t.Errorf("unexpected default receiver %+v", receiver)
+	}
+	// 告警发回 kmpp 后再按 Webhook 设置的格式、签名和请求头转发
+	if webhook := receiver.WebhookConfigs[0]; webhook["url"] != hook.URL || webhook["http_config"].(map[string]interface{})["bearer_token"] != "token" {
+		t.Errorf("unexpected webhook config %v", webhook)
+	}
+	route := config.Route.Routes[0]
+	if route.Receiver != "kmpp-route-oncall" || route.MatchRe["severity"] != "warning|critical" || route.RepeatInterval != "30m" {
+		t.Errorf("unexpected sub route %+v", route)
+	}
+	if len(config.Receivers[1].WebhookConfigs) != 0 {
+		t.Error("route without webhook send type should not get webhook config")
+	}
+}
+
+func TestAlertmanagerEvents(t *testing.T) {
+	payload := dto.AlertmanagerWebhook{Alerts: []dto.AlertmanagerAlert{
+		{
+			Status:      "firing",
+			Labels:      map[string]string{"alertname": "NodeDown", "severity": "critical", "instance": "10.0.0.1"},
+			Annotations: map[string]string{"summary": "node 10.0.0.1 is down"},
+			Fingerprint: "a1",
+		},
+		{
+			Status:      "resolved",
+			Labels:      map[string]string{"alertname": "PodRestart", "severity": "info"},
+			Annotations: map[string]string{"description": "pod restarted"},
+			EndsAt:      time.Unix(1600000000, 0),
+			Fingerprint: "b2",
+		},
+	}}
+	events := alertmanagerEvents(payload, "demo", "default")
+	if len(events) != 2 {
+		t.Fatalf("unexpected events %+v", events)
+	}
+	if e := events[0]; e.Title != "NodeDown" || e.Level != constant.MsgWarning || e.Message != "node 10.0.0.1 is down" || e.Cluster != "demo" || e.Detail["instance"] != "10.0.0.1" {
+		t.Errorf("unexpected firing event %+v", e)
+	}
+	if e := events[1]; e.Level != constant.MsgInfo || e.Message != "pod restarted" || e.Detail["status"] != "resolved" || !e.Timestamp.Equal(time.Unix(1600000000, 0)) {
+		t.Errorf("unexpected resolved event %+v", e)
+	}
+}
+
+func TestAlertmanagerToken(t *testing.T) {
+	a, b := alertmanagerToken(model.Cluster{ID: "1"}), alertmanagerToken(model.Cluster{ID: "2"})
+	if a == "" || a == b || a != alertmanagerToken(model.Cluster{ID: "1"}) {
+		t.Errorf("token should be stable and differ between clusters: %s %s", a, b)
+	}
+}
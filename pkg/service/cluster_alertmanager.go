@@ -0,0 +1,327 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/util/message/client"
+	"github.com/ghodss/yaml"
+	"github.com/spf13/viper"
+)
+
+// Alertmanager 配置由 kmpp 的通知设置生成：邮件和企业微信使用系统设置，
+// Webhook 发回 kmpp 后按系统设置的格式、签名和请求头转发，
+// 默认接收者为订阅了集群事件告警的项目成员和管理员，匹配该集群的告警路由转换为子路由。
+// 钉钉没有 Alertmanager 原生的接收方式，不会生成。
+
+var errAlertmanagerTokenInvalid = errors.New("ALERTMANAGER_TOKEN_INVALID")
+
+const alertmanagerReceivePath = "/api/v1/alertmanager/receive"
+
+type alertmanagerConfig struct {
+	Global    map[string]interface{} `json:"global,omitempty"`
+	Route     alertmanagerRoute      `json:"route"`
+	Receivers []alertmanagerReceiver `json:"receivers"`
+}
+
+type alertmanagerRoute struct {
+	Receiver       string              `json:"receiver"`
+	GroupBy        []string            `json:"group_by,omitempty"`
+	GroupWait      string              `json:"group_wait,omitempty"`
+	GroupInterval  string              `json:"group_interval,omitempty"`
+	RepeatInterval string              `json:"repeat_interval,omitempty"`
+	MatchRe        map[string]string   `json:"match_re,omitempty"`
+	Routes         []alertmanagerRoute `json:"routes,omitempty"`
+}
+
+type alertmanagerReceiver struct {
+	Name           string                   `json:"name"`
+	EmailConfigs   []map[string]interface{} `json:"email_configs,omitempty"`
+	WechatConfigs  []map[string]interface{} `json:"wechat_configs,omitempty"`
+	WebhookConfigs []map[string]interface{} `json:"webhook_configs,omitempty"`
+}
+
+// alertmanagerWebhook kmpp 接收告警的地址，Token 按集群生成，用于校验请求来源
+type alertmanagerWebhook struct {
+	URL   string
+	Token string
+}
+
+// alertmanagerTarget 一组接收人，Receivers 的 key 为发送方式，Severity 为空时匹配所有级别
+type alertmanagerTarget struct {
+	Name           string
+	Severity       string
+	Receivers      map[string][]string
+	Webhook        bool
+	RepeatInterval int
+}
+
+// buildAlertmanagerConfig settings 的 key 为已启用的发送方式，value 为对应的系统设置
+func buildAlertmanagerConfig(settings map[string]map[string]string, hook alertmanagerWebhook, def alertmanagerTarget, routes []alertmanagerTarget) alertmanagerConfig {
+	config := alertmanagerConfig{Global: map[string]interface{}{"resolve_timeout": "5m"}}
+	if email, ok := settings[constant.Email]; ok {
+		config.Global["smtp_smarthost"] = email["SMTP_ADDRESS"] + ":" + email["SMTP_PORT"]
+		config.Global["smtp_from"] = email["SMTP_USERNAME"]
+		config.Global["smtp_auth_username"] = email["SMTP_USERNAME"]
+		config.Global["smtp_auth_password"] = email["SMTP_PASSWORD"]
+	}
+	if weixin, ok := settings[constant.WorkWeiXin]; ok {
+		config.Global["wechat_api_corp_id"] = weixin["WORK_WEIXIN_CORP_ID"]
+		config.Global["wechat_api_secret"] = weixin["WORK_WEIXIN_CORP_SECRET"]
+	}
+	receiver := func(target alertmanagerTarget) alertmanagerReceiver {
+		r := alertmanagerReceiver{Name: target.Name}
+		if _, ok := settings[constant.Email]; ok && len(target.Receivers[constant.Email]) > 0 {
+			r.EmailConfigs = append(r.EmailConfigs, map[string]interface{}{
+				"to":            strings.Join(target.Receivers[constant.Email], ","),
+				"send_resolved": true,
+			})
+		}
+		if weixin, ok := settings[constant.WorkWeiXin]; ok && len(target.Receivers[constant.WorkWeiXin]) > 0 {
+			r.WechatConfigs = append(r.WechatConfigs, map[string]interface{}{
+				"agent_id":      weixin["WORK_WEIXIN_AGENT_ID"],
+				"to_user":       strings.Join(target.Receivers[constant.WorkWeiXin], "|"),
+				"send_resolved": true,
+			})
+		}
+		if _, ok := settings[constant.Webhook]; ok && target.Webhook && hook.URL != "" {
+			r.WebhookConfigs = append(r.WebhookConfigs, map[string]interface{}{
+				"url":           hook.URL,
+				"send_resolved": true,
+				"http_config":   map[string]interface{}{"bearer_token": hook.Token},
+			})
+		}
+		return r
+	}
+	repeat := func(minutes int) string {
+		if minutes <= 0 {
+			minutes = constant.AlertDefaultRepeatInterval
+		}
+		return fmt.Sprintf("%dm", minutes)
+	}
+
+	config.Route = alertmanagerRoute{
+		Receiver:       def.Name,
+		GroupBy:        []string{"alertname", "instance"},
+		GroupWait:      "30s",
+		GroupInterval:  "5m",
+		RepeatInterval: repeat(def.RepeatInterval),
+	}
+	config.Receivers = append(config.Receivers, receiver(def))
+	for _, target := range routes {
+		route := alertmanagerRoute{Receiver: target.Name, RepeatInterval: repeat(target.RepeatInterval)}
+		if target.Severity != "" {
+			route.MatchRe = map[string]string{"severity": target.Severity}
+		}
+		config.Route.Routes = append(config.Route.Routes, route)
+		config.Receivers = append(config.Receivers, receiver(target))
+	}
+	return config
+}
+
+// alertmanagerSeverity 将告警路由的消息级别转换为 Prometheus 规则的 severity 标签
+func alertmanagerSeverity(level string) string {
+	switch level {
+	case constant.MsgWarning:
+		return "warning|critical"
+	case constant.MsgInfo:
+		return "info|none"
+	}
+	return ""
+}
+
+// renderAlertmanagerConfig 根据当前的通知设置生成集群的 alertmanager.yml
+func renderAlertmanagerConfig(cluster model.Cluster) ([]byte, error) {
+	m := NewMessageService().(*messageService)
+	settings := make(map[string]map[string]string)
+	for _, tab := range []string{constant.Email, constant.WorkWeiXin, constant.Webhook} {
+		setting, err := m.systemSettingService.ListByTab(tab)
+		if err != nil {
+			return nil, err
+		}
+		if setting.Vars != nil && setting.Vars[tab+"_STATUS"] == constant.Enable {
+			settings[tab] = setting.Vars
+		}
+	}
+
+	def := alertmanagerTarget{Name: constant.AlertmanagerReceiverDefault, Receivers: map[string][]string{}, Webhook: true}
+	userIds, err := m.clusterUserIds(cluster.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, userId := range userIds {
+		receiver, _ := m.GetUserReceiver(userId)
+		if receiver == nil {
+			continue
+		}
+		for _, sendType := range m.getUserSendTypes(userId, constant.ClusterEventWarning) {
+			if receiver.Vars[sendType] != "" {
+				def.Receivers[sendType] = append(def.Receivers[sendType], receiver.Vars[sendType])
+			}
+		}
+	}
+
+	routes, err := NewAlertService().ListRoutes()
+	if err != nil {
+		return nil, err
+	}
+	labels := alertLabels{ClusterName: cluster.Name, ProjectName: m.getProjectName(cluster.ID), MessageType: constant.PrometheusAlert}
+	var targets []alertmanagerTarget
+	for _, route := range routes {
+		if !alertMatch(labels, route.ClusterName, route.ProjectName, route.MessageType, "") {
+			continue
+		}
+		target := alertmanagerTarget{
+			Name:           "kmpp-route-" + route.Name,
+			Severity:       alertmanagerSeverity(route.Level),
+			Receivers:      map[string][]string{},
+			Webhook:        hasSendType(route.SendTypes, constant.Webhook),
+			RepeatInterval: route.RepeatInterval,
+		}
+		for _, name := range route.Users {
+			user, err := m.userRepo.Get(name)
+			if err != nil {
+				continue
+			}
+			receiver, _ := m.GetUserReceiver(user.ID)
+			if receiver == nil {
+				continue
+			}
+			for _, sendType := range route.SendTypes {
+				if receiver.Vars[sendType] != "" {
+					target.Receivers[sendType] = append(target.Receivers[sendType], receiver.Vars[sendType])
+				}
+			}
+		}
+		targets = append(targets, target)
+	}
+	hook := alertmanagerWebhook{URL: alertmanagerReceiverURL(cluster), Token: alertmanagerToken(cluster)}
+	return yaml.Marshal(buildAlertmanagerConfig(settings, hook, def, targets))
+}
+
+// alertmanagerReceiverURL 集群访问 kmpp 的地址，未配置时使用仓库地址，默认部署中仓库与 kmpp 在同一台主机
+func alertmanagerReceiverURL(cluster model.Cluster) string {
+	base := strings.TrimRight(viper.GetString("alertmanager.receiver_url"), "/")
+	if base == "" {
+		registry, err := model.GetActiveRegistry(model.RegistryArchitecture(cluster.Spec.Architectures))
+		if err != nil {
+			return ""
+		}
+		base = "http://" + registry.Hostname + alertmanagerReceivePath
+	}
+	return base + "?cluster=" + url.QueryEscape(cluster.Name)
+}
+
+// alertmanagerToken 以 jwt 密钥对集群 ID 计算 HMAC，不需要单独保存
+func alertmanagerToken(cluster model.Cluster) string {
+	h := hmac.New(sha256.New, []byte(viper.GetString("jwt.secret")+":alertmanager"))
+	h.Write([]byte(cluster.ID))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// alertmanagerEvents 将 Alertmanager 推送的告警转换为 Webhook 事件，级别按 severity 标签区分
+func alertmanagerEvents(payload dto.AlertmanagerWebhook, clusterName, projectName string) []client.WebhookEvent {
+	var events []client.WebhookEvent
+	for _, alert := range payload.Alerts {
+		detail := map[string]string{"status": alert.Status}
+		for k, v := range alert.Labels {
+			detail[k] = v
+		}
+		for k, v := range alert.Annotations {
+			detail[k] = v
+		}
+		level := constant.MsgInfo
+		if severity := alert.Labels["severity"]; severity == "warning" || severity == "critical" {
+			level = constant.MsgWarning
+		}
+		message := alert.Annotations["summary"]
+		if message == "" {
+			message = alert.Annotations["description"]
+		}
+		timestamp := alert.StartsAt
+		if alert.Status == "resolved" && !alert.EndsAt.IsZero() {
+			timestamp = alert.EndsAt
+		}
+		events = append(events, client.WebhookEvent{
+			ID:        alert.Fingerprint,
+			Event:     constant.PrometheusAlert,
+			Category:  constant.Cluster,
+			Level:     level,
+			Title:     alert.Labels["alertname"],
+			Project:   projectName,
+			Cluster:   clusterName,
+			Message:   message,
+			Detail:    detail,
+			Timestamp: timestamp,
+		})
+	}
+	return events
+}
+
+// ReceiveAlertmanagerWebhook 校验集群 Token 后通过 Webhook 客户端转发告警，发送失败的重试在后台进行
+func ReceiveAlertmanagerWebhook(clusterName, token string, payload dto.AlertmanagerWebhook) error {
+	var cluster model.Cluster
+	if err := db.DB.Where("name = ?", clusterName).First(&cluster).Error; err != nil {
+		return errAlertmanagerTokenInvalid
+	}
+	if !hmac.Equal([]byte(token), []byte(alertmanagerToken(cluster))) {
+		return errAlertmanagerTokenInvalid
+	}
+	m := NewMessageService().(*messageService)
+	for _, event := range alertmanagerEvents(payload, cluster.Name, m.getProjectName(cluster.ID)) {
+		go m.SendWebhookMessage(event)
+	}
+	return nil
+}
+
+// refreshAlertmanagerConfigs 通知设置或告警路由变化后，重新下发所有开启了 Alertmanager 的集群配置
+func refreshAlertmanagerConfigs() {
+	var tools []model.ClusterTool
+	if err := db.DB.Where("name = ? AND status = ?", "prometheus", constant.ClusterRunning).Find(&tools).Error; err != nil {
+		logger.Log.Errorf("list prometheus tools failed: %s", err.Error())
+		return
+	}
+	ruleService := NewClusterAlertRuleService()
+	for _, tool := range tools {
+		var cluster model.Cluster
+		if err := db.DB.Where("id = ?", tool.ClusterID).First(&cluster).Error; err != nil {
+			continue
+		}
+		if err := ruleService.Apply(cluster.Name); err != nil {
+			logger.Log.Errorf("refresh alertmanager config of cluster %s failed: %s", cluster.Name, err.Error())
+		}
+	}
+}
+
+// clusterUserIds 返回集群所属项目的成员和管理员
+func (m messageService) clusterUserIds(clusterId string) ([]string, error) {
+	var userIds []string
+	proResources, err := m.projectResourceRepo.ListByResourceIDAndType(clusterId, constant.ResourceCluster)
+	if err != nil {
+		return nil, err
+	}
+	if len(proResources) != 0 {
+		members, err := m.projectMemberRepo.ListByProjectId(proResources[0].ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			userIds = append(userIds, member.UserID)
+		}
+	}
+	admins, _ := m.userRepo.ListIsAdmin()
+	for _, admin := range admins {
+		userIds = append(userIds, admin.ID)
+	}
+	return userIds, nil
+}
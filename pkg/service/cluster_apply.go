@@ -0,0 +1,570 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/errorf"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/repository"
+)
+
+// ClusterApplyService 对比声明式的集群描述与数据库中的状态，并调用已有的服务使其一致
+type ClusterApplyService interface {
+	Diff(apply dto.ClusterApply) (*dto.ClusterApplyDiff, error)
+	Apply(apply dto.ClusterApply) (*dto.ClusterApplyDiff, error)
+}
+
+func NewClusterApplyService() ClusterApplyService {
+	return &clusterApplyService{
+		clusterRepo:           repository.NewClusterRepository(),
+		backupStrategyRepo:    repository.NewClusterBackupStrategyRepository(),
+		provisionerRepo:       repository.NewClusterStorageProvisionerRepository(),
+		nodeService:           NewClusterNodeService(),
+		upgradeService:        NewClusterUpgradeService(),
+		toolService:           NewClusterToolService(),
+		istioService:          NewClusterIstioService(),
+		provisionerService:    NewClusterStorageProvisionerService(),
+		backupStrategyService: NewCLusterBackupStrategyService(),
+	}
+}
+
+type clusterApplyService struct {
+	clusterRepo           repository.ClusterRepository
+	backupStrategyRepo    repository.ClusterBackupStrategyRepository
+	provisionerRepo       repository.ClusterStorageProvisionerRepository
+	nodeService           ClusterNodeService
+	upgradeService        ClusterUpgradeService
+	toolService           ClusterToolService
+	istioService          ClusterIstioService
+	provisionerService    ClusterStorageProvisionerService
+	backupStrategyService CLusterBackupStrategyService
+}
+
+// clusterApplyPlan 由 diff 得出的需要调用的操作
+type clusterApplyPlan struct {
+	diff               dto.ClusterApplyDiff
+	upgradeVersion     string
+	masters            int
+	addHosts           []string
+	increase           int
+	deleteNodes        []string
+	addMasters         []string
+	deleteMasters      []string
+	enableTools        []dto.ClusterTool
+	disableTools       []dto.ClusterTool
+	enableIstios       []dto.ClusterIstio
+	disableIstios      []dto.ClusterIstio
+	createProvisioners []dto.ClusterStorageProvisionerCreation
+	deleteProvisioners []string
+	backupStrategy     *dto.ClusterBackupStrategyRequest
+}
+
+func (p *clusterApplyPlan) add(kind, name, action, current, desired string) {
+	p.diff.Changes = append(p.diff.Changes, dto.ClusterApplyChange{
+		Kind:    kind,
+		Name:    name,
+		Action:  action,
+		Current: current,
+		Desired: desired,
+	})
+}
+
+func (c clusterApplyService) Diff(apply dto.ClusterApply) (*dto.ClusterApplyDiff, error) {
+	p, err := c.plan(apply)
+	if err != nil {
+		return nil, err
+	}
+	return &p.diff, nil
+}
+
+// mark 更新 kind 类型中 names 对应变更的执行状态，names 为空时更新该类型的全部变更
+func (p *clusterApplyPlan) mark(status string, kind string, names ...string) {
+	for i := range p.diff.Changes {
+		change := &p.diff.Changes[i]
+		if change.Kind != kind || change.Action == constant.ClusterApplyActionUnsupported {
+			continue
+		}
+		match := len(names) == 0
+		for _, name := range names {
+			if change.Name == name {
+				match = true
+			}
+		}
+		if match {
+			change.Status = status
+		}
+	}
+}
+
+// clusterOperations 升级、增删 worker 和增删 master 都会占用集群的变更锁，一次只能执行其中一项
+func (p *clusterApplyPlan) clusterOperations() int {
+	count := 0
+	for _, ok := range []bool{
+		p.upgradeVersion != "",
+		len(p.addHosts) > 0 || p.increase > 0,
+		len(p.deleteNodes) > 0,
+		len(p.addMasters) > 0,
+		len(p.deleteMasters) > 0,
+	} {
+		if ok {
+			count++
+		}
+	}
+	return count
+}
+
+// check 执行前检查所有变更均可执行，避免部分变更已生效后才失败
+func (p *clusterApplyPlan) check() error {
+	for _, change := range p.diff.Changes {
+		if change.Action == constant.ClusterApplyActionUnsupported {
+			return errors.New("CLUSTER_APPLY_UNSUPPORTED_CHANGE")
+		}
+	}
+	if p.clusterOperations() > 1 {
+		return errors.New("CLUSTER_APPLY_OPERATION_CONFLICT")
+	}
+	if len(p.addMasters) > 0 || len(p.deleteMasters) > 0 {
+		return checkMasterCount(p.masters, len(p.addMasters), len(p.deleteMasters))
+	}
+	return nil
+}
+
+// Apply 先检查所有变更均可执行，再依次更新备份策略、存储供应商、工具、Istio，最后执行升级或节点变更。
+// 中途失败时返回每个变更的执行状态，错误信息中列出已生效的变更
+func (c clusterApplyService) Apply(apply dto.ClusterApply) (*dto.ClusterApplyDiff, error) {
+	p, err := c.plan(apply)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.check(); err != nil {
+		return &p.diff, err
+	}
+	for i := range p.diff.Changes {
+		p.diff.Changes[i].Status = constant.ClusterApplyStatusPending
+	}
+
+	type step struct {
+		kind  string
+		names []string
+		run   func() error
+	}
+	var steps []step
+	if p.backupStrategy != nil {
+		steps = append(steps, step{constant.ClusterApplyKindBackupStrategy, nil, func() error {
+			_, err := c.backupStrategyService.Save(*p.backupStrategy)
+			return err
+		}})
+	}
+	for _, name := range p.deleteProvisioners {
+		name := name
+		steps = append(steps, step{constant.ClusterApplyKindProvisioner, []string{name}, func() error {
+			return c.provisionerService.DeleteStorageProvisioner(apply.Name, name)
+		}})
+	}
+	for _, creation := range p.createProvisioners {
+		creation := creation
+		steps = append(steps, step{constant.ClusterApplyKindProvisioner, []string{creation.Name}, func() error {
+			_, err := c.provisionerService.CreateStorageProvisioner(apply.Name, creation)
+			return err
+		}})
+	}
+	for _, tool := range p.disableTools {
+		tool := tool
+		steps = append(steps, step{constant.ClusterApplyKindTool, []string{tool.Name}, func() error {
+			_, err := c.toolService.Disable(apply.Name, tool)
+			return err
+		}})
+	}
+	for _, tool := range p.enableTools {
+		tool := tool
+		steps = append(steps, step{constant.ClusterApplyKindTool, []string{tool.Name}, func() error {
+			_, err := c.toolService.Enable(apply.Name, tool)
+			return err
+		}})
+	}
+	if len(p.disableIstios) > 0 {
+		steps = append(steps, step{constant.ClusterApplyKindIstio, istioNames(p.disableIstios), func() error {
+			_, err := c.istioService.Disable(apply.Name, p.disableIstios)
+			return err
+		}})
+	}
+	if len(p.enableIstios) > 0 {
+		steps = append(steps, step{constant.ClusterApplyKindIstio, istioNames(p.enableIstios), func() error {
+			_, err := c.istioService.Enable(apply.Name, p.enableIstios)
+			return err
+		}})
+	}
+	if p.upgradeVersion != "" {
+		steps = append(steps, step{constant.ClusterApplyKindSpec, nil, func() error {
+			return c.upgradeService.Upgrade(dto.ClusterUpgrade{ClusterName: apply.Name, Version: p.upgradeVersion})
+		}})
+	}
+	for _, batch := range []dto.NodeBatch{
+		{Operation: constant.BatchOperationDelete, Nodes: p.deleteNodes},
+		{Operation: constant.BatchOperationCreate, Hosts: p.addHosts, Increase: p.increase},
+		{Operation: constant.BatchOperationDelete, Role: constant.NodeRoleNameMaster, Nodes: p.deleteMasters},
+		{Operation: constant.BatchOperationCreate, Role: constant.NodeRoleNameMaster, Hosts: p.addMasters},
+	} {
+		if len(batch.Nodes) == 0 && len(batch.Hosts) == 0 && batch.Increase == 0 {
+			continue
+		}
+		batch := batch
+		steps = append(steps, step{constant.ClusterApplyKindNode, nil, func() error {
+			return c.nodeService.Batch(apply.Name, batch)
+		}})
+	}
+
+	var applied []string
+	for _, s := range steps {
+		if err := s.run(); err != nil {
+			p.mark(constant.ClusterApplyStatusFailed, s.kind, s.names...)
+			if len(applied) == 0 {
+				return &p.diff, err
+			}
+			return &p.diff, errorf.CErrFs{errorf.New("CLUSTER_APPLY_PARTIALLY_APPLIED", strings.Join(applied, ", "), err.Error())}
+		}
+		p.mark(constant.ClusterApplyStatusApplied, s.kind, s.names...)
+		if len(s.names) == 0 {
+			applied = append(applied, s.kind)
+		} else {
+			applied = append(applied, s.kind+"/"+strings.Join(s.names, ","))
+		}
+	}
+	return &p.diff, nil
+}
+
+func istioNames(istios []dto.ClusterIstio) []string {
+	var names []string
+	for _, istio := range istios {
+		names = append(names, istio.ClusterIstio.Name)
+	}
+	return names
+}
+
+func (c clusterApplyService) plan(apply dto.ClusterApply) (*clusterApplyPlan, error) {
+	cluster, err := c.clusterRepo.Get(apply.Name)
+	if err != nil {
+		return nil, err
+	}
+	if cluster.Source != constant.ClusterSourceLocal {
+		return nil, errors.New("CLUSTER_IS_NOT_LOCAL")
+	}
+	p := &clusterApplyPlan{diff: dto.ClusterApplyDiff{Cluster: cluster.Name}}
+	diffClusterSpec(p, cluster.Spec, apply.Spec)
+	if len(apply.Nodes) > 0 {
+		if err := diffClusterNodes(p, cluster, apply.Nodes); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.diffTools(p, apply); err != nil {
+		return nil, err
+	}
+	if err := c.diffIstios(p, apply); err != nil {
+		return nil, err
+	}
+	if err := c.diffProvisioners(p, apply); err != nil {
+		return nil, err
+	}
+	if err := c.diffBackupStrategy(p, apply); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// diffClusterSpec 版本变化通过升级集群完成，其它字段在集群创建后不能再修改，只列出与文档不一致的字段
+func diffClusterSpec(p *clusterApplyPlan, current model.ClusterSpec, desired map[string]interface{}) {
+	currentMap := map[string]interface{}{}
+	buf, _ := json.Marshal(&current)
+	_ = json.Unmarshal(buf, &currentMap)
+	var keys []string
+	for k := range desired {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		cv, ok := currentMap[k]
+		if !ok {
+			p.add(constant.ClusterApplyKindSpec, k, constant.ClusterApplyActionUnsupported, "", fmt.Sprint(desired[k]))
+			continue
+		}
+		if fmt.Sprint(cv) == fmt.Sprint(desired[k]) {
+			continue
+		}
+		if version, ok := desired[k].(string); ok && k == "version" && version != "" {
+			p.upgradeVersion = version
+			p.add(constant.ClusterApplyKindSpec, k, constant.ClusterApplyActionUpgrade, fmt.Sprint(cv), version)
+			continue
+		}
+		p.add(constant.ClusterApplyKindSpec, k, constant.ClusterApplyActionUnsupported, fmt.Sprint(cv), fmt.Sprint(desired[k]))
+	}
+}
+
+// diffClusterNodes 手动模式按主机名对比节点，自动模式按节点名对比 worker，未指定名称的节点视为新增。
+// master 只能在手动模式下增删，节点的角色不能修改
+func diffClusterNodes(p *clusterApplyPlan, cluster model.Cluster, desired []dto.ClusterApplyNode) error {
+	bareMetal := cluster.Spec.Provider == constant.ClusterProviderBareMetal
+	key := func(name, host string) string {
+		if bareMetal {
+			return host
+		}
+		return name
+	}
+	current := map[string]model.ClusterNode{}
+	for _, n := range cluster.Nodes {
+		current[key(n.Name, n.Host.Name)] = n
+		if n.Role == constant.NodeRoleNameMaster {
+			p.masters++
+		}
+	}
+	wanted := map[string]dto.ClusterApplyNode{}
+	for _, n := range desired {
+		if bareMetal && n.Host == "" {
+			return errors.New("CLUSTER_APPLY_NODE_HOST_REQUIRED")
+		}
+		k := key(n.Name, n.Host)
+		if k != "" {
+			wanted[k] = n
+		}
+		cn, ok := current[k]
+		if ok && cn.Role == n.Role {
+			continue
+		}
+		if ok || (n.Role == constant.NodeRoleNameMaster && !bareMetal) {
+			p.add(constant.ClusterApplyKindNode, k, constant.ClusterApplyActionUnsupported, cn.Role, n.Role)
+			continue
+		}
+		switch {
+		case n.Role == constant.NodeRoleNameMaster:
+			p.addMasters = append(p.addMasters, n.Host)
+		case bareMetal:
+			p.addHosts = append(p.addHosts, n.Host)
+		default:
+			p.increase++
+		}
+		p.add(constant.ClusterApplyKindNode, k, constant.ClusterApplyActionCreate, "", n.Role)
+	}
+	for _, n := range cluster.Nodes {
+		k := key(n.Name, n.Host.Name)
+		if _, ok := wanted[k]; ok {
+			continue
+		}
+		switch {
+		case n.Role != constant.NodeRoleNameMaster:
+			p.deleteNodes = append(p.deleteNodes, n.Name)
+		case bareMetal:
+			p.deleteMasters = append(p.deleteMasters, n.Name)
+		default:
+			p.add(constant.ClusterApplyKindNode, k, constant.ClusterApplyActionUnsupported, n.Role, "")
+			continue
+		}
+		p.add(constant.ClusterApplyKindNode, k, constant.ClusterApplyActionDelete, n.Role, "")
+	}
+	return nil
+}
+
+func (c clusterApplyService) diffTools(p *clusterApplyPlan, apply dto.ClusterApply) error {
+	if len(apply.Tools) == 0 {
+		return nil
+	}
+	tools, err := c.toolService.List(apply.Name)
+	if err != nil {
+		return err
+	}
+	for _, d := range apply.Tools {
+		var tool *dto.ClusterTool
+		for i := range tools {
+			if tools[i].Name == d.Name {
+				tool = &tools[i]
+				break
+			}
+		}
+		if tool == nil {
+			p.add(constant.ClusterApplyKindTool, d.Name, constant.ClusterApplyActionUnsupported, "", enableState(d.Enable))
+			continue
+		}
+		if d.Vars != nil {
+			tool.Vars = d.Vars
+		}
+		switch {
+		case d.Enable && !isComponentEnabled(tool.Status):
+			p.enableTools = append(p.enableTools, *tool)
+			p.add(constant.ClusterApplyKindTool, d.Name, constant.ClusterApplyActionEnable, tool.Status, enableState(d.Enable))
+		case !d.Enable && isComponentActive(tool.Status):
+			p.disableTools = append(p.disableTools, *tool)
+			p.add(constant.ClusterApplyKindTool, d.Name, constant.ClusterApplyActionDisable, tool.Status, enableState(d.Enable))
+		}
+	}
+	return nil
+}
+
+func (c clusterApplyService) diffIstios(p *clusterApplyPlan, apply dto.ClusterApply) error {
+	if len(apply.Istios) == 0 {
+		return nil
+	}
+	istios, err := c.istioService.List(apply.Name)
+	if err != nil {
+		return err
+	}
+	var base *dto.ClusterIstio
+	baseEnabled := false
+	for i := range istios {
+		if istios[i].ClusterIstio.Name == "base" {
+			base = &istios[i]
+			baseEnabled = isComponentEnabled(base.ClusterIstio.Status)
+		}
+	}
+	for _, d := range apply.Istios {
+		var istio *dto.ClusterIstio
+		for i := range istios {
+			if istios[i].ClusterIstio.Name == d.Name {
+				istio = &istios[i]
+				break
+			}
+		}
+		if istio == nil {
+			p.add(constant.ClusterApplyKindIstio, d.Name, constant.ClusterApplyActionUnsupported, "", enableState(d.Enable))
+			continue
+		}
+		if d.Vars != nil {
+			istio.Vars = d.Vars
+		}
+		status := istio.ClusterIstio.Status
+		switch {
+		case d.Enable && !isComponentEnabled(status):
+			istio.Operation = constant.ClusterApplyActionEnable
+			istio.Enable = true
+			if d.Name == "base" {
+				baseEnabled = true
+			}
+			p.enableIstios = append(p.enableIstios, *istio)
+			p.add(constant.ClusterApplyKindIstio, d.Name, constant.ClusterApplyActionEnable, status, enableState(d.Enable))
+		case !d.Enable && isComponentActive(status):
+			istio.Operation = constant.ClusterApplyActionDisable
+			p.disableIstios = append(p.disableIstios, *istio)
+			p.add(constant.ClusterApplyKindIstio, d.Name, constant.ClusterApplyActionDisable, status, enableState(d.Enable))
+		}
+	}
+	// 其它组件依赖 base，需先启用 base
+	if len(p.enableIstios) > 0 && !baseEnabled && base != nil {
+		b := *base
+		b.Operation = constant.ClusterApplyActionEnable
+		b.Enable = true
+		p.enableIstios = append([]dto.ClusterIstio{b}, p.enableIstios...)
+		p.add(constant.ClusterApplyKindIstio, b.ClusterIstio.Name, constant.ClusterApplyActionEnable, b.ClusterIstio.Status, enableState(true))
+	}
+	return nil
+}
+
+func (c clusterApplyService) diffProvisioners(p *clusterApplyPlan, apply dto.ClusterApply) error {
+	if len(apply.Provisioners) == 0 && !apply.Prune {
+		return nil
+	}
+	current, err := c.provisionerRepo.List(apply.Name)
+	if err != nil {
+		return err
+	}
+	wanted := map[string]interface{}{}
+	for _, d := range apply.Provisioners {
+		wanted[d.Name] = nil
+		var exist *model.ClusterStorageProvisioner
+		for i := range current {
+			if current[i].Name == d.Name {
+				exist = &current[i]
+				break
+			}
+		}
+		if exist == nil {
+			p.createProvisioners = append(p.createProvisioners, dto.ClusterStorageProvisionerCreation{
+				Name: d.Name,
+				Type: d.Type,
+				Vars: d.Vars,
+			})
+			p.add(constant.ClusterApplyKindProvisioner, d.Name, constant.ClusterApplyActionCreate, "", d.Type)
+			continue
+		}
+		if exist.Type != d.Type {
+			p.add(constant.ClusterApplyKindProvisioner, d.Name, constant.ClusterApplyActionUnsupported, exist.Type, d.Type)
+		}
+	}
+	if apply.Prune {
+		for _, cp := range current {
+			if _, ok := wanted[cp.Name]; !ok {
+				p.deleteProvisioners = append(p.deleteProvisioners, cp.Name)
+				p.add(constant.ClusterApplyKindProvisioner, cp.Name, constant.ClusterApplyActionDelete, cp.Type, "")
+			}
+		}
+	}
+	return nil
+}
+
+func (c clusterApplyService) diffBackupStrategy(p *clusterApplyPlan, apply dto.ClusterApply) error {
+	d := apply.BackupStrategy
+	if d == nil {
+		return nil
+	}
+	current, err := c.backupStrategyRepo.Get(apply.Name)
+	if err != nil {
+		return err
+	}
+	currentState := ""
+	action := constant.ClusterApplyActionCreate
+	if current.ID != "" {
+		action = constant.ClusterApplyActionUpdate
+		currentState = backupStrategyState(dto.ClusterApplyBackupStrategy{
+			Cron:              current.Cron,
+			SaveNum:           current.SaveNum,
+			RetainHourly:      current.RetainHourly,
+			RetainDaily:       current.RetainDaily,
+			RetainWeekly:      current.RetainWeekly,
+			VerifyCron:        current.VerifyCron,
+			BackupAccountName: current.BackupAccount.Name,
+			Status:            current.Status,
+		})
+	}
+	desiredState := backupStrategyState(*d)
+	if currentState == desiredState {
+		return nil
+	}
+	p.backupStrategy = &dto.ClusterBackupStrategyRequest{
+		ID:                current.ID,
+		Cron:              d.Cron,
+		SaveNum:           d.SaveNum,
+		RetainHourly:      d.RetainHourly,
+		RetainDaily:       d.RetainDaily,
+		RetainWeekly:      d.RetainWeekly,
+		VerifyCron:        d.VerifyCron,
+		BackupAccountName: d.BackupAccountName,
+		ClusterName:       apply.Name,
+		Status:            d.Status,
+	}
+	p.add(constant.ClusterApplyKindBackupStrategy, apply.Name, action, currentState, desiredState)
+	return nil
+}
+
+func backupStrategyState(s dto.ClusterApplyBackupStrategy) string {
+	return fmt.Sprintf("cron=%s saveNum=%d retain=%d/%d/%d verifyCron=%s backupAccount=%s status=%s",
+		s.Cron, s.SaveNum, s.RetainHourly, s.RetainDaily, s.RetainWeekly, s.VerifyCron, s.BackupAccountName, s.Status)
+}
+
+func enableState(enable bool) string {
+	if enable {
+		return constant.ClusterApplyActionEnable
+	}
+	return constant.ClusterApplyActionDisable
+}
+
+// isComponentEnabled 已启用或正在启用
+func isComponentEnabled(status string) bool {
+	return status == constant.ClusterRunning || status == constant.ClusterInitializing || status == constant.ClusterUpgrading
+}
+
+// isComponentActive 未处于停用状态
+func isComponentActive(status string) bool {
+	return status != "" && status != constant.ClusterWaiting && status != constant.ClusterTerminating && status != constant.ClusterTerminated
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/model"
+)
+
+func TestDiffClusterSpec(t *testing.T) {
+	p := &clusterApplyPlan{}
+	diffClusterSpec(p, model.ClusterSpec{KubeApiServerPort: 8443, RuntimeType: "docker"}, map[string]interface{}{
+		"kubeApiServerPort": float64(8443),
+		"runtimeType":       "containerd",
+	})
+	if len(p.diff.Changes) != 1 || p.upgradeVersion != "" {
+		t.Fatalf("unexpected changes %v", p.diff.Changes)
+	}
+	change := p.diff.Changes[0]
+	if change.Name != "runtimeType" || change.Action != constant.ClusterApplyActionUnsupported || change.Current != "docker" {
+		t.Errorf("unexpected change %v", change)
+	}
+
+	p = &clusterApplyPlan{}
+	diffClusterSpec(p, model.ClusterSpec{Version: "v1.18.6"}, map[string]interface{}{"version": "v1.20.6"})
+	if len(p.diff.Changes) != 1 || p.diff.Changes[0].Action != constant.ClusterApplyActionUpgrade || p.upgradeVersion != "v1.20.6" {
+		t.Errorf("version change should upgrade the cluster %v", p.diff.Changes)
+	}
+}
+
+func TestDiffClusterNodes(t *testing.T) {
+	cluster := model.Cluster{
+		Name: "demo",
+		Spec: model.ClusterSpec{Provider: constant.ClusterProviderBareMetal},
+		Nodes: []model.ClusterNode{
+			{Name: "demo-master-1", Role: constant.NodeRoleNameMaster, Host: model.Host{Name: "host-1"}},
+			{Name: "demo-worker-1", Role: constant.NodeRoleNameWorker, Host: model.Host{Name: "host-2"}},
+			{Name: "demo-worker-2", Role: constant.NodeRoleNameWorker, Host: model.Host{Name: "host-3"}},
+		},
+	}
+	p := &clusterApplyPlan{}
+	if err := diffClusterNodes(p, cluster, []dto.ClusterApplyNode{
+		{Role: constant.NodeRoleNameMaster, Host: "host-1"},
+		{Role: constant.NodeRoleNameWorker, Host: "host-2"},
+		{Role: constant.NodeRoleNameWorker, Host: "host-4"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.addHosts) != 1 || p.addHosts[0] != "host-4" {
+		t.Errorf("unexpected hosts to add %v", p.addHosts)
+	}
+	if len(p.deleteNodes) != 1 || p.deleteNodes[0] != "demo-worker-2" {
+		t.Errorf("unexpected nodes to delete %v", p.deleteNodes)
+	}
+
+	p = &clusterApplyPlan{}
+	if err := diffClusterNodes(p, cluster, []dto.ClusterApplyNode{
+		{Role: constant.NodeRoleNameWorker, Host: "host-2"},
+		{Role: constant.NodeRoleNameWorker, Host: "host-3"},
+		{Role: constant.NodeRoleNameMaster, Host: "host-4"},
+		{Role: constant.NodeRoleNameMaster, Host: "host-5"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.deleteMasters) != 1 || p.deleteMasters[0] != "demo-master-1" || len(p.addMasters) != 2 || p.masters != 1 {
+		t.Errorf("unexpected masters %v %v", p.addMasters, p.deleteMasters)
+	}
+	if err := p.check(); err == nil || err.Error() != "CLUSTER_APPLY_OPERATION_CONFLICT" {
+		t.Errorf("expected CLUSTER_APPLY_OPERATION_CONFLICT, got %v", err)
+	}
+
+	p = &clusterApplyPlan{}
+	if err := diffClusterNodes(p, cluster, []dto.ClusterApplyNode{
+		{Role: constant.NodeRoleNameMaster, Host: "host-1"},
+		{Role: constant.NodeRoleNameMaster, Host: "host-2"},
+		{Role: constant.NodeRoleNameWorker, Host: "host-3"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.diff.Changes) != 1 || p.diff.Changes[0].Action != constant.ClusterApplyActionUnsupported {
+		t.Errorf("changing node role should be unsupported %v", p.diff.Changes)
+	}
+
+	if err := diffClusterNodes(&clusterApplyPlan{}, cluster, []dto.ClusterApplyNode{{Role: constant.NodeRoleNameWorker}}); err == nil || err.Error() != "CLUSTER_APPLY_NODE_HOST_REQUIRED" {
+		t.Errorf("expected CLUSTER_APPLY_NODE_HOST_REQUIRED, got %v", err)
+	}
+}
+
+func TestClusterApplyPlanCheck(t *testing.T) {
+	p := &clusterApplyPlan{upgradeVersion: "v1.20.6", addHosts: []string{"host-4"}}
+	if err := p.check(); err == nil || err.Error() != "CLUSTER_APPLY_OPERATION_CONFLICT" {
+		t.Errorf("expected CLUSTER_APPLY_OPERATION_CONFLICT, got %v", err)
+	}
+	p = &clusterApplyPlan{masters: 1, addMasters: []string{"host-4"}}
+	if err := p.check(); err == nil || err.Error() != "MASTER_COUNT_MUST_BE_ODD" {
+		t.Errorf("expected MASTER_COUNT_MUST_BE_ODD, got %v", err)
+	}
+	p = &clusterApplyPlan{deleteNodes: []string{"demo-worker-2"}}
+	p.add(constant.ClusterApplyKindNode, "host-3", constant.ClusterApplyActionDelete, constant.NodeRoleNameWorker, "")
+	p.add(constant.ClusterApplyKindTool, "grafana", constant.ClusterApplyActionEnable, "", "")
+	if err := p.check(); err != nil {
+		t.Fatal(err)
+	}
+	p.mark(constant.ClusterApplyStatusApplied, constant.ClusterApplyKindTool, "grafana")
+	if p.diff.Changes[0].Status != "" || p.diff.Changes[1].Status != constant.ClusterApplyStatusApplied {
+		t.Errorf("unexpected status %v", p.diff.Changes)
+	}
+}
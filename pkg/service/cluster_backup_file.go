@@ -1,10 +1,13 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/kmpp/pkg/cloud_storage"
@@ -17,15 +20,22 @@ import (
 	"github.com/kmpp/pkg/repository"
 	"github.com/kmpp/pkg/service/cluster/adm"
 	"github.com/kmpp/pkg/service/cluster/adm/phases/backup"
+	"github.com/kmpp/pkg/util/etcd"
+	"github.com/kmpp/pkg/util/hash"
 	"github.com/jinzhu/gorm"
 )
 
+var BackupFileChecksumMismatchError = "BACKUP_FILE_CHECKSUM_MISMATCH"
+
+const backupVerifyTimeout = 5 * time.Minute
+
 type CLusterBackupFileService interface {
 	Page(num, size int, clusterName string) (*page.Page, error)
 	Create(creation dto.ClusterBackupFileCreate) (*dto.ClusterBackupFile, error)
 	Batch(op dto.ClusterBackupFileOp) error
 	Backup(creation dto.ClusterBackupFileCreate) error
 	Restore(restore dto.ClusterBackupFileRestore) error
+	Verify(verify dto.ClusterBackupFileVerify) error
 	Delete(name string) error
 	LocalRestore(clusterName string, file []byte) error
 }
@@ -85,6 +95,7 @@ func (c cLusterBackupFileService) Create(creation dto.ClusterBackupFileCreate) (
 		Name:                    creation.Name,
 		ClusterBackupStrategyID: creation.ClusterBackupStrategyID,
 		Folder:                  creation.Folder,
+		Checksum:                creation.Checksum,
 		ClusterID:               cluster.ID,
 	}
 
@@ -147,6 +158,16 @@ func (c cLusterBackupFileService) Delete(name string) error {
 }
 
 func (c cLusterBackupFileService) Backup(creation dto.ClusterBackupFileCreate) error {
+	lock, err := lockClusterOperation(creation.ClusterName, clusterOperationBackup)
+	if err != nil {
+		return err
+	}
+	started := false
+	defer func() {
+		if !started {
+			lock.Unlock()
+		}
+	}()
 
 	backupLog, err := c.clusterLogService.GetRunningLogWithClusterNameAndType(creation.ClusterName, constant.ClusterLogTypeBackup)
 	if err != nil && !gorm.IsRecordNotFoundError(err) {
@@ -173,7 +194,11 @@ func (c cLusterBackupFileService) Backup(creation dto.ClusterBackupFileCreate) e
 	fileName := cluster.Name + "-" + day + ".backup.tar.gz"
 	creation.Name = fileName
 	creation.Folder = cluster.Name + "/" + fileName
-	go c.doBackup(cluster.Cluster, creation)
+	started = true
+	go func() {
+		defer lock.Unlock()
+		c.doBackup(cluster.Cluster, creation)
+	}()
 	return nil
 }
 
@@ -229,6 +254,13 @@ func (c cLusterBackupFileService) doBackup(cluster model.Cluster, creation dto.C
 			return
 		}
 		srcFilePath := constant.BackupDir + "/" + cluster.Name + "/" + constant.BackupFileDefaultName
+		creation.Checksum, err = hash.Sha256WithFile(srcFilePath)
+		if err != nil {
+			_ = c.clusterLogService.End(&clog, false, err.Error())
+			logger.Log.Errorf("backup file checksum failed, error: %s", err.Error())
+			_ = c.messageService.SendMessage(constant.System, false, GetContent(constant.ClusterBackup, false, err.Error()), cluster.Name, constant.ClusterBackup)
+			return
+		}
 		_, err = client.Upload(srcFilePath, creation.Folder)
 		if err != nil {
 			_ = c.clusterLogService.End(&clog, false, err.Error())
@@ -247,11 +279,37 @@ func (c cLusterBackupFileService) doBackup(cluster model.Cluster, creation dto.C
 		} else {
 			_ = c.messageService.SendMessage(constant.System, true, GetContent(constant.ClusterBackup, true, ""), cluster.Name, constant.ClusterBackup)
 		}
+		c.deleteExpiredFiles(cluster, *clusterBackupStrategy)
 	}
 
 }
 
+// deleteExpiredFiles 按备份策略的保留规则清理过期的备份文件
+func (c cLusterBackupFileService) deleteExpiredFiles(cluster model.Cluster, strategy model.ClusterBackupStrategy) {
+	backupFiles, err := c.clusterBackupFileRepo.List(cluster.ID)
+	if err != nil {
+		logger.Log.Errorf("list cluster [%s] backup files error : %s", cluster.Name, err.Error())
+		return
+	}
+	for _, f := range expiredBackupFiles(backupFiles, strategy) {
+		logger.Log.Infof("delete backup file %s", f.Name)
+		if err := c.Delete(f.Name); err != nil {
+			logger.Log.Errorf("delete cluster [%s] backup file error : %s", cluster.Name, err.Error())
+		}
+	}
+}
+
 func (c cLusterBackupFileService) Restore(restore dto.ClusterBackupFileRestore) error {
+	lock, err := lockClusterOperation(restore.ClusterName, clusterOperationChange)
+	if err != nil {
+		return err
+	}
+	started := false
+	defer func() {
+		if !started {
+			lock.Unlock()
+		}
+	}()
 
 	backupLog, err := c.clusterLogService.GetRunningLogWithClusterNameAndType(restore.ClusterName, constant.ClusterLogTypeBackup)
 	if err != nil && !gorm.IsRecordNotFoundError(err) {
@@ -283,7 +341,11 @@ func (c cLusterBackupFileService) Restore(restore dto.ClusterBackupFileRestore)
 		return err
 	}
 	restore.BackupAccount = *backupAccount
-	go c.doRestore(restore)
+	started = true
+	go func() {
+		defer lock.Unlock()
+		c.doRestore(restore)
+	}()
 	return nil
 }
 
@@ -309,25 +371,10 @@ func (c cLusterBackupFileService) doRestore(restore dto.ClusterBackupFileRestore
 		_ = c.messageService.SendMessage(constant.System, false, GetContent(constant.ClusterRestore, false, err.Error()), cluster.Name, constant.ClusterRestore)
 	}
 
-	vars := make(map[string]interface{})
-	if err := json.Unmarshal([]byte(restore.BackupAccount.Credential), &vars); err != nil {
-		logger.Log.Errorf("doRestore json.Unmarshal failed,  error: %s", err.Error())
-	}
-	vars["type"] = restore.BackupAccount.Type
-	vars["bucket"] = restore.BackupAccount.Bucket
-	client, err := cloud_storage.NewCloudStorageClient(vars)
-	if err != nil {
-		_ = c.clusterLogService.End(&clog, false, err.Error())
-		logger.Log.Errorf("cloud storage new client failed, error: %s", err.Error())
-		_ = c.messageService.SendMessage(constant.System, false, GetContent(constant.ClusterRestore, false, err.Error()), cluster.Name, constant.ClusterRestore)
-		return
-	}
-
-	srcFilePath := restore.File.Folder
 	targetPath := constant.BackupDir + "/" + cluster.Name + "/" + constant.BackupFileDefaultName
-	_, err = client.Download(srcFilePath, targetPath)
-	if err != nil {
-		logger.Log.Errorf("cloud storage download failed, error: %s", err.Error())
+	if err := downloadBackupFile(restore.File, restore.BackupAccount, targetPath); err != nil {
+		_ = c.clusterLogService.End(&clog, false, err.Error())
+		logger.Log.Errorf("download backup file failed, error: %s", err.Error())
 		_ = c.messageService.SendMessage(constant.System, false, GetContent(constant.ClusterRestore, false, err.Error()), cluster.Name, constant.ClusterRestore)
 		return
 	}
@@ -345,6 +392,115 @@ func (c cLusterBackupFileService) doRestore(restore dto.ClusterBackupFileRestore
 	}
 }
 
+// downloadBackupFile 下载备份文件到本地并校验 checksum，校验失败时删除已下载的文件
+func downloadBackupFile(file model.ClusterBackupFile, account model.BackupAccount, targetPath string) error {
+	vars := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(account.Credential), &vars); err != nil {
+		logger.Log.Errorf("doRestore json.Unmarshal failed,  error: %s", err.Error())
+	}
+	vars["type"] = account.Type
+	vars["bucket"] = account.Bucket
+	client, err := cloud_storage.NewCloudStorageClient(vars)
+	if err != nil {
+		return fmt.Errorf("cloud storage new client failed, error: %s", err.Error())
+	}
+	if _, err := client.Download(file.Folder, targetPath); err != nil {
+		return fmt.Errorf("cloud storage download failed, error: %s", err.Error())
+	}
+	// 旧版本生成的备份没有 checksum，跳过校验
+	if file.Checksum == "" {
+		return nil
+	}
+	checksum, err := hash.Sha256WithFile(targetPath)
+	if err != nil {
+		return err
+	}
+	if checksum != file.Checksum {
+		_ = os.Remove(targetPath)
+		return errors.New(BackupFileChecksumMismatchError)
+	}
+	return nil
+}
+
+// Verify 校验备份文件的 checksum，并将快照恢复到临时 etcd 中确认可用
+func (c cLusterBackupFileService) Verify(verify dto.ClusterBackupFileVerify) error {
+	cluster, err := c.clusterService.Get(verify.ClusterName)
+	if err != nil {
+		return err
+	}
+	file, err := c.clusterBackupFileRepo.Get(verify.Name)
+	if err != nil {
+		return err
+	}
+	if file.ID == "" || file.ClusterID != cluster.ID {
+		return errors.New("BACKUP_FILE_NOT_FOUND")
+	}
+	// 服务在校验过程中重启时状态会停留在 Running，超时后允许重新校验
+	if file.VerifyStatus == constant.StatusRunning && time.Since(file.UpdatedAt) < backupVerifyTimeout+time.Minute {
+		return errors.New("BACKUP_FILE_IS_VERIFYING")
+	}
+	backupAccount, err := c.backupAccountRepository.Get(file.ClusterBackupStrategy.BackupAccount.Name)
+	if err != nil {
+		return err
+	}
+	file.VerifyStatus = constant.StatusRunning
+	file.VerifyMessage = ""
+	if err := c.clusterBackupFileRepo.UpdateVerifyResult(&file); err != nil {
+		return err
+	}
+	go c.doVerify(cluster.Name, file, *backupAccount)
+	return nil
+}
+
+func (c cLusterBackupFileService) doVerify(clusterName string, file model.ClusterBackupFile, account model.BackupAccount) {
+	status, err := safeVerifyBackupFile(file, account)
+	now := time.Now()
+	file.VerifyTime = &now
+	if err != nil {
+		logger.Log.Errorf("verify backup file %s failed, error: %s", file.Name, err.Error())
+		file.VerifyStatus = constant.StatusFailed
+		file.VerifyMessage = err.Error()
+		file.KeyCount, file.Revision = 0, 0
+	} else {
+		file.VerifyStatus = constant.StatusSuccess
+		file.KeyCount = status.KeyCount
+		file.Revision = status.Revision
+	}
+	if err := c.clusterBackupFileRepo.UpdateVerifyResult(&file); err != nil {
+		logger.Log.Errorf("save backup file %s failed, error: %s", file.Name, err.Error())
+	}
+	if err != nil {
+		_ = c.messageService.SendMessage(constant.System, false, GetContent(constant.ClusterBackupVerify, false, err.Error()), clusterName, constant.ClusterBackupVerify)
+		return
+	}
+	_ = c.messageService.SendMessage(constant.System, true, GetContent(constant.ClusterBackupVerify, true, ""), clusterName, constant.ClusterBackupVerify)
+}
+
+// safeVerifyBackupFile 校验过程中 panic 时同样返回错误，保证校验状态最终被更新
+func safeVerifyBackupFile(file model.ClusterBackupFile, account model.BackupAccount) (status *etcd.SnapshotStatus, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			status, err = nil, fmt.Errorf("verify backup file panic: %v", r)
+		}
+	}()
+	return verifyBackupFile(file, account)
+}
+
+func verifyBackupFile(file model.ClusterBackupFile, account model.BackupAccount) (*etcd.SnapshotStatus, error) {
+	dir, err := ioutil.TempDir("", "backup-verify-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	snapshot := filepath.Join(dir, constant.BackupFileDefaultName)
+	if err := downloadBackupFile(file, account, snapshot); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), backupVerifyTimeout)
+	defer cancel()
+	return etcd.VerifySnapshot(ctx, snapshot)
+}
+
 func (c cLusterBackupFileService) LocalRestore(clusterName string, file []byte) error {
 	clusterPath := constant.BackupDir + "/" + clusterName
 	targetPath := clusterPath + "/" + constant.BackupFileDefaultName
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kmpp/pkg/model"
+)
+
+// expiredBackupFiles 按祖父-父-子策略筛选需要删除的备份：
+// 保留最近 SaveNum 份，以及最近 RetainHourly 个小时、RetainDaily 天、RetainWeekly 周中每个周期的最新一份
+func expiredBackupFiles(files []model.ClusterBackupFile, strategy model.ClusterBackupStrategy) []model.ClusterBackupFile {
+	if strategy.SaveNum <= 0 && strategy.RetainHourly <= 0 && strategy.RetainDaily <= 0 && strategy.RetainWeekly <= 0 {
+		return nil
+	}
+	sorted := make([]model.ClusterBackupFile, len(files))
+	copy(sorted, files)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+	})
+
+	keep := make(map[string]bool)
+	for i := 0; i < strategy.SaveNum && i < len(sorted); i++ {
+		keep[sorted[i].ID] = true
+	}
+	buckets := []struct {
+		num int
+		key func(f model.ClusterBackupFile) string
+	}{
+		{strategy.RetainHourly, func(f model.ClusterBackupFile) string { return f.CreatedAt.Format("2006-01-02 15") }},
+		{strategy.RetainDaily, func(f model.ClusterBackupFile) string { return f.CreatedAt.Format("2006-01-02") }},
+		{strategy.RetainWeekly, func(f model.ClusterBackupFile) string {
+			year, week := f.CreatedAt.ISOWeek()
+			return fmt.Sprintf("%d-%d", year, week)
+		}},
+	}
+	for _, b := range buckets {
+		seen := make(map[string]bool)
+		for _, f := range sorted {
+			if len(seen) >= b.num {
+				break
+			}
+			k := b.key(f)
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			keep[f.ID] = true
+		}
+	}
+
+	var expired []model.ClusterBackupFile
+	for _, f := range sorted {
+		if !keep[f.ID] {
+			expired = append(expired, f)
+		}
+	}
+	return expired
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/model/common"
+)
+
+func TestExpiredBackupFiles(t *testing.T) {
+	// 每 6 小时一份，共 30 天
+	now := time.Date(2021, 3, 31, 23, 0, 0, 0, time.Local)
+	var files []model.ClusterBackupFile
+	for i := 0; i < 30*4; i++ {
+		files = append(files, model.ClusterBackupFile{
+			ID:        fmt.Sprintf("%d", i),
+			BaseModel: common.BaseModel{CreatedAt: now.Add(-time.Duration(i*6) * time.Hour)},
+		})
+	}
+
+	expired := expiredBackupFiles(files, model.ClusterBackupStrategy{SaveNum: 3})
+	if len(expired) != len(files)-3 {
+		t.Errorf("expected %d expired files, got %d", len(files)-3, len(expired))
+	}
+
+	expired = expiredBackupFiles(files, model.ClusterBackupStrategy{SaveNum: 1, RetainHourly: 4, RetainDaily: 7, RetainWeekly: 4})
+	kept := make(map[string]bool)
+	for _, f := range files {
+		kept[f.ID] = true
+	}
+	for _, f := range expired {
+		delete(kept, f.ID)
+	}
+	// 最近 4 份（小时）+ 之前 6 天各一份（每日）+ 更早 2 周各一份（每周，本周和上周与前面重叠）
+	for _, id := range []string{"0", "1", "2", "3"} {
+		if !kept[id] {
+			t.Errorf("expected hourly backup %s to be kept", id)
+		}
+	}
+	if kept[fmt.Sprintf("%d", len(files)-1)] {
+		t.Error("expected the oldest backup to be expired")
+	}
+	if len(kept) != 4+6+2 {
+		t.Errorf("expected 12 backups to be kept, got %d", len(kept))
+	}
+
+	if expired := expiredBackupFiles(files, model.ClusterBackupStrategy{}); len(expired) != 0 {
+		t.Error("expected nothing expired without a retention policy")
+	}
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/repository"
+	"github.com/robfig/cron/v3"
+)
+
+var BackupCronInvalidError = "BACKUP_CRON_INVALID"
+
+type backupScheduleEntry struct {
+	id   cron.EntryID
+	spec string
+}
+
+// backupSchedule 每个启用的备份策略在 cron 中对应一个任务
+var backupSchedule = struct {
+	sync.Mutex
+	cron    *cron.Cron
+	entries map[string]backupScheduleEntry
+}{entries: map[string]backupScheduleEntry{}}
+
+// InitClusterBackupSchedule 为所有备份策略注册定时任务
+func InitClusterBackupSchedule(c *cron.Cron) error {
+	backupSchedule.Lock()
+	backupSchedule.cron = c
+	backupSchedule.Unlock()
+	return SyncClusterBackupSchedule()
+}
+
+// SyncClusterBackupSchedule 按数据库中的备份策略刷新定时任务，移除已删除策略的任务
+func SyncClusterBackupSchedule() error {
+	strategies, err := repository.NewClusterBackupStrategyRepository().List()
+	if err != nil {
+		return err
+	}
+	exists := make(map[string]bool)
+	for _, strategy := range strategies {
+		exists[strategy.ID] = true
+		exists[verifyScheduleKey(strategy.ID)] = true
+		if err := scheduleClusterBackup(strategy); err != nil {
+			logger.Log.Errorf("schedule backup strategy %s error %s", strategy.ID, err.Error())
+		}
+	}
+	backupSchedule.Lock()
+	defer backupSchedule.Unlock()
+	for id, entry := range backupSchedule.entries {
+		if !exists[id] {
+			backupSchedule.cron.Remove(entry.id)
+			delete(backupSchedule.entries, id)
+		}
+	}
+	return nil
+}
+
+// ValidateBackupCron 校验标准的 5 段 cron 表达式，也支持 @daily、@every 1h 等写法
+func ValidateBackupCron(spec string) error {
+	if _, err := cron.ParseStandard(spec); err != nil {
+		return errors.New(BackupCronInvalidError)
+	}
+	return nil
+}
+
+// verifyScheduleKey 备份校验任务与备份任务共用 entries，以前缀区分
+func verifyScheduleKey(strategyID string) string {
+	return "verify/" + strategyID
+}
+
+func scheduleClusterBackup(strategy model.ClusterBackupStrategy) error {
+	backupSchedule.Lock()
+	defer backupSchedule.Unlock()
+	if backupSchedule.cron == nil {
+		return nil
+	}
+	enable := strategy.Status == constant.Enable
+	if err := scheduleEntry(strategy.ID, strategy.Cron, enable, &clusterBackupJob{strategyID: strategy.ID}); err != nil {
+		return err
+	}
+	return scheduleEntry(verifyScheduleKey(strategy.ID), strategy.VerifyCron, enable, &clusterBackupVerifyJob{strategyID: strategy.ID})
+}
+
+func scheduleEntry(key, spec string, enable bool, job cron.Job) error {
+	enable = enable && spec != ""
+	if entry, ok := backupSchedule.entries[key]; ok {
+		// 计划未变化时保留原任务，避免 @every 类的任务被重新计时
+		if enable && entry.spec == spec {
+			return nil
+		}
+		backupSchedule.cron.Remove(entry.id)
+		delete(backupSchedule.entries, key)
+	}
+	if !enable {
+		return nil
+	}
+	id, err := backupSchedule.cron.AddJob(spec, job)
+	if err != nil {
+		return err
+	}
+	backupSchedule.entries[key] = backupScheduleEntry{id: id, spec: spec}
+	return nil
+}
+
+type clusterBackupJob struct {
+	strategyID string
+}
+
+func (j *clusterBackupJob) Run() {
+	strategy, cluster, err := getScheduledStrategy(j.strategyID)
+	if err != nil {
+		logger.Log.Errorf("get backup strategy %s error %s", j.strategyID, err.Error())
+		return
+	}
+	if strategy.Status != constant.Enable {
+		return
+	}
+	logger.Log.Infof("backup cluster [%s]", cluster.Name)
+	if err := NewClusterBackupFileService().Backup(dto.ClusterBackupFileCreate{ClusterName: cluster.Name}); err != nil {
+		logger.Log.Errorf("backup cluster [%s] error: %s", cluster.Name, err.Error())
+	}
+}
+
+// clusterBackupVerifyJob 校验集群最新的一份备份
+type clusterBackupVerifyJob struct {
+	strategyID string
+}
+
+func (j *clusterBackupVerifyJob) Run() {
+	strategy, cluster, err := getScheduledStrategy(j.strategyID)
+	if err != nil {
+		logger.Log.Errorf("get backup strategy %s error %s", j.strategyID, err.Error())
+		return
+	}
+	if strategy.Status != constant.Enable {
+		return
+	}
+	files, err := repository.NewClusterBackupFileRepository().List(cluster.ID)
+	if err != nil {
+		logger.Log.Errorf("list cluster [%s] backup files error: %s", cluster.Name, err.Error())
+		return
+	}
+	if len(files) == 0 {
+		return
+	}
+	logger.Log.Infof("verify cluster [%s] backup file %s", cluster.Name, files[0].Name)
+	if err := NewClusterBackupFileService().Verify(dto.ClusterBackupFileVerify{ClusterName: cluster.Name, Name: files[0].Name}); err != nil {
+		logger.Log.Errorf("verify cluster [%s] backup file error: %s", cluster.Name, err.Error())
+	}
+}
+
+func getScheduledStrategy(strategyID string) (model.ClusterBackupStrategy, model.Cluster, error) {
+	var (
+		strategy model.ClusterBackupStrategy
+		cluster  model.Cluster
+	)
+	if err := db.DB.Where("id = ?", strategyID).First(&strategy).Error; err != nil {
+		return strategy, cluster, err
+	}
+	if err := db.DB.Where("id = ?", strategy.ClusterID).First(&cluster).Error; err != nil {
+		return strategy, cluster, err
+	}
+	return strategy, cluster, nil
+}
@@ -51,6 +51,14 @@ func (c cLusterBackupStrategyService) Get(clusterName string) (*dto.ClusterBacku
 }
 
 func (c cLusterBackupStrategyService) Save(creation dto.ClusterBackupStrategyRequest) (*dto.ClusterBackupStrategy, error) {
+	if err := ValidateBackupCron(creation.Cron); err != nil {
+		return nil, err
+	}
+	if creation.VerifyCron != "" {
+		if err := ValidateBackupCron(creation.VerifyCron); err != nil {
+			return nil, err
+		}
+	}
 	backupAccount, err := c.backupAccountService.Get(creation.BackupAccountName)
 	if err != nil {
 		return nil, err
@@ -83,11 +91,18 @@ func (c cLusterBackupStrategyService) Save(creation dto.ClusterBackupStrategyReq
 		Status:          creation.Status,
 		BackupAccountID: backupAccount.ID,
 		SaveNum:         creation.SaveNum,
+		RetainHourly:    creation.RetainHourly,
+		RetainDaily:     creation.RetainDaily,
+		RetainWeekly:    creation.RetainWeekly,
+		VerifyCron:      creation.VerifyCron,
 	}
 
 	err = c.clusterBackupStrategyRepo.Save(&clusterBackupStrategy)
 	if err != nil {
 		return nil, err
 	}
+	if err := scheduleClusterBackup(clusterBackupStrategy); err != nil {
+		return nil, err
+	}
 	return &dto.ClusterBackupStrategy{ClusterBackupStrategy: clusterBackupStrategy}, err
 }
@@ -0,0 +1,231 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/repository"
+	"github.com/kmpp/pkg/service/cluster/adm"
+	"github.com/kmpp/pkg/util/ansible"
+	kmppNet "github.com/kmpp/pkg/util/net"
+)
+
+// certificateNotifyDays 证书剩余天数低于阈值时提醒，每个阈值只提醒一次
+var certificateNotifyDays = []int{30, 7, 1}
+
+type ClusterCertificateService interface {
+	List(clusterName string) ([]dto.ClusterCertificate, error)
+	Check(clusterName string) ([]dto.ClusterCertificate, error)
+	Renew(clusterName string) error
+}
+
+func NewClusterCertificateService() ClusterCertificateService {
+	return &clusterCertificateService{
+		clusterService:    NewClusterService(),
+		clusterNodeRepo:   repository.NewClusterNodeRepository(),
+		clusterLogService: NewClusterLogService(),
+		messageService:    NewMessageService(),
+	}
+}
+
+type clusterCertificateService struct {
+	clusterService    ClusterService
+	clusterNodeRepo   repository.ClusterNodeRepository
+	clusterLogService ClusterLogService
+	messageService    MessageService
+}
+
+func (c clusterCertificateService) List(clusterName string) ([]dto.ClusterCertificate, error) {
+	cluster, err := c.clusterService.Get(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	var certs []model.ClusterCertificate
+	if err := db.DB.Where("cluster_id = ?", cluster.ID).Order("node_name, name").Find(&certs).Error; err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var result []dto.ClusterCertificate
+	for _, cert := range certs {
+		result = append(result, dto.ClusterCertificate{ClusterCertificate: cert, DaysLeft: certificateDaysLeft(cert.NotAfter, now)})
+	}
+	return result, nil
+}
+
+// Check 从集群各节点的 apiserver、etcd 和 kubelet 端点读取证书有效期并保存，剩余天数首次低于 30/7/1 天时发送消息
+func (c clusterCertificateService) Check(clusterName string) ([]dto.ClusterCertificate, error) {
+	cluster, err := c.clusterService.Get(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if cluster.Source != constant.ClusterSourceLocal {
+		return nil, errors.New("CLUSTER_CERTIFICATE_NOT_LOCAL")
+	}
+	nodes, err := c.clusterNodeRepo.List(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	var old []model.ClusterCertificate
+	if err := db.DB.Where("cluster_id = ?", cluster.ID).Find(&old).Error; err != nil {
+		return nil, err
+	}
+	existing := make(map[string]model.ClusterCertificate)
+	for _, cert := range old {
+		existing[cert.NodeName+"/"+cert.Name] = cert
+	}
+
+	apiServerPort := cluster.Spec.KubeApiServerPort
+	if apiServerPort == 0 {
+		apiServerPort = constant.DefaultKubeApiServerPort
+	}
+	now := time.Now()
+	var (
+		expiring []string
+		keep     []string
+	)
+	for _, node := range nodes {
+		endpoints := map[string]int{constant.CertificateKubelet: constant.KubeletPort}
+		if node.Role == constant.NodeRoleNameMaster {
+			endpoints[constant.CertificateApiServer] = apiServerPort
+			endpoints[constant.CertificateEtcd] = constant.EtcdClientPort
+		}
+		for name, port := range endpoints {
+			cert := existing[node.Name+"/"+name]
+			cert.ClusterID = cluster.ID
+			cert.NodeName = node.Name
+			cert.Name = name
+			cert.Endpoint = fmt.Sprintf("%s:%d", node.Host.Ip, port)
+			peer, err := kmppNet.PeerCertificate(cert.Endpoint)
+			if err != nil {
+				// 端点暂时不可达时保留上次读取的有效期
+				cert.Message = err.Error()
+			} else {
+				notBefore, notAfter := peer.NotBefore, peer.NotAfter
+				cert.Subject = peer.Subject.CommonName
+				cert.Issuer = peer.Issuer.CommonName
+				cert.NotBefore = &notBefore
+				cert.NotAfter = &notAfter
+				cert.Message = ""
+			}
+			if cert.NotAfter != nil {
+				days := certificateDaysLeft(cert.NotAfter, now)
+				threshold := certificateThreshold(days)
+				if threshold > 0 && (cert.NotifiedDays == 0 || threshold < cert.NotifiedDays) {
+					expiring = append(expiring, fmt.Sprintf("%s %s(%s) 到期时间 %s，剩余 %d 天", node.Name, name, cert.Endpoint, cert.NotAfter.Format("2006-01-02"), days))
+				}
+				cert.NotifiedDays = threshold
+			}
+			if err := db.DB.Save(&cert).Error; err != nil {
+				return nil, err
+			}
+			keep = append(keep, cert.ID)
+		}
+	}
+	// 清理已删除节点的记录
+	clean := db.DB.Where("cluster_id = ?", cluster.ID)
+	if len(keep) > 0 {
+		clean = clean.Where("id NOT IN (?)", keep)
+	}
+	if err := clean.Delete(&model.ClusterCertificate{}).Error; err != nil {
+		return nil, err
+	}
+	if len(expiring) > 0 {
+		content, _ := json.Marshal(map[string]string{"message": "证书即将过期: " + strings.Join(expiring, "; ")})
+		if err := c.messageService.SendMessage(constant.System, false, string(content), cluster.Name, constant.ClusterCertExpiry); err != nil {
+			logger.Log.Errorf("send certificate expiry message of cluster %s error %s", cluster.Name, err.Error())
+		}
+	}
+	return c.List(clusterName)
+}
+
+// Renew 单独执行证书 playbook 更新集群证书，不升级集群版本，完成后重新读取有效期
+func (c clusterCertificateService) Renew(clusterName string) error {
+	cluster, err := c.clusterService.Get(clusterName)
+	if err != nil {
+		return err
+	}
+	if cluster.Source != constant.ClusterSourceLocal {
+		return errors.New("CLUSTER_CERTIFICATE_NOT_LOCAL")
+	}
+	if cluster.Status != constant.StatusRunning {
+		return fmt.Errorf("cluster status error %s", cluster.Status)
+	}
+	lock, err := lockClusterOperation(cluster.Name, clusterOperationChange)
+	if err != nil {
+		return err
+	}
+	started := false
+	defer func() {
+		if !started {
+			lock.Unlock()
+		}
+	}()
+
+	logId, writer, err := ansible.CreateAnsibleLogWriter(cluster.Name)
+	if err != nil {
+		return fmt.Errorf("create log error %s", err.Error())
+	}
+	if err := db.DB.Model(&model.Cluster{}).Where("id = ?", cluster.ID).Update("log_id", logId).Error; err != nil {
+		return err
+	}
+	cluster.Cluster.LogId = logId
+	var clog model.ClusterLog
+	clog.Type = constant.ClusterLogTypeRenewCerts
+	clog.StartTime = time.Now()
+	clog.EndTime = time.Now()
+	if err := c.clusterLogService.Save(cluster.Name, &clog); err != nil {
+		return err
+	}
+	if err := c.clusterLogService.Start(&clog); err != nil {
+		return err
+	}
+	started = true
+	go func() {
+		defer lock.Unlock()
+		c.doRenew(cluster.Cluster, writer, &clog)
+	}()
+	return nil
+}
+
+func (c clusterCertificateService) doRenew(cluster model.Cluster, writer io.Writer, clog *model.ClusterLog) {
+	admCluster := adm.NewCluster(cluster, writer)
+	if err := adm.NewClusterAdm().EnsureUpdateCertificates(admCluster); err != nil {
+		logger.Log.Errorf("renew certificates of cluster %s error %s", cluster.Name, err.Error())
+		_ = c.clusterLogService.End(clog, false, err.Error())
+		_ = c.messageService.SendMessage(constant.System, false, GetContent(constant.ClusterRenewCerts, false, err.Error()), cluster.Name, constant.ClusterRenewCerts)
+		return
+	}
+	_ = c.clusterLogService.End(clog, true, "")
+	_ = c.messageService.SendMessage(constant.System, true, GetContent(constant.ClusterRenewCerts, true, ""), cluster.Name, constant.ClusterRenewCerts)
+	if _, err := c.Check(cluster.Name); err != nil {
+		logger.Log.Errorf("check certificates of cluster %s error %s", cluster.Name, err.Error())
+	}
+}
+
+func certificateDaysLeft(notAfter *time.Time, now time.Time) int {
+	if notAfter == nil {
+		return 0
+	}
+	return int(math.Floor(notAfter.Sub(now).Hours() / 24))
+}
+
+// certificateThreshold 返回剩余天数所处的最小提醒阈值，超过最大阈值返回 0
+func certificateThreshold(daysLeft int) int {
+	threshold := 0
+	for _, d := range certificateNotifyDays {
+		if daysLeft <= d {
+			threshold = d
+		}
+	}
+	return threshold
+}
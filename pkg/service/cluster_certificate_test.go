@@ -0,0 +1,20 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCertificateThreshold(t *testing.T) {
+	cases := map[int]int{365: 0, 31: 0, 30: 30, 8: 30, 7: 7, 2: 7, 1: 1, 0: 1, -3: 1}
+	for days, expect := range cases {
+		if got := certificateThreshold(days); got != expect {
+			t.Errorf("days %d: expect threshold %d, got %d", days, expect, got)
+		}
+	}
+	now := time.Now()
+	notAfter := now.Add(7*24*time.Hour - time.Minute)
+	if days := certificateDaysLeft(&notAfter, now); days != 6 {
+		t.Errorf("expect 6 days left, got %d", days)
+	}
+}
@@ -0,0 +1,351 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/KubeOperator/kobe/api"
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/repository"
+	"github.com/kmpp/pkg/service/cluster/adm"
+	"github.com/kmpp/pkg/service/cluster/adm/facts"
+)
+
+const dryRunMask = "******"
+
+// ClusterDryRunService 返回创建、升级、扩缩容集群时将要执行的计划，不修改任何数据也不执行 playbook
+type ClusterDryRunService interface {
+	Create(creation dto.ClusterCreate) (*dto.ClusterDryRun, error)
+	Upgrade(upgrade dto.ClusterUpgrade) (*dto.ClusterDryRun, error)
+	NodeBatch(clusterName string, batch dto.NodeBatch) (*dto.ClusterDryRun, error)
+}
+
+func NewClusterDryRunService() ClusterDryRunService {
+	return &clusterDryRunService{
+		clusterService: NewClusterService(),
+		clusterRepo:    repository.NewClusterRepository(),
+		planRepo:       repository.NewPlanRepository(),
+		vmConfigRepo:   repository.NewVmConfigRepository(),
+	}
+}
+
+type clusterDryRunService struct {
+	clusterService ClusterService
+	clusterRepo    repository.ClusterRepository
+	planRepo       repository.PlanRepository
+	vmConfigRepo   repository.VmConfigRepository
+}
+
+func (c clusterDryRunService) Create(creation dto.ClusterCreate) (*dto.ClusterDryRun, error) {
+	if c.clusterService.CheckExistence(creation.Name) {
+		return nil, errors.New("NAME_EXISTS")
+	}
+	spec, err := newClusterSpec(creation)
+	if err != nil {
+		return nil, err
+	}
+	cluster := model.Cluster{
+		Name:   creation.Name,
+		Source: constant.ClusterSourceLocal,
+	}
+	var hosts []dto.ClusterDryRunHost
+	switch spec.Provider {
+	case constant.ClusterProviderPlan:
+		spec.WorkerAmount = creation.WorkerAmount
+		plan, err := c.planRepo.Get(creation.Plan)
+		if err != nil {
+			return nil, fmt.Errorf("can not query plan %s reason %s", creation.Plan, err.Error())
+		}
+		var names []string
+		masterAmount := 1
+		if plan.DeployTemplate != constant.SINGLE {
+			masterAmount = 3
+		}
+		for i := 0; i < masterAmount; i++ {
+			names = append(names, fmt.Sprintf("%s-master-%d", cluster.Name, i+1))
+		}
+		for i := 0; i < spec.WorkerAmount; i++ {
+			names = append(names, fmt.Sprintf("%s-worker-%d", cluster.Name, i+1))
+		}
+		hosts, err = c.planHosts(plan, names)
+		if err != nil {
+			return nil, err
+		}
+	case constant.ClusterProviderBareMetal:
+		workerNo := 1
+		masterNo := 1
+		for _, nc := range creation.Nodes {
+			n := model.ClusterNode{Role: nc.Role}
+			switch n.Role {
+			case constant.NodeRoleNameMaster:
+				n.Name = fmt.Sprintf("%s-%s-%d", cluster.Name, constant.NodeRoleNameMaster, masterNo)
+				masterNo++
+			case constant.NodeRoleNameWorker:
+				n.Name = fmt.Sprintf("%s-%s-%d", cluster.Name, constant.NodeRoleNameWorker, workerNo)
+				workerNo++
+			}
+			var host model.Host
+			if err := db.DB.Where("name = ?", nc.HostName).Preload("Credential").First(&host).Error; err != nil {
+				return nil, fmt.Errorf("can not find host %s", nc.HostName)
+			}
+			n.Host = host
+			cluster.Nodes = append(cluster.Nodes, n)
+		}
+		if len(cluster.Nodes) > 0 {
+			spec.KubeRouter = cluster.Nodes[0].Host.Ip
+		}
+	}
+	cluster.Spec = spec
+
+	admCluster := adm.NewCluster(cluster)
+	result := newClusterDryRun(constant.ClusterDryRunCreate, admCluster.Inventory(), adm.NewClusterAdm().CreateSteps())
+	result.Cluster = cluster.Name
+	result.Hosts = hosts
+	return result, nil
+}
+
+func (c clusterDryRunService) Upgrade(upgrade dto.ClusterUpgrade) (*dto.ClusterDryRun, error) {
+	cluster, err := c.clusterRepo.Get(upgrade.ClusterName)
+	if err != nil {
+		return nil, fmt.Errorf("can not get cluster %s error %s", upgrade.ClusterName, err.Error())
+	}
+	if !(cluster.Source == constant.ClusterSourceLocal) {
+		return nil, errors.New("CLUSTER_IS_NOT_LOCAL")
+	}
+	if cluster.Status.Phase != constant.StatusRunning && cluster.Status.Phase != constant.StatusFailed {
+		return nil, fmt.Errorf("cluster status error %s", cluster.Status.Phase)
+	}
+	cluster.Spec.UpgradeVersion = upgrade.Version
+	manifest, err := adm.GetManiFestBy(upgrade.Version)
+	if err != nil {
+		return nil, fmt.Errorf("can not get manifest %s error %s", upgrade.Version, err.Error())
+	}
+
+	admCluster := adm.NewCluster(cluster)
+	// NewCluster 按当前版本的清单设置变量，预览时改为目标版本的组件版本
+	for k, v := range manifest.GetVars() {
+		admCluster.Kobe.SetVar(k, v)
+	}
+	if index := strings.Index(upgrade.Version, "-"); index > 0 {
+		admCluster.Kobe.SetVar(facts.KubeUpgradeVersionFactName, upgrade.Version[:index])
+	}
+	result := newClusterDryRun(constant.ClusterDryRunUpgrade, admCluster.Inventory(), adm.NewClusterAdm().UpgradeSteps())
+	result.Cluster = cluster.Name
+	return result, nil
+}
+
+func (c clusterDryRunService) NodeBatch(clusterName string, batch dto.NodeBatch) (*dto.ClusterDryRun, error) {
+	cluster, err := c.clusterRepo.Get(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		operation string
+		groups    []string
+		hosts     []dto.ClusterDryRunHost
+		newNodes  []string
+		planNodes []string
+		steps     []adm.PlanStep
+	)
+	role := constant.NodeRoleNameWorker
+	if batch.Role == constant.NodeRoleNameMaster {
+		role = constant.NodeRoleNameMaster
+	}
+	switch batch.Operation {
+	case constant.BatchOperationCreate:
+		operation, groups = constant.ClusterDryRunAddWorker, []string{"new-worker"}
+		steps = []adm.PlanStep{{Name: operation, Playbook: addWorkerPlaybook}}
+		if role == constant.NodeRoleNameMaster {
+			operation, groups, steps = constant.ClusterDryRunAddMaster, addMasterGroups, nil
+			for _, playbook := range addMasterPlaybooks {
+				steps = append(steps, adm.PlanStep{Name: operation, Playbook: playbook})
+			}
+		}
+		switch cluster.Spec.Provider {
+		case constant.ClusterProviderBareMetal:
+			var hs []model.Host
+			if err := db.DB.Where("name in (?)", batch.Hosts).Preload("Credential").Find(&hs).Error; err != nil {
+				return nil, fmt.Errorf("get hosts failed: %v", err)
+			}
+			names := newNodeNames(cluster.Name, role, cluster.Nodes, len(hs))
+			for i := range hs {
+				cluster.Nodes = append(cluster.Nodes, model.ClusterNode{
+					Name:   names[i],
+					Role:   role,
+					Status: constant.ClusterWaiting,
+					Host:   hs[i],
+				})
+			}
+			newNodes = names
+		case constant.ClusterProviderPlan:
+			plan, err := c.planRepo.GetById(cluster.PlanID)
+			if err != nil {
+				return nil, fmt.Errorf("load plan failed: %v", err)
+			}
+			hosts, err = c.planHosts(plan, newWorkerHostNames(&cluster, batch.Increase))
+			if err != nil {
+				return nil, err
+			}
+			planNodes = newWorkerNodeNames(cluster.Name, cluster.Nodes, batch.Increase)
+			newNodes = planNodes
+		}
+	case constant.BatchOperationDelete:
+		operation, groups = constant.ClusterDryRunRemoveWorker, []string{"del-worker"}
+		if role == constant.NodeRoleNameMaster {
+			operation, groups = constant.ClusterDryRunRemoveMaster, removeMasterGroups
+		}
+		steps = []adm.PlanStep{{Name: operation, Playbook: deleteWorkerPlaybook}}
+		for _, name := range batch.Nodes {
+			var node *model.ClusterNode
+			for i := range cluster.Nodes {
+				if cluster.Nodes[i].Name == name {
+					node = &cluster.Nodes[i]
+					break
+				}
+			}
+			if node == nil {
+				return nil, fmt.Errorf("node %s not found in cluster %s", name, cluster.Name)
+			}
+			if cluster.Spec.Provider == constant.ClusterProviderPlan {
+				hosts = append(hosts, dto.ClusterDryRunHost{
+					Name:   node.Host.Name,
+					Role:   node.Role,
+					Zone:   node.Host.Zone.Name,
+					Cpu:    node.Host.CpuCore,
+					Memory: node.Host.Memory,
+					Action: constant.DryRunHostActionDelete,
+				})
+			}
+		}
+	default:
+		return nil, constant.NotSupportedBatchOperation
+	}
+
+	admCluster := adm.NewCluster(cluster)
+	inventory := admCluster.Inventory()
+	if inventory != nil {
+		// 云主机尚未创建，只能给出主机名
+		for _, name := range planNodes {
+			inventory.Hosts = append(inventory.Hosts, &api.Host{Name: name})
+		}
+		for i := range inventory.Groups {
+			for _, group := range groups {
+				if inventory.Groups[i].Name != group {
+					continue
+				}
+				switch batch.Operation {
+				case constant.BatchOperationCreate:
+					inventory.Groups[i].Hosts = append(inventory.Groups[i].Hosts, newNodes...)
+				case constant.BatchOperationDelete:
+					inventory.Groups[i].Hosts = append(inventory.Groups[i].Hosts, batch.Nodes...)
+				}
+			}
+		}
+	}
+	if batch.Operation == constant.BatchOperationCreate {
+		admCluster.Kobe.SetVar(facts.SupportGpuName, batch.SupportGpu)
+	}
+	result := newClusterDryRun(operation, inventory, steps)
+	result.Cluster = cluster.Name
+	result.Hosts = hosts
+	return result, nil
+}
+
+// planHosts 计算自动模式下需要创建的主机规格及所在可用区
+func (c clusterDryRunService) planHosts(plan model.Plan, names []string) ([]dto.ClusterDryRunHost, error) {
+	if len(plan.Zones) == 0 {
+		return nil, fmt.Errorf("plan %s has no zone", plan.Name)
+	}
+	planVars := map[string]string{}
+	_ = json.Unmarshal([]byte(plan.Vars), &planVars)
+	var hosts []dto.ClusterDryRunHost
+	for i, name := range names {
+		role := getHostRole(name)
+		host := dto.ClusterDryRunHost{
+			Name:   name,
+			Role:   role,
+			Zone:   plan.Zones[i%len(plan.Zones)].Name,
+			Action: constant.DryRunHostActionCreate,
+		}
+		if plan.Region.Provider != constant.OpenStack {
+			config, err := c.vmConfigRepo.Get(planVars[fmt.Sprintf("%sModel", role)])
+			if err != nil {
+				return nil, err
+			}
+			host.Cpu = config.Cpu
+			host.Memory = config.Memory * 1024
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
+
+// newClusterDryRun 复制 inventory 并隐藏主机密码、私钥和敏感变量，Vars 为合并后的变量
+func newClusterDryRun(operation string, inventory *api.Inventory, steps []adm.PlanStep) *dto.ClusterDryRun {
+	result := &dto.ClusterDryRun{Operation: operation}
+	for _, s := range steps {
+		result.Steps = append(result.Steps, dto.ClusterDryRunStep{Name: s.Name, Playbook: s.Playbook, Tag: s.Tag})
+	}
+	if inventory == nil {
+		return result
+	}
+	masked := &api.Inventory{Vars: maskDryRunVars(inventory.Vars)}
+	for _, g := range inventory.Groups {
+		masked.Groups = append(masked.Groups, &api.Group{
+			Name:     g.Name,
+			Hosts:    g.Hosts,
+			Children: g.Children,
+			Vars:     maskDryRunVars(g.Vars),
+		})
+	}
+	for _, h := range inventory.Hosts {
+		host := api.Host{
+			Ip:         h.Ip,
+			Name:       h.Name,
+			Port:       h.Port,
+			User:       h.User,
+			Password:   h.Password,
+			PrivateKey: h.PrivateKey,
+			Vars:       maskDryRunVars(h.Vars),
+		}
+		if host.Password != "" {
+			host.Password = dryRunMask
+		}
+		if host.PrivateKey != "" {
+			host.PrivateKey = dryRunMask
+		}
+		masked.Hosts = append(masked.Hosts, &host)
+	}
+	result.Inventory = masked
+	result.Vars = masked.Vars
+	return result
+}
+
+// 变量名包含以下关键字时按主机密码的规则隐藏，如仓库、nexus 的账号密码和 kubeadm token
+var dryRunSecretKeywords = []string{"password", "passwd", "secret", "token", "private_key", "access_key", "credential"}
+
+func maskDryRunVars(vars map[string]string) map[string]string {
+	if vars == nil {
+		return nil
+	}
+	masked := make(map[string]string, len(vars))
+	for k, v := range vars {
+		masked[k] = v
+		if v == "" {
+			continue
+		}
+		key := strings.ToLower(k)
+		for _, keyword := range dryRunSecretKeywords {
+			if strings.Contains(key, keyword) {
+				masked[k] = dryRunMask
+				break
+			}
+		}
+	}
+	return masked
+}
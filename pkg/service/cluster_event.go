@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/kmpp/pkg/constant"
-	"github.com/kmpp/pkg/db"
 	"github.com/kmpp/pkg/dto"
 	"github.com/kmpp/pkg/model"
 	"github.com/kmpp/pkg/repository"
@@ -140,15 +139,9 @@ func (c clusterEventService) CreateNpd(clusterName string) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	var registery model.SystemRegistry
-	if cluster.Spec.Architectures == constant.ArchAMD64 {
-		if err := db.DB.Where("architecture = ?", constant.ArchitectureOfAMD64).First(&registery).Error; err != nil {
-			return false, errors.New("load image pull port failed")
-		}
-	} else {
-		if err := db.DB.Where("architecture = ?", constant.ArchitectureOfARM64).First(&registery).Error; err != nil {
-			return false, errors.New("load image pull port failed")
-		}
+	registery, err := model.GetActiveRegistry(model.RegistryArchitecture(cluster.Spec.Architectures))
+	if err != nil {
+		return false, errors.New("load image pull port failed")
 	}
 	localRepoPort := registery.RegistryPort
 
@@ -0,0 +1,387 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/repository"
+	"github.com/kmpp/pkg/util/encrypt"
+	"github.com/kmpp/pkg/util/f5"
+	kubeUtil "github.com/kmpp/pkg/util/kubernetes"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	f5VarURL       = "url"
+	f5VarUser      = "user"
+	f5VarPassword  = "password"
+	f5VarPartition = "partition"
+	f5VarPublicIP  = "publicIP"
+
+	f5CommonPartition = "Common"
+)
+
+var (
+	F5SettingExists   = "F5_SETTING_EXISTS"
+	F5SettingRequired = "F5_SETTING_REQUIRED"
+	F5PartitionCommon = "F5_PARTITION_COMMON_NOT_ALLOWED"
+)
+
+// ClusterF5Service 管理集群的 F5 BIG-IP 配置，并在集群中部署 F5 Container Ingress Services
+type ClusterF5Service interface {
+	Get(clusterName string) (*dto.F5Setting, error)
+	Create(clusterName string, req dto.F5SettingCreate) (*dto.F5Setting, error)
+	Update(clusterName string, req dto.F5SettingUpdate) (*dto.F5Setting, error)
+	Delete(clusterName string) error
+	Check(clusterName string) (*dto.F5Setting, error)
+}
+
+func NewClusterF5Service() ClusterF5Service {
+	return &clusterF5Service{
+		clusterService: NewClusterService(),
+		clusterRepo:    repository.NewClusterRepository(),
+		f5Repo:         repository.NewF5Repository(),
+	}
+}
+
+type clusterF5Service struct {
+	clusterService ClusterService
+	clusterRepo    repository.ClusterRepository
+	f5Repo         repository.F5Repository
+}
+
+func (c clusterF5Service) Get(clusterName string) (*dto.F5Setting, error) {
+	cluster, err := c.clusterRepo.Get(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	setting, err := c.f5Repo.Get(cluster.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.F5Setting{F5Setting: *setting, ClusterName: cluster.Name}, nil
+}
+
+func (c clusterF5Service) Create(clusterName string, req dto.F5SettingCreate) (*dto.F5Setting, error) {
+	cluster, err := c.clusterRepo.Get(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if old, _ := c.f5Repo.Get(cluster.ID); old != nil {
+		return nil, errors.New(F5SettingExists)
+	}
+	setting := model.F5Setting{ClusterID: cluster.ID}
+	if err := c.save(cluster, &setting, req.Vars); err != nil {
+		return nil, err
+	}
+	return &dto.F5Setting{F5Setting: setting, ClusterName: cluster.Name}, nil
+}
+
+func (c clusterF5Service) Update(clusterName string, req dto.F5SettingUpdate) (*dto.F5Setting, error) {
+	cluster, err := c.clusterRepo.Get(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	setting, err := c.f5Repo.Get(cluster.ID)
+	if err != nil {
+		return nil, err
+	}
+	// 未填写密码时沿用原密码
+	if req.Vars[f5VarPassword] == "" {
+		password, err := encrypt.StringDecrypt(setting.Password)
+		if err != nil {
+			return nil, err
+		}
+		req.Vars[f5VarPassword] = password
+	}
+	if err := c.save(cluster, setting, req.Vars); err != nil {
+		return nil, err
+	}
+	return &dto.F5Setting{F5Setting: *setting, ClusterName: cluster.Name}, nil
+}
+
+func (c clusterF5Service) Delete(clusterName string) error {
+	cluster, err := c.clusterRepo.Get(clusterName)
+	if err != nil {
+		return err
+	}
+	if _, err := c.f5Repo.Get(cluster.ID); err != nil {
+		return err
+	}
+	if err := c.undeploy(cluster.Name); err != nil {
+		return err
+	}
+	return c.f5Repo.Delete(cluster.ID)
+}
+
+// Check 检查 BIG-IP 是否可用以及 CIS 是否就绪，并更新状态
+func (c clusterF5Service) Check(clusterName string) (*dto.F5Setting, error) {
+	cluster, err := c.clusterRepo.Get(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	setting, err := c.f5Repo.Get(cluster.ID)
+	if err != nil {
+		return nil, err
+	}
+	setting.Status, setting.Message = constant.StatusRunning, ""
+	if err := c.check(cluster.Name, setting); err != nil {
+		setting.Status, setting.Message = constant.StatusFailed, err.Error()
+	}
+	if err := c.f5Repo.Save(setting); err != nil {
+		return nil, err
+	}
+	return &dto.F5Setting{F5Setting: *setting, ClusterName: cluster.Name}, nil
+}
+
+func (c clusterF5Service) check(clusterName string, setting *model.F5Setting) error {
+	password, err := encrypt.StringDecrypt(setting.Password)
+	if err != nil {
+		return err
+	}
+	client := f5.NewClient(&f5.Config{URL: setting.URL, User: setting.User, Password: password})
+	if _, err := client.Version(); err != nil {
+		return err
+	}
+	kubeClient, err := c.kubeClient(clusterName)
+	if err != nil {
+		return err
+	}
+	deployment, err := kubeClient.AppsV1().Deployments(constant.F5Namespace).Get(context.TODO(), constant.F5CisDeploymentName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if deployment.Status.ReadyReplicas < 1 {
+		return fmt.Errorf("deployment %s is not ready", constant.F5CisDeploymentName)
+	}
+	return nil
+}
+
+// save 校验 BIG-IP 账号和分区，加密保存后在后台部署 CIS
+func (c clusterF5Service) save(cluster model.Cluster, setting *model.F5Setting, vars map[string]string) error {
+	for _, key := range []string{f5VarURL, f5VarUser, f5VarPassword, f5VarPartition} {
+		if vars[key] == "" {
+			return errors.New(F5SettingRequired)
+		}
+	}
+	// CIS 不允许管理 Common 分区
+	if vars[f5VarPartition] == f5CommonPartition {
+		return errors.New(F5PartitionCommon)
+	}
+	client := f5.NewClient(&f5.Config{URL: vars[f5VarURL], User: vars[f5VarUser], Password: vars[f5VarPassword]})
+	if _, err := client.Version(); err != nil {
+		return err
+	}
+	if err := client.CheckPartition(vars[f5VarPartition]); err != nil {
+		return err
+	}
+	password, err := encrypt.StringEncrypt(vars[f5VarPassword])
+	if err != nil {
+		return err
+	}
+	setting.URL = vars[f5VarURL]
+	setting.User = vars[f5VarUser]
+	setting.Password = password
+	setting.Partition = vars[f5VarPartition]
+	setting.PublicIP = vars[f5VarPublicIP]
+	setting.Status = constant.StatusCreating
+	setting.Message = ""
+	if err := c.f5Repo.Save(setting); err != nil {
+		return err
+	}
+	go c.deploy(cluster, *setting, vars[f5VarPassword])
+	return nil
+}
+
+func (c clusterF5Service) deploy(cluster model.Cluster, setting model.F5Setting, password string) {
+	setting.Status, setting.Message = constant.StatusRunning, ""
+	if err := c.doDeploy(cluster, setting, password); err != nil {
+		logger.Log.Errorf("deploy f5 cis to cluster %s failed: %s", cluster.Name, err.Error())
+		setting.Status, setting.Message = constant.StatusFailed, err.Error()
+	}
+	if err := db.DB.Model(&setting).Updates(map[string]interface{}{"status": setting.Status, "message": setting.Message}).Error; err != nil {
+		logger.Log.Errorf("save f5 setting status failed: %s", err.Error())
+	}
+}
+
+func (c clusterF5Service) doDeploy(cluster model.Cluster, setting model.F5Setting, password string) error {
+	registry, err := model.GetActiveRegistry(model.RegistryArchitecture(cluster.Spec.Architectures))
+	if err != nil {
+		return errors.New("load image pull port failed")
+	}
+	endpoints, err := c.clusterService.GetApiServerEndpoints(cluster.Name)
+	if err != nil {
+		return err
+	}
+	secret, err := c.clusterService.GetSecrets(cluster.Name)
+	if err != nil {
+		return err
+	}
+	applier, err := kubeUtil.NewApplier(&kubeUtil.Config{Hosts: endpoints, Token: secret.KubernetesToken})
+	if err != nil {
+		return err
+	}
+	image := fmt.Sprintf("%s:%d/kubeoperator/k8s-bigip-ctlr:%s", constant.LocalRepositoryDomainName, registry.RegistryPort, constant.F5CisVersion)
+	for _, obj := range f5CisObjects(setting, password, image) {
+		data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		if err := applier.Apply(&unstructured.Unstructured{Object: data}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c clusterF5Service) undeploy(clusterName string) error {
+	client, err := c.kubeClient(clusterName)
+	if err != nil {
+		return err
+	}
+	deletes := []func() error{
+		func() error {
+			return client.AppsV1().Deployments(constant.F5Namespace).Delete(context.TODO(), constant.F5CisDeploymentName, metav1.DeleteOptions{})
+		},
+		func() error {
+			return client.RbacV1().ClusterRoleBindings().Delete(context.TODO(), constant.F5CisServiceAccountName, metav1.DeleteOptions{})
+		},
+		func() error {
+			return client.RbacV1().ClusterRoles().Delete(context.TODO(), constant.F5CisServiceAccountName, metav1.DeleteOptions{})
+		},
+		func() error {
+			return client.CoreV1().ServiceAccounts(constant.F5Namespace).Delete(context.TODO(), constant.F5CisServiceAccountName, metav1.DeleteOptions{})
+		},
+		func() error {
+			return client.CoreV1().Secrets(constant.F5Namespace).Delete(context.TODO(), constant.F5CisSecretName, metav1.DeleteOptions{})
+		},
+	}
+	for _, d := range deletes {
+		if err := d(); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c clusterF5Service) kubeClient(clusterName string) (*kubernetes.Clientset, error) {
+	endpoints, err := c.clusterService.GetApiServerEndpoints(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := c.clusterService.GetSecrets(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return kubeUtil.NewKubernetesClient(&kubeUtil.Config{Hosts: endpoints, Token: secret.KubernetesToken})
+}
+
+// f5CisObjects 生成 CIS 所需的凭据、RBAC 和 Deployment
+func f5CisObjects(setting model.F5Setting, password string, image string) []runtime.Object {
+	labels := map[string]string{"app": constant.F5CisDeploymentName}
+	args := []string{
+		"--bigip-username=$(BIGIP_USERNAME)",
+		"--bigip-password=$(BIGIP_PASSWORD)",
+		"--bigip-url=" + setting.URL,
+		"--bigip-partition=" + setting.Partition,
+		"--pool-member-type=nodeport",
+		"--manage-ingress=true",
+		"--insecure=true",
+	}
+	if setting.PublicIP != "" {
+		args = append(args, "--default-ingress-ip="+setting.PublicIP)
+	}
+	replicas := int32(1)
+	return []runtime.Object{
+		&corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Name: constant.F5CisSecretName, Namespace: constant.F5Namespace},
+			Type:       corev1.SecretTypeOpaque,
+			StringData: map[string]string{"username": setting.User, "password": password},
+		},
+		&corev1.ServiceAccount{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ServiceAccount"},
+			ObjectMeta: metav1.ObjectMeta{Name: constant.F5CisServiceAccountName, Namespace: constant.F5Namespace},
+		},
+		&rbacv1.ClusterRole{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+			ObjectMeta: metav1.ObjectMeta{Name: constant.F5CisServiceAccountName},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{"", "extensions", "networking.k8s.io"},
+					Resources: []string{"nodes", "services", "endpoints", "namespaces", "ingresses", "pods", "secrets", "ingressclasses"},
+					Verbs:     []string{"get", "list", "watch"},
+				},
+				{
+					APIGroups: []string{"", "extensions", "networking.k8s.io"},
+					Resources: []string{"configmaps", "events", "ingresses/status", "services/status"},
+					Verbs:     []string{"get", "list", "watch", "update", "create", "patch"},
+				},
+			},
+		},
+		&rbacv1.ClusterRoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{Name: constant.F5CisServiceAccountName},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: "rbac.authorization.k8s.io",
+				Kind:     "ClusterRole",
+				Name:     constant.F5CisServiceAccountName,
+			},
+			Subjects: []rbacv1.Subject{
+				{Kind: "ServiceAccount", Name: constant.F5CisServiceAccountName, Namespace: constant.F5Namespace},
+			},
+		},
+		&appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Name: constant.F5CisDeploymentName, Namespace: constant.F5Namespace, Labels: labels},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec: corev1.PodSpec{
+						ServiceAccountName: constant.F5CisServiceAccountName,
+						Containers: []corev1.Container{
+							{
+								Name:            constant.F5CisDeploymentName,
+								Image:           image,
+								ImagePullPolicy: corev1.PullIfNotPresent,
+								Command:         []string{"/app/bin/k8s-bigip-ctlr"},
+								Args:            args,
+								Env: []corev1.EnvVar{
+									f5SecretEnv("BIGIP_USERNAME", "username"),
+									f5SecretEnv("BIGIP_PASSWORD", "password"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func f5SecretEnv(name, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: constant.F5CisSecretName},
+				Key:                  key,
+			},
+		},
+	}
+}
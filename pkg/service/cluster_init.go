@@ -8,6 +8,7 @@ import (
 	"github.com/kmpp/pkg/constant"
 	"github.com/kmpp/pkg/logger"
 	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/redis"
 	"github.com/kmpp/pkg/repository"
 	"github.com/kmpp/pkg/service/cluster/adm"
 	"github.com/kmpp/pkg/util/ansible"
@@ -22,6 +23,10 @@ type ClusterInitService interface {
 }
 
 func NewClusterInitService() ClusterInitService {
+	return newClusterInitService()
+}
+
+func newClusterInitService() *clusterInitService {
 	return &clusterInitService{
 		clusterRepo:                repository.NewClusterRepository(),
 		clusterNodeRepo:            repository.NewClusterNodeRepository(),
@@ -46,6 +51,21 @@ type clusterInitService struct {
 }
 
 func (c clusterInitService) Init(name string) error {
+	lock, err := lockClusterOperation(name, clusterOperationChange)
+	if err != nil {
+		return err
+	}
+	return c.start(name, lock)
+}
+
+// start 在已持有集群变更锁时开始安装，锁在安装结束后释放，出错时立即释放
+func (c clusterInitService) start(name string, lock *redis.Lock) error {
+	started := false
+	defer func() {
+		if !started {
+			lock.Unlock()
+		}
+	}()
 	cluster, err := c.clusterRepo.Get(name)
 	if err != nil {
 		return err
@@ -77,7 +97,11 @@ func (c clusterInitService) Init(name string) error {
 		"log_id": logId,
 	}).Debugf("get ansible writer log of cluster %s successful, now start to init the cluster", cluster.Name)
 
-	go c.do(cluster, writer)
+	started = true
+	go func() {
+		defer lock.Unlock()
+		c.do(cluster, writer)
+	}()
 	return nil
 }
 
@@ -246,15 +246,9 @@ func NewIstioHelmInfo(cluster model.Cluster, endpoints []kubernetesUtil.Host, se
 		Namespace:     namespace,
 		Architectures: cluster.Spec.Architectures,
 	})
-	var registery model.SystemRegistry
-	if cluster.Spec.Architectures == constant.ArchAMD64 {
-		if err := db.DB.Where("architecture = ?", constant.ArchitectureOfAMD64).First(&registery).Error; err != nil {
-			return p, errors.New("load image pull port failed")
-		}
-	} else {
-		if err := db.DB.Where("architecture = ?", constant.ArchitectureOfARM64).First(&registery).Error; err != nil {
-			return p, errors.New("load image pull port failed")
-		}
+	registery, err := model.GetActiveRegistry(model.RegistryArchitecture(cluster.Spec.Architectures))
+	if err != nil {
+		return p, errors.New("load image pull port failed")
 	}
 	p.LocalhostPort = registery.RegistryPort
 	if err != nil {
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kmpp/pkg/redis"
+)
+
+const (
+	clusterLockTTL = time.Minute
+
+	clusterOperationChange = "change"
+	clusterOperationBackup = "backup"
+)
+
+var ClusterOperationRunningError = "CLUSTER_OPERATION_RUNNING"
+
+// lockClusterOperation 多副本部署时保证同一集群的同类操作同时只在一个副本上执行
+func lockClusterOperation(clusterName, operation string) (*redis.Lock, error) {
+	lock, err := redis.TryLock("cluster/"+clusterName+"/"+operation, clusterLockTTL)
+	if err == redis.ErrLocked {
+		return nil, errors.New(ClusterOperationRunningError)
+	}
+	return lock, err
+}
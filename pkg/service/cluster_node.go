@@ -12,6 +12,7 @@ import (
 	"github.com/kmpp/pkg/dto"
 	"github.com/kmpp/pkg/logger"
 	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/redis"
 	"github.com/kmpp/pkg/repository"
 	"github.com/kmpp/pkg/service/cluster/adm"
 	"github.com/kmpp/pkg/service/cluster/adm/facts"
@@ -28,8 +29,10 @@ type ClusterNodeService interface {
 	Get(clusterName, name string) (*dto.Node, error)
 	List(clusterName string) ([]dto.Node, error)
 	Batch(clusterName string, batch dto.NodeBatch) error
+	Replace(clusterName string, replace dto.NodeReplace) error
 	Recreate(clusterName string, name string) error
 	Page(num, size int, clusterName string) (*dto.NodePage, error)
+	RepointRegistry(clusterName string) error
 }
 
 func NewClusterNodeService() ClusterNodeService {
@@ -44,6 +47,9 @@ func NewClusterNodeService() ClusterNodeService {
 		vmConfigRepo:        repository.NewVmConfigRepository(),
 		hostService:         NewHostService(),
 		planService:         NewPlanService(),
+		backupFileRepo:      repository.NewClusterBackupFileRepository(),
+		backupStrategyRepo:  repository.NewClusterBackupStrategyRepository(),
+		backupAccountRepo:   repository.NewBackupAccountRepository(),
 	}
 }
 
@@ -58,6 +64,9 @@ type clusterNodeService struct {
 	messageService      MessageService
 	vmConfigRepo        repository.VmConfigRepository
 	hostService         HostService
+	backupFileRepo      repository.ClusterBackupFileRepository
+	backupStrategyRepo  repository.ClusterBackupStrategyRepository
+	backupAccountRepo   repository.BackupAccountRepository
 }
 
 func (c *clusterNodeService) Get(clusterName, name string) (*dto.Node, error) {
@@ -304,6 +313,16 @@ func (c *clusterNodeService) Batch(clusterName string, item dto.NodeBatch) error
 	if err != nil {
 		return fmt.Errorf("can not found %s", clusterName)
 	}
+	lock, err := lockClusterOperation(clusterName, clusterOperationChange)
+	if err != nil {
+		return err
+	}
+	started := false
+	defer func() {
+		if !started {
+			lock.Unlock()
+		}
+	}()
 	var currentNodes []model.ClusterNode
 	if err := db.DB.Where("cluster_id = ?", cluster.ID).Preload("Host").Preload("Host.Credential").Preload("Host.Zone").Find(&currentNodes).Error; err != nil {
 		return fmt.Errorf("can not read cluster %s current nodes %s", cluster.Name, err.Error())
@@ -313,17 +332,23 @@ func (c *clusterNodeService) Batch(clusterName string, item dto.NodeBatch) error
 			return errors.New("NODE_ALREADY_RUNNING_TASK")
 		}
 	}
-	switch item.Operation {
-	case constant.BatchOperationCreate:
-		return c.batchCreate(&cluster.Cluster, currentNodes, item)
-	case constant.BatchOperationDelete:
-		return c.batchDelete(&cluster.Cluster, currentNodes, item)
+	// 节点变更在后台执行，锁在后台任务结束后释放
+	switch {
+	case item.Role == constant.NodeRoleNameMaster:
+		err = c.batchMaster(&cluster.Cluster, currentNodes, item, lock)
+	case item.Operation == constant.BatchOperationCreate:
+		err = c.batchCreate(&cluster.Cluster, currentNodes, item, lock)
+	case item.Operation == constant.BatchOperationDelete:
+		err = c.batchDelete(&cluster.Cluster, currentNodes, item, lock)
+	default:
+		return nil
 	}
-	return nil
+	started = err == nil
+	return err
 }
 
 // 脏节点只删除数据库数据，正常节点集群中删除节点然后删数据库
-func (c clusterNodeService) batchDelete(cluster *model.Cluster, currentNodes []model.ClusterNode, item dto.NodeBatch) error {
+func (c clusterNodeService) batchDelete(cluster *model.Cluster, currentNodes []model.ClusterNode, item dto.NodeBatch, lock *redis.Lock) error {
 	var (
 		nodesForDelete []model.ClusterNode
 		notDirtyNodes  []model.ClusterNode
@@ -354,7 +379,10 @@ func (c clusterNodeService) batchDelete(cluster *model.Cluster, currentNodes []m
 		return err
 	}
 
-	go c.removeNodes(cluster, currentNodes, notDirtyNodes, hostIDs, hostIPs, nodeIDs)
+	go func() {
+		defer lock.Unlock()
+		c.removeNodes(cluster, currentNodes, notDirtyNodes, hostIDs, hostIPs, nodeIDs)
+	}()
 	return nil
 }
 
@@ -378,7 +406,7 @@ func (c *clusterNodeService) removeNodes(cluster *model.Cluster, currentNodes, n
 			return
 		}
 		if err := c.destroyHosts(cluster, currentNodes, notDirtyNodes); err != nil {
-			logger.Log.Error("destroy host failed error %+v", err)
+			logger.Log.Errorf("destroy host failed error %+v", err)
 		}
 		logger.Log.Info("delete all nodes successful! now start updata cluster datas")
 
@@ -454,7 +482,7 @@ func (c *clusterNodeService) destroyHosts(cluster *model.Cluster, currentNodes [
 	return doInit(k, cluster.Plan, aliveHosts)
 }
 
-func (c clusterNodeService) batchCreate(cluster *model.Cluster, currentNodes []model.ClusterNode, item dto.NodeBatch) error {
+func (c clusterNodeService) batchCreate(cluster *model.Cluster, currentNodes []model.ClusterNode, item dto.NodeBatch, lock *redis.Lock) error {
 	var (
 		newNodes  []model.ClusterNode
 		hostNames []string
@@ -471,7 +499,7 @@ func (c clusterNodeService) batchCreate(cluster *model.Cluster, currentNodes []m
 			Find(&hosts).Error; err != nil {
 			return fmt.Errorf("get hosts failed: %v", err)
 		}
-		ns, err := c.createNodeModels(cluster, currentNodes, hosts)
+		ns, err := c.createNodeModels(cluster, currentNodes, hosts, constant.NodeRoleNameWorker)
 		if err != nil {
 			return fmt.Errorf("create node model failed: %v", err)
 		}
@@ -488,13 +516,16 @@ func (c clusterNodeService) batchCreate(cluster *model.Cluster, currentNodes []m
 		if err != nil {
 			return fmt.Errorf("create host model failed: %v", err)
 		}
-		ns, err := c.createNodeModels(cluster, currentNodes, hosts)
+		ns, err := c.createNodeModels(cluster, currentNodes, hosts, constant.NodeRoleNameWorker)
 		if err != nil {
 			return fmt.Errorf("create node model failed: %v", err)
 		}
 		newNodes = ns
 	}
-	go c.addNodes(cluster, newNodes, item.SupportGpu)
+	go func() {
+		defer lock.Unlock()
+		c.addNodes(cluster, newNodes, item.SupportGpu)
+	}()
 	return nil
 }
 
@@ -587,27 +618,15 @@ func (c clusterNodeService) updataHostInfo(cluster *model.Cluster, newNodeIDs, n
 	return nil
 }
 
-func (c clusterNodeService) createNodeModels(cluster *model.Cluster, currentNodes []model.ClusterNode, hosts []model.Host) ([]model.ClusterNode, error) {
+func (c clusterNodeService) createNodeModels(cluster *model.Cluster, currentNodes []model.ClusterNode, hosts []model.Host, role string) ([]model.ClusterNode, error) {
 	var newNodes []model.ClusterNode
-	hash := map[string]interface{}{}
-	for _, n := range currentNodes {
-		hash[n.Name] = nil
-	}
-	for _, host := range hosts {
-		var name string
-		for i := 1; i < len(currentNodes)+len(hosts); i++ {
-			name = fmt.Sprintf("%s-%s-%d", cluster.Name, constant.NodeRoleNameWorker, i)
-			if _, ok := hash[name]; ok {
-				continue
-			}
-			hash[name] = nil
-			break
-		}
+	names := newNodeNames(cluster.Name, role, currentNodes, len(hosts))
+	for i, host := range hosts {
 		n := model.ClusterNode{
-			Name:      name,
+			Name:      names[i],
 			ClusterID: cluster.ID,
 			HostID:    host.ID,
-			Role:      constant.NodeRoleNameWorker,
+			Role:      role,
 			Status:    constant.ClusterWaiting,
 			Host:      host,
 		}
@@ -629,17 +648,40 @@ func (c clusterNodeService) createNodeModels(cluster *model.Cluster, currentNode
 	return newNodes, nil
 }
 
-func (c clusterNodeService) createHostModels(cluster *model.Cluster, increase int) ([]model.Host, error) {
-	var hosts []*model.Host
+func newWorkerNodeNames(clusterName string, currentNodes []model.ClusterNode, count int) []string {
+	return newNodeNames(clusterName, constant.NodeRoleNameWorker, currentNodes, count)
+}
+
+func newNodeNames(clusterName, role string, currentNodes []model.ClusterNode, count int) []string {
+	var names []string
+	hash := map[string]interface{}{}
+	for _, n := range currentNodes {
+		hash[n.Name] = nil
+	}
+	for j := 0; j < count; j++ {
+		var name string
+		for i := 1; i <= len(currentNodes)+count; i++ {
+			name = fmt.Sprintf("%s-%s-%d", clusterName, role, i)
+			if _, ok := hash[name]; ok {
+				continue
+			}
+			hash[name] = nil
+			break
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func newWorkerHostNames(cluster *model.Cluster, increase int) []string {
+	var names []string
 	hash := map[string]interface{}{}
 	for _, node := range cluster.Nodes {
-		hosts = append(hosts, &node.Host)
 		hash[node.Host.Name] = nil
 	}
-	var newHosts []*model.Host
 	for i := 0; i < increase; i++ {
 		var name string
-		for k := 0; k < increase+len(hosts); k++ {
+		for k := 0; k < increase+len(cluster.Nodes); k++ {
 			n := fmt.Sprintf("%s-worker-%d", cluster.Name, k+1)
 			if _, ok := hash[n]; !ok {
 				name = n
@@ -647,6 +689,14 @@ func (c clusterNodeService) createHostModels(cluster *model.Cluster, increase in
 				break
 			}
 		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func (c clusterNodeService) createHostModels(cluster *model.Cluster, increase int) ([]model.Host, error) {
+	var newHosts []*model.Host
+	for _, name := range newWorkerHostNames(cluster, increase) {
 		newHost := &model.Host{
 			Name:   name,
 			Port:   22,
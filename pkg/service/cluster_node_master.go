@@ -0,0 +1,529 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/errorf"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/redis"
+	"github.com/kmpp/pkg/service/cluster/adm"
+	"github.com/kmpp/pkg/service/cluster/adm/phases"
+	"github.com/kmpp/pkg/service/cluster/adm/phases/backup"
+	"github.com/kmpp/pkg/service/cluster/adm/phases/initial"
+	"github.com/kmpp/pkg/service/cluster/adm/phases/prepare"
+	"github.com/kmpp/pkg/util/ansible"
+	"github.com/kmpp/pkg/util/etcd"
+	"github.com/kmpp/pkg/util/ssh"
+)
+
+// 添加 master 复用集群初始化的 playbook，新节点同时放入 kube-master 和 etcd 组后重新执行
+var addMasterPlaybooks = []string{
+	prepare.BaseSystemConfigPlaybook,
+	prepare.ContainerRuntimePlaybook,
+	prepare.KubernetesComponentPlaybook,
+	prepare.CertificatesPlaybook,
+	initial.EtcdPlaybook,
+	initial.MasterPlaybook,
+	initial.WorkerPlaybook,
+	initial.NetworkPlaybook,
+}
+
+var (
+	addMasterGroups = []string{"new-master", "kube-master", "etcd"}
+	// 删除 master 复用删除 worker 的 playbook，etcd 成员在执行前单独移除
+	removeMasterGroups = []string{"del-master", "del-worker"}
+)
+
+// batchMaster 添加或删除 master 节点，变更后 master 数量须为奇数以保证 etcd 可用
+func (c clusterNodeService) batchMaster(cluster *model.Cluster, currentNodes []model.ClusterNode, item dto.NodeBatch, lock *redis.Lock) error {
+	if cluster.Spec.Provider != constant.ClusterProviderBareMetal {
+		return errors.New("MASTER_SCALE_PROVIDER_NOT_SUPPORTED")
+	}
+	var masters []model.ClusterNode
+	for _, n := range currentNodes {
+		if n.Role == constant.NodeRoleNameMaster {
+			masters = append(masters, n)
+		}
+	}
+	switch item.Operation {
+	case constant.BatchOperationCreate:
+		var hosts []model.Host
+		if err := db.DB.Where("name in (?)", item.Hosts).
+			Preload("Volumes").
+			Preload("Credential").
+			Find(&hosts).Error; err != nil {
+			return fmt.Errorf("get hosts failed: %v", err)
+		}
+		if err := checkMasterHosts(item.Hosts, hosts); err != nil {
+			return err
+		}
+		if err := checkMasterCount(len(masters), len(hosts), 0); err != nil {
+			return err
+		}
+		newNodes, err := c.createNodeModels(cluster, currentNodes, hosts, constant.NodeRoleNameMaster)
+		if err != nil {
+			return fmt.Errorf("create node model failed: %v", err)
+		}
+		go func() {
+			defer lock.Unlock()
+			c.addMasters(cluster, newNodes)
+		}()
+		return nil
+	case constant.BatchOperationDelete:
+		nodes, err := mastersByName(currentNodes, item.Nodes)
+		if err != nil {
+			return err
+		}
+		if err := checkMasterCount(len(masters), 0, len(nodes)); err != nil {
+			return err
+		}
+		if err := db.DB.Model(&model.ClusterNode{}).Where("id in (?)", nodeIDs(nodes)).
+			Updates(map[string]interface{}{"Status": constant.StatusTerminating, "PreStatus": constant.StatusRunning, "Message": ""}).Error; err != nil {
+			return err
+		}
+		go func() {
+			defer lock.Unlock()
+			c.removeMasters(cluster, nodes)
+		}()
+		return nil
+	}
+	return constant.NotSupportedBatchOperation
+}
+
+// checkMasterCount 变更后至少保留一个 master，且数量须为奇数
+func checkMasterCount(current, add, remove int) error {
+	remain := current + add - remove
+	if remain < 1 {
+		return errors.New("MASTER_KEEP_AT_LEAST_ONE")
+	}
+	if remain%2 == 0 {
+		return errors.New("MASTER_COUNT_MUST_BE_ODD")
+	}
+	return nil
+}
+
+// etcdQuorumLost 除被替换节点外运行中的 master 不足半数以上时，etcd 已无法变更成员
+func etcdQuorumLost(masters, running int) bool {
+	return running < masters/2+1
+}
+
+// checkMasterHosts 要添加的主机须全部存在且未加入任何集群
+func checkMasterHosts(names []string, hosts []model.Host) error {
+	var errs errorf.CErrFs
+	for _, name := range names {
+		var host *model.Host
+		for i := range hosts {
+			if hosts[i].Name == name {
+				host = &hosts[i]
+			}
+		}
+		if host == nil {
+			errs = errs.Add(errorf.New("HOST_IS_NOT_FOUND", name))
+		} else if host.ClusterID != "" {
+			errs = errs.Add(errorf.New("HOST_ALREADY_IN_CLUSTER", name))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// mastersByName 按名称找到要删除的 master，名称不存在或不是 master 时返回错误
+func mastersByName(currentNodes []model.ClusterNode, names []string) ([]model.ClusterNode, error) {
+	var (
+		nodes []model.ClusterNode
+		errs  errorf.CErrFs
+	)
+	for _, name := range names {
+		var node *model.ClusterNode
+		for i := range currentNodes {
+			if currentNodes[i].Name == name {
+				node = &currentNodes[i]
+			}
+		}
+		if node == nil {
+			errs = errs.Add(errorf.New("NODE_IS_NOT_FOUND", name))
+			continue
+		}
+		if node.Role != constant.NodeRoleNameMaster {
+			return nil, errors.New("NODE_ROLE_NOT_MASTER")
+		}
+		nodes = append(nodes, *node)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return nodes, nil
+}
+
+func (c clusterNodeService) addMasters(cluster *model.Cluster, newNodes []model.ClusterNode) {
+	ids := nodeIDs(newNodes)
+	if err := db.DB.Model(&model.ClusterNode{}).Where("id in (?)", ids).
+		Updates(map[string]interface{}{"Status": constant.StatusInitializing}).Error; err != nil {
+		logger.Log.Errorf("can not update node status reason %s", err.Error())
+	}
+	// etcd 同时只能有一个尚未启动的新成员，否则会失去多数成员，逐个加入
+	for i, n := range newNodes {
+		if err := c.joinEtcd(cluster.Name, n); err != nil {
+			c.masterFailed(cluster.Name, ids[i:], constant.StatusInitializing, err)
+			return
+		}
+		if err := c.runMasterPlaybooks(cluster, addMasterGroups, []model.ClusterNode{n}, addMasterPlaybooks...); err != nil {
+			c.masterFailed(cluster.Name, ids[i:], constant.StatusInitializing, err)
+			return
+		}
+		if err := db.DB.Model(&model.ClusterNode{}).Where("id = ?", n.ID).
+			Updates(map[string]interface{}{"Status": constant.StatusRunning, "PreStatus": constant.StatusInitializing}).Error; err != nil {
+			logger.Log.Errorf("can not update node status reason %s", err.Error())
+		}
+	}
+	c.refreshLoadBalancer(cluster)
+}
+
+func (c clusterNodeService) removeMasters(cluster *model.Cluster, nodes []model.ClusterNode) {
+	ids := nodeIDs(nodes)
+	for _, n := range nodes {
+		if err := c.leaveEtcd(cluster.Name, n, nodes); err != nil {
+			c.masterFailed(cluster.Name, ids, constant.StatusTerminating, err)
+			return
+		}
+	}
+	if err := c.runMasterPlaybooks(cluster, removeMasterGroups, nodes, deleteWorkerPlaybook); err != nil {
+		c.masterFailed(cluster.Name, ids, constant.StatusTerminating, err)
+		return
+	}
+	var hostIDs, hostIPs []string
+	for _, n := range nodes {
+		hostIDs = append(hostIDs, n.HostID)
+		hostIPs = append(hostIPs, n.Host.Ip)
+	}
+	tx := db.DB.Begin()
+	if err := tx.Model(&model.Host{}).Where("id in (?)", hostIDs).
+		Updates(map[string]interface{}{"ClusterID": ""}).Error; err != nil {
+		tx.Rollback()
+		c.masterFailed(cluster.Name, ids, constant.StatusTerminating, err)
+		return
+	}
+	if err := tx.Where("resource_id in (?) AND resource_type = ?", hostIDs, constant.ResourceHost).
+		Delete(&model.ClusterResource{}).Error; err != nil {
+		tx.Rollback()
+		c.masterFailed(cluster.Name, ids, constant.StatusTerminating, err)
+		return
+	}
+	if err := tx.Where("id in (?)", ids).Delete(&model.ClusterNode{}).Error; err != nil {
+		tx.Rollback()
+		c.masterFailed(cluster.Name, ids, constant.StatusTerminating, err)
+		return
+	}
+	tx.Commit()
+	c.updateKubeRouter(cluster, hostIPs)
+	c.refreshLoadBalancer(cluster)
+}
+
+// Replace 用新主机替换故障的 master，etcd 失去多数成员时从备份快照恢复
+func (c clusterNodeService) Replace(clusterName string, replace dto.NodeReplace) error {
+	cluster, err := c.ClusterService.Get(clusterName)
+	if err != nil {
+		return fmt.Errorf("can not found %s", clusterName)
+	}
+	if cluster.Spec.Provider != constant.ClusterProviderBareMetal {
+		return errors.New("MASTER_SCALE_PROVIDER_NOT_SUPPORTED")
+	}
+	lock, err := lockClusterOperation(clusterName, clusterOperationChange)
+	if err != nil {
+		return err
+	}
+	started := false
+	defer func() {
+		if !started {
+			lock.Unlock()
+		}
+	}()
+	currentNodes, err := c.NodeRepo.List(clusterName)
+	if err != nil {
+		return err
+	}
+	var (
+		node    *model.ClusterNode
+		masters int
+		others  int
+	)
+	for i := range currentNodes {
+		n := currentNodes[i]
+		if !n.Dirty && (n.Status == constant.StatusCreating || n.Status == constant.StatusInitializing || n.Status == constant.StatusWaiting) {
+			return errors.New("NODE_ALREADY_RUNNING_TASK")
+		}
+		if n.Role != constant.NodeRoleNameMaster {
+			continue
+		}
+		masters++
+		if n.Name == replace.Node {
+			node = &currentNodes[i]
+		} else if n.Status == constant.StatusRunning {
+			others++
+		}
+	}
+	if node == nil {
+		if _, err := mastersByName(currentNodes, []string{replace.Node}); err != nil {
+			return err
+		}
+		return errors.New("NODE_ROLE_NOT_MASTER")
+	}
+	host, err := c.HostRepo.Get(replace.Host)
+	if err != nil {
+		return errorf.CErrFs{errorf.New("HOST_IS_NOT_FOUND", replace.Host)}
+	}
+	if host.ClusterID != "" {
+		return errorf.CErrFs{errorf.New("HOST_ALREADY_IN_CLUSTER", host.Name)}
+	}
+
+	var restore *dto.ClusterBackupFileRestore
+	if etcdQuorumLost(masters, others) {
+		r, err := c.snapshotForReplace(clusterName, cluster.ID, replace.BackupFile)
+		if err != nil {
+			return err
+		}
+		restore = r
+	}
+
+	oldHost := node.Host
+	tx := db.DB.Begin()
+	if err := tx.Model(&model.Host{}).Where("id = ?", oldHost.ID).Updates(map[string]interface{}{"ClusterID": ""}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Model(&model.Host{}).Where("id = ?", host.ID).Updates(map[string]interface{}{"ClusterID": cluster.ID}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Model(&model.ClusterResource{}).
+		Where("resource_id = ? AND resource_type = ?", oldHost.ID, constant.ResourceHost).
+		Updates(map[string]interface{}{"ResourceID": host.ID}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Model(&model.ClusterNode{}).Where("id = ?", node.ID).
+		Updates(map[string]interface{}{"HostID": host.ID, "Status": constant.StatusInitializing, "PreStatus": node.Status, "Message": ""}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+
+	node.HostID = host.ID
+	node.Host = host
+	started = true
+	go func() {
+		defer lock.Unlock()
+		c.replaceMaster(&cluster.Cluster, *node, oldHost.Ip, restore)
+	}()
+	return nil
+}
+
+func (c clusterNodeService) replaceMaster(cluster *model.Cluster, node model.ClusterNode, oldIP string, restore *dto.ClusterBackupFileRestore) {
+	ids := []string{node.ID}
+	if restore == nil {
+		// 故障节点已无法连接，在其他 master 上移除旧成员和节点后以相同名称重新加入
+		old := node
+		old.Host.Ip = oldIP
+		if err := c.leaveEtcd(cluster.Name, old, []model.ClusterNode{node}); err != nil {
+			c.masterFailed(cluster.Name, ids, constant.StatusInitializing, err)
+			return
+		}
+		if err := c.deleteKubeNode(cluster.Name, node); err != nil {
+			c.masterFailed(cluster.Name, ids, constant.StatusInitializing, err)
+			return
+		}
+		if err := c.joinEtcd(cluster.Name, node); err != nil {
+			c.masterFailed(cluster.Name, ids, constant.StatusInitializing, err)
+			return
+		}
+	} else {
+		targetPath := constant.BackupDir + "/" + cluster.Name + "/" + constant.BackupFileDefaultName
+		if err := downloadBackupFile(restore.File, restore.BackupAccount, targetPath); err != nil {
+			c.masterFailed(cluster.Name, ids, constant.StatusInitializing, err)
+			return
+		}
+	}
+	if err := c.runMasterPlaybooks(cluster, addMasterGroups, []model.ClusterNode{node}, addMasterPlaybooks...); err != nil {
+		c.masterFailed(cluster.Name, ids, constant.StatusInitializing, err)
+		return
+	}
+	if err := db.DB.Model(&model.ClusterNode{}).Where("id in (?)", ids).
+		Updates(map[string]interface{}{"Status": constant.StatusRunning, "PreStatus": constant.StatusInitializing}).Error; err != nil {
+		logger.Log.Errorf("can not update node status reason %s", err.Error())
+	}
+	// etcd 已失去多数成员，新 master 加入后从快照恢复整个 etcd 集群
+	if restore != nil {
+		if err := c.runMasterPlaybooks(cluster, nil, nil, backup.RestoreClusterPlaybook); err != nil {
+			c.masterFailed(cluster.Name, ids, constant.StatusInitializing, err)
+			return
+		}
+	}
+	c.updateKubeRouter(cluster, []string{oldIP})
+	c.refreshLoadBalancer(cluster)
+}
+
+// snapshotForReplace 返回指定的备份文件，未指定时使用最新的备份
+func (c clusterNodeService) snapshotForReplace(clusterName, clusterID, fileName string) (*dto.ClusterBackupFileRestore, error) {
+	var file model.ClusterBackupFile
+	if fileName != "" {
+		f, err := c.backupFileRepo.Get(fileName)
+		if err != nil {
+			return nil, err
+		}
+		file = f
+	} else if err := db.DB.Where("cluster_id = ?", clusterID).Order("created_at desc").First(&file).Error; err != nil {
+		return nil, errors.New("MASTER_REPLACE_SNAPSHOT_NOT_FOUND")
+	}
+	strategy, err := c.backupStrategyRepo.Get(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	account, err := c.backupAccountRepo.Get(strategy.BackupAccount.Name)
+	if err != nil {
+		return nil, errors.New("MASTER_REPLACE_SNAPSHOT_NOT_FOUND")
+	}
+	return &dto.ClusterBackupFileRestore{ClusterName: clusterName, Name: file.Name, File: file, BackupAccount: *account}, nil
+}
+
+// runMasterPlaybooks 将 nodes 放入 groups 中的各组后依次执行 playbooks
+func (c clusterNodeService) runMasterPlaybooks(cluster *model.Cluster, groups []string, nodes []model.ClusterNode, playbooks ...string) error {
+	logId, writer, err := ansible.CreateAnsibleLogWriter(cluster.Name)
+	if err != nil {
+		logger.Log.Error(err)
+	}
+	cluster.LogId = logId
+	db.DB.Save(cluster)
+	cluster.Nodes, _ = c.NodeRepo.List(cluster.Name)
+	admCluster := adm.NewCluster(*cluster)
+	inventory := admCluster.Inventory()
+	for i := range inventory.Groups {
+		for _, group := range groups {
+			if inventory.Groups[i].Name != group {
+				continue
+			}
+			for _, n := range nodes {
+				inventory.Groups[i].Hosts = append(inventory.Groups[i].Hosts, n.Name)
+			}
+		}
+	}
+	for _, playbook := range playbooks {
+		if err := phases.RunPlaybookAndGetResult(admCluster.Kobe, playbook, "", writer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// etcdClient 连接一个不在 excludes 中且运行中的 master，用于变更 etcd 成员
+func (c clusterNodeService) etcdClient(clusterName string, excludes []model.ClusterNode) (ssh.Interface, error) {
+	nodes, err := c.NodeRepo.List(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		if n.Role != constant.NodeRoleNameMaster || n.Status != constant.StatusRunning {
+			continue
+		}
+		excluded := false
+		for _, e := range excludes {
+			if e.ID == n.ID {
+				excluded = true
+			}
+		}
+		if excluded {
+			continue
+		}
+		cfg := n.ToSSHConfig()
+		return ssh.New(&cfg)
+	}
+	return nil, fmt.Errorf("no running master left in cluster %s to change etcd members", clusterName)
+}
+
+// joinEtcd 在启动新 master 前将其登记为 etcd 成员
+func (c clusterNodeService) joinEtcd(clusterName string, node model.ClusterNode) error {
+	client, err := c.etcdClient(clusterName, []model.ClusterNode{node})
+	if err != nil {
+		return err
+	}
+	return etcd.AddMember(client, node.Name, node.Host.Ip)
+}
+
+// leaveEtcd 移除 node 的 etcd 成员，excludes 为同批次将被移除的节点
+func (c clusterNodeService) leaveEtcd(clusterName string, node model.ClusterNode, excludes []model.ClusterNode) error {
+	client, err := c.etcdClient(clusterName, append(excludes, node))
+	if err != nil {
+		return err
+	}
+	return etcd.RemoveMember(client, node.Name, node.Host.Ip)
+}
+
+// deleteKubeNode 节点无法连接时不能执行删除 worker 的 playbook，直接删除 kubernetes 节点
+func (c clusterNodeService) deleteKubeNode(clusterName string, node model.ClusterNode) error {
+	client, err := c.etcdClient(clusterName, []model.ClusterNode{node})
+	if err != nil {
+		return err
+	}
+	if out, err := client.CombinedOutput("sudo /usr/local/bin/kubectl delete node " + node.Name + " --ignore-not-found"); err != nil {
+		return fmt.Errorf("delete node %s failed: %s %s", node.Name, err.Error(), string(out))
+	}
+	return nil
+}
+
+// refreshLoadBalancer master 变化后更新各节点上 apiserver 的负载均衡配置
+func (c clusterNodeService) refreshLoadBalancer(cluster *model.Cluster) {
+	if err := c.runMasterPlaybooks(cluster, nil, nil, prepare.LoadBalancerPlaybook); err != nil {
+		logger.Log.Errorf("refresh load balancer of cluster %s failed: %s", cluster.Name, err.Error())
+		_ = c.messageService.SendMessage(constant.System, false, GetContent(constant.ClusterScale, false, err.Error()), cluster.Name, constant.ClusterScale)
+		return
+	}
+	_ = c.messageService.SendMessage(constant.System, true, GetContent(constant.ClusterScale, true, ""), cluster.Name, constant.ClusterScale)
+}
+
+// updateKubeRouter 集群入口指向已移除的 master 时改为第一个运行中的 master
+func (c clusterNodeService) updateKubeRouter(cluster *model.Cluster, removedIPs []string) {
+	removed := false
+	for _, ip := range removedIPs {
+		if ip == cluster.Spec.KubeRouter {
+			removed = true
+		}
+	}
+	if !removed {
+		return
+	}
+	nodes, err := c.NodeRepo.List(cluster.Name)
+	if err != nil {
+		logger.Log.Errorf("can not list nodes of cluster %s: %s", cluster.Name, err.Error())
+		return
+	}
+	for _, n := range nodes {
+		if n.Role == constant.NodeRoleNameMaster && n.Status == constant.StatusRunning {
+			cluster.Spec.KubeRouter = n.Host.Ip
+			db.DB.Save(&cluster.Spec)
+			return
+		}
+	}
+}
+
+func (c clusterNodeService) masterFailed(clusterName string, ids []string, preStatus string, err error) {
+	logger.Log.Errorf("scale master of cluster %s failed: %+v", clusterName, err)
+	_ = c.messageService.SendMessage(constant.System, false, GetContent(constant.ClusterScale, false, err.Error()), clusterName, constant.ClusterScale)
+	if err := db.DB.Model(&model.ClusterNode{}).Where("id in (?)", ids).
+		Updates(map[string]interface{}{"Status": constant.StatusFailed, "PreStatus": preStatus, "Message": err.Error()}).Error; err != nil {
+		logger.Log.Errorf("can not update node status %s", err.Error())
+	}
+}
+
+func nodeIDs(nodes []model.ClusterNode) []string {
+	var ids []string
+	for _, n := range nodes {
+		ids = append(ids, n.ID)
+	}
+	return ids
+}
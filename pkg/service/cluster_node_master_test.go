@@ -0,0 +1,85 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/errorf"
+	"github.com/kmpp/pkg/model"
+)
+
+func TestCheckMasterCount(t *testing.T) {
+	cases := []struct {
+		current, add, remove int
+		err                  string
+	}{
+		{1, 2, 0, ""},
+		{3, 2, 0, ""},
+		{1, 1, 0, "MASTER_COUNT_MUST_BE_ODD"},
+		{3, 0, 2, ""},
+		{3, 0, 1, "MASTER_COUNT_MUST_BE_ODD"},
+		{3, 0, 3, "MASTER_KEEP_AT_LEAST_ONE"},
+		{1, 0, 1, "MASTER_KEEP_AT_LEAST_ONE"},
+	}
+	for _, c := range cases {
+		err := checkMasterCount(c.current, c.add, c.remove)
+		if (err == nil && c.err != "") || (err != nil && err.Error() != c.err) {
+			t.Errorf("%d masters +%d -%d: expected %q, got %v", c.current, c.add, c.remove, c.err, err)
+		}
+	}
+}
+
+func TestEtcdQuorumLost(t *testing.T) {
+	cases := []struct {
+		masters, running int
+		lost             bool
+	}{
+		{1, 0, true},
+		{3, 2, false},
+		{3, 1, true},
+		{5, 3, false},
+		{5, 2, true},
+	}
+	for _, c := range cases {
+		if lost := etcdQuorumLost(c.masters, c.running); lost != c.lost {
+			t.Errorf("%d masters with %d running: expected lost=%v", c.masters, c.running, c.lost)
+		}
+	}
+}
+
+func TestCheckMasterHosts(t *testing.T) {
+	hosts := []model.Host{
+		{Name: "host-1"},
+		{Name: "host-2", ClusterID: "cluster-id"},
+	}
+	if err := checkMasterHosts([]string{"host-1"}, hosts); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	err := checkMasterHosts([]string{"host-1", "host-2", "host-3"}, hosts)
+	errs, ok := err.(errorf.CErrFs)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("expected two errors, got %v", err)
+	}
+	if errs[0].Msg != "HOST_ALREADY_IN_CLUSTER" || errs[1].Msg != "HOST_IS_NOT_FOUND" {
+		t.Errorf("unexpected errors %+v", errs)
+	}
+}
+
+func TestMastersByName(t *testing.T) {
+	nodes := []model.ClusterNode{
+		{ID: "1", Name: "test-master-1", Role: constant.NodeRoleNameMaster},
+		{ID: "2", Name: "test-master-2", Role: constant.NodeRoleNameMaster},
+		{ID: "3", Name: "test-worker-1", Role: constant.NodeRoleNameWorker},
+	}
+	masters, err := mastersByName(nodes, []string{"test-master-2"})
+	if err != nil || len(masters) != 1 || masters[0].ID != "2" {
+		t.Errorf("unexpected result %+v %v", masters, err)
+	}
+	if _, err := mastersByName(nodes, []string{"test-worker-1"}); err == nil || err.Error() != "NODE_ROLE_NOT_MASTER" {
+		t.Errorf("expected NODE_ROLE_NOT_MASTER, got %v", err)
+	}
+	_, err = mastersByName(nodes, []string{"test-master-1", "test-master-9"})
+	if errs, ok := err.(errorf.CErrFs); !ok || len(errs) != 1 || errs[0].Msg != "NODE_IS_NOT_FOUND" {
+		t.Errorf("expected NODE_IS_NOT_FOUND, got %v", err)
+	}
+}
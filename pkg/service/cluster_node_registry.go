@@ -0,0 +1,38 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/service/cluster/adm/phases/prepare"
+)
+
+// RepointRegistry 将集群所有节点的软件源、镜像仓库地址切换到当前可用的仓库
+func (c clusterNodeService) RepointRegistry(clusterName string) error {
+	cluster, err := c.clusterRepo.Get(clusterName)
+	if err != nil {
+		return err
+	}
+	if cluster.Source == constant.ClusterSourceExternal {
+		return errors.New("CLUSTER_REGISTRY_REPOINT_NOT_SUPPORTED")
+	}
+	if cluster.Status.Phase != constant.StatusRunning {
+		return errors.New("CLUSTER_IS_NOT_RUNNING")
+	}
+	if _, err := model.GetActiveRegistry(model.RegistryArchitecture(cluster.Spec.Architectures)); err != nil {
+		return err
+	}
+	go func() {
+		for _, playbook := range []string{prepare.BaseSystemConfigPlaybook, prepare.ContainerRuntimePlaybook} {
+			if err := c.runMasterPlaybooks(&cluster, nil, nil, playbook); err != nil {
+				logger.Log.Errorf("repoint registry of cluster %s error %s", clusterName, err.Error())
+				_ = c.messageService.SendMessage(constant.System, false, GetContent(constant.ClusterRepointRegistry, false, err.Error()), clusterName, constant.ClusterRepointRegistry)
+				return
+			}
+		}
+		_ = c.messageService.SendMessage(constant.System, true, GetContent(constant.ClusterRepointRegistry, true, ""), clusterName, constant.ClusterRepointRegistry)
+	}()
+	return nil
+}
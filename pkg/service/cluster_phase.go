@@ -0,0 +1,166 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/repository"
+	"github.com/kmpp/pkg/service/cluster/adm"
+)
+
+type ClusterPhaseService interface {
+	List(clusterName string) (*dto.ClusterPhases, error)
+	Operate(clusterName string, operation dto.ClusterPhaseOperation) error
+}
+
+func NewClusterPhaseService() ClusterPhaseService {
+	return &clusterPhaseService{
+		clusterRepo:        repository.NewClusterRepository(),
+		clusterStatusRepo:  repository.NewClusterStatusRepository(),
+		clusterInitService: newClusterInitService(),
+	}
+}
+
+type clusterPhaseService struct {
+	clusterRepo        repository.ClusterRepository
+	clusterStatusRepo  repository.ClusterStatusRepository
+	clusterInitService *clusterInitService
+}
+
+// List 按执行顺序返回集群安装(或升级)各阶段的状态，未执行的阶段状态为空
+func (c clusterPhaseService) List(clusterName string) (*dto.ClusterPhases, error) {
+	cluster, err := c.clusterRepo.Get(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	status, err := c.clusterStatusRepo.Get(cluster.StatusID)
+	if err != nil {
+		return nil, err
+	}
+	ca := adm.NewClusterAdm()
+	phases := &dto.ClusterPhases{Type: constant.ClusterPhaseTypeCreate}
+	names := ca.CreatePhases()
+	if containsAnyCondition(status.ClusterStatusConditions, ca.UpgradePhases()) {
+		phases.Type = constant.ClusterPhaseTypeUpgrade
+		names = ca.UpgradePhases()
+	}
+	for _, name := range names {
+		phase := dto.ClusterPhase{Name: name}
+		if condition := findCondition(status.ClusterStatusConditions, name); condition != nil {
+			phase.Status = condition.Status
+			phase.Message = condition.Message
+			phase.LastProbeTime = condition.LastProbeTime
+		}
+		phases.Phases = append(phases.Phases, phase)
+	}
+	return phases, nil
+}
+
+// Operate 对安装失败的集群从指定阶段重试或跳过指定阶段，之前已完成的阶段不会重复执行
+func (c clusterPhaseService) Operate(clusterName string, operation dto.ClusterPhaseOperation) error {
+	cluster, err := c.clusterRepo.Get(clusterName)
+	if err != nil {
+		return err
+	}
+	lock, err := lockClusterOperation(clusterName, clusterOperationChange)
+	if err != nil {
+		return err
+	}
+	started := false
+	defer func() {
+		if !started {
+			lock.Unlock()
+		}
+	}()
+	status, err := c.clusterStatusRepo.Get(cluster.StatusID)
+	if err != nil {
+		return err
+	}
+	if status.Phase != constant.ClusterFailed || status.PrePhase != constant.ClusterInitializing {
+		return errors.New("CLUSTER_PHASE_NOT_FAILED")
+	}
+	conditions, err := phaseConditions(adm.NewClusterAdm().CreatePhases(), status.ClusterStatusConditions, operation, time.Now())
+	if err != nil {
+		return err
+	}
+	status.ClusterStatusConditions = conditions
+	status.Message = ""
+	if err := c.clusterStatusRepo.Save(&status); err != nil {
+		return err
+	}
+	// 安装任务接管锁，由其负责释放
+	started = true
+	return c.clusterInitService.start(clusterName, lock)
+}
+
+// phaseConditions 计算重试或跳过后的阶段状态：保留之前已完成的阶段，重试的阶段或跳过阶段的下一阶段置为待执行
+func phaseConditions(names []string, current []model.ClusterStatusCondition, operation dto.ClusterPhaseOperation, now time.Time) ([]model.ClusterStatusCondition, error) {
+	index := -1
+	for i := range names {
+		if names[i] == operation.Phase {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return nil, errors.New("CLUSTER_PHASE_NOT_FOUND")
+	}
+	var conditions []model.ClusterStatusCondition
+	for _, name := range names[:index] {
+		condition := findCondition(current, name)
+		if condition == nil || condition.Status != constant.ConditionTrue {
+			return nil, errors.New("CLUSTER_PHASE_PREVIOUS_NOT_DONE")
+		}
+		conditions = append(conditions, *condition)
+	}
+	switch operation.Operation {
+	case constant.ClusterPhaseOperationRetry:
+		conditions = append(conditions, model.ClusterStatusCondition{
+			Name:          operation.Phase,
+			Status:        constant.ConditionUnknown,
+			LastProbeTime: now,
+		})
+	case constant.ClusterPhaseOperationSkip:
+		condition := findCondition(current, operation.Phase)
+		if condition == nil || condition.Status != constant.ConditionFalse {
+			return nil, errors.New("CLUSTER_PHASE_NOT_FAILED")
+		}
+		conditions = append(conditions, model.ClusterStatusCondition{
+			Name:          operation.Phase,
+			Status:        constant.ConditionTrue,
+			LastProbeTime: now,
+			Message:       constant.ClusterPhaseSkippedMessage,
+		})
+		if index+1 < len(names) {
+			conditions = append(conditions, model.ClusterStatusCondition{
+				Name:          names[index+1],
+				Status:        constant.ConditionUnknown,
+				LastProbeTime: now,
+			})
+		}
+	default:
+		return nil, errors.New("CLUSTER_PHASE_OPERATION_INVALID")
+	}
+	return conditions, nil
+}
+
+func findCondition(conditions []model.ClusterStatusCondition, name string) *model.ClusterStatusCondition {
+	for i := range conditions {
+		if conditions[i].Name == name {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+func containsAnyCondition(conditions []model.ClusterStatusCondition, names []string) bool {
+	for _, name := range names {
+		if findCondition(conditions, name) != nil {
+			return true
+		}
+	}
+	return false
+}
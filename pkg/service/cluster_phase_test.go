@@ -0,0 +1,52 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/model"
+)
+
+func TestPhaseConditions(t *testing.T) {
+	names := []string{"prepare", "etcd", "master", "worker"}
+	current := []model.ClusterStatusCondition{
+		{Name: "prepare", Status: constant.ConditionTrue},
+		{Name: "etcd", Status: constant.ConditionTrue},
+		{Name: "master", Status: constant.ConditionFalse, Message: "timeout"},
+	}
+	now := time.Now()
+
+	conditions, err := phaseConditions(names, current, dto.ClusterPhaseOperation{Phase: "master", Operation: constant.ClusterPhaseOperationRetry}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conditions) != 3 || conditions[2].Name != "master" || conditions[2].Status != constant.ConditionUnknown || conditions[2].Message != "" {
+		t.Errorf("retry should rerun master: %+v", conditions)
+	}
+
+	conditions, err = phaseConditions(names, current, dto.ClusterPhaseOperation{Phase: "master", Operation: constant.ClusterPhaseOperationSkip}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conditions) != 4 || conditions[2].Status != constant.ConditionTrue || conditions[2].Message != constant.ClusterPhaseSkippedMessage ||
+		conditions[3].Name != "worker" || conditions[3].Status != constant.ConditionUnknown {
+		t.Errorf("skip should mark master done and continue from worker: %+v", conditions)
+	}
+
+	cases := []struct {
+		operation dto.ClusterPhaseOperation
+		err       string
+	}{
+		{dto.ClusterPhaseOperation{Phase: "storage", Operation: constant.ClusterPhaseOperationRetry}, "CLUSTER_PHASE_NOT_FOUND"},
+		{dto.ClusterPhaseOperation{Phase: "worker", Operation: constant.ClusterPhaseOperationRetry}, "CLUSTER_PHASE_PREVIOUS_NOT_DONE"},
+		{dto.ClusterPhaseOperation{Phase: "etcd", Operation: constant.ClusterPhaseOperationSkip}, "CLUSTER_PHASE_NOT_FAILED"},
+		{dto.ClusterPhaseOperation{Phase: "master", Operation: "delete"}, "CLUSTER_PHASE_OPERATION_INVALID"},
+	}
+	for _, c := range cases {
+		if _, err := phaseConditions(names, current, c.operation, now); err == nil || err.Error() != c.err {
+			t.Errorf("%s %s: expected %s, got %v", c.operation.Operation, c.operation.Phase, c.err, err)
+		}
+	}
+}
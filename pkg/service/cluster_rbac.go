@@ -0,0 +1,141 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/model"
+	kubeUtil "github.com/kmpp/pkg/util/kubernetes"
+	"github.com/jinzhu/gorm"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var (
+	ClusterAccessDenied = "CLUSTER_ACCESS_DENIED"
+)
+
+// ClusterRbacService 将 kmpp 的集群/项目成员角色映射为 Kubernetes 模拟身份，并同步对应的 ClusterRoleBinding
+type ClusterRbacService interface {
+	GetIdentity(clusterName string, user dto.SessionUser) (*dto.KubernetesIdentity, error)
+	Sync(clusterName string) error
+}
+
+func NewClusterRbacService() ClusterRbacService {
+	return &clusterRbacService{
+		clusterService: NewClusterService(),
+	}
+}
+
+type clusterRbacService struct {
+	clusterService ClusterService
+}
+
+func (c clusterRbacService) GetIdentity(clusterName string, user dto.SessionUser) (*dto.KubernetesIdentity, error) {
+	if user.Name == "" {
+		return nil, errors.New(ClusterAccessDenied)
+	}
+	identity := &dto.KubernetesIdentity{User: constant.KubernetesUserPrefix + user.Name}
+	if user.IsAdmin {
+		identity.Groups = kubernetesGroups(true, "", "")
+		return identity, nil
+	}
+	var cluster model.Cluster
+	if err := db.DB.Where("name = ?", clusterName).First(&cluster).Error; err != nil {
+		return nil, err
+	}
+	var cm model.ClusterMember
+	if err := db.DB.Where("cluster_id = ? AND user_id = ?", cluster.ID, user.UserId).First(&cm).Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+		return nil, err
+	}
+	var pm model.ProjectMember
+	var pr model.ProjectResource
+	err := db.DB.Where("resource_id = ? AND resource_type = ?", cluster.ID, constant.ResourceCluster).First(&pr).Error
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return nil, err
+	}
+	if err == nil {
+		if err := db.DB.Where("project_id = ? AND user_id = ?", pr.ProjectID, user.UserId).First(&pm).Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+			return nil, err
+		}
+	}
+	identity.Groups = kubernetesGroups(false, cm.Role, pm.Role)
+	if len(identity.Groups) == 0 {
+		return nil, errors.New(ClusterAccessDenied)
+	}
+	return identity, nil
+}
+
+func (c clusterRbacService) Sync(clusterName string) error {
+	endpoints, err := c.clusterService.GetApiServerEndpoints(clusterName)
+	if err != nil {
+		return err
+	}
+	secret, err := c.clusterService.GetSecrets(clusterName)
+	if err != nil {
+		return err
+	}
+	applier, err := kubeUtil.NewApplier(&kubeUtil.Config{Hosts: endpoints, Token: secret.KubernetesToken})
+	if err != nil {
+		return err
+	}
+	for _, obj := range kubernetesRoleBindings() {
+		data, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return err
+		}
+		if err := applier.Apply(&unstructured.Unstructured{Object: data}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// kubernetesGroups 根据系统管理员标识、集群成员角色和项目成员角色计算模拟的用户组，无权限时返回空
+func kubernetesGroups(isAdmin bool, clusterRole, projectRole string) []string {
+	if isAdmin {
+		return []string{constant.KubernetesGroupAdmin}
+	}
+	switch {
+	case clusterRole == constant.ProjectRoleClusterManager,
+		projectRole == constant.ProjectRoleProjectManager,
+		projectRole == constant.ProjectRoleClusterManager:
+		return []string{constant.KubernetesGroupClusterManager}
+	case projectRole == constant.ProjectRoleProjectViewer:
+		return []string{constant.KubernetesGroupViewer}
+	}
+	return nil
+}
+
+// kubernetesRoleBindings 生成 kmpp 用户组到集群内置 ClusterRole 的绑定
+func kubernetesRoleBindings() []runtime.Object {
+	bindings := []struct {
+		name  string
+		group string
+		role  string
+	}{
+		{"kmpp-admins", constant.KubernetesGroupAdmin, "cluster-admin"},
+		{"kmpp-cluster-managers", constant.KubernetesGroupClusterManager, "cluster-admin"},
+		{"kmpp-viewers", constant.KubernetesGroupViewer, "view"},
+	}
+	var objs []runtime.Object
+	for _, b := range bindings {
+		objs = append(objs, &rbacv1.ClusterRoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{Name: b.name, Labels: map[string]string{"app.kubernetes.io/managed-by": "kmpp"}},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: "rbac.authorization.k8s.io",
+				Kind:     "ClusterRole",
+				Name:     b.role,
+			},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.GroupKind, APIGroup: "rbac.authorization.k8s.io", Name: b.group},
+			},
+		})
+	}
+	return objs
+}
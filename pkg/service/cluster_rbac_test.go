@@ -0,0 +1,30 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kmpp/pkg/constant"
+)
+
+func TestKubernetesGroups(t *testing.T) {
+	cases := []struct {
+		isAdmin     bool
+		clusterRole string
+		projectRole string
+		want        []string
+	}{
+		{true, "", "", []string{constant.KubernetesGroupAdmin}},
+		{false, constant.ProjectRoleClusterManager, "", []string{constant.KubernetesGroupClusterManager}},
+		{false, "", constant.ProjectRoleProjectManager, []string{constant.KubernetesGroupClusterManager}},
+		{false, constant.ProjectRoleClusterManager, constant.ProjectRoleProjectViewer, []string{constant.KubernetesGroupClusterManager}},
+		{false, "", constant.ProjectRoleProjectViewer, []string{constant.KubernetesGroupViewer}},
+		{false, "", "", nil},
+	}
+	for _, c := range cases {
+		got := kubernetesGroups(c.isAdmin, c.clusterRole, c.projectRole)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("kubernetesGroups(%v, %q, %q) = %v, want %v", c.isAdmin, c.clusterRole, c.projectRole, got, c.want)
+		}
+	}
+}
@@ -282,6 +282,11 @@ func (c clusterResourceService) GetResources(resourceType, projectName, clusterN
 		if err := db.DB.Raw("SELECT * FROM ko_backup_account WHERE id in (SELECT resource_id FROM ko_project_resource WHERE resource_type='BACKUP_ACCOUNT' AND project_id= ? AND resource_id NOT IN (SELECT resource_id FROM ko_cluster_resource WHERE resource_type='BACKUP_ACCOUNT' AND cluster_id  =?) )", project.ID, cluster.ID).Scan(&backupAccounts).Error; err != nil {
 			return nil, err
 		}
+		for i := range backupAccounts {
+			if err := backupAccounts[i].AfterFind(); err != nil {
+				return nil, err
+			}
+		}
 		result = backupAccounts
 	}
 
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 
 	"github.com/kmpp/pkg/constant"
 	"github.com/kmpp/pkg/db"
@@ -57,6 +58,7 @@ func (c clusterToolService) Disable(clusterName string, tool dto.ClusterTool) (d
 	}
 
 	tool.ClusterID = cluster.ID
+	c.splitToolSecret(&tool)
 	mo := tool.ClusterTool
 	buf, _ := json.Marshal(&tool.Vars)
 	mo.Vars = string(buf)
@@ -85,6 +87,9 @@ func (c clusterToolService) Enable(clusterName string, tool dto.ClusterTool) (dt
 	if err != nil {
 		return tool, err
 	}
+	if err := c.validatePrometheusVars(tool); err != nil {
+		return tool, err
+	}
 
 	var toolDetail model.ClusterToolDetail
 	if err := db.DB.Where("name = ? AND version = ?", tool.Name, tool.Version).Find(&toolDetail).Error; err != nil {
@@ -92,6 +97,7 @@ func (c clusterToolService) Enable(clusterName string, tool dto.ClusterTool) (dt
 	}
 
 	tool.ClusterID = cluster.ID
+	c.splitToolSecret(&tool)
 	mo := tool.ClusterTool
 	buf, _ := json.Marshal(&tool.Vars)
 	mo.Vars = string(buf)
@@ -121,6 +127,9 @@ func (c clusterToolService) Enable(clusterName string, tool dto.ClusterTool) (dt
 	if err != nil {
 		return tool, err
 	}
+	if err := c.setAlertConfig(cluster.Cluster, ct); err != nil {
+		return tool, err
+	}
 	mo.Status = constant.ClusterInitializing
 	_ = c.toolRepo.Save(&mo)
 	go c.doInstall(ct, &tool.ClusterTool, toolDetail)
@@ -132,6 +141,20 @@ func (c clusterToolService) Upgrade(clusterName string, tool dto.ClusterTool) (d
 	if err != nil {
 		return tool, err
 	}
+	if err := c.validatePrometheusVars(tool); err != nil {
+		return tool, err
+	}
+	if tool.Name == "prometheus" {
+		var old model.ClusterTool
+		if err := db.DB.Where("cluster_id = ? AND name = ?", cluster.ID, tool.Name).First(&old).Error; err == nil {
+			oldVars := map[string]interface{}{}
+			_ = json.Unmarshal([]byte(old.Vars), &oldVars)
+			// 两种部署方式的 chart 不同，不能通过升级切换
+			if tools.IsKubePrometheusStack(oldVars) != tools.IsKubePrometheusStack(tool.Vars) {
+				return tool, errors.New("PROMETHEUS_STACK_IMMUTABLE")
+			}
+		}
+	}
 
 	var toolDetail model.ClusterToolDetail
 	if err := db.DB.Where("name = ? AND version = ?", tool.Name, tool.HigherVersion).Find(&toolDetail).Error; err != nil {
@@ -139,6 +162,7 @@ func (c clusterToolService) Upgrade(clusterName string, tool dto.ClusterTool) (d
 	}
 
 	tool.ClusterID = cluster.ID
+	c.splitToolSecret(&tool)
 	mo := tool.ClusterTool
 	buf, _ := json.Marshal(&tool.Vars)
 	mo.Vars = string(buf)
@@ -158,6 +182,9 @@ func (c clusterToolService) Upgrade(clusterName string, tool dto.ClusterTool) (d
 	if err != nil {
 		return tool, err
 	}
+	if err := c.setAlertConfig(cluster.Cluster, ct); err != nil {
+		return tool, err
+	}
 
 	_ = c.toolRepo.Save(&mo)
 	go c.doUpgrade(ct, &tool.ClusterTool, toolDetail)
@@ -200,6 +227,55 @@ func (c clusterToolService) doUninstall(p tools.Interface, tool *model.ClusterTo
 	_ = c.toolRepo.Save(tool)
 }
 
+// splitToolSecret 远端写入密码从 vars 移到加密保存的 secret 列，不随工具参数返回和下发，未填写时保留已保存的密码
+func (c clusterToolService) splitToolSecret(tool *dto.ClusterTool) {
+	password, _ := tool.Vars[constant.RemoteWritePasswordVar].(string)
+	delete(tool.Vars, constant.RemoteWritePasswordVar)
+	if password != "" {
+		tool.Secret = password
+		return
+	}
+	var old model.ClusterTool
+	if err := db.DB.Where("cluster_id = ? AND name = ?", tool.ClusterID, tool.Name).First(&old).Error; err == nil {
+		tool.Secret = old.Secret
+	}
+}
+
+// validatePrometheusVars 校验 Prometheus 的部署方式和远端写入配置
+func (c clusterToolService) validatePrometheusVars(tool dto.ClusterTool) error {
+	if tool.Name != "prometheus" {
+		return nil
+	}
+	if stack, ok := tool.Vars["stack"].(string); ok && stack != "" && stack != constant.PrometheusStackStandalone && stack != constant.PrometheusStackKube {
+		return errors.New("PROMETHEUS_STACK_INVALID")
+	}
+	_, err := tools.GetRemoteWrite(tool.Vars)
+	return err
+}
+
+// setAlertConfig 为 Prometheus 生成告警规则和 Alertmanager 配置，随 chart 一起下发
+func (c clusterToolService) setAlertConfig(cluster model.Cluster, ct tools.Interface) error {
+	p, ok := ct.(*tools.Prometheus)
+	if !ok {
+		return nil
+	}
+	rules, err := renderClusterAlertRules(cluster.ID)
+	if err != nil {
+		return err
+	}
+	p.AlertRules = rules
+	vars := map[string]interface{}{}
+	_ = json.Unmarshal([]byte(p.Tool.Vars), &vars)
+	if enabled, _ := vars["alertmanager.enabled"].(bool); enabled {
+		config, err := renderAlertmanagerConfig(cluster)
+		if err != nil {
+			return err
+		}
+		p.AlertmanagerConfig = config
+	}
+	return nil
+}
+
 func (c clusterToolService) getNamespace(clusterID string, tool dto.ClusterTool) (string, string) {
 	namespace := ""
 	Sp, ok := tool.Vars["namespace"]
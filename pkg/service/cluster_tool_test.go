@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/model"
+	"github.com/spf13/viper"
+)
+
+func TestValidatePrometheusVars(t *testing.T) {
+	viper.Set("prometheus.remote_write.url", "http://victoria:8428/api/v1/write")
+	defer viper.Set("prometheus.remote_write.url", "")
+	viper.Set("secret.vault.allowed_prefixes", []string{"kv"})
+	defer viper.Set("secret.vault.allowed_prefixes", nil)
+
+	var c clusterToolService
+	cases := []struct {
+		vars  map[string]interface{}
+		valid bool
+	}{
+		{map[string]interface{}{}, true},
+		{map[string]interface{}{"stack": "kube-prometheus-stack", "remoteWrite.mode": "central"}, true},
+		{map[string]interface{}{"remoteWrite.mode": "custom", "remoteWrite.url": "https://thanos/api/v1/receive", "remoteWrite.password": "vault://kv/thanos#password"}, true},
+		{map[string]interface{}{"remoteWrite.mode": "custom", "remoteWrite.url": "thanos:19291"}, false},
+		{map[string]interface{}{"remoteWrite.mode": "custom", "remoteWrite.url": "http://thanos", "remoteWrite.queryUrl": "thanos-query"}, false},
+		{map[string]interface{}{"remoteWrite.mode": "federate"}, false},
+		{map[string]interface{}{"stack": "prometheus-operator"}, false},
+	}
+	for _, tc := range cases {
+		tool := dto.ClusterTool{ClusterTool: model.ClusterTool{Name: "prometheus"}, Vars: tc.vars}
+		if err := c.validatePrometheusVars(tool); (err == nil) != tc.valid {
+			t.Errorf("vars %v: unexpected %v", tc.vars, err)
+		}
+	}
+	viper.Set("prometheus.remote_write.url", "")
+	tool := dto.ClusterTool{ClusterTool: model.ClusterTool{Name: "prometheus"}, Vars: map[string]interface{}{"remoteWrite.mode": "central"}}
+	if err := c.validatePrometheusVars(tool); err == nil {
+		t.Error("central mode without configured endpoint should fail")
+	}
+}
@@ -50,6 +50,16 @@ func (c *clusterUpgradeService) Upgrade(upgrade dto.ClusterUpgrade) error {
 	if !(cluster.Source == constant.ClusterSourceLocal) {
 		return errors.New("CLUSTER_IS_NOT_LOCAL")
 	}
+	lock, err := lockClusterOperation(cluster.Name, clusterOperationChange)
+	if err != nil {
+		return err
+	}
+	started := false
+	defer func() {
+		if !started {
+			lock.Unlock()
+		}
+	}()
 	if cluster.Status != constant.StatusRunning && cluster.Status != constant.StatusFailed {
 		return fmt.Errorf("cluster status error %s", cluster.Status)
 	}
@@ -106,7 +116,11 @@ func (c *clusterUpgradeService) Upgrade(upgrade dto.ClusterUpgrade) error {
 	tx.Commit()
 
 	logger.Log.Infof("update db data of cluster %s successful, now start to upgrade cluster", cluster.Name)
-	go c.do(&cluster.Cluster, writer)
+	started = true
+	go func() {
+		defer lock.Unlock()
+		c.do(&cluster.Cluster, writer)
+	}()
 	return nil
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/kmpp/pkg/repository"
 	dbUtil "github.com/kmpp/pkg/util/db"
 	"github.com/kmpp/pkg/util/encrypt"
+	"github.com/kmpp/pkg/util/secret"
 )
 
 var CredentialNameExist = "NAME_EXISTS"
@@ -115,6 +116,9 @@ func (c credentialService) Create(creation dto.CredentialCreate) (*dto.Credentia
 	if old.ID != "" {
 		return nil, errors.New(CredentialNameExist)
 	}
+	if err := validateSecretReference(creation.Password, creation.PrivateKey); err != nil {
+		return nil, err
+	}
 	password, err := encrypt.StringEncrypt(creation.Password)
 	if err != nil {
 		return nil, err
@@ -141,6 +145,9 @@ func (c credentialService) Update(name string, update dto.CredentialUpdate) (*dt
 	if err != nil {
 		return nil, err
 	}
+	if err := validateSecretReference(update.Password, update.PrivateKey); err != nil {
+		return nil, err
+	}
 	if update.Username != "" {
 		credential.Username = update.Username
 	}
@@ -193,3 +200,13 @@ func (c credentialService) Batch(op dto.CredentialBatchOp) error {
 	}
 	return nil
 }
+
+// validateSecretReference 检查凭据中 vault:// 或 file:// 引用的格式，引用在连接主机时才解析
+func validateSecretReference(values ...string) error {
+	for _, v := range values {
+		if err := secret.Validate(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
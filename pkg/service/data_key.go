@@ -0,0 +1,328 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/redis"
+	"github.com/kmpp/pkg/util/encrypt"
+	"github.com/jinzhu/gorm"
+)
+
+var errDataKeyRotating = errors.New("DATA_KEY_ROTATING")
+
+const (
+	dataKeyLockName = "datakey/reencrypt"
+	dataKeyLockTTL  = 5 * time.Minute
+	dataKeyBatch    = 100
+	dataKeyCacheTTL = 30 * time.Second
+)
+
+// secretColumns 以信封加密保存的列，新增敏感字段时同时在模型上加 sealFields/openFields 钩子，
+// Keys 不为空时只处理 key 列在其中的行
+var secretColumns = []struct {
+	Table   string
+	Columns []string
+	Keys    []string
+}{
+	{Table: "ko_credential", Columns: []string{"private_key", "password"}},
+	{Table: "ko_cluster_secret", Columns: []string{"kubeadm_token", "kubernetes_token"}},
+	{Table: "ko_backup_account", Columns: []string{"credential"}},
+	{Table: "ko_multi_cluster_repository", Columns: []string{"password"}},
+	{Table: "ko_region", Columns: []string{"vars"}},
+	{Table: "ko_cluster_tool", Columns: []string{"secret"}},
+	{Table: "ko_f5_setting", Columns: []string{"password"}},
+	{Table: "ko_user_mfa", Columns: []string{"secret"}},
+	{Table: "ko_user", Columns: []string{"password"}},
+	{Table: "ko_system_setting", Columns: []string{"value"}, Keys: model.SecretSettingKeys},
+}
+
+// DataKeyService 管理信封加密的数据密钥，轮换后在后台分批将敏感列重新加密到新版本
+type DataKeyService interface {
+	List() (*dto.DataKeyList, error)
+	Rotate() (*dto.DataKey, error)
+	Reencrypt() error
+}
+
+func NewDataKeyService() DataKeyService {
+	return &dataKeyService{}
+}
+
+type dataKeyService struct{}
+
+func (d dataKeyService) List() (*dto.DataKeyList, error) {
+	var keys []model.DataKey
+	if err := db.DB.Order("version desc").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	counts, err := countSecretVersions()
+	if err != nil {
+		return nil, err
+	}
+	result := dto.DataKeyList{Plaintext: counts[0]}
+	for _, k := range keys {
+		result.Items = append(result.Items, dto.DataKey{DataKey: k, Rows: counts[k.Version]})
+	}
+	// 只读取锁的状态，获取锁会让同时发起的轮换被拒绝
+	result.Reencrypting, _ = redis.IsLocked(dataKeyLockName)
+	return &result, nil
+}
+
+// Rotate 生成新的数据密钥版本并立即用于新写入的数据，已有数据在后台重新加密，旧版本保留用于解密
+func (d dataKeyService) Rotate() (*dto.DataKey, error) {
+	lock, err := redis.TryLock(dataKeyLockName, dataKeyLockTTL)
+	if err == redis.ErrLocked {
+		return nil, errDataKeyRotating
+	}
+	if err != nil {
+		return nil, err
+	}
+	key, err := createDataKey()
+	if err != nil {
+		lock.Unlock()
+		return nil, err
+	}
+	go func() {
+		defer lock.Unlock()
+		if err := reencryptSecrets(); err != nil {
+			logger.Log.Errorf("reencrypt secrets with data key v%d error %s", key.Version, err.Error())
+		}
+	}()
+	return &dto.DataKey{DataKey: *key}, nil
+}
+
+// Reencrypt 重新加密仍使用旧版本或未加密的数据，用于轮换中断后继续
+func (d dataKeyService) Reencrypt() error {
+	lock, err := redis.TryLock(dataKeyLockName, dataKeyLockTTL)
+	if err == redis.ErrLocked {
+		return errDataKeyRotating
+	}
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer lock.Unlock()
+		if err := reencryptSecrets(); err != nil {
+			logger.Log.Errorf("reencrypt secrets error %s", err.Error())
+		}
+	}()
+	return nil
+}
+
+func createDataKey() (*model.DataKey, error) {
+	_, wrapped, err := encrypt.GenerateDataKey()
+	if err != nil {
+		return nil, err
+	}
+	var last model.DataKey
+	if err := db.DB.Order("version desc").First(&last).Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+		return nil, err
+	}
+	key := model.DataKey{Version: last.Version + 1, WrappedKey: wrapped, Status: constant.DataKeyActive}
+	tx := db.DB.Begin()
+	if err := tx.Model(&model.DataKey{}).Where("status = ?", constant.DataKeyActive).Update("status", constant.DataKeyInactive).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Create(&key).Error; err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+	if err := dataKeys.load(); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// reencryptSecrets 按主键分批扫描敏感列，只更新版本不是当前版本的值，更新时以原值为条件避免覆盖并发写入
+func reencryptSecrets() error {
+	active, err := dataKeys.Active()
+	if err != nil {
+		return err
+	}
+	var total int
+	for _, t := range secretColumns {
+		lastID := ""
+		for {
+			query := db.DB.Table(t.Table).Select(append([]string{"id"}, t.Columns...)).Where("id > ?", lastID)
+			if len(t.Keys) > 0 {
+				query = query.Where("`key` IN (?)", t.Keys)
+			}
+			rows, err := query.Order("id").Limit(dataKeyBatch).Rows()
+			if err != nil {
+				return err
+			}
+			var batch [][]sql.NullString
+			for rows.Next() {
+				values := make([]sql.NullString, len(t.Columns)+1)
+				dest := make([]interface{}, len(values))
+				for i := range values {
+					dest[i] = &values[i]
+				}
+				if err := rows.Scan(dest...); err != nil {
+					rows.Close()
+					return err
+				}
+				batch = append(batch, values)
+			}
+			rows.Close()
+			for _, values := range batch {
+				for i, column := range t.Columns {
+					old := values[i+1].String
+					if old == "" || encrypt.SealedVersion(old) == active.Version {
+						continue
+					}
+					plain, err := encrypt.Open(old)
+					if err != nil {
+						return fmt.Errorf("decrypt %s.%s of %s error %s", t.Table, column, values[0].String, err.Error())
+					}
+					sealed, err := encrypt.Seal(plain)
+					if err != nil {
+						return err
+					}
+					if err := db.DB.Table(t.Table).Where("id = ? AND "+column+" = ?", values[0].String, old).Update(column, sealed).Error; err != nil {
+						return err
+					}
+					total++
+				}
+			}
+			if len(batch) < dataKeyBatch {
+				break
+			}
+			lastID = batch[len(batch)-1][0].String
+		}
+	}
+	logger.Log.Infof("reencrypt %d secret values with data key v%d", total, active.Version)
+	return nil
+}
+
+// countSecretVersions 统计各版本数据密钥加密的值的数量，0 表示未加密
+func countSecretVersions() (map[int]int, error) {
+	counts := make(map[int]int)
+	for _, t := range secretColumns {
+		for _, column := range t.Columns {
+			query := db.DB.Table(t.Table).Select(column).Where(column + " <> ''")
+			if len(t.Keys) > 0 {
+				query = query.Where("`key` IN (?)", t.Keys)
+			}
+			rows, err := query.Rows()
+			if err != nil {
+				return nil, err
+			}
+			for rows.Next() {
+				var value sql.NullString
+				if err := rows.Scan(&value); err != nil {
+					rows.Close()
+					return nil, err
+				}
+				counts[encrypt.SealedVersion(value.String)]++
+			}
+			rows.Close()
+		}
+	}
+	return counts, nil
+}
+
+// dataKeyStore 缓存解密后的数据密钥，定期从数据库刷新，使其他副本轮换的新版本能被及时使用
+type dataKeyStore struct {
+	sync.RWMutex
+	keys     map[int][]byte
+	active   int
+	loadedAt time.Time
+}
+
+var dataKeys = &dataKeyStore{}
+
+func (s *dataKeyStore) load() error {
+	var keys []model.DataKey
+	if err := db.DB.Find(&keys).Error; err != nil {
+		return err
+	}
+	loaded := make(map[int][]byte)
+	active := 0
+	for _, k := range keys {
+		key, err := encrypt.UnwrapDataKey(k.WrappedKey)
+		if err != nil {
+			return err
+		}
+		loaded[k.Version] = key
+		if k.Status == constant.DataKeyActive && k.Version > active {
+			active = k.Version
+		}
+	}
+	s.Lock()
+	defer s.Unlock()
+	s.keys = loaded
+	s.active = active
+	s.loadedAt = time.Now()
+	return nil
+}
+
+func (s *dataKeyStore) Active() (encrypt.DataKey, error) {
+	s.RLock()
+	expired := time.Since(s.loadedAt) > dataKeyCacheTTL
+	s.RUnlock()
+	if expired {
+		if err := s.load(); err != nil {
+			return encrypt.DataKey{}, err
+		}
+	}
+	s.RLock()
+	defer s.RUnlock()
+	if s.active == 0 {
+		return encrypt.DataKey{}, errors.New("no active data key")
+	}
+	return encrypt.DataKey{Version: s.active, Key: s.keys[s.active]}, nil
+}
+
+func (s *dataKeyStore) Get(version int) (encrypt.DataKey, error) {
+	s.RLock()
+	key, ok := s.keys[version]
+	s.RUnlock()
+	if !ok {
+		if err := s.load(); err != nil {
+			return encrypt.DataKey{}, err
+		}
+		s.RLock()
+		key, ok = s.keys[version]
+		s.RUnlock()
+		if !ok {
+			return encrypt.DataKey{}, fmt.Errorf("data key v%d not found", version)
+		}
+	}
+	return encrypt.DataKey{Version: version, Key: key}, nil
+}
+
+// InitDataKeyStore 启动时注册数据密钥，首次启动生成第一个版本，并在后台加密升级前遗留的明文数据
+func InitDataKeyStore() error {
+	encrypt.SetKeyStore(dataKeys)
+	if err := dataKeys.load(); err != nil {
+		return err
+	}
+	if _, err := dataKeys.Active(); err != nil {
+		if _, err := createDataKey(); err != nil {
+			// 多副本同时启动时可能由其他副本先创建
+			if err := dataKeys.load(); err != nil {
+				return err
+			}
+			if _, err := dataKeys.Active(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := NewDataKeyService().Reencrypt(); err != nil && err != errDataKeyRotating {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/model"
+	"github.com/jinzhu/gorm"
+)
+
+// groupBinding 外部用户组(LDAP/OIDC)映射到的项目角色，Cluster 仅对集群管理员有效
+type groupBinding struct {
+	Project string
+	Role    string
+	Cluster string
+}
+
+// parseGroupBindings 解析用户组映射，格式为 "group=project[:role[:cluster]]"，多条以逗号或换行分隔
+// role 可以是 PROJECT_MANAGER(缺省)、PROJECT_VIEWER 或 CLUSTER_MANAGER，集群管理员未指定集群时管理项目下的全部集群
+func parseGroupBindings(groups []string, mapping string) []groupBinding {
+	inGroups := make(map[string]bool)
+	for _, g := range groups {
+		inGroups[g] = true
+	}
+	var bindings []groupBinding
+	for _, item := range splitSetting(mapping) {
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 || !inGroups[strings.TrimSpace(kv[0])] {
+			continue
+		}
+		target := strings.SplitN(strings.TrimSpace(kv[1]), ":", 3)
+		b := groupBinding{Project: strings.TrimSpace(target[0]), Role: constant.ProjectRoleProjectManager}
+		if b.Project == "" {
+			continue
+		}
+		if len(target) > 1 {
+			switch role := strings.TrimSpace(target[1]); role {
+			case constant.ProjectRoleProjectManager, constant.ProjectRoleProjectViewer, constant.ProjectRoleClusterManager:
+				b.Role = role
+			default:
+				continue
+			}
+		}
+		if len(target) > 2 && b.Role == constant.ProjectRoleClusterManager {
+			b.Cluster = strings.TrimSpace(target[2])
+		}
+		bindings = append(bindings, b)
+	}
+	return bindings
+}
+
+// projectRoles 同一项目命中多条映射时以项目管理员优先
+func projectRoles(bindings []groupBinding) map[string]string {
+	roles := make(map[string]string)
+	for _, b := range bindings {
+		if b.Role == constant.ProjectRoleClusterManager {
+			continue
+		}
+		if roles[b.Project] != constant.ProjectRoleProjectManager {
+			roles[b.Project] = b.Role
+		}
+	}
+	return roles
+}
+
+func inAnyGroup(groups []string, targets []string) bool {
+	for _, g := range groups {
+		for _, t := range targets {
+			if g == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// groupAdmin 配置了管理员用户组时按用户组决定管理员身份，未配置时保留当前值，手工授予的管理员不会被覆盖
+func groupAdmin(current bool, groups []string, adminGroups []string) bool {
+	if len(adminGroups) == 0 {
+		return current
+	}
+	return inAnyGroup(groups, adminGroups)
+}
+
+// syncGroupMembers 按映射调整用户的项目成员和集群成员
+// 只维护同一来源(source)创建的记录，手工添加的成员不会被修改或删除
+func syncGroupMembers(user model.User, source string, bindings []groupBinding) (added int, removed int, err error) {
+	desiredProjects := make(map[string]string)
+	for name, role := range projectRoles(bindings) {
+		var project model.Project
+		if err := db.DB.Where("name = ?", name).First(&project).Error; err != nil {
+			if gorm.IsRecordNotFoundError(err) {
+				continue
+			}
+			return added, removed, err
+		}
+		desiredProjects[project.ID] = role
+	}
+	desiredClusters := make(map[string]bool)
+	for _, b := range bindings {
+		if b.Role != constant.ProjectRoleClusterManager {
+			continue
+		}
+		ids, err := bindingClusterIDs(b)
+		if err != nil {
+			return added, removed, err
+		}
+		for _, id := range ids {
+			desiredClusters[id] = true
+		}
+	}
+
+	for projectID, role := range desiredProjects {
+		var pm model.ProjectMember
+		if err := db.DB.Where("project_id = ? AND user_id = ?", projectID, user.ID).First(&pm).Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+			return added, removed, err
+		}
+		if pm.ID == "" {
+			pm = model.ProjectMember{ProjectID: projectID, UserID: user.ID, Role: role, Source: source}
+			if err := db.DB.Create(&pm).Error; err != nil {
+				return added, removed, err
+			}
+			added++
+		} else if pm.Source == source && pm.Role != role {
+			if err := db.DB.Model(&pm).Update("role", role).Error; err != nil {
+				return added, removed, err
+			}
+		}
+	}
+	var pms []model.ProjectMember
+	if err := db.DB.Where("user_id = ? AND source = ?", user.ID, source).Find(&pms).Error; err != nil {
+		return added, removed, err
+	}
+	for i := range pms {
+		if _, ok := desiredProjects[pms[i].ProjectID]; !ok {
+			if err := db.DB.Delete(&pms[i]).Error; err != nil {
+				return added, removed, err
+			}
+			removed++
+		}
+	}
+
+	for clusterID := range desiredClusters {
+		var cm model.ClusterMember
+		if err := db.DB.Where("cluster_id = ? AND user_id = ?", clusterID, user.ID).First(&cm).Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+			return added, removed, err
+		}
+		if cm.ID == "" {
+			cm = model.ClusterMember{ClusterID: clusterID, UserID: user.ID, Role: constant.ProjectRoleClusterManager, Source: source}
+			if err := db.DB.Create(&cm).Error; err != nil {
+				return added, removed, err
+			}
+			added++
+		}
+	}
+	var cms []model.ClusterMember
+	if err := db.DB.Where("user_id = ? AND source = ?", user.ID, source).Find(&cms).Error; err != nil {
+		return added, removed, err
+	}
+	for i := range cms {
+		if !desiredClusters[cms[i].ClusterID] {
+			if err := db.DB.Delete(&cms[i]).Error; err != nil {
+				return added, removed, err
+			}
+			removed++
+		}
+	}
+	return added, removed, nil
+}
+
+func bindingClusterIDs(b groupBinding) ([]string, error) {
+	var project model.Project
+	if err := db.DB.Where("name = ?", b.Project).First(&project).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var resources []model.ProjectResource
+	if err := db.DB.Where("project_id = ? AND resource_type = ?", project.ID, constant.ResourceCluster).Find(&resources).Error; err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, r := range resources {
+		if b.Cluster == "" {
+			ids = append(ids, r.ResourceID)
+			continue
+		}
+		var cluster model.Cluster
+		if err := db.DB.Where("id = ? AND name = ?", r.ResourceID, b.Cluster).First(&cluster).Error; err == nil {
+			ids = append(ids, cluster.ID)
+		}
+	}
+	return ids, nil
+}
+
+func splitSetting(value string) []string {
+	var result []string
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == '\n'
+	}) {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
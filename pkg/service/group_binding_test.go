@@ -0,0 +1,42 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kmpp/pkg/constant"
+)
+
+func TestParseGroupBindings(t *testing.T) {
+	mapping := "dev=project-a, ops=project-a:PROJECT_VIEWER\nqa=project-b:PROJECT_VIEWER,sre=project-b:CLUSTER_MANAGER:c1,other=project-c,bad=project-d:ADMIN"
+	bindings := parseGroupBindings([]string{"ops", "dev", "qa", "sre", "bad"}, mapping)
+	want := []groupBinding{
+		{Project: "project-a", Role: constant.ProjectRoleProjectManager},
+		{Project: "project-a", Role: constant.ProjectRoleProjectViewer},
+		{Project: "project-b", Role: constant.ProjectRoleProjectViewer},
+		{Project: "project-b", Role: constant.ProjectRoleClusterManager, Cluster: "c1"},
+	}
+	if !reflect.DeepEqual(bindings, want) {
+		t.Fatalf("got %v, want %v", bindings, want)
+	}
+	roles := projectRoles(bindings)
+	wantRoles := map[string]string{
+		"project-a": constant.ProjectRoleProjectManager,
+		"project-b": constant.ProjectRoleProjectViewer,
+	}
+	if !reflect.DeepEqual(roles, wantRoles) {
+		t.Errorf("got %v, want %v", roles, wantRoles)
+	}
+	if len(parseGroupBindings(nil, mapping)) != 0 {
+		t.Error("user without groups should get nothing")
+	}
+	if !inAnyGroup([]string{"dev", "kmpp-admins"}, []string{"kmpp-admins"}) || inAnyGroup([]string{"dev"}, nil) {
+		t.Error("unexpected admin group match")
+	}
+	if !groupAdmin(true, []string{"dev"}, nil) || groupAdmin(false, []string{"dev"}, nil) {
+		t.Error("admin should be kept when no admin group is configured")
+	}
+	if groupAdmin(true, []string{"dev"}, []string{"kmpp-admins"}) || !groupAdmin(false, []string{"kmpp-admins"}, []string{"kmpp-admins"}) {
+		t.Error("admin should follow the configured admin groups")
+	}
+}
@@ -251,6 +251,10 @@ func (h *hostService) Create(creation dto.HostCreate) (*dto.Host, error) {
 			return nil, err
 		}
 	} else {
+		if err := validateSecretReference(creation.Credential.Password, creation.Credential.PrivateKey); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
 		var password string
 		if creation.Credential.Password != "" {
 			p, err := encrypt.StringEncrypt(creation.Credential.Password)
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/redis"
+	"github.com/kmpp/pkg/util/ldap"
+	"github.com/jinzhu/gorm"
+)
+
+var (
+	errLdapSyncRunning = errors.New("LDAP_SYNC_RUNNING")
+)
+
+const ldapSyncLockTTL = 5 * time.Minute
+
+// LdapSyncService 同步 LDAP 用户和用户组：导入新用户、按映射调整项目/集群成员、停用 LDAP 中已不存在的用户，
+// 被同步停用的用户重新出现在 LDAP 中时会被重新启用
+type LdapSyncService interface {
+	Sync() (*dto.LdapSyncResult, error)
+}
+
+func NewLdapSyncService() LdapSyncService {
+	return &ldapSyncService{
+		systemSettingService: NewSystemSettingService(),
+	}
+}
+
+type ldapSyncService struct {
+	systemSettingService SystemSettingService
+}
+
+type ldapUser struct {
+	DN    string
+	Name  string
+	UID   string
+	Email string
+}
+
+func (l ldapSyncService) Sync() (*dto.LdapSyncResult, error) {
+	settings, err := l.systemSettingService.List()
+	if err != nil {
+		return nil, err
+	}
+	vars := settings.Vars
+	if vars["ldap_status"] != constant.Enable {
+		return nil, errLdapDisable
+	}
+	lock, err := redis.TryLock("ldap/sync", ldapSyncLockTTL)
+	if err == redis.ErrLocked {
+		return nil, errLdapSyncRunning
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Unlock()
+
+	client := ldap.NewLdap(vars)
+	if err := client.Connect(); err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	entries, err := client.Search()
+	if err != nil {
+		return nil, err
+	}
+	groups, err := client.SearchGroups()
+	if err != nil {
+		return nil, err
+	}
+	var users []ldapUser
+	for _, e := range entries {
+		users = append(users, ldapUser{
+			DN:    e.DN,
+			Name:  e.GetAttributeValue("cn"),
+			UID:   e.GetAttributeValue("uid"),
+			Email: e.GetAttributeValue("mail"),
+		})
+	}
+	userGroups := ldapUserGroups(users, groups)
+	adminGroups := splitSetting(vars["ldap_admin_groups"])
+
+	result := &dto.LdapSyncResult{}
+	names := make(map[string]bool)
+	for _, u := range users {
+		if u.Name == "" {
+			continue
+		}
+		names[u.Name] = true
+		user, created, err := l.syncUser(u, userGroups[u.Name], adminGroups)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %s", u.Name, err.Error()))
+			continue
+		}
+		if user == nil {
+			continue
+		}
+		if created {
+			result.Created++
+		}
+		if !user.IsActive && user.DeactivatedBySync {
+			if err := db.DB.Model(&model.User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{"is_active": true, "deactivated_by_sync": false}).Error; err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: %s", u.Name, err.Error()))
+				continue
+			}
+			result.Reactivated = append(result.Reactivated, u.Name)
+		}
+		added, removed, err := syncGroupMembers(*user, constant.Ldap, parseGroupBindings(userGroups[u.Name], vars["ldap_group_mapping"]))
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %s", u.Name, err.Error()))
+			continue
+		}
+		result.MembersAdded += added
+		result.MembersRemoved += removed
+	}
+
+	var ldapUsers []model.User
+	if err := db.DB.Where("type = ? AND is_active = ?", constant.Ldap, true).Find(&ldapUsers).Error; err != nil {
+		return result, err
+	}
+	if len(names) == 0 {
+		logger.Log.Warn("ldap sync found no users, skip deactivation")
+		result.Errors = append(result.Errors, "ldap search returned no users, skip deactivation")
+	}
+	for _, u := range ldapStaleUsers(ldapUsers, names) {
+		if err := db.DB.Model(&model.User{}).Where("id = ?", u.ID).Updates(map[string]interface{}{"is_active": false, "deactivated_by_sync": true}).Error; err != nil {
+			return result, err
+		}
+		result.Deactivated = append(result.Deactivated, u.Name)
+	}
+	if len(result.Deactivated) > 0 {
+		logger.Log.Infof("ldap sync deactivated users: %s", strings.Join(result.Deactivated, ","))
+	}
+	if len(result.Reactivated) > 0 {
+		logger.Log.Infof("ldap sync reactivated users: %s", strings.Join(result.Reactivated, ","))
+	}
+	return result, nil
+}
+
+// ldapStaleUsers 返回 LDAP 中已不存在的用户；搜索没有返回任何用户时多半是配置或服务异常，不停用任何用户
+func ldapStaleUsers(users []model.User, names map[string]bool) []model.User {
+	if len(names) == 0 {
+		return nil
+	}
+	var stale []model.User
+	for _, u := range users {
+		if !names[u.Name] {
+			stale = append(stale, u)
+		}
+	}
+	return stale
+}
+
+// syncUser 导入新的 LDAP 用户，配置了管理员用户组时同步管理员身份；同名的本地用户不做处理
+func (l ldapSyncService) syncUser(u ldapUser, groups []string, adminGroups []string) (*model.User, bool, error) {
+	var user model.User
+	if err := db.DB.Where("name = ?", u.Name).First(&user).Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+		return nil, false, err
+	}
+	if user.ID != "" && user.Type != constant.Ldap {
+		return nil, false, nil
+	}
+	isAdmin := groupAdmin(user.IsAdmin, groups, adminGroups)
+	if user.ID == "" {
+		email := u.Email
+		if email == "" {
+			email = fmt.Sprintf("%s@ldap.local", u.Name)
+		}
+		user = model.User{
+			Name:     u.Name,
+			Email:    email,
+			IsActive: true,
+			IsAdmin:  isAdmin,
+			Language: model.ZH,
+			Type:     constant.Ldap,
+		}
+		if err := db.DB.Create(&user).Error; err != nil {
+			return nil, false, err
+		}
+		return &user, true, nil
+	}
+	updates := map[string]interface{}{}
+	if isAdmin != user.IsAdmin {
+		updates["is_admin"] = isAdmin
+	}
+	if u.Email != "" && u.Email != user.Email {
+		updates["email"] = u.Email
+	}
+	if len(updates) > 0 {
+		if err := db.DB.Model(&model.User{}).Where("id = ?", user.ID).Updates(updates).Error; err != nil {
+			return nil, false, err
+		}
+	}
+	return &user, false, nil
+}
+
+// ldapUserGroups 计算每个用户(按 cn)所属的用户组，成员按 DN、uid 或 cn 匹配
+func ldapUserGroups(users []ldapUser, groups []ldap.Group) map[string][]string {
+	index := make(map[string]string)
+	for _, u := range users {
+		index[strings.ToLower(u.DN)] = u.Name
+		if u.UID != "" {
+			index[strings.ToLower(u.UID)] = u.Name
+		}
+		index[strings.ToLower(u.Name)] = u.Name
+	}
+	result := make(map[string][]string)
+	for _, g := range groups {
+		seen := make(map[string]bool)
+		for _, m := range g.Members {
+			name, ok := index[strings.ToLower(strings.TrimSpace(m))]
+			if !ok || seen[name] {
+				continue
+			}
+			seen[name] = true
+			result[name] = append(result[name], g.Name)
+		}
+	}
+	return result
+}
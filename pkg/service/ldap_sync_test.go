@@ -0,0 +1,40 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/util/ldap"
+)
+
+func TestLdapUserGroups(t *testing.T) {
+	users := []ldapUser{
+		{DN: "cn=alice,ou=people,dc=ko,dc=com", Name: "alice", UID: "alice01"},
+		{DN: "cn=bob,ou=people,dc=ko,dc=com", Name: "bob"},
+	}
+	groups := []ldap.Group{
+		{Name: "dev", Members: []string{"CN=alice,ou=people,dc=ko,dc=com", "cn=bob,ou=people,dc=ko,dc=com"}},
+		{Name: "ops", Members: []string{"alice01", "carol"}},
+		{Name: "qa", Members: []string{"bob", "cn=bob,ou=people,dc=ko,dc=com"}},
+	}
+	got := ldapUserGroups(users, groups)
+	want := map[string][]string{
+		"alice": {"dev", "ops"},
+		"bob":   {"dev", "qa"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestLdapStaleUsers(t *testing.T) {
+	users := []model.User{{Name: "alice"}, {Name: "bob"}}
+	if stale := ldapStaleUsers(users, map[string]bool{}); len(stale) != 0 {
+		t.Errorf("empty search result should not deactivate users, got %v", stale)
+	}
+	stale := ldapStaleUsers(users, map[string]bool{"alice": true})
+	if len(stale) != 1 || stale[0].Name != "bob" {
+		t.Errorf("expected bob to be deactivated, got %v", stale)
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/kmpp/pkg/constant"
@@ -17,6 +18,7 @@ import (
 
 type MessageService interface {
 	SendMessage(mType string, result bool, content string, clusterName string, title string) error
+	EscalateAlerts() error
 }
 
 type messageService struct {
@@ -66,17 +68,182 @@ func (m messageService) SendMessage(mType string, result bool, content string, c
 	if err != nil {
 		return err
 	}
-	userMessages, err := m.GetUserMessages(msg)
+	projectName := m.getProjectName(cluster.ID)
+	labels := alertLabels{ClusterName: clusterName, ProjectName: projectName, MessageType: title, Level: msg.Level}
+	silence, err := matchAlertSilence(labels, time.Now())
 	if err != nil {
 		return err
 	}
+	if silence != nil {
+		logger.Log.Infof("message %s of cluster %s is silenced by %s", title, clusterName, silence.ID)
+		return nil
+	}
+	route, err := matchAlertRoute(labels)
+	if err != nil {
+		return err
+	}
+	if msg.Level == constant.MsgWarning {
+		notify, err := m.trackAlert(msg, labels, route)
+		if err != nil {
+			return err
+		}
+		if !notify {
+			return nil
+		}
+	}
+	var userMessages []model.UserMessage
+	if route == nil {
+		userMessages, err = m.GetUserMessages(msg)
+	} else {
+		userMessages, err = m.GetRouteUserMessages(msg, route.Users, route.SendTypes)
+	}
+	if err != nil {
+		return err
+	}
+	webhook := route == nil || hasSendType(route.SendTypes, constant.Webhook)
+	event := m.GetWebhookEvent(msg, clusterName, projectName)
 	if title == constant.ClusterUnInstall {
 		m.SendUserMessage(userMessages, clusterName)
+		if webhook {
+			m.SendWebhookMessage(event)
+		}
 	} else {
 		go m.SendUserMessage(userMessages, clusterName)
+		if webhook {
+			go m.SendWebhookMessage(event)
+		}
+	}
+	return nil
+}
+
+// trackAlert 记录告警级消息并去重，返回本次是否需要通知
+func (m messageService) trackAlert(msg model.Message, labels alertLabels, route *dto.AlertRoute) (bool, error) {
+	var alert model.Alert
+	fingerprint := alertFingerprint(labels, msg.Content)
+	if err := db.DB.Where("fingerprint = ?", fingerprint).First(&alert).Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+		return false, err
+	}
+	notify := updateAlert(&alert, route, time.Now())
+	alert.Fingerprint = fingerprint
+	alert.MessageID = msg.ID
+	alert.ClusterName = labels.ClusterName
+	alert.ProjectName = labels.ProjectName
+	alert.MessageType = labels.MessageType
+	alert.Level = labels.Level
+	alert.RouteID = ""
+	if route != nil {
+		alert.RouteID = route.ID
+	}
+	if alert.ID == "" {
+		return notify, db.DB.Create(&alert).Error
+	}
+	return notify, db.DB.Save(&alert).Error
+}
+
+// EscalateAlerts 将超过升级时间仍未确认的告警发送给路由的升级接收人
+func (m messageService) EscalateAlerts() error {
+	var alerts []model.Alert
+	if err := db.DB.Where("status = ? AND escalate_at IS NOT NULL AND escalate_at <= ?", constant.AlertFiring, time.Now()).Find(&alerts).Error; err != nil {
+		return err
+	}
+	for i := range alerts {
+		alert := alerts[i]
+		alert.Status = constant.AlertEscalated
+		alert.EscalateAt = nil
+		if err := db.DB.Save(&alert).Error; err != nil {
+			return err
+		}
+		var route model.AlertRoute
+		if err := db.DB.Where("id = ?", alert.RouteID).First(&route).Error; err != nil {
+			logger.Log.Errorf("escalate alert %s failed, get route error: %s", alert.ID, err.Error())
+			continue
+		}
+		var msg model.Message
+		if err := db.DB.Where("id = ?", alert.MessageID).First(&msg).Error; err != nil {
+			logger.Log.Errorf("escalate alert %s failed, get message error: %s", alert.ID, err.Error())
+			continue
+		}
+		d := toAlertRouteDTO(route)
+		userMessages, err := m.GetRouteUserMessages(msg, d.EscalateUsers, d.SendTypes)
+		if err != nil {
+			logger.Log.Errorf("escalate alert %s failed, error: %s", alert.ID, err.Error())
+			continue
+		}
+		m.SendUserMessage(userMessages, alert.ClusterName)
+		if hasSendType(d.SendTypes, constant.Webhook) {
+			m.SendWebhookMessage(m.GetWebhookEvent(msg, alert.ClusterName, alert.ProjectName))
+		}
 	}
 	return nil
 }
+
+// GetRouteUserMessages 按路由指定的用户和发送方式生成消息，不再读取用户的通知配置
+func (m messageService) GetRouteUserMessages(message model.Message, usernames []string, sendTypes []string) ([]model.UserMessage, error) {
+	var userIds []string
+	for _, name := range usernames {
+		user, err := m.userRepo.Get(name)
+		if err != nil {
+			logger.Log.Errorf("get alert route user %s error: %s", name, err.Error())
+			continue
+		}
+		userIds = append(userIds, user.ID)
+	}
+	msgReceivers := make(map[string][]string)
+	for _, sendType := range sendTypes {
+		if sendType == constant.LocalMail {
+			for _, userId := range userIds {
+				userMessage := model.UserMessage{
+					UserID:     userId,
+					MessageID:  message.ID,
+					SendStatus: constant.SendSuccess,
+					ReadStatus: constant.UnRead,
+					SendType:   constant.LocalMail,
+				}
+				_ = m.userMessageRepo.Save(&userMessage)
+			}
+			continue
+		}
+		if sendType == constant.Webhook {
+			continue
+		}
+		setting, _ := m.systemSettingService.Get(sendType + "_STATUS")
+		if setting.ID == "" || setting.Value != constant.Enable {
+			continue
+		}
+		for _, userId := range userIds {
+			receiver, _ := m.GetUserReceiver(userId)
+			if receiver == nil || receiver.ID == "" || receiver.Vars[sendType] == "" {
+				continue
+			}
+			msgReceivers[sendType] = append(msgReceivers[sendType], receiver.Vars[sendType])
+		}
+	}
+	var userMessages []model.UserMessage
+	for k, v := range msgReceivers {
+		sep := "|"
+		if k == constant.Email || k == constant.DingTalk {
+			sep = ","
+		}
+		userMessages = append(userMessages, model.UserMessage{
+			MessageID:  message.ID,
+			SendStatus: constant.SendSuccess,
+			ReadStatus: constant.UnRead,
+			SendType:   k,
+			Receive:    strings.Join(v, sep),
+			Message:    message,
+		})
+	}
+	return userMessages, nil
+}
+
+func hasSendType(sendTypes []string, sendType string) bool {
+	for _, t := range sendTypes {
+		if t == sendType {
+			return true
+		}
+	}
+	return false
+}
 func (m messageService) GetContentByTitleAndType(content, title, sendType, clusterName string) string {
 	date := time.Now().Add(time.Hour * 8).Format("2006-01-02 15:04:05")
 	var result string
@@ -178,6 +345,16 @@ func Tr(title string) string {
 		result = "集群备份"
 	case constant.ClusterEventWarning:
 		result = "集群事件告警"
+	case constant.ClusterRepointRegistry:
+		result = "集群仓库切换"
+	case constant.ClusterBackupVerify:
+		result = "集群备份校验"
+	case constant.ClusterHealth:
+		result = "集群健康检查"
+	case constant.ClusterCertExpiry:
+		result = "集群证书即将过期"
+	case constant.ClusterRenewCerts:
+		result = "集群证书更新"
 	}
 	return result
 }
@@ -241,6 +418,60 @@ func (m messageService) SendUserMessage(messages []model.UserMessage, clusterNam
 	}
 }
 
+// GetWebhookEvent 在发送前生成事件，集群卸载后仍能带上集群和项目信息
+func (m messageService) GetWebhookEvent(msg model.Message, clusterName, projectName string) client.WebhookEvent {
+	detail := make(map[string]string)
+	if err := json.Unmarshal([]byte(msg.Content), &detail); err != nil {
+		logger.Log.Errorf("messageService GetWebhookEvent json.Unmarshal failed, error: %s", err.Error())
+	}
+	return client.WebhookEvent{
+		ID:        msg.ID,
+		Event:     msg.Title,
+		Category:  msg.Type,
+		Level:     msg.Level,
+		Title:     Tr(msg.Title),
+		Project:   projectName,
+		Cluster:   clusterName,
+		Message:   detail["message"],
+		Detail:    detail,
+		Timestamp: time.Now(),
+	}
+}
+
+func (m messageService) getProjectName(clusterId string) string {
+	proResources, err := m.projectResourceRepo.ListByResourceIDAndType(clusterId, constant.ResourceCluster)
+	if err != nil || len(proResources) == 0 {
+		return ""
+	}
+	var project model.Project
+	if err := db.DB.Where("id = ?", proResources[0].ProjectID).First(&project).Error; err != nil {
+		return ""
+	}
+	return project.Name
+}
+
+// SendWebhookMessage Webhook 是系统级的接收方，不依赖用户的通知配置
+func (m messageService) SendWebhookMessage(event client.WebhookEvent) {
+	systemSetting, _ := m.systemSettingService.ListByTab(constant.Webhook)
+	if systemSetting.Vars == nil || systemSetting.Vars[constant.Webhook+"_STATUS"] != constant.Enable {
+		return
+	}
+	vars := make(map[string]interface{})
+	for k, value := range systemSetting.Vars {
+		vars[k] = value
+	}
+	vars["type"] = constant.Webhook
+	vars["EVENT"] = event
+	mClient, err := message.NewMessageClient(vars)
+	if err != nil {
+		logger.Log.Errorf("send webhook failed,create client error: %v\n", err.Error())
+		return
+	}
+	if err := mClient.SendMessage(vars); err != nil {
+		logger.Log.Errorf("send webhook failed,send message error: %v\n", err.Error())
+	}
+}
+
 func (m messageService) GetUserMessages(message model.Message) ([]model.UserMessage, error) {
 	var projectId string
 	var userMessages []model.UserMessage
@@ -0,0 +1,457 @@
+package service
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/redis"
+	"github.com/kmpp/pkg/util/encrypt"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/jinzhu/gorm"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/hotp"
+	"github.com/pquerna/otp/totp"
+	uuid "github.com/satori/go.uuid"
+	"github.com/spf13/viper"
+)
+
+var (
+	errMfaCodeInvalid    = errors.New("MFA_CODE_INVALID")
+	errMfaNotEnrolled    = errors.New("MFA_NOT_ENROLLED")
+	errMfaRequired       = errors.New("MFA_REQUIRED")
+	errMfaAlreadyEnabled = errors.New("MFA_ALREADY_ENABLED")
+	errMfaTokenInvalid   = errors.New("MFA_TOKEN_INVALID")
+	errMfaLocked         = errors.New("MFA_LOCKED")
+)
+
+const (
+	mfaPeriod       = 30
+	mfaPendingTTL   = 5 * time.Minute
+	mfaTokenPurpose = "mfa"
+	// 待验证 Token 在 redis 中的状态前缀
+	mfaChallengePrefix = "mfa:"
+)
+
+// MfaService 管理 TOTP 二次认证，登录时先签发短期的待验证 Token，验证通过后再签发会话或 JWT
+type MfaService interface {
+	Status(user *model.User) (*dto.MfaStatus, error)
+	Enroll(user *model.User) (*dto.MfaEnroll, error)
+	Activate(user *model.User, code string) (*dto.MfaRecoveryCodes, error)
+	Disable(user *model.User, code string) error
+	RegenerateRecoveryCodes(user *model.User, code string) (*dto.MfaRecoveryCodes, error)
+	Challenge(user *model.User, authMethod string) (*dto.MfaChallenge, error)
+	ParseChallenge(token string) (userID string, authMethod string, err error)
+	Verify(user *model.User, token string, code string) ([]string, error)
+	Reset(userName string) error
+}
+
+func NewMfaService() MfaService {
+	return &mfaService{
+		systemSettingService: NewSystemSettingService(),
+	}
+}
+
+type mfaService struct {
+	systemSettingService SystemSettingService
+}
+
+func (m mfaService) Status(user *model.User) (*dto.MfaStatus, error) {
+	mfa, err := getUserMfa(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.MfaStatus{Enabled: mfa.Enabled, Required: m.required(user)}, nil
+}
+
+func (m mfaService) Enroll(user *model.User) (*dto.MfaEnroll, error) {
+	mfa, err := getUserMfa(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa.Enabled {
+		return nil, errMfaAlreadyEnabled
+	}
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      constant.MfaIssuer,
+		AccountName: user.Name,
+		Period:      mfaPeriod,
+	})
+	if err != nil {
+		return nil, err
+	}
+	secret, err := encrypt.StringEncrypt(key.Secret())
+	if err != nil {
+		return nil, err
+	}
+	mfa.UserID = user.ID
+	mfa.Secret = secret
+	mfa.LastCounter = 0
+	if err := db.DB.Save(&mfa).Error; err != nil {
+		return nil, err
+	}
+	img, err := key.Image(200, 200)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return &dto.MfaEnroll{
+		Secret: key.Secret(),
+		URL:    key.URL(),
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()),
+	}, nil
+}
+
+func (m mfaService) Activate(user *model.User, code string) (*dto.MfaRecoveryCodes, error) {
+	mfa, err := getUserMfa(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa.Enabled {
+		return nil, errMfaAlreadyEnabled
+	}
+	if mfa.Secret == "" {
+		return nil, errMfaNotEnrolled
+	}
+	if err := verifyTotp(&mfa, code, time.Now()); err != nil {
+		return nil, err
+	}
+	codes, err := resetRecoveryCodes(&mfa)
+	if err != nil {
+		return nil, err
+	}
+	mfa.Enabled = true
+	if err := db.DB.Save(&mfa).Error; err != nil {
+		return nil, err
+	}
+	return &dto.MfaRecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+func (m mfaService) Disable(user *model.User, code string) error {
+	if m.required(user) {
+		return errMfaRequired
+	}
+	mfa, err := getUserMfa(user.ID)
+	if err != nil {
+		return err
+	}
+	if !mfa.Enabled {
+		return errMfaNotEnrolled
+	}
+	if err := verifyMfaCode(&mfa, code); err != nil {
+		return err
+	}
+	return db.DB.Delete(&mfa).Error
+}
+
+func (m mfaService) RegenerateRecoveryCodes(user *model.User, code string) (*dto.MfaRecoveryCodes, error) {
+	mfa, err := getUserMfa(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !mfa.Enabled {
+		return nil, errMfaNotEnrolled
+	}
+	if err := verifyTotp(&mfa, code, time.Now()); err != nil {
+		return nil, err
+	}
+	codes, err := resetRecoveryCodes(&mfa)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.DB.Save(&mfa).Error; err != nil {
+		return nil, err
+	}
+	return &dto.MfaRecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// Challenge 返回 nil 表示不需要二次认证，可以直接登录
+func (m mfaService) Challenge(user *model.User, authMethod string) (*dto.MfaChallenge, error) {
+	mfa, err := getUserMfa(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !mfa.Enabled && !m.required(user) {
+		return nil, nil
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"jti":        uuid.NewV4().String(),
+		"userId":     user.ID,
+		"authMethod": authMethod,
+		"purpose":    mfaTokenPurpose,
+		"exp":        time.Now().Add(mfaPendingTTL).Unix(),
+	}).SignedString(mfaSigningKey())
+	if err != nil {
+		return nil, err
+	}
+	return &dto.MfaChallenge{Token: token, Enroll: !mfa.Enabled}, nil
+}
+
+func (m mfaService) ParseChallenge(token string) (string, string, error) {
+	claims, err := parseChallenge(token)
+	if err != nil {
+		return "", "", err
+	}
+	userID, _ := claims["userId"].(string)
+	authMethod, _ := claims["authMethod"].(string)
+	return userID, authMethod, nil
+}
+
+// Verify 校验登录时的动态码或恢复码；首次绑定时校验通过即启用，并返回新生成的恢复码。
+// 同一个待验证 Token 失败次数过多时失效，用户连续失败次数过多时锁定
+func (m mfaService) Verify(user *model.User, token string, code string) ([]string, error) {
+	claims, err := parseChallenge(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims["userId"] != user.ID {
+		return nil, errMfaTokenInvalid
+	}
+	id, _ := claims["jti"].(string)
+	exp, _ := claims["exp"].(float64)
+	expires := time.Unix(int64(exp), 0)
+
+	mfa, err := getUserMfa(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if mfaLocked(&mfa, now) {
+		return nil, errMfaLocked
+	}
+	var codes []string
+	if !mfa.Enabled {
+		result, err := m.Activate(user, code)
+		if err == nil {
+			codes = result.RecoveryCodes
+		}
+		err = m.checkResult(&mfa, id, expires, now, err)
+		if err != nil {
+			return nil, err
+		}
+		return codes, nil
+	}
+	if err := m.checkResult(&mfa, id, expires, now, verifyMfaCode(&mfa, code)); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// checkResult 记录验证结果：失败时累计待验证 Token 和用户的失败次数，成功时 Token 失效并清零用户的失败次数
+func (m mfaService) checkResult(mfa *model.UserMfa, id string, expires, now time.Time, err error) error {
+	if err != nil && err != errMfaCodeInvalid {
+		return err
+	}
+	if err == nil {
+		// 验证通过后 Token 不能再次使用，多个副本同时验证同一个 Token 时只有一个成功
+		used, e := redis.ChallengeUse(mfaChallengePrefix+id, expires)
+		if e != nil {
+			return e
+		}
+		if !used {
+			return errMfaTokenInvalid
+		}
+		if mfa.ID == "" || (mfa.FailedCount == 0 && mfa.LockedUntil == nil) {
+			return nil
+		}
+		return db.DB.Model(&model.UserMfa{}).Where("id = ?", mfa.ID).
+			Updates(map[string]interface{}{"failed_count": 0, "locked_until": nil}).Error
+	}
+	if _, e := redis.ChallengeFail(mfaChallengePrefix+id, expires); e != nil {
+		return e
+	}
+	if mfa.ID == "" {
+		return err
+	}
+	recordMfaFailure(mfa, now)
+	if e := db.DB.Model(&model.UserMfa{}).Where("id = ?", mfa.ID).
+		Updates(map[string]interface{}{"failed_count": mfa.FailedCount, "locked_until": mfa.LockedUntil}).Error; e != nil {
+		return e
+	}
+	if mfaLocked(mfa, now) {
+		return errMfaLocked
+	}
+	return err
+}
+
+// Reset 管理员为丢失认证器的用户清除二次认证配置
+func (m mfaService) Reset(userName string) error {
+	var user model.User
+	if err := db.DB.Where("name = ?", userName).First(&user).Error; err != nil {
+		return err
+	}
+	return db.DB.Where("user_id = ?", user.ID).Delete(&model.UserMfa{}).Error
+}
+
+func (m mfaService) required(user *model.User) bool {
+	if !user.IsAdmin {
+		return false
+	}
+	setting, err := m.systemSettingService.Get(constant.MfaAdminRequiredKey)
+	if err != nil {
+		return false
+	}
+	return setting.Value == constant.Enable
+}
+
+func getUserMfa(userID string) (model.UserMfa, error) {
+	var mfa model.UserMfa
+	if err := db.DB.Where("user_id = ?", userID).First(&mfa).Error; err != nil && !gorm.IsRecordNotFoundError(err) {
+		return mfa, err
+	}
+	return mfa, nil
+}
+
+func parseChallenge(token string) (jwt.MapClaims, error) {
+	parsed, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errMfaTokenInvalid
+		}
+		return mfaSigningKey(), nil
+	})
+	if err != nil || !parsed.Valid {
+		return nil, errMfaTokenInvalid
+	}
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != mfaTokenPurpose {
+		return nil, errMfaTokenInvalid
+	}
+	id, _ := claims["jti"].(string)
+	if userID, _ := claims["userId"].(string); userID == "" || id == "" {
+		return nil, errMfaTokenInvalid
+	}
+	if mfaChallengeExhausted(id) {
+		return nil, errMfaTokenInvalid
+	}
+	return claims, nil
+}
+
+// mfaChallengeExhausted 待验证 Token 已使用或失败次数达到上限，状态保存在 redis 中由多副本共享，读取失败时视为不可用
+func mfaChallengeExhausted(id string) bool {
+	failures, used, err := redis.ChallengeState(mfaChallengePrefix + id)
+	if err != nil {
+		logger.Log.Errorf("get mfa challenge %s error %s", id, err.Error())
+		return true
+	}
+	return used || failures >= constant.MfaMaxChallengeFailures
+}
+
+func mfaLocked(mfa *model.UserMfa, now time.Time) bool {
+	return mfa.LockedUntil != nil && now.Before(*mfa.LockedUntil)
+}
+
+// recordMfaFailure 累计失败次数，达到上限时锁定用户并重新计数
+func recordMfaFailure(mfa *model.UserMfa, now time.Time) {
+	mfa.FailedCount++
+	if mfa.FailedCount >= constant.MfaMaxUserFailures {
+		until := now.Add(constant.MfaLockDuration)
+		mfa.LockedUntil = &until
+		mfa.FailedCount = 0
+	}
+}
+
+// mfaSigningKey 待验证 Token 使用单独的密钥，避免被 JWTMiddleware 当作登录凭证
+func mfaSigningKey() []byte {
+	return []byte(viper.GetString("jwt.secret") + ":" + mfaTokenPurpose)
+}
+
+// verifyMfaCode 先按动态码校验，失败时再按恢复码校验，恢复码使用后即失效
+func verifyMfaCode(mfa *model.UserMfa, code string) error {
+	if err := verifyTotp(mfa, code, time.Now()); err == nil {
+		return nil
+	}
+	var hashes []string
+	_ = json.Unmarshal([]byte(mfa.RecoveryCodes), &hashes)
+	remain, ok := consumeRecoveryCode(hashes, code)
+	if !ok {
+		return errMfaCodeInvalid
+	}
+	data, _ := json.Marshal(remain)
+	mfa.RecoveryCodes = string(data)
+	return db.DB.Model(&model.UserMfa{}).Where("id = ?", mfa.ID).Update("recovery_codes", mfa.RecoveryCodes).Error
+}
+
+// verifyTotp 允许前后各一个周期的时钟偏差，已使用过的周期不能重复使用
+func verifyTotp(mfa *model.UserMfa, code string, now time.Time) error {
+	secret, err := encrypt.StringDecrypt(mfa.Secret)
+	if err != nil {
+		return err
+	}
+	counter, ok := matchTotp(secret, code, now, mfa.LastCounter)
+	if !ok {
+		return errMfaCodeInvalid
+	}
+	mfa.LastCounter = counter
+	if mfa.ID == "" {
+		return nil
+	}
+	return db.DB.Model(&model.UserMfa{}).Where("id = ?", mfa.ID).Update("last_counter", counter).Error
+}
+
+func matchTotp(secret, code string, now time.Time, lastCounter int64) (int64, bool) {
+	current := now.Unix() / mfaPeriod
+	for _, counter := range []int64{current - 1, current, current + 1} {
+		if counter <= lastCounter {
+			continue
+		}
+		ok, err := hotp.ValidateCustom(strings.TrimSpace(code), uint64(counter), secret, hotp.ValidateOpts{
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err == nil && ok {
+			return counter, true
+		}
+	}
+	return 0, false
+}
+
+func resetRecoveryCodes(mfa *model.UserMfa) ([]string, error) {
+	var codes, hashes []string
+	for i := 0; i < constant.MfaRecoveryCodeNumber; i++ {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		code := hex.EncodeToString(buf)
+		code = fmt.Sprintf("%s-%s", code[:5], code[5:])
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	data, err := json.Marshal(hashes)
+	if err != nil {
+		return nil, err
+	}
+	mfa.RecoveryCodes = string(data)
+	return codes, nil
+}
+
+func consumeRecoveryCode(hashes []string, code string) ([]string, bool) {
+	h := hashRecoveryCode(code)
+	for i := range hashes {
+		if hashes[i] == h {
+			return append(hashes[:i:i], hashes[i+1:]...), true
+		}
+	}
+	return hashes, false
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
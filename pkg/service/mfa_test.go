@@ -0,0 +1,120 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/model"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/pquerna/otp/totp"
+	uuid "github.com/satori/go.uuid"
+)
+
+func TestMatchTotp(t *testing.T) {
+	secret := "JBSWY3DPEHPK3PXP"
+	now := time.Unix(1700000000, 0)
+	code, err := totp.GenerateCode(secret, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter, ok := matchTotp(secret, code, now, 0)
+	if !ok || counter != now.Unix()/mfaPeriod {
+		t.Fatalf("code should match current period, got %d %v", counter, ok)
+	}
+	if _, ok := matchTotp(secret, code, now, counter); ok {
+		t.Error("code of a used period should not be accepted again")
+	}
+	if _, ok := matchTotp(secret, code, now.Add(time.Duration(mfaPeriod)*time.Second), 0); !ok {
+		t.Error("code of the previous period should be accepted")
+	}
+	if _, ok := matchTotp(secret, code, now.Add(5*time.Minute), 0); ok {
+		t.Error("expired code should not be accepted")
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	var mfa model.UserMfa
+	codes, err := resetRecoveryCodes(&mfa)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var hashes []string
+	if err := json.Unmarshal([]byte(mfa.RecoveryCodes), &hashes); err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != constant.MfaRecoveryCodeNumber || len(hashes) != len(codes) {
+		t.Fatalf("unexpected recovery codes %v", codes)
+	}
+	remain, ok := consumeRecoveryCode(hashes, codes[0])
+	if !ok || len(remain) != len(hashes)-1 {
+		t.Fatal("recovery code should be consumed")
+	}
+	if _, ok := consumeRecoveryCode(remain, codes[0]); ok {
+		t.Error("recovery code should only be used once")
+	}
+}
+
+func TestMfaChallengeLimit(t *testing.T) {
+	now := time.Now()
+	newToken := func() string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"jti":     uuid.NewV4().String(),
+			"userId":  "user-id",
+			"purpose": mfaTokenPurpose,
+			"exp":     now.Add(mfaPendingTTL).Unix(),
+		}).SignedString(mfaSigningKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	var m mfaService
+	mfa := model.UserMfa{}
+	token := newToken()
+	claims, err := parseChallenge(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := claims["jti"].(string)
+	expires := now.Add(mfaPendingTTL)
+	for i := 0; i < constant.MfaMaxChallengeFailures; i++ {
+		if _, err := parseChallenge(token); err != nil {
+			t.Fatalf("challenge should be valid after %d failures", i)
+		}
+		if err := m.checkResult(&mfa, id, expires, now, errMfaCodeInvalid); err != errMfaCodeInvalid {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+	if _, err := parseChallenge(token); err != errMfaTokenInvalid {
+		t.Errorf("challenge should be invalid after %d failures", constant.MfaMaxChallengeFailures)
+	}
+
+	token = newToken()
+	claims, _ = parseChallenge(token)
+	if err := m.checkResult(&mfa, claims["jti"].(string), expires, now, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parseChallenge(token); err != errMfaTokenInvalid {
+		t.Error("challenge should not be reused after a successful verification")
+	}
+}
+
+func TestRecordMfaFailure(t *testing.T) {
+	now := time.Now()
+	var mfa model.UserMfa
+	for i := 1; i < constant.MfaMaxUserFailures; i++ {
+		recordMfaFailure(&mfa, now)
+		if mfaLocked(&mfa, now) {
+			t.Fatalf("user should not be locked after %d failures", i)
+		}
+	}
+	recordMfaFailure(&mfa, now)
+	if !mfaLocked(&mfa, now) || mfa.FailedCount != 0 {
+		t.Fatalf("user should be locked after %d failures", constant.MfaMaxUserFailures)
+	}
+	if mfaLocked(&mfa, now.Add(constant.MfaLockDuration)) {
+		t.Error("lock should expire")
+	}
+}
@@ -0,0 +1,572 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/controller/page"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/logger"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/util/git"
+	kubeUtil "github.com/kmpp/pkg/util/kubernetes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var (
+	MultiClusterRepositoryNotReady = "MULTI_CLUSTER_REPOSITORY_NOT_READY"
+	MultiClusterRepositorySyncing  = "MULTI_CLUSTER_REPOSITORY_SYNCING"
+)
+
+type MultiClusterRepositoryService interface {
+	Get(name string) (*dto.MultiClusterRepository, error)
+	List() ([]dto.MultiClusterRepository, error)
+	Page(num, size int) (*page.Page, error)
+	Create(req dto.MultiClusterRepositoryCreateRequest) (*dto.MultiClusterRepository, error)
+	Update(name string, req dto.MultiClusterRepositoryUpdateRequest) (*dto.MultiClusterRepository, error)
+	Delete(name string) error
+	Batch(req dto.MultiClusterRepositoryBatch) error
+	GetClusterRelations(name string) ([]dto.ClusterRelation, error)
+	UpdateClusterRelations(name string, req dto.UpdateRelationRequest) error
+	PageSyncLogs(name string, num, size int) (*page.Page, error)
+	GetSyncLogDetail(name string, logId string) (*dto.MultiClusterSyncLogDetail, error)
+	Sync(name string, force bool) error
+}
+
+func NewMultiClusterRepositoryService() MultiClusterRepositoryService {
+	return &multiClusterRepositoryService{
+		clusterService: NewClusterService(),
+	}
+}
+
+type multiClusterRepositoryService struct {
+	clusterService ClusterService
+}
+
+func (m multiClusterRepositoryService) Get(name string) (*dto.MultiClusterRepository, error) {
+	mo, err := m.get(name)
+	if err != nil {
+		return nil, err
+	}
+	return toMultiClusterRepositoryDTO(mo), nil
+}
+
+func (m multiClusterRepositoryService) List() ([]dto.MultiClusterRepository, error) {
+	var mos []model.MultiClusterRepository
+	if err := db.DB.Order("name").Find(&mos).Error; err != nil {
+		return nil, err
+	}
+	var result []dto.MultiClusterRepository
+	for _, mo := range mos {
+		result = append(result, *toMultiClusterRepositoryDTO(mo))
+	}
+	return result, nil
+}
+
+func (m multiClusterRepositoryService) Page(num, size int) (*page.Page, error) {
+	var (
+		p     page.Page
+		mos   []model.MultiClusterRepository
+		items []dto.MultiClusterRepository
+	)
+	if err := db.DB.Model(model.MultiClusterRepository{}).
+		Count(&p.Total).
+		Order("name").
+		Offset((num - 1) * size).
+		Limit(size).
+		Find(&mos).Error; err != nil {
+		return nil, err
+	}
+	for _, mo := range mos {
+		items = append(items, *toMultiClusterRepositoryDTO(mo))
+	}
+	p.Items = items
+	return &p, nil
+}
+
+func (m multiClusterRepositoryService) Create(req dto.MultiClusterRepositoryCreateRequest) (*dto.MultiClusterRepository, error) {
+	var count int
+	if err := db.DB.Model(model.MultiClusterRepository{}).Where("name = ?", req.Name).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, errors.New("NAME_EXISTS")
+	}
+	branch := req.Branch
+	if branch == "" {
+		branch = "master"
+	}
+	repo := model.MultiClusterRepository{
+		Name:         req.Name,
+		Source:       req.Source,
+		Branch:       branch,
+		Username:     req.Username,
+		Password:     req.Password,
+		Status:       constant.StatusInitializing,
+		SyncInterval: constant.DefaultMultiClusterSyncInterval,
+		GitTimeout:   constant.DefaultMultiClusterGitTimeout,
+		SyncStatus:   constant.StatusWaiting,
+	}
+	if err := db.DB.Create(&repo).Error; err != nil {
+		return nil, err
+	}
+	go m.clone(repo)
+	return toMultiClusterRepositoryDTO(repo), nil
+}
+
+func (m multiClusterRepositoryService) clone(repo model.MultiClusterRepository) {
+	_ = os.RemoveAll(repo.LocalPath())
+	if err := repo.Clone(); err != nil {
+		logger.Log.Errorf("clone repository %s failed: %s", repo.Name, err.Error())
+		_ = os.RemoveAll(repo.LocalPath())
+		repo.Status = constant.StatusFailed
+		repo.Message = err.Error()
+	} else {
+		repo.Status = constant.StatusRunning
+		repo.Message = ""
+	}
+	if err := db.DB.Model(&repo).Updates(map[string]interface{}{"status": repo.Status, "message": repo.Message}).Error; err != nil {
+		logger.Log.Errorf("save repository %s status failed: %s", repo.Name, err.Error())
+	}
+}
+
+func (m multiClusterRepositoryService) Update(name string, req dto.MultiClusterRepositoryUpdateRequest) (*dto.MultiClusterRepository, error) {
+	repo, err := m.get(name)
+	if err != nil {
+		return nil, err
+	}
+	if req.SyncInterval > 0 {
+		repo.SyncInterval = req.SyncInterval
+	}
+	if req.GitTimeout > 0 {
+		repo.GitTimeout = req.GitTimeout
+	}
+	if req.SyncEnable != nil {
+		repo.SyncEnable = *req.SyncEnable
+	}
+	if err := db.DB.Model(&repo).Updates(map[string]interface{}{
+		"sync_interval": repo.SyncInterval,
+		"git_timeout":   repo.GitTimeout,
+		"sync_enable":   repo.SyncEnable,
+	}).Error; err != nil {
+		return nil, err
+	}
+	return toMultiClusterRepositoryDTO(repo), nil
+}
+
+func (m multiClusterRepositoryService) Delete(name string) error {
+	repo, err := m.get(name)
+	if err != nil {
+		return err
+	}
+	if repo.SyncRunning(time.Now()) {
+		return errors.New(MultiClusterRepositorySyncing)
+	}
+	tx := db.DB.Begin()
+	if err := tx.Where("multi_cluster_repository_id = ?", repo.ID).Delete(model.ClusterMultiClusterRepository{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Delete(&repo).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+func (m multiClusterRepositoryService) Batch(req dto.MultiClusterRepositoryBatch) error {
+	switch req.Operation {
+	case constant.BatchOperationDelete:
+		for _, item := range req.Items {
+			if err := m.Delete(item.Name); err != nil {
+				return err
+			}
+		}
+	default:
+		return constant.NotSupportedBatchOperation
+	}
+	return nil
+}
+
+func (m multiClusterRepositoryService) GetClusterRelations(name string) ([]dto.ClusterRelation, error) {
+	repo, err := m.get(name)
+	if err != nil {
+		return nil, err
+	}
+	var relations []model.ClusterMultiClusterRepository
+	if err := db.DB.Where("multi_cluster_repository_id = ?", repo.ID).Find(&relations).Error; err != nil {
+		return nil, err
+	}
+	var result []dto.ClusterRelation
+	for _, r := range relations {
+		var cluster model.Cluster
+		if err := db.DB.Where("id = ?", r.ClusterID).First(&cluster).Error; err != nil {
+			return nil, err
+		}
+		result = append(result, dto.ClusterRelation{ClusterMultiClusterRepository: r, ClusterName: cluster.Name})
+	}
+	return result, nil
+}
+
+func (m multiClusterRepositoryService) UpdateClusterRelations(name string, req dto.UpdateRelationRequest) error {
+	repo, err := m.get(name)
+	if err != nil {
+		return err
+	}
+	var clusters []model.Cluster
+	if err := db.DB.Where("name in (?)", req.ClusterNames).Find(&clusters).Error; err != nil {
+		return err
+	}
+	tx := db.DB.Begin()
+	for _, c := range clusters {
+		if req.Delete {
+			if err := tx.Where("multi_cluster_repository_id = ? AND cluster_id = ?", repo.ID, c.ID).
+				Delete(model.ClusterMultiClusterRepository{}).Error; err != nil {
+				tx.Rollback()
+				return err
+			}
+			continue
+		}
+		var count int
+		if err := tx.Model(model.ClusterMultiClusterRepository{}).
+			Where("multi_cluster_repository_id = ? AND cluster_id = ?", repo.ID, c.ID).
+			Count(&count).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if count > 0 {
+			continue
+		}
+		if err := tx.Create(&model.ClusterMultiClusterRepository{
+			ClusterID:                c.ID,
+			MultiClusterRepositoryID: repo.ID,
+			Status:                   constant.StatusWaiting,
+		}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	// 新绑定的集群需要在下一次同步时应用全部资源
+	if !req.Delete {
+		if err := tx.Model(&repo).Update("last_sync_head", "").Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	tx.Commit()
+	return nil
+}
+
+func (m multiClusterRepositoryService) PageSyncLogs(name string, num, size int) (*page.Page, error) {
+	repo, err := m.get(name)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		p     page.Page
+		logs  []model.MultiClusterSyncLog
+		items []dto.MultiClusterSyncLog
+	)
+	if err := db.DB.Model(model.MultiClusterSyncLog{}).
+		Where("multi_cluster_repository_id = ?", repo.ID).
+		Count(&p.Total).
+		Order("created_at desc").
+		Offset((num - 1) * size).
+		Limit(size).
+		Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	for _, l := range logs {
+		items = append(items, dto.MultiClusterSyncLog{MultiClusterSyncLog: l})
+	}
+	p.Items = items
+	return &p, nil
+}
+
+func (m multiClusterRepositoryService) GetSyncLogDetail(name string, logId string) (*dto.MultiClusterSyncLogDetail, error) {
+	repo, err := m.get(name)
+	if err != nil {
+		return nil, err
+	}
+	var syncLog model.MultiClusterSyncLog
+	if err := db.DB.Where("id = ? AND multi_cluster_repository_id = ?", logId, repo.ID).First(&syncLog).Error; err != nil {
+		return nil, err
+	}
+	detail := dto.MultiClusterSyncLogDetail{MultiClusterSyncLog: syncLog}
+	var clusterLogs []model.MultiClusterSyncClusterLog
+	if err := db.DB.Where("multi_cluster_sync_log_id = ?", syncLog.ID).Find(&clusterLogs).Error; err != nil {
+		return nil, err
+	}
+	for _, cl := range clusterLogs {
+		item := dto.MultiClusterSyncClusterLog{MultiClusterSyncClusterLog: cl}
+		var cluster model.Cluster
+		if err := db.DB.Where("id = ?", cl.ClusterID).First(&cluster).Error; err == nil {
+			item.ClusterName = cluster.Name
+		}
+		if err := db.DB.Where("multi_cluster_sync_cluster_log_id = ?", cl.ID).
+			Order("source_file").
+			Find(&item.MultiClusterSyncClusterResourceLogs).Error; err != nil {
+			return nil, err
+		}
+		detail.MultiClusterSyncClusterLogs = append(detail.MultiClusterSyncClusterLogs, item)
+	}
+	return &detail, nil
+}
+
+// Sync 拉取仓库并将清单应用到所有关联集群，force 为 false 时仓库没有新提交则跳过
+func (m multiClusterRepositoryService) Sync(name string, force bool) error {
+	repo, err := m.get(name)
+	if err != nil {
+		return err
+	}
+	if repo.Status != constant.StatusRunning {
+		return errors.New(MultiClusterRepositoryNotReady)
+	}
+	// 用条件更新抢占同步状态，避免定时任务和手动同步同时执行；进程异常退出遗留的 Running 状态超时后可以重新抢占
+	now := time.Now()
+	result := db.DB.Model(model.MultiClusterRepository{}).
+		Where("id = ? AND (sync_status IS NULL OR sync_status <> ? OR updated_at < ?)", repo.ID, constant.StatusRunning, repo.SyncStaleBefore(now)).
+		Updates(map[string]interface{}{"sync_status": constant.StatusRunning, "updated_at": now})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(MultiClusterRepositorySyncing)
+	}
+	status := constant.StatusSuccess
+	head, err := m.sync(repo, force)
+	if err != nil {
+		logger.Log.Errorf("sync repository %s failed: %s", repo.Name, err.Error())
+		status = constant.StatusFailed
+	}
+	updates := map[string]interface{}{
+		"sync_status":    status,
+		"last_sync_time": time.Now(),
+	}
+	if head != "" {
+		updates["last_sync_head"] = head
+	}
+	if err := db.DB.Model(&repo).Updates(updates).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m multiClusterRepositoryService) sync(repo model.MultiClusterRepository, force bool) (string, error) {
+	syncLog := model.MultiClusterSyncLog{
+		Status:                   constant.StatusRunning,
+		MultiClusterRepositoryID: repo.ID,
+	}
+	if err := repo.Pull(); err != nil {
+		syncLog.Status = constant.StatusFailed
+		syncLog.Message = err.Error()
+		_ = db.DB.Create(&syncLog).Error
+		return "", err
+	}
+	head, err := git.HeadCommit(repo.LocalPath())
+	if err != nil {
+		syncLog.Status = constant.StatusFailed
+		syncLog.Message = err.Error()
+		_ = db.DB.Create(&syncLog).Error
+		return "", err
+	}
+	if !force && head == repo.LastSyncHead && repo.SyncStatus == constant.StatusSuccess {
+		return head, nil
+	}
+	syncLog.GitCommitId = head
+	if err := db.DB.Create(&syncLog).Error; err != nil {
+		return "", err
+	}
+
+	manifests, err := readRepositoryManifests(repo.LocalPath())
+	if err != nil {
+		m.finishSyncLog(&syncLog, err)
+		return "", err
+	}
+	var relations []model.ClusterMultiClusterRepository
+	if err := db.DB.Where("multi_cluster_repository_id = ?", repo.ID).Find(&relations).Error; err != nil {
+		m.finishSyncLog(&syncLog, err)
+		return "", err
+	}
+
+	var (
+		wg     sync.WaitGroup
+		lock   sync.Mutex
+		failed []string
+	)
+	for i := range relations {
+		wg.Add(1)
+		go func(relation model.ClusterMultiClusterRepository) {
+			defer wg.Done()
+			if err := m.syncCluster(syncLog, relation, manifests); err != nil {
+				lock.Lock()
+				failed = append(failed, err.Error())
+				lock.Unlock()
+			}
+		}(relations[i])
+	}
+	wg.Wait()
+	if len(failed) > 0 {
+		err = errors.New(strings.Join(failed, "; "))
+	}
+	m.finishSyncLog(&syncLog, err)
+	return head, err
+}
+
+func (m multiClusterRepositoryService) syncCluster(syncLog model.MultiClusterSyncLog, relation model.ClusterMultiClusterRepository, manifests []repositoryManifest) error {
+	clusterLog := model.MultiClusterSyncClusterLog{
+		Status:                constant.StatusRunning,
+		MultiClusterSyncLogID: syncLog.ID,
+		ClusterID:             relation.ClusterID,
+	}
+	if err := db.DB.Create(&clusterLog).Error; err != nil {
+		return err
+	}
+	err := m.applyToCluster(clusterLog, relation.ClusterID, manifests)
+	clusterLog.Status = constant.StatusSuccess
+	clusterLog.Message = ""
+	if err != nil {
+		clusterLog.Status = constant.StatusFailed
+		clusterLog.Message = err.Error()
+	}
+	_ = db.DB.Save(&clusterLog).Error
+	_ = db.DB.Model(&relation).Updates(map[string]interface{}{"status": clusterLog.Status, "message": clusterLog.Message}).Error
+	return err
+}
+
+func (m multiClusterRepositoryService) applyToCluster(clusterLog model.MultiClusterSyncClusterLog, clusterID string, manifests []repositoryManifest) error {
+	var cluster model.Cluster
+	if err := db.DB.Where("id = ?", clusterID).Preload("Status").First(&cluster).Error; err != nil {
+		return err
+	}
+	if cluster.Status.Phase != constant.StatusRunning {
+		return fmt.Errorf("cluster %s status is %s", cluster.Name, cluster.Status.Phase)
+	}
+	endpoints, err := m.clusterService.GetApiServerEndpoints(cluster.Name)
+	if err != nil {
+		return err
+	}
+	secret, err := m.clusterService.GetSecrets(cluster.Name)
+	if err != nil {
+		return err
+	}
+	applier, err := kubeUtil.NewApplier(&kubeUtil.Config{Hosts: endpoints, Token: secret.KubernetesToken})
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for _, manifest := range manifests {
+		if manifest.err != nil {
+			failed++
+			m.saveResourceLog(clusterLog, manifest.file, "", manifest.err)
+			continue
+		}
+		for _, obj := range manifest.objects {
+			// Apply 会修改对象，每个集群使用自己的副本
+			o := obj.DeepCopy()
+			err := applier.Apply(o)
+			if err != nil {
+				failed++
+			}
+			m.saveResourceLog(clusterLog, manifest.file, resourceName(o), err)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("cluster %s: %d resources failed", cluster.Name, failed)
+	}
+	return nil
+}
+
+func (m multiClusterRepositoryService) saveResourceLog(clusterLog model.MultiClusterSyncClusterLog, file, name string, err error) {
+	resourceLog := model.MultiClusterSyncClusterResourceLog{
+		SourceFile:                   file,
+		ResourceName:                 name,
+		Status:                       constant.StatusSuccess,
+		MultiClusterSyncClusterLogID: clusterLog.ID,
+	}
+	if err != nil {
+		resourceLog.Status = constant.StatusFailed
+		resourceLog.Message = err.Error()
+	}
+	if err := db.DB.Create(&resourceLog).Error; err != nil {
+		logger.Log.Errorf("save sync resource log failed: %s", err.Error())
+	}
+}
+
+func (m multiClusterRepositoryService) finishSyncLog(syncLog *model.MultiClusterSyncLog, err error) {
+	syncLog.Status = constant.StatusSuccess
+	if err != nil {
+		syncLog.Status = constant.StatusFailed
+		syncLog.Message = err.Error()
+	}
+	if err := db.DB.Save(syncLog).Error; err != nil {
+		logger.Log.Errorf("save sync log failed: %s", err.Error())
+	}
+}
+
+func (m multiClusterRepositoryService) get(name string) (model.MultiClusterRepository, error) {
+	var repo model.MultiClusterRepository
+	if err := db.DB.Where("name = ?", name).First(&repo).Error; err != nil {
+		return repo, err
+	}
+	return repo, nil
+}
+
+func toMultiClusterRepositoryDTO(mo model.MultiClusterRepository) *dto.MultiClusterRepository {
+	mo.Password = ""
+	return &dto.MultiClusterRepository{MultiClusterRepository: mo}
+}
+
+type repositoryManifest struct {
+	file    string
+	objects []*unstructured.Unstructured
+	err     error
+}
+
+// readRepositoryManifests 按路径顺序读取仓库中的 yaml 和 json 清单，跳过隐藏目录
+func readRepositoryManifests(root string) ([]repositoryManifest, error) {
+	var manifests []repositoryManifest
+	err := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if p != root && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(p)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+		rel, _ := filepath.Rel(root, p)
+		manifest := repositoryManifest{file: rel}
+		data, err := ioutil.ReadFile(p)
+		if err != nil {
+			manifest.err = err
+		} else {
+			manifest.objects, manifest.err = kubeUtil.DecodeManifests(data)
+		}
+		manifests = append(manifests, manifest)
+		return nil
+	})
+	return manifests, err
+}
+
+func resourceName(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName())
+	}
+	return fmt.Sprintf("%s/%s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+}
@@ -157,7 +157,7 @@ func (p *projectService) Page(num, size int, user dto.SessionUser, conditions co
 			return nil, err
 		}
 	} else {
-		if user.IsRole(constant.RoleProjectManager) {
+		if (user.IsRole(constant.RoleProjectManager) || user.IsRole(constant.RoleProjectViewer)) {
 
 			var projectResources []model.ProjectMember
 			err := db.DB.Where("user_id = ?", user.UserId).Find(&projectResources).Error
@@ -230,7 +230,7 @@ func (p projectService) GetResourceTree(user dto.SessionUser) ([]dto.ProjectReso
 		if err := db.DB.Model(model.Project{}).Order("created_at ASC").Find(&projects).Error; err != nil {
 			return nil, err
 		}
-	} else if user.IsRole(constant.RoleProjectManager) {
+	} else if (user.IsRole(constant.RoleProjectManager) || user.IsRole(constant.RoleProjectViewer)) {
 		if err := db.DB.Model(model.Project{}).Where("name = ?", user.CurrentProject).Order("created_at ASC").Find(&projects).Error; err != nil {
 			return nil, err
 		}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/kmpp/pkg/constant"
@@ -108,7 +109,7 @@ func (p *projectMemberService) GetUsers(name, projectName string) (*dto.AddMembe
 	if err := db.DB.Model(model.Project{}).Where("name = ?", projectName).First(&project).Error; err != nil {
 		return nil, err
 	}
-	err := db.DB.Select("name").Where("is_admin = 0 AND name LIKE ?  AND id  NOT IN (SELECT user_id FROM ko_project_member WHERE project_id = ? AND role = 'PROJECT_MANAGER' ) ", "%"+name+"%", project.ID).Find(&users).Error
+	err := db.DB.Select("name").Where("is_admin = 0 AND name LIKE ?  AND id  NOT IN (SELECT user_id FROM ko_project_member WHERE project_id = ?) ", "%"+name+"%", project.ID).Find(&users).Error
 	if err != nil {
 		return nil, err
 	}
@@ -129,6 +130,13 @@ func (p *projectMemberService) Create(projectName string, request dto.ProjectMem
 	if err := db.DB.Model(model.Project{}).Where("name = ?", projectName).First(&project).Error; err != nil {
 		return nil, err
 	}
+	role := request.Role
+	if role == "" {
+		role = constant.ProjectRoleProjectManager
+	}
+	if role != constant.ProjectRoleProjectManager && role != constant.ProjectRoleProjectViewer {
+		return nil, errors.New("PROJECT_MEMBER_ROLE_INVALID")
+	}
 
 	for _, name := range request.Usernames {
 		var user model.User
@@ -147,7 +155,7 @@ func (p *projectMemberService) Create(projectName string, request dto.ProjectMem
 			}
 			pm := model.ProjectMember{
 				UserID:    user.ID,
-				Role:      constant.ProjectRoleProjectManager,
+				Role:      role,
 				ProjectID: project.ID,
 			}
 			if err := db.DB.Create(&pm).Error; err != nil {
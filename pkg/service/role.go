@@ -0,0 +1,421 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/repository"
+	"github.com/jinzhu/gorm"
+	"github.com/storyicon/grbac/pkg/meta"
+)
+
+var (
+	errRoleNotFound        = errors.New("ROLE_NOT_FOUND")
+	errRoleNameExists      = errors.New("ROLE_NAME_EXISTS")
+	errRoleNameReserved    = errors.New("ROLE_NAME_RESERVED")
+	errRoleRuleInvalid     = errors.New("ROLE_RULE_INVALID")
+	errRoleBindingNotFound = errors.New("ROLE_BINDING_NOT_FOUND")
+	errRoleBindingExists   = errors.New("ROLE_BINDING_EXISTS")
+	errRoleBindingResource = errors.New("ROLE_BINDING_RESOURCE_REQUIRED")
+)
+
+var roleRuleMethods = map[string]bool{
+	"GET":    true,
+	"POST":   true,
+	"PUT":    true,
+	"PATCH":  true,
+	"DELETE": true,
+	"*":      true,
+}
+
+// RoleService 管理自定义角色及其绑定，内置角色的规则仍由 constant.Roles 定义
+type RoleService interface {
+	List() ([]dto.Role, error)
+	Get(name string) (*dto.Role, error)
+	Create(req dto.RoleCreate) (*dto.Role, error)
+	Update(name string, req dto.RoleUpdate) (*dto.Role, error)
+	Delete(name string) error
+	ListBindings(roleName string) ([]dto.RoleBinding, error)
+	CreateBinding(roleName string, req dto.RoleBindingCreate) (*dto.RoleBinding, error)
+	DeleteBinding(roleName, id string) error
+	IsRequestGranted(userId string, r *http.Request, scope RequestScope) (bool, error)
+}
+
+// RequestScope 请求指向的项目和集群，只取自匹配路由中的资源参数，不读取查询参数和请求体
+type RequestScope struct {
+	ProjectName string
+	ClusterName string
+}
+
+func NewRoleService() RoleService {
+	return &roleService{
+		userRepo:    repository.NewUserRepository(),
+		projectRepo: repository.NewProjectRepository(),
+		clusterRepo: repository.NewClusterRepository(),
+	}
+}
+
+type roleService struct {
+	userRepo    repository.UserRepository
+	projectRepo repository.ProjectRepository
+	clusterRepo repository.ClusterRepository
+}
+
+func (r roleService) List() ([]dto.Role, error) {
+	var roles []model.Role
+	if err := db.DB.Order("created_at desc").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	var result []dto.Role
+	for _, role := range roles {
+		result = append(result, toRoleDTO(role))
+	}
+	return result, nil
+}
+
+func (r roleService) Get(name string) (*dto.Role, error) {
+	role, err := r.getRole(name)
+	if err != nil {
+		return nil, err
+	}
+	d := toRoleDTO(role)
+	return &d, nil
+}
+
+func (r roleService) Create(req dto.RoleCreate) (*dto.Role, error) {
+	switch req.Name {
+	case constant.RoleAdmin, constant.RoleProjectManager, constant.RoleClusterManager, constant.RoleProjectViewer:
+		return nil, errRoleNameReserved
+	}
+	var old model.Role
+	db.DB.Where("name = ?", req.Name).First(&old)
+	if old.ID != "" {
+		return nil, errRoleNameExists
+	}
+	rules, err := marshalRoleRules(req.Rules)
+	if err != nil {
+		return nil, err
+	}
+	role := model.Role{
+		Name:        req.Name,
+		Description: req.Description,
+		Rules:       rules,
+	}
+	if err := db.DB.Create(&role).Error; err != nil {
+		return nil, err
+	}
+	d := toRoleDTO(role)
+	return &d, nil
+}
+
+func (r roleService) Update(name string, req dto.RoleUpdate) (*dto.Role, error) {
+	role, err := r.getRole(name)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := marshalRoleRules(req.Rules)
+	if err != nil {
+		return nil, err
+	}
+	role.Description = req.Description
+	role.Rules = rules
+	if err := db.DB.Save(&role).Error; err != nil {
+		return nil, err
+	}
+	d := toRoleDTO(role)
+	return &d, nil
+}
+
+func (r roleService) Delete(name string) error {
+	role, err := r.getRole(name)
+	if err != nil {
+		return err
+	}
+	tx := db.DB.Begin()
+	if err := tx.Where("role_id = ?", role.ID).Delete(&model.RoleBinding{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Delete(&role).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (r roleService) ListBindings(roleName string) ([]dto.RoleBinding, error) {
+	role, err := r.getRole(roleName)
+	if err != nil {
+		return nil, err
+	}
+	var bindings []model.RoleBinding
+	if err := db.DB.Where("role_id = ?", role.ID).Preload("User").Order("created_at desc").Find(&bindings).Error; err != nil {
+		return nil, err
+	}
+	var result []dto.RoleBinding
+	for _, b := range bindings {
+		b.Role = role
+		result = append(result, r.toRoleBindingDTO(b))
+	}
+	return result, nil
+}
+
+func (r roleService) CreateBinding(roleName string, req dto.RoleBindingCreate) (*dto.RoleBinding, error) {
+	role, err := r.getRole(roleName)
+	if err != nil {
+		return nil, err
+	}
+	user, err := r.userRepo.Get(req.Username)
+	if err != nil {
+		return nil, err
+	}
+	binding := model.RoleBinding{
+		RoleID: role.ID,
+		UserID: user.ID,
+		Scope:  req.Scope,
+	}
+	switch req.Scope {
+	case constant.RoleBindingScopeProject:
+		if req.ResourceName == "" {
+			return nil, errRoleBindingResource
+		}
+		project, err := r.projectRepo.Get(req.ResourceName)
+		if err != nil {
+			return nil, err
+		}
+		binding.ResourceID = project.ID
+	case constant.RoleBindingScopeCluster:
+		if req.ResourceName == "" {
+			return nil, errRoleBindingResource
+		}
+		cluster, err := r.clusterRepo.Get(req.ResourceName)
+		if err != nil {
+			return nil, err
+		}
+		binding.ResourceID = cluster.ID
+	}
+	var old model.RoleBinding
+	db.DB.Where("role_id = ? AND user_id = ? AND scope = ? AND resource_id = ?", role.ID, user.ID, binding.Scope, binding.ResourceID).First(&old)
+	if old.ID != "" {
+		return nil, errRoleBindingExists
+	}
+	if err := db.DB.Create(&binding).Error; err != nil {
+		return nil, err
+	}
+	binding.Role = role
+	binding.User = user
+	d := r.toRoleBindingDTO(binding)
+	return &d, nil
+}
+
+func (r roleService) DeleteBinding(roleName, id string) error {
+	role, err := r.getRole(roleName)
+	if err != nil {
+		return err
+	}
+	var binding model.RoleBinding
+	if err := db.DB.Where("id = ? AND role_id = ?", id, role.ID).First(&binding).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return errRoleBindingNotFound
+		}
+		return err
+	}
+	return db.DB.Delete(&binding).Error
+}
+
+// IsRequestGranted 每次请求都从数据库读取用户的角色绑定，修改角色后所有实例立即生效
+func (r roleService) IsRequestGranted(userId string, req *http.Request, scope RequestScope) (bool, error) {
+	var bindings []model.RoleBinding
+	if err := db.DB.Where("user_id = ?", userId).Preload("Role").Find(&bindings).Error; err != nil {
+		return false, err
+	}
+	if len(bindings) == 0 {
+		return false, nil
+	}
+	query := &meta.Query{Host: req.Host, Path: req.URL.Path, Method: req.Method}
+	for _, b := range bindings {
+		granted, err := matchRoleRules(b.Role.Rules, query)
+		if err != nil {
+			return false, err
+		}
+		if !granted {
+			continue
+		}
+		if b.Scope == constant.RoleBindingScopeSystem {
+			return true, nil
+		}
+		inScope, err := r.bindingInScope(b, scope)
+		if err != nil {
+			return false, err
+		}
+		if inScope {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// bindingInScope 集群级绑定要求请求指向该集群，项目级绑定覆盖项目本身及项目下的全部集群
+func (r roleService) bindingInScope(b model.RoleBinding, scope RequestScope) (bool, error) {
+	switch b.Scope {
+	case constant.RoleBindingScopeCluster:
+		if scope.ClusterName == "" {
+			return false, nil
+		}
+		var count int
+		err := db.DB.Model(&model.Cluster{}).Where("id = ? AND name = ?", b.ResourceID, scope.ClusterName).Count(&count).Error
+		return count > 0, err
+	case constant.RoleBindingScopeProject:
+		if scope.ProjectName == "" && scope.ClusterName == "" {
+			return false, nil
+		}
+		if scope.ProjectName != "" {
+			var count int
+			if err := db.DB.Model(&model.Project{}).Where("id = ? AND name = ?", b.ResourceID, scope.ProjectName).Count(&count).Error; err != nil {
+				return false, err
+			}
+			if count == 0 {
+				return false, nil
+			}
+		}
+		if scope.ClusterName == "" {
+			return true, nil
+		}
+		var cluster model.Cluster
+		if err := db.DB.Where("name = ?", scope.ClusterName).First(&cluster).Error; err != nil {
+			if gorm.IsRecordNotFoundError(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		var count int
+		err := db.DB.Model(&model.ProjectResource{}).
+			Where("project_id = ? AND resource_type = ? AND resource_id = ?", b.ResourceID, constant.ResourceCluster, cluster.ID).
+			Count(&count).Error
+		return count > 0, err
+	}
+	return false, nil
+}
+
+func (r roleService) getRole(name string) (model.Role, error) {
+	var role model.Role
+	if err := db.DB.Where("name = ?", name).First(&role).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return role, errRoleNotFound
+		}
+		return role, err
+	}
+	return role, nil
+}
+
+func (r roleService) toRoleBindingDTO(b model.RoleBinding) dto.RoleBinding {
+	d := dto.RoleBinding{
+		RoleBinding: b,
+		RoleName:    b.Role.Name,
+		Username:    b.User.Name,
+	}
+	switch b.Scope {
+	case constant.RoleBindingScopeProject:
+		var project model.Project
+		db.DB.Where("id = ?", b.ResourceID).First(&project)
+		d.ResourceName = project.Name
+	case constant.RoleBindingScopeCluster:
+		var cluster model.Cluster
+		db.DB.Where("id = ?", b.ResourceID).First(&cluster)
+		d.ResourceName = cluster.Name
+	}
+	return d
+}
+
+func toRoleDTO(role model.Role) dto.Role {
+	d := dto.Role{Role: role}
+	_ = json.Unmarshal([]byte(role.Rules), &d.Rules)
+	return d
+}
+
+// marshalRoleRules 校验并序列化规则，自定义角色不能授予角色管理本身的权限
+func marshalRoleRules(rules []dto.RoleRule) (string, error) {
+	if len(rules) == 0 {
+		return "", errRoleRuleInvalid
+	}
+	for i := range rules {
+		if len(rules[i].Path) == 0 || len(rules[i].Method) == 0 {
+			return "", errRoleRuleInvalid
+		}
+		for _, p := range rules[i].Path {
+			if !strings.HasPrefix(p, "/api/v1/") || strings.HasPrefix(p, "/api/v1/roles") {
+				return "", errRoleRuleInvalid
+			}
+		}
+		for j, m := range rules[i].Method {
+			rules[i].Method[j] = strings.ToUpper(m)
+			if !roleRuleMethods[rules[i].Method[j]] {
+				return "", errRoleRuleInvalid
+			}
+		}
+	}
+	bs, err := json.Marshal(rules)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+func matchRoleRules(rules string, query *meta.Query) (bool, error) {
+	var roleRules []dto.RoleRule
+	if err := json.Unmarshal([]byte(rules), &roleRules); err != nil {
+		return false, err
+	}
+	for _, rule := range roleRules {
+		for _, p := range rule.Path {
+			for _, m := range rule.Method {
+				resource := meta.Resource{Host: "*", Path: p, Method: m}
+				matched, err := resource.Match(query)
+				if err != nil {
+					return false, err
+				}
+				if matched {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+// RouteRequestScope 从匹配的路由取资源参数：嵌套路由的 {project}/{cluster}，
+// 以及 /clusters、/projects 下控制器 By 方法的第一个参数
+func RouteRequestScope(routeTmpl string, param func(key string) string) RequestScope {
+	scope := RequestScope{
+		ProjectName: firstNonEmpty(param("project"), param("project_name")),
+		ClusterName: firstNonEmpty(param("cluster"), param("cluster_name")),
+	}
+	if scope.ProjectName != "" || scope.ClusterName != "" {
+		return scope
+	}
+	name := firstNonEmpty(param("param1"), param("name"))
+	switch {
+	case strings.HasPrefix(routeTmpl, "/api/v1/clusters/backup/files/"):
+		// 备份文件的参数是文件名
+	case strings.HasPrefix(routeTmpl, "/api/v1/clusters/"):
+		scope.ClusterName = name
+	case strings.HasPrefix(routeTmpl, "/api/v1/projects/"):
+		scope.ProjectName = name
+	}
+	return scope
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
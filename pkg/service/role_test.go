@@ -0,0 +1,94 @@
+package service
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kmpp/pkg/dto"
+	"github.com/kataras/iris/v12"
+	"github.com/kataras/iris/v12/context"
+	"github.com/kataras/iris/v12/mvc"
+	"github.com/storyicon/grbac/pkg/meta"
+)
+
+func TestRoleRules(t *testing.T) {
+	rules, err := marshalRoleRules([]dto.RoleRule{{
+		Path:   []string{"/api/v1/clusters/backup/files/{backup,restore}"},
+		Method: []string{"post"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		path, method string
+		want         bool
+	}{
+		{"/api/v1/clusters/backup/files/backup", "POST", true},
+		{"/api/v1/clusters/backup/files/restore", "POST", true},
+		{"/api/v1/clusters/c1", "DELETE", false},
+		{"/api/v1/clusters/backup/files/backup", "DELETE", false},
+	}
+	for _, c := range cases {
+		got, err := matchRoleRules(rules, &meta.Query{Host: "kmpp", Path: c.path, Method: c.method})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Errorf("%s %s: got %v, want %v", c.method, c.path, got, c.want)
+		}
+	}
+	for _, invalid := range [][]dto.RoleRule{
+		nil,
+		{{Path: []string{"/proxy/c1"}, Method: []string{"GET"}}},
+		{{Path: []string{"/api/v1/roles/{**}"}, Method: []string{"POST"}}},
+		{{Path: []string{"/api/v1/clusters"}, Method: []string{"TRACE"}}},
+	} {
+		if _, err := marshalRoleRules(invalid); err != errRoleRuleInvalid {
+			t.Errorf("%v: expected invalid rule, got %v", invalid, err)
+		}
+	}
+}
+
+type scopeController struct{}
+
+func (scopeController) DeleteBy(name string) string                     { return name }
+func (scopeController) GetNodeDetailBy(clusterName, node string) string { return node }
+
+func TestRouteRequestScope(t *testing.T) {
+	app := iris.New()
+	var scope RequestScope
+	v1 := app.Party("/api/v1")
+	v1.Use(func(ctx context.Context) {
+		scope = RouteRequestScope(ctx.GetCurrentRoute().Tmpl().Src, ctx.Params().Get)
+		ctx.Next()
+	})
+	mvc.New(v1.Party("/clusters")).Handle(new(scopeController))
+	mvc.New(v1.Party("/clusters/backup/files")).Handle(new(scopeController))
+	mvc.New(v1.Party("/projects")).Handle(new(scopeController))
+	mvc.New(v1.Party("/projects/{project}/clusters/{cluster}/members")).Handle(new(scopeController))
+	if err := app.Build(); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		method, url, body string
+		want              RequestScope
+	}{
+		// 查询参数和请求体中的 clusterName 不能改变作用范围
+		{"DELETE", "/api/v1/clusters/bar?clusterName=foo&projectName=p1", `{"clusterName":"foo","projectName":"p1"}`, RequestScope{ClusterName: "bar"}},
+		{"GET", "/api/v1/clusters/node/detail/c1/n1", "", RequestScope{ClusterName: "c1"}},
+		{"DELETE", "/api/v1/clusters/backup/files/foo", "", RequestScope{}},
+		{"DELETE", "/api/v1/projects/p1", "", RequestScope{ProjectName: "p1"}},
+		{"DELETE", "/api/v1/projects/p1/clusters/c1/members/u1", "", RequestScope{ProjectName: "p1", ClusterName: "c1"}},
+	}
+	for _, c := range cases {
+		scope = RequestScope{}
+		req := httptest.NewRequest(c.method, c.url, strings.NewReader(c.body))
+		req.Header.Set("Content-Type", "application/json")
+		app.ServeHTTP(httptest.NewRecorder(), req)
+		if scope != c.want {
+			t.Errorf("%s %s: got %+v, want %+v", c.method, c.url, scope, c.want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/repository"
+)
+
+var (
+	errServiceAccountNotFound = errors.New("SERVICE_ACCOUNT_NOT_FOUND")
+)
+
+// ServiceAccountService 管理供 CI 等自动化场景使用的非人类用户
+type ServiceAccountService interface {
+	List() ([]dto.User, error)
+	Get(name string) (*dto.User, error)
+	Create(req dto.ServiceAccountCreate) (*dto.User, error)
+	Delete(name string) error
+}
+
+func NewServiceAccountService() ServiceAccountService {
+	return &serviceAccountService{
+		userRepo: repository.NewUserRepository(),
+	}
+}
+
+type serviceAccountService struct {
+	userRepo repository.UserRepository
+}
+
+func (s serviceAccountService) List() ([]dto.User, error) {
+	var users []model.User
+	if err := db.DB.Where("type = ?", constant.ServiceAccount).Order("created_at desc").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	var result []dto.User
+	for _, u := range users {
+		result = append(result, toUserDTO(u))
+	}
+	return result, nil
+}
+
+func (s serviceAccountService) Get(name string) (*dto.User, error) {
+	user, err := s.userRepo.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if user.Type != constant.ServiceAccount {
+		return nil, errServiceAccountNotFound
+	}
+	d := toUserDTO(user)
+	return &d, nil
+}
+
+func (s serviceAccountService) Create(req dto.ServiceAccountCreate) (*dto.User, error) {
+	var old model.User
+	db.DB.Where("name = ?", req.Name).First(&old)
+	if old.ID != "" {
+		return nil, errUserNameExist
+	}
+	user := model.User{
+		Name:     req.Name,
+		Email:    fmt.Sprintf("%s@serviceaccount.local", req.Name),
+		IsActive: true,
+		Language: model.ZH,
+		IsAdmin:  strings.ToLower(req.Role) == constant.SystemRoleAdmin,
+		Type:     constant.ServiceAccount,
+	}
+	if err := s.userRepo.Save(&user); err != nil {
+		return nil, err
+	}
+	d := toUserDTO(user)
+	return &d, nil
+}
+
+func (s serviceAccountService) Delete(name string) error {
+	if _, err := s.Get(name); err != nil {
+		return err
+	}
+	return s.userRepo.Delete(name)
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/db"
+	"github.com/kmpp/pkg/dto"
+	"github.com/kmpp/pkg/model"
+	"github.com/kmpp/pkg/util/sso"
+	"github.com/jinzhu/gorm"
+)
+
+var (
+	errSsoDisable      = errors.New("SSO_DISABLE")
+	errSsoUserConflict = errors.New("SSO_USER_CONFLICT")
+)
+
+// SsoService 通过 OIDC 授权码(PKCE)流程登录，并按 ID Token 中的用户组即时创建/同步用户
+type SsoService interface {
+	Status() (*dto.SsoStatus, error)
+	Login() (*sso.AuthRequest, error)
+	Callback(code, verifier, nonce string) (*model.User, error)
+}
+
+func NewSsoService() SsoService {
+	return &ssoService{
+		systemSettingService: NewSystemSettingService(),
+	}
+}
+
+type ssoService struct {
+	systemSettingService SystemSettingService
+}
+
+func (s ssoService) Status() (*dto.SsoStatus, error) {
+	vars, err := s.settings()
+	if err != nil {
+		return nil, err
+	}
+	return &dto.SsoStatus{Enable: vars["oidc_status"] == constant.Enable}, nil
+}
+
+func (s ssoService) Login() (*sso.AuthRequest, error) {
+	client, _, err := s.client()
+	if err != nil {
+		return nil, err
+	}
+	return client.AuthCodeURL()
+}
+
+func (s ssoService) Callback(code, verifier, nonce string) (*model.User, error) {
+	client, vars, err := s.client()
+	if err != nil {
+		return nil, err
+	}
+	identity, err := client.Exchange(context.Background(), code, verifier, nonce)
+	if err != nil {
+		return nil, err
+	}
+	bindings := parseGroupBindings(identity.Groups, vars["oidc_project_mapping"])
+
+	user, err := s.findUser(identity, vars["oidc_link_ldap_users"] == constant.Enable)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID != "" && !user.IsActive {
+		return nil, errUserIsNotActive
+	}
+	if user.ID == "" {
+		user.Name = identity.Username
+	}
+	user.Subject = identity.Subject
+	user.Type = constant.Oidc
+	user.IsAdmin = groupAdmin(user.IsAdmin, identity.Groups, splitSetting(vars["oidc_admin_groups"]))
+	if identity.Email != "" {
+		user.Email = identity.Email
+	} else if user.Email == "" {
+		user.Email = fmt.Sprintf("%s@sso.local", identity.Username)
+	}
+	if user.ID == "" {
+		user.IsActive = true
+		user.Language = model.ZH
+		if err := db.DB.Create(user).Error; err != nil {
+			return nil, err
+		}
+	} else if err := db.DB.Save(user).Error; err != nil {
+		return nil, err
+	}
+	if _, _, err := syncGroupMembers(*user, constant.Oidc, bindings); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// findUser 按 ID Token 的 subject 查找已登录过的用户，找不到时按用户名关联尚未记录 subject 的 SSO 用户；
+// 同名的 LDAP 用户只有开启 oidc_link_ldap_users 时才会被关联，避免 IdP 中的同名账号接管 LDAP 用户
+func (s ssoService) findUser(identity *sso.Identity, linkLdap bool) (*model.User, error) {
+	var user model.User
+	if identity.Subject != "" {
+		err := db.DB.Where("type = ? AND subject = ?", constant.Oidc, identity.Subject).Preload("CurrentProject").First(&user).Error
+		if err == nil {
+			return &user, nil
+		}
+		if !gorm.IsRecordNotFoundError(err) {
+			return nil, err
+		}
+	}
+	err := db.DB.Where("name = ?", identity.Username).Preload("CurrentProject").First(&user).Error
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return nil, err
+	}
+	if user.ID != "" && !ssoLinkable(user, identity.Subject, linkLdap) {
+		return nil, errSsoUserConflict
+	}
+	return &user, nil
+}
+
+// ssoLinkable 同名的已有用户能否关联到当前 SSO 身份
+func ssoLinkable(user model.User, subject string, linkLdap bool) bool {
+	switch user.Type {
+	case constant.Oidc:
+		return user.Subject == "" || user.Subject == subject
+	case constant.Ldap:
+		return linkLdap
+	default:
+		return false
+	}
+}
+
+func (s ssoService) settings() (map[string]string, error) {
+	result, err := s.systemSettingService.ListByTab(constant.OidcSettingTab)
+	if err != nil {
+		return nil, err
+	}
+	return result.Vars, nil
+}
+
+func (s ssoService) client() (*sso.Client, map[string]string, error) {
+	vars, err := s.settings()
+	if err != nil {
+		return nil, nil, err
+	}
+	if vars["oidc_status"] != constant.Enable {
+		return nil, nil, errSsoDisable
+	}
+	client, err := sso.NewClient(context.Background(), sso.Config{
+		Issuer:        vars["oidc_issuer"],
+		ClientID:      vars["oidc_client_id"],
+		ClientSecret:  vars["oidc_client_secret"],
+		RedirectURL:   vars["oidc_redirect_url"],
+		Scopes:        strings.Fields(vars["oidc_scopes"]),
+		UsernameClaim: vars["oidc_username_claim"],
+		EmailClaim:    vars["oidc_email_claim"],
+		GroupsClaim:   vars["oidc_groups_claim"],
+	})
+	return client, vars, err
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/kmpp/pkg/constant"
+	"github.com/kmpp/pkg/model"
+)
+
+func TestSsoLinkable(t *testing.T) {
+	cases := []struct {
+		user     model.User
+		linkLdap bool
+		want     bool
+	}{
+		{model.User{Type: constant.Oidc}, false, true},
+		{model.User{Type: constant.Oidc, Subject: "sub-1"}, false, true},
+		{model.User{Type: constant.Oidc, Subject: "sub-2"}, true, false},
+		{model.User{Type: constant.Ldap}, false, false},
+		{model.User{Type: constant.Ldap}, true, true},
+		{model.User{Type: constant.Local}, true, false},
+	}
+	for i, c := range cases {
+		if got := ssoLinkable(c.user, "sub-1", c.linkLdap); got != c.want {
+			t.Errorf("case %d: expected %v, got %v", i, c.want, got)
+		}
+	}
+}
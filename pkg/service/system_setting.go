@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kmpp/pkg/controller/condition"
 	dbUtil "github.com/kmpp/pkg/util/db"
@@ -16,6 +17,7 @@ import (
 	"github.com/kmpp/pkg/repository"
 	"github.com/kmpp/pkg/util/message"
 	"github.com/kmpp/pkg/util/message/client"
+	"github.com/kmpp/pkg/util/registry"
 	"github.com/jinzhu/gorm"
 )
 
@@ -34,6 +36,7 @@ type SystemSettingService interface {
 	UpdateRegistry(arch string, creation dto.SystemRegistryUpdate) (*dto.SystemRegistry, error)
 	BatchRegistry(op dto.SystemRegistryBatchOp) error
 	DeleteRegistry(id string) error
+	CheckRegistries() ([]dto.SystemRegistry, error)
 }
 
 type systemSettingService struct {
@@ -121,6 +124,10 @@ func (s systemSettingService) Create(creation dto.SystemSettingCreate) ([]dto.Sy
 			result = append(result, dto.SystemSetting{SystemSetting: systemSetting})
 		}
 	}
+	switch creation.Tab {
+	case constant.Email, constant.WorkWeiXin, constant.Webhook:
+		go refreshAlertmanagerConfigs()
+	}
 	return result, nil
 }
 
@@ -152,6 +159,10 @@ func (s systemSettingService) CheckSettingByType(tabName string, creation dto.Sy
 		vars["type"] = constant.WorkWeiXin
 		vars["CONTENT"] = "此邮件由 KubeOperator 发送，用于测试消息发送"
 		vars["RECEIVERS"] = vars["WORK_WEIXIN_TEST_USER"]
+	} else if tabName == constant.Webhook {
+		vars["type"] = constant.Webhook
+		vars["TITLE"] = "KubeOperator测试消息"
+		vars["CONTENT"] = "此消息由 KubeOperator 发送，用于测试 Webhook"
 	}
 	c, err := message.NewMessageClient(vars)
 	if err != nil {
@@ -204,6 +215,10 @@ func (s systemSettingService) GetRegistryByID(id string) (dto.SystemRegistry, er
 			RepoPort:           r.RepoPort,
 			RegistryPort:       r.RegistryPort,
 			RegistryHostedPort: r.RegistryHostedPort,
+			Priority:           r.Priority,
+			Status:             r.Status,
+			Message:            r.Message,
+			LastCheckTime:      r.LastCheckTime,
 		},
 	}
 	return systemRegistryDto, nil
@@ -260,6 +275,7 @@ func (s systemSettingService) CreateRegistry(creation dto.SystemRegistryCreate)
 		RepoPort:           creation.RepoPort,
 		RegistryPort:       creation.RegistryPort,
 		RegistryHostedPort: creation.RegistryHostedPort,
+		Priority:           creation.Priority,
 	}
 	err := s.systemRegistryRepo.Save(&systemRegistry)
 	if err != nil {
@@ -269,16 +285,18 @@ func (s systemSettingService) CreateRegistry(creation dto.SystemRegistryCreate)
 }
 
 func (s systemSettingService) UpdateRegistry(arch string, creation dto.SystemRegistryUpdate) (*dto.SystemRegistry, error) {
-	systemRegistry := model.SystemRegistry{
-		ID:                 creation.ID,
-		Architecture:       arch,
-		Protocol:           creation.Protocol,
-		Hostname:           creation.Hostname,
-		RepoPort:           creation.RepoPort,
-		RegistryPort:       creation.RegistryPort,
-		RegistryHostedPort: creation.RegistryHostedPort,
+	systemRegistry, err := s.systemRegistryRepo.Get(creation.ID)
+	if err != nil {
+		return nil, err
 	}
-	err := s.systemRegistryRepo.Save(&systemRegistry)
+	systemRegistry.Architecture = arch
+	systemRegistry.Protocol = creation.Protocol
+	systemRegistry.Hostname = creation.Hostname
+	systemRegistry.RepoPort = creation.RepoPort
+	systemRegistry.RegistryPort = creation.RegistryPort
+	systemRegistry.RegistryHostedPort = creation.RegistryHostedPort
+	systemRegistry.Priority = creation.Priority
+	err = s.systemRegistryRepo.Save(&systemRegistry)
 	if err != nil {
 		return nil, err
 	}
@@ -308,3 +326,36 @@ func (s systemSettingService) DeleteRegistry(id string) error {
 	}
 	return nil
 }
+
+// CheckRegistries 探测所有仓库的可用性并记录结果，供仓库故障切换使用
+func (s systemSettingService) CheckRegistries() ([]dto.SystemRegistry, error) {
+	var result []dto.SystemRegistry
+	registries, err := s.systemRegistryRepo.List()
+	if err != nil {
+		return result, err
+	}
+	for i := range registries {
+		r := registries[i]
+		r.Status = constant.RegistryStatusHealthy
+		r.Message = ""
+		if err := registry.Probe(&registry.Config{
+			Protocol:     r.Protocol,
+			Hostname:     r.Hostname,
+			RepoPort:     r.RepoPort,
+			RegistryPort: r.RegistryPort,
+		}); err != nil {
+			r.Status = constant.RegistryStatusUnhealthy
+			r.Message = err.Error()
+		}
+		r.LastCheckTime = time.Now()
+		if err := db.DB.Model(&model.SystemRegistry{}).Where("id = ?", r.ID).Updates(map[string]interface{}{
+			"status":          r.Status,
+			"message":         r.Message,
+			"last_check_time": r.LastCheckTime,
+		}).Error; err != nil {
+			return result, err
+		}
+		result = append(result, dto.SystemRegistry{SystemRegistry: r})
+	}
+	return result, nil
+}
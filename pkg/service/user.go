@@ -30,6 +30,8 @@ var (
 	errEmailDisable      = errors.New("EMAIL_DISABLE")
 	errEmailNotMatch     = errors.New("EMAIL_NOT_MATCH")
 	errNameOrPasswordErr = errors.New("NAME_PASSWORD_ERROR")
+	errServiceAccount    = errors.New("SERVICE_ACCOUNT_CAN_NOT_LOGIN")
+	errSsoUserLogin      = errors.New("SSO_USER_USE_SSO_LOGIN")
 )
 
 type UserService interface {
@@ -43,6 +45,8 @@ type UserService interface {
 	ChangePassword(ch dto.UserChangePassword) error
 	UserAuth(name string, password string) (user *model.User, err error)
 	ResetPassword(fp dto.UserForgotPassword) error
+	GetSessionUser(user *model.User) (*dto.SessionUser, error)
+	CheckActive(name string) error
 }
 
 type userService struct {
@@ -57,6 +61,21 @@ func NewUserService() UserService {
 	}
 }
 
+// CheckActive 用户被停用或删除后返回错误，使其已签发的会话和 JWT 随即失效
+func (u *userService) CheckActive(name string) error {
+	var mo model.User
+	if err := db.DB.Select("is_active").Where("name = ?", name).First(&mo).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return errUserIsNotActive
+		}
+		return err
+	}
+	if !mo.IsActive {
+		return errUserIsNotActive
+	}
+	return nil
+}
+
 func (u *userService) Get(name string) (*dto.User, error) {
 	var mo model.User
 	if err := db.DB.Where(model.User{Name: name}).
@@ -171,6 +190,8 @@ func (u *userService) Update(name string, update dto.UserUpdate) (*dto.User, err
 
 	if update.Status != "" {
 		mo.IsActive = strings.ToLower(update.Status) == constant.UserStatusActive
+		// 管理员手动设置的状态不再被 LDAP 同步覆盖
+		mo.DeactivatedBySync = false
 	}
 
 	if update.CurrentProject != "" {
@@ -240,6 +261,12 @@ func (u *userService) UserAuth(name string, password string) (user *model.User,
 	if !dbUser.IsActive {
 		return nil, errUserIsNotActive
 	}
+	if dbUser.Type == constant.ServiceAccount {
+		return nil, errServiceAccount
+	}
+	if dbUser.Type == constant.Oidc {
+		return nil, errSsoUserLogin
+	}
 
 	if dbUser.Type == constant.Ldap {
 		enable, err := NewSystemSettingService().Get("ldap_status")
@@ -258,6 +285,7 @@ func (u *userService) UserAuth(name string, password string) (user *model.User,
 		if err != nil {
 			return nil, err
 		}
+		defer ldapClient.Close()
 		err = ldapClient.Login(name, password)
 		if err != nil {
 			return nil, err
@@ -325,6 +353,117 @@ func (u *userService) ResetPassword(fp dto.UserForgotPassword) error {
 	return nil
 }
 
+func (u *userService) GetSessionUser(user *model.User) (*dto.SessionUser, error) {
+	roles, err := getUserRole(user)
+	if err != nil {
+		return nil, err
+	}
+	sessionUser := toSessionUser(*user)
+	sessionUser.Roles = roles
+	return &sessionUser, nil
+}
+
+func getUserRole(user *model.User) ([]string, error) {
+
+	if user.IsAdmin {
+		return []string{constant.RoleAdmin}, nil
+	}
+	if user.CurrentProject.Name == "" {
+		var projectMember model.ProjectMember
+		err := db.DB.Model(&model.ProjectMember{}).Where("user_id = ?", user.ID).Preload("Project").First(&projectMember).Error
+		if err != nil && !gorm.IsRecordNotFoundError(err) {
+			return nil, err
+		}
+		if gorm.IsRecordNotFoundError(err) {
+			var clusterMember model.ClusterMember
+			err := db.DB.Model(&model.ClusterMember{}).Where("user_id = ?", user.ID).First(&clusterMember).Error
+			if err != nil && !gorm.IsRecordNotFoundError(err) {
+				return nil, err
+			}
+			if gorm.IsRecordNotFoundError(err) {
+				return rolesOfUserWithoutMember(user)
+			}
+			var projectResource model.ProjectResource
+			err = db.DB.Model(&model.ProjectResource{}).Where("resource_id = ?", clusterMember.ClusterID).Preload("Project").First(&projectResource).Error
+			if err != nil {
+				return nil, err
+			}
+			if projectResource.Project.Name != "" {
+				user.CurrentProject = projectResource.Project
+				user.CurrentProjectID = projectResource.Project.ID
+				db.DB.Save(user)
+			}
+			return []string{constant.RoleClusterManager}, nil
+		}
+		if projectMember.Project.Name != "" {
+			user.CurrentProject = projectMember.Project
+			user.CurrentProjectID = projectMember.Project.ID
+			db.DB.Save(user)
+		}
+		return projectMemberRoles(projectMember), nil
+	} else {
+		var project model.Project
+		err := db.DB.Model(model.Project{}).Where("name = ?", user.CurrentProject.Name).Find(&project).Error
+		if err != nil && !gorm.IsRecordNotFoundError(err) {
+			return nil, err
+		}
+		if gorm.IsRecordNotFoundError(err) {
+			user.CurrentProjectID = ""
+			db.DB.Save(user)
+			return nil, errors.New("project is not found")
+		}
+		var projectMember model.ProjectMember
+		err = db.DB.Model(&model.ProjectMember{}).Where("user_id = ? AND project_id = ?", user.ID, project.ID).First(&projectMember).Error
+		if err != nil && !gorm.IsRecordNotFoundError(err) {
+			return nil, err
+		}
+		if gorm.IsRecordNotFoundError(err) {
+			var clusterMember model.ClusterMember
+			err := db.DB.Model(&model.ClusterMember{}).Where("user_id = ?", user.ID).First(&clusterMember).Error
+			if err != nil && !gorm.IsRecordNotFoundError(err) {
+				return nil, err
+			}
+			if gorm.IsRecordNotFoundError(err) {
+				return rolesOfUserWithoutMember(user)
+			}
+			return []string{constant.RoleClusterManager}, nil
+		}
+		return projectMemberRoles(projectMember), nil
+	}
+}
+
+// projectMemberRoles 项目只读成员使用只读的系统角色，其余项目成员为项目管理员
+func projectMemberRoles(member model.ProjectMember) []string {
+	if member.Role == constant.ProjectRoleProjectViewer {
+		return []string{constant.RoleProjectViewer}
+	}
+	return []string{constant.RoleProjectManager}
+}
+
+// rolesOfUserWithoutMember 不属于任何项目和集群的用户只有绑定了自定义角色才允许登录
+func rolesOfUserWithoutMember(user *model.User) ([]string, error) {
+	var count int
+	if err := db.DB.Model(&model.RoleBinding{}).Where("user_id = ?", user.ID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return nil, errors.New("USER_HAS_NO_RESOURCE")
+	}
+	return []string{}, nil
+}
+
+func toSessionUser(u model.User) dto.SessionUser {
+	return dto.SessionUser{
+		UserId:         u.ID,
+		Name:           u.Name,
+		Email:          u.Email,
+		Language:       u.Language,
+		IsActive:       u.IsActive,
+		IsAdmin:        u.IsAdmin,
+		CurrentProject: u.CurrentProject.Name,
+	}
+}
+
 func toUserDTO(user model.User) dto.User {
 	u := dto.User{User: user}
 	u.Role = func() string {
@@ -167,11 +167,10 @@ func (z zoneService) Delete(name string) error {
 func (z zoneService) Create(creation dto.ZoneCreate) (*dto.Zone, error) {
 	var (
 		credential dto.Credential
-		repo       model.SystemRegistry
 		region     dto.Region
 		old        model.Zone
 	)
-	if err := db.DB.Where("architecture = ?", constant.ArchitectureOfAMD64).First(&repo).Error; err != nil {
+	if _, err := model.GetActiveRegistry(constant.ArchitectureOfAMD64); err != nil {
 		return nil, errors.New("IP_NOT_EXISTS")
 	}
 
@@ -379,8 +378,8 @@ func (z zoneService) uploadImage(creation dto.ZoneCreate) error {
 	if err != nil {
 		return err
 	}
-	var repo model.SystemRegistry
-	if err := db.DB.Where("architecture = ?", constant.ArchitectureOfAMD64).First(&repo).Error; err != nil {
+	repo, err := model.GetActiveRegistry(constant.ArchitectureOfAMD64)
+	if err != nil {
 		return fmt.Errorf("can't find local ip from system setting, err %s", err.Error())
 	}
 	ip := repo.Hostname
@@ -9,6 +9,11 @@ import (
 var store = base64Captcha.DefaultMemStore
 var verifyCodeFailed = errors.New("VERIFY_CODE_FAILED")
 
+// SetStore 替换默认的进程内存储，多副本部署时使用共享存储
+func SetStore(s base64Captcha.Store) {
+	store = s
+}
+
 func VerifyCode(codeId string, code string) error {
 	if code == "" {
 		return verifyCodeFailed
@@ -0,0 +1,69 @@
+package encrypt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testKeyStore struct {
+	active int
+	keys   map[int][]byte
+}
+
+func (s *testKeyStore) Active() (DataKey, error) {
+	return s.Get(s.active)
+}
+
+func (s *testKeyStore) Get(version int) (DataKey, error) {
+	key, ok := s.keys[version]
+	if !ok {
+		return DataKey{}, errors.New("data key not found")
+	}
+	return DataKey{Version: version, Key: key}, nil
+}
+
+func TestEnvelopeRotate(t *testing.T) {
+	key1, wrapped, err := GenerateDataKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	unwrapped, err := UnwrapDataKey(wrapped)
+	if err != nil || string(unwrapped) != string(key1) {
+		t.Fatalf("unwrap data key failed: %v", err)
+	}
+	key2, _, _ := GenerateDataKey()
+	store := &testKeyStore{active: 1, keys: map[int][]byte{1: key1}}
+	SetKeyStore(store)
+	defer SetKeyStore(nil)
+
+	sealed, err := Seal("kubernetes-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sealed, "enc:v1:") || SealedVersion(sealed) != 1 {
+		t.Fatalf("unexpected sealed text %s", sealed)
+	}
+	store.keys[2] = key2
+	store.active = 2
+	resealed, _ := Seal("kubernetes-token")
+	if SealedVersion(resealed) != 2 {
+		t.Errorf("expect version 2, got %d", SealedVersion(resealed))
+	}
+	for _, text := range []string{sealed, resealed} {
+		plain, err := Open(text)
+		if err != nil || plain != "kubernetes-token" {
+			t.Errorf("open %s failed: %s %v", text, plain, err)
+		}
+	}
+	if plain, err := Open("legacy plaintext"); err != nil || plain != "legacy plaintext" {
+		t.Errorf("plaintext should be returned as is")
+	}
+	if s, _ := Seal(""); s != "" {
+		t.Errorf("empty string should not be sealed")
+	}
+	tampered := sealed[:len(sealed)-4] + "AAA="
+	if _, err := Open(tampered); err == nil {
+		t.Error("tampered text should not be opened")
+	}
+}
@@ -0,0 +1,111 @@
+package etcd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kmpp/pkg/util/ssh"
+)
+
+// etcdctl 和证书由 kobe 安装在 master 节点上
+const (
+	etcdctlBin  = "/usr/local/bin/etcdctl"
+	certDir     = "/etc/kubernetes/pki/etcd"
+	peerPort    = 2380
+	localClient = "https://127.0.0.1:2379"
+)
+
+// Member etcd 集群成员，ID 为 etcdctl 输出的十进制成员 ID
+type Member struct {
+	ID         uint64   `json:"ID"`
+	Name       string   `json:"name"`
+	PeerURLs   []string `json:"peerURLs"`
+	ClientURLs []string `json:"clientURLs"`
+}
+
+type memberListResult struct {
+	Members []Member `json:"members"`
+}
+
+// PeerURL 返回节点作为 etcd 成员时的 peer 地址
+func PeerURL(ip string) string {
+	return fmt.Sprintf("https://%s:%d", ip, peerPort)
+}
+
+func etcdctl(args ...string) string {
+	return strings.Join(append([]string{
+		"sudo", "ETCDCTL_API=3", etcdctlBin,
+		"--endpoints=" + localClient,
+		"--cacert=" + certDir + "/ca.crt",
+		"--cert=" + certDir + "/server.crt",
+		"--key=" + certDir + "/server.key",
+	}, args...), " ")
+}
+
+// ListMembers 在 client 所连接的 master 上列出 etcd 成员
+func ListMembers(client ssh.Interface) ([]Member, error) {
+	out, err := client.CombinedOutput(etcdctl("member", "list", "-w", "json"))
+	if err != nil {
+		return nil, fmt.Errorf("list etcd members failed: %s %s", err.Error(), string(out))
+	}
+	return parseMemberList(out)
+}
+
+// AddMember 将节点加入 etcd 集群，同名且地址相同的成员已存在时不做处理
+func AddMember(client ssh.Interface, name, ip string) error {
+	members, err := ListMembers(client)
+	if err != nil {
+		return err
+	}
+	peerURL := PeerURL(ip)
+	for _, m := range members {
+		for _, u := range m.PeerURLs {
+			if u == peerURL {
+				if m.Name == name || m.Name == "" {
+					return nil
+				}
+				return fmt.Errorf("etcd peer %s is used by member %s", peerURL, m.Name)
+			}
+		}
+		if m.Name == name {
+			return fmt.Errorf("etcd member %s already exists with peer %s", name, strings.Join(m.PeerURLs, ","))
+		}
+	}
+	if out, err := client.CombinedOutput(etcdctl("member", "add", name, "--peer-urls="+peerURL)); err != nil {
+		return fmt.Errorf("add etcd member %s failed: %s %s", name, err.Error(), string(out))
+	}
+	return nil
+}
+
+// RemoveMember 按名称或 peer 地址从 etcd 集群中移除成员，成员不存在时不做处理
+func RemoveMember(client ssh.Interface, name, ip string) error {
+	members, err := ListMembers(client)
+	if err != nil {
+		return err
+	}
+	peerURL := PeerURL(ip)
+	for _, m := range members {
+		match := m.Name == name
+		for _, u := range m.PeerURLs {
+			if u == peerURL {
+				match = true
+			}
+		}
+		if !match {
+			continue
+		}
+		if out, err := client.CombinedOutput(etcdctl("member", "remove", fmt.Sprintf("%x", m.ID))); err != nil {
+			return fmt.Errorf("remove etcd member %s failed: %s %s", m.Name, err.Error(), string(out))
+		}
+	}
+	return nil
+}
+
+func parseMemberList(out []byte) ([]Member, error) {
+	var r memberListResult
+	if err := json.Unmarshal(out, &r); err != nil {
+		return nil, fmt.Errorf("parse etcdctl output failed: %s", err.Error())
+	}
+	return r.Members, nil
+}
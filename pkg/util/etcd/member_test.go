@@ -0,0 +1,97 @@
+package etcd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kmpp/pkg/util/ssh"
+)
+
+const memberListOutput = `{"header":{"cluster_id":14841639068965178418,"member_id":10276657743932975437,"raft_term":2},"members":[{"ID":10276657743932975437,"name":"test-master-1","peerURLs":["https://10.0.0.1:2380"],"clientURLs":["https://10.0.0.1:2379"]},{"ID":2886367545391271487,"name":"test-master-2","peerURLs":["https://10.0.0.2:2380"],"clientURLs":["https://10.0.0.2:2379"]},{"ID":12345,"peerURLs":["https://10.0.0.3:2380"]}]}`
+
+// fakeClient 记录执行的命令，member list 返回固定的成员列表
+type fakeClient struct {
+	ssh.Interface
+	cmds []string
+}
+
+func (f *fakeClient) CombinedOutput(cmd ...string) ([]byte, error) {
+	c := strings.Join(cmd, " ")
+	f.cmds = append(f.cmds, c)
+	if strings.Contains(c, "member list") {
+		return []byte(memberListOutput), nil
+	}
+	return nil, nil
+}
+
+func (f *fakeClient) changes() []string {
+	var cmds []string
+	for _, c := range f.cmds {
+		if !strings.Contains(c, "member list") {
+			cmds = append(cmds, c[strings.Index(c, "member "):])
+		}
+	}
+	return cmds
+}
+
+func TestParseMemberList(t *testing.T) {
+	members, err := parseMemberList([]byte(memberListOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 3 || members[0].ID != 10276657743932975437 || members[1].Name != "test-master-2" {
+		t.Errorf("unexpected members %+v", members)
+	}
+	if _, err := parseMemberList([]byte("Error: context deadline exceeded")); err == nil {
+		t.Error("expected parse error")
+	}
+}
+
+func TestAddMember(t *testing.T) {
+	cases := []struct {
+		name    string
+		ip      string
+		changes []string
+		err     bool
+	}{
+		{name: "test-master-4", ip: "10.0.0.4", changes: []string{"member add test-master-4 --peer-urls=https://10.0.0.4:2380"}},
+		{name: "test-master-2", ip: "10.0.0.2"},
+		// 已添加但尚未启动的成员没有名称
+		{name: "test-master-3", ip: "10.0.0.3"},
+		{name: "test-master-2", ip: "10.0.0.5", err: true},
+		{name: "test-master-5", ip: "10.0.0.1", err: true},
+	}
+	for _, c := range cases {
+		client := &fakeClient{}
+		err := AddMember(client, c.name, c.ip)
+		if (err != nil) != c.err {
+			t.Errorf("add %s(%s): unexpected error %v", c.name, c.ip, err)
+		}
+		if strings.Join(client.changes(), ";") != strings.Join(c.changes, ";") {
+			t.Errorf("add %s(%s): expected %v, got %v", c.name, c.ip, c.changes, client.changes())
+		}
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	cases := []struct {
+		name    string
+		ip      string
+		changes []string
+	}{
+		{name: "test-master-2", ip: "10.0.0.2", changes: []string{"member remove 280e6ffd6a434e3f"}},
+		// 替换故障 master 时节点名称不变，按名称移除旧地址的成员
+		{name: "test-master-1", ip: "10.0.0.9", changes: []string{"member remove 8e9e05c52164694d"}},
+		{name: "test-master-3", ip: "10.0.0.3", changes: []string{"member remove 3039"}},
+		{name: "test-master-4", ip: "10.0.0.4"},
+	}
+	for _, c := range cases {
+		client := &fakeClient{}
+		if err := RemoveMember(client, c.name, c.ip); err != nil {
+			t.Errorf("remove %s: %v", c.name, err)
+		}
+		if strings.Join(client.changes(), ";") != strings.Join(c.changes, ";") {
+			t.Errorf("remove %s: expected %v, got %v", c.name, c.changes, client.changes())
+		}
+	}
+}
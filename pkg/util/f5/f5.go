@@ -0,0 +1,114 @@
+package f5
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnauthorized      = errors.New("F5_LOGIN_FAILED")
+	ErrPartitionNotFound = errors.New("F5_PARTITION_NOT_FOUND")
+
+	errNotFound = errors.New("not found")
+)
+
+type Interface interface {
+	Version() (string, error)
+	CheckPartition(name string) error
+}
+
+type Config struct {
+	URL      string
+	User     string
+	Password string
+}
+
+// Client 通过 iControl REST 访问 BIG-IP，设备证书通常为自签名，不校验证书
+type Client struct {
+	url      string
+	user     string
+	password string
+	client   *http.Client
+}
+
+func NewClient(c *Config) *Client {
+	return &Client{
+		url:      strings.TrimSuffix(c.URL, "/"),
+		user:     c.User,
+		password: c.Password,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		},
+	}
+}
+
+type versionResponse struct {
+	Entries map[string]struct {
+		NestedStats struct {
+			Entries map[string]struct {
+				Description string `json:"description"`
+			} `json:"entries"`
+		} `json:"nestedStats"`
+	} `json:"entries"`
+}
+
+// Version 返回 BIG-IP 版本，同时用于校验地址和账号
+func (c *Client) Version() (string, error) {
+	var resp versionResponse
+	if err := c.get("/mgmt/tm/sys/version", &resp); err != nil {
+		return "", err
+	}
+	for _, entry := range resp.Entries {
+		if v, ok := entry.NestedStats.Entries["Version"]; ok {
+			return v.Description, nil
+		}
+	}
+	return "", errors.New("can not parse BIG-IP version")
+}
+
+func (c *Client) CheckPartition(name string) error {
+	err := c.get(fmt.Sprintf("/mgmt/tm/auth/partition/%s", name), nil)
+	if err == errNotFound {
+		return ErrPartitionNotFound
+	}
+	return err
+}
+
+func (c *Client) get(path string, result interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(c.user, c.password)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case resp.StatusCode == http.StatusNotFound:
+		return errNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("request %s failed: %d %s", path, resp.StatusCode, string(body))
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(body, result)
+}
@@ -0,0 +1,51 @@
+package f5
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newBigIP 模拟 iControl REST 接口
+func newBigIP(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mgmt/tm/sys/version", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"kind":"tm:sys:version:versionstats","entries":{"https://localhost/mgmt/tm/sys/version/0":{"nestedStats":{"entries":{"Build":{"description":"0.0.6"},"Version":{"description":"15.1.0"}}}}}}`))
+	})
+	mux.HandleFunc("/mgmt/tm/auth/partition/kubernetes", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"kind":"tm:auth:partition:partitionstate","name":"kubernetes","fullPath":"kubernetes"}`))
+	})
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+}
+
+func TestClient(t *testing.T) {
+	server := newBigIP(t)
+	defer server.Close()
+
+	c := NewClient(&Config{URL: server.URL, User: "admin", Password: "secret"})
+	version, err := c.Version()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != "15.1.0" {
+		t.Errorf("unexpected version %s", version)
+	}
+	if err := c.CheckPartition("kubernetes"); err != nil {
+		t.Error(err)
+	}
+	if err := c.CheckPartition("missing"); err != ErrPartitionNotFound {
+		t.Errorf("expected partition not found, got %v", err)
+	}
+
+	c = NewClient(&Config{URL: server.URL, User: "admin", Password: "wrong"})
+	if _, err := c.Version(); err != ErrUnauthorized {
+		t.Errorf("expected unauthorized, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"fmt"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
@@ -16,7 +17,7 @@ type Credential struct {
 	Password string
 }
 
-func CloneRepository(url string, path string, branch string, auth transport.AuthMethod) error {
+func CloneRepository(ctx context.Context, url string, path string, branch string, auth transport.AuthMethod) error {
 	exists := fileutil.Exist(path)
 	if exists {
 		return fmt.Errorf("path: %s already exists", path)
@@ -36,13 +37,13 @@ func CloneRepository(url string, path string, branch string, auth transport.Auth
 	if err != nil {
 		return err
 	}
-	if err := workTree.Pull(&git.PullOptions{RemoteName: "origin", Auth: auth, ReferenceName: plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", branch))}); err != nil {
+	if err := workTree.PullContext(ctx, &git.PullOptions{RemoteName: "origin", Auth: auth, ReferenceName: plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", branch))}); err != nil {
 		return err
 	}
 	return err
 }
 
-func UpdateRepository(path string, branch string, auth transport.AuthMethod) error {
+func UpdateRepository(ctx context.Context, path string, branch string, auth transport.AuthMethod) error {
 	r, err := git.PlainOpen(path)
 	if err != nil {
 		return err
@@ -51,7 +52,7 @@ func UpdateRepository(path string, branch string, auth transport.AuthMethod) err
 	if err != nil {
 		return err
 	}
-	if err := workTree.Pull(&git.PullOptions{RemoteName: "origin", Auth: auth, ReferenceName: plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", branch))}); err != nil && err != git.NoErrAlreadyUpToDate {
+	if err := workTree.PullContext(ctx, &git.PullOptions{RemoteName: "origin", Auth: auth, ReferenceName: plumbing.ReferenceName(fmt.Sprintf("refs/heads/%s", branch))}); err != nil && err != git.NoErrAlreadyUpToDate {
 		return err
 	}
 	return nil
@@ -93,3 +94,15 @@ func PushRepository(path string, auth transport.AuthMethod) error {
 	}
 	return nil
 }
+
+func HeadCommit(path string) (string, error) {
+	r, err := git.PlainOpen(path)
+	if err != nil {
+		return "", err
+	}
+	ref, err := r.Head()
+	if err != nil {
+		return "", err
+	}
+	return ref.Hash().String(), nil
+}
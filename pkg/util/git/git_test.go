@@ -1,6 +1,7 @@
 package git
 
 import (
+	"context"
 	"log"
 	"testing"
 
@@ -37,7 +38,7 @@ import (
 // }
 
 func TestCloneRepository(t *testing.T) {
-	err := CloneRepository("https://github.com/KubeOperator/MultiClusterRepositoryExample.git", "/var/ko/data/test", "master", &http.BasicAuth{
+	err := CloneRepository(context.Background(), "https://github.com/KubeOperator/MultiClusterRepositoryExample.git", "/var/ko/data/test", "master", &http.BasicAuth{
 		Username: "Aaron3S",
 		Password: "scydeai251",
 	})
@@ -47,7 +48,7 @@ func TestCloneRepository(t *testing.T) {
 }
 
 func TestUpdateRepository(t *testing.T) {
-	err := UpdateRepository("/var/ko/data/test", "master", &http.BasicAuth{
+	err := UpdateRepository(context.Background(), "/var/ko/data/test", "master", &http.BasicAuth{
 		Username: "Aaron3S",
 		Password: "scydeai251",
 	})
@@ -10,7 +10,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/kmpp/pkg/db"
 	"github.com/kmpp/pkg/model"
 	"github.com/kmpp/pkg/util/kubernetes"
 
@@ -180,25 +179,26 @@ func updateRepo(arch string) error {
 	if err != nil {
 		logger.Log.Infof("list repo failed: %v, start reading from db repo", err)
 	}
+	r := repository.NewSystemSettingRepository()
+	p, err := r.Get("REGISTRY_PROTOCOL")
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("load system repo failed: %v", err))
+	}
+	var c Client
+	repoIP, repoPort, _, err := c.GetRepoIP(arch)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("load system repo of arch %s failed: %v", arch, err))
+	}
+	url := fmt.Sprintf("%s://%s:%d/repository/applications", p.Value, repoIP, repoPort)
+	// 仓库故障切换后 nexus 地址会变化，需要重新添加
 	flag := false
 	for _, r := range repos {
-		if r.Name == "nexus" {
+		if r.Name == "nexus" && r.URL == url {
 			logger.Log.Infof("my nexus addr is %s", r.URL)
 			flag = true
 		}
 	}
 	if !flag {
-		r := repository.NewSystemSettingRepository()
-		p, err := r.Get("REGISTRY_PROTOCOL")
-		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("load system repo failed: %v", err))
-		}
-		var c Client
-		repoIP, repoPort, _, err := c.GetRepoIP(arch)
-		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("load system repo of arch %s failed: %v", arch, err))
-		}
-		url := fmt.Sprintf("%s://%s:%d/repository/applications", p.Value, repoIP, repoPort)
 		err = addRepo("nexus", url, "admin", "admin123")
 		if err != nil {
 			return errors.Wrap(err, fmt.Sprintf("add helm repo %s failed: %v", url, err))
@@ -280,8 +280,9 @@ func addRepo(name string, url string, username string, password string) error {
 		return err
 	}
 
-	if f.Has(name) {
-		return errors.Errorf("repository name (%s) already exists, please specify a different name", name)
+	// 仓库故障切换后同名仓库的地址会变化，直接覆盖原有配置
+	if e := f.Get(name); e != nil && e.URL == url {
+		return nil
 	}
 
 	e := repo.Entry{
@@ -310,25 +311,20 @@ func addRepo(name string, url string, username string, password string) error {
 }
 
 func (c Client) GetRepoIP(arch string) (string, int, int, error) {
-	var repo model.SystemRegistry
+	var architecture string
 	switch arch {
 	case "amd64":
-		if err := db.DB.Where("architecture = ?", constant.ArchitectureOfAMD64).First(&repo).Error; err != nil {
-			return "", 0, 0, err
-		}
-		return repo.Hostname, repo.RepoPort, repo.RegistryPort, nil
-	case "arm64":
-		if err := db.DB.Where("architecture = ?", constant.ArchitectureOfARM64).First(&repo).Error; err != nil {
-			return "", 0, 0, err
-		}
-		return repo.Hostname, repo.RepoPort, repo.RegistryPort, nil
-	case "all":
-		if err := db.DB.Where("architecture = ?", constant.ArchitectureOfARM64).First(&repo).Error; err != nil {
-			return "", 0, 0, err
-		}
-		return repo.Hostname, repo.RepoPort, repo.RegistryPort, nil
+		architecture = constant.ArchitectureOfAMD64
+	case "arm64", "all":
+		architecture = constant.ArchitectureOfARM64
+	default:
+		return "", 0, 0, errors.New("no such architecture")
+	}
+	repo, err := model.GetActiveRegistry(architecture)
+	if err != nil {
+		return "", 0, 0, err
 	}
-	return "", 0, 0, errors.New("no such architecture")
+	return repo.Hostname, repo.RepoPort, repo.RegistryPort, nil
 }
 
 func ListRepo() ([]*repo.Entry, error) {
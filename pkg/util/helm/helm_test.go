@@ -2,6 +2,10 @@ package helm
 
 import (
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/kmpp/pkg/config"
@@ -9,6 +13,7 @@ import (
 	"github.com/kmpp/pkg/logger"
 	"github.com/kmpp/pkg/util/kubernetes"
 	"github.com/spf13/viper"
+	"helm.sh/helm/v3/pkg/repo"
 )
 
 func GetClient() (*Client, error) {
@@ -88,3 +93,48 @@ func TestClient_Uninstall(t *testing.T) {
 // 		logger.Log.Fatal(err)
 // 	}
 // }
+
+func TestAddRepo_Failover(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("apiVersion: v1\nentries: {}\n"))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "helm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, env := range []string{"HELM_CONFIG_HOME", "HELM_CACHE_HOME", "HELM_DATA_HOME"} {
+		old, ok := os.LookupEnv(env)
+		_ = os.Setenv(env, dir)
+		defer func(env, old string, ok bool) {
+			if ok {
+				_ = os.Setenv(env, old)
+			} else {
+				_ = os.Unsetenv(env)
+			}
+		}(env, old, ok)
+	}
+
+	f := repo.NewFile()
+	f.Add(&repo.Entry{Name: "nexus", URL: "http://172.16.10.1:8081/repository/applications"})
+	if err := f.WriteFile(GetSettings().RepositoryConfig, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := addRepo("nexus", server.URL, "admin", "admin123"); err != nil {
+		t.Fatalf("failover to %s failed: %v", server.URL, err)
+	}
+	repos, err := ListRepo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != 1 || repos[0].URL != server.URL {
+		t.Fatalf("expected nexus to point at %s, got %+v", server.URL, repos)
+	}
+
+	if err := addRepo("nexus", server.URL, "admin", "admin123"); err != nil {
+		t.Fatalf("re-adding the same address failed: %v", err)
+	}
+}
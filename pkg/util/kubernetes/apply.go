@@ -0,0 +1,113 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+const defaultApplyNamespace = "default"
+
+// Applier 将清单中的资源创建或更新到集群
+type Applier struct {
+	client dynamic.Interface
+	mapper meta.RESTMapper
+}
+
+func NewApplier(c *Config) (*Applier, error) {
+	aliveHost, err := SelectAliveHost(c.Hosts)
+	if err != nil {
+		return nil, err
+	}
+	kubeConf := &rest.Config{
+		Host:        string(aliveHost),
+		BearerToken: c.Token,
+		TLSClientConfig: rest.TLSClientConfig{
+			Insecure: true,
+		},
+	}
+	client, err := dynamic.NewForConfig(kubeConf)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("new dynamic client with config failed: %v", err))
+	}
+	dc, err := discovery.NewDiscoveryClientForConfig(kubeConf)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("new discovery client with config failed: %v", err))
+	}
+	return &Applier{
+		client: client,
+		mapper: restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc)),
+	}, nil
+}
+
+// Apply 资源不存在时创建，存在时以当前 resourceVersion 更新
+func (a *Applier) Apply(obj *unstructured.Unstructured) error {
+	gvk := obj.GroupVersionKind()
+	mapping, err := a.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return err
+	}
+	var ri dynamic.ResourceInterface
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(defaultApplyNamespace)
+		}
+		ri = a.client.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+	} else {
+		ri = a.client.Resource(mapping.Resource)
+	}
+	current, err := ri.Get(context.TODO(), obj.GetName(), metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		_, err = ri.Create(context.TODO(), obj, metav1.CreateOptions{})
+		return err
+	}
+	obj.SetResourceVersion(current.GetResourceVersion())
+	_, err = ri.Update(context.TODO(), obj, metav1.UpdateOptions{})
+	return err
+}
+
+// DecodeManifests 解析包含多个文档的 yaml 或 json 清单，跳过空文档并展开 List
+func DecodeManifests(data []byte) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		if len(obj.Object) == 0 || obj.GetKind() == "" {
+			continue
+		}
+		if obj.IsList() {
+			if err := obj.EachListItem(func(o runtime.Object) error {
+				objs = append(objs, o.(*unstructured.Unstructured))
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		objs = append(objs, obj)
+	}
+	return objs, nil
+}
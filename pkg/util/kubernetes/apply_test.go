@@ -0,0 +1,38 @@
+package kubernetes
+
+import "testing"
+
+func TestDecodeManifests(t *testing.T) {
+	data := []byte(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: demo
+---
+# empty document
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: a
+    namespace: demo
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: b
+    namespace: demo
+`)
+	objs, err := DecodeManifests(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objs) != 3 {
+		t.Fatalf("expected 3 objects, got %d", len(objs))
+	}
+	if objs[0].GetKind() != "Namespace" || objs[2].GetName() != "b" || objs[2].GetNamespace() != "demo" {
+		t.Errorf("unexpected objects %v", objs)
+	}
+}
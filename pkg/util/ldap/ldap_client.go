@@ -10,6 +10,13 @@ var (
 	ParamEmpty = "PARAM_EMPTY"
 )
 
+const defaultGroupFilter = "(|(objectClass=groupOfNames)(objectClass=groupOfUniqueNames)(objectClass=posixGroup))"
+
+type Group struct {
+	Name    string
+	Members []string
+}
+
 type LdapClient struct {
 	Vars map[string]string
 	Conn *ldap.Conn
@@ -58,6 +65,7 @@ func (l *LdapClient) Connect() error {
 	return err
 }
 
+// Search 查询用户，连接保持打开以便继续查询用户组，由调用方通过 Close 关闭
 func (l *LdapClient) Search() ([]*ldap.Entry, error) {
 	var dn string
 	if _, ok := l.Vars["ldap_dn"]; ok {
@@ -75,7 +83,7 @@ func (l *LdapClient) Search() ([]*ldap.Entry, error) {
 	searchRequest := ldap.NewSearchRequest(dn,
 		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
 		userFilter,
-		[]string{"cn", "mail"},
+		[]string{"cn", "mail", "uid"},
 		nil)
 	sr, err := l.Conn.Search(searchRequest)
 	if err != nil {
@@ -84,10 +92,48 @@ func (l *LdapClient) Search() ([]*ldap.Entry, error) {
 	if len(sr.Entries) == 0 {
 		return nil, errors.New("LDAP_USER_IS_NULL")
 	}
-	defer l.Conn.Close()
 	return sr.Entries, err
 }
 
+// SearchGroups 查询用户组及其成员，成员可能是 DN(member/uniqueMember)或用户名(memberUid)
+func (l *LdapClient) SearchGroups() ([]Group, error) {
+	dn := l.Vars["ldap_group_dn"]
+	if dn == "" {
+		dn = l.Vars["ldap_dn"]
+	}
+	if dn == "" {
+		return nil, errors.New(ParamEmpty)
+	}
+	groupFilter := l.Vars["ldap_group_filter"]
+	if groupFilter == "" {
+		groupFilter = defaultGroupFilter
+	}
+	searchRequest := ldap.NewSearchRequest(dn,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		groupFilter,
+		[]string{"cn", "member", "uniqueMember", "memberUid"},
+		nil)
+	sr, err := l.Conn.Search(searchRequest)
+	if err != nil {
+		return nil, err
+	}
+	var groups []Group
+	for _, entry := range sr.Entries {
+		group := Group{Name: entry.GetAttributeValue("cn")}
+		for _, attr := range []string{"member", "uniqueMember", "memberUid"} {
+			group.Members = append(group.Members, entry.GetAttributeValues(attr)...)
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+func (l *LdapClient) Close() {
+	if l.Conn != nil {
+		l.Conn.Close()
+	}
+}
+
 func (l *LdapClient) Login(userName, password string) error {
 	var dn string
 	if _, ok := l.Vars["ldap_dn"]; ok {
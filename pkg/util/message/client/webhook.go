@@ -0,0 +1,266 @@
+package client
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/kmpp/pkg/constant"
+)
+
+const (
+	WebhookSignatureHeader = "X-Kmpp-Signature"
+	WebhookTimestampHeader = "X-Kmpp-Timestamp"
+	WebhookEventHeader     = "X-Kmpp-Event"
+
+	webhookDefaultRetry = 3
+	webhookMaxRetry     = 10
+)
+
+var (
+	WebhookFormatInvalid  = "WEBHOOK_FORMAT_INVALID"
+	WebhookHeadersInvalid = "WEBHOOK_HEADERS_INVALID"
+
+	// webhookBackoff 第一次重试前的等待时间，之后每次翻倍
+	webhookBackoff    = time.Second
+	webhookHttpClient = &http.Client{Timeout: 10 * time.Second}
+)
+
+// WebhookEvent 以结构化的形式描述一条通知，json 格式直接发送，slack 和 teams 格式由它渲染
+type WebhookEvent struct {
+	ID        string            `json:"id"`
+	Event     string            `json:"event"`
+	Category  string            `json:"category"`
+	Level     string            `json:"level"`
+	Title     string            `json:"title"`
+	Project   string            `json:"project"`
+	Cluster   string            `json:"cluster"`
+	Message   string            `json:"message"`
+	Detail    map[string]string `json:"detail,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+type webhook struct {
+	Vars    map[string]interface{}
+	url     string
+	secret  string
+	format  string
+	retry   int
+	headers map[string]string
+}
+
+func NewWebhookClient(vars map[string]interface{}) (*webhook, error) {
+	url, _ := vars["WEBHOOK_URL"].(string)
+	if url == "" {
+		return nil, errors.New(ParamEmpty)
+	}
+	w := &webhook{
+		Vars:    vars,
+		url:     url,
+		format:  constant.WebhookFormatJSON,
+		retry:   webhookDefaultRetry,
+		headers: map[string]string{},
+	}
+	w.secret, _ = vars["WEBHOOK_SECRET"].(string)
+	if format, _ := vars["WEBHOOK_FORMAT"].(string); format != "" {
+		switch format {
+		case constant.WebhookFormatJSON, constant.WebhookFormatSlack, constant.WebhookFormatTeams:
+			w.format = format
+		default:
+			return nil, errors.New(WebhookFormatInvalid)
+		}
+	}
+	if retry, _ := vars["WEBHOOK_RETRY"].(string); retry != "" {
+		n, err := strconv.Atoi(retry)
+		if err != nil || n < 0 {
+			return nil, errors.New(ParamEmpty)
+		}
+		if n > webhookMaxRetry {
+			n = webhookMaxRetry
+		}
+		w.retry = n
+	}
+	if headers, _ := vars["WEBHOOK_HEADERS"].(string); headers != "" {
+		if err := json.Unmarshal([]byte(headers), &w.headers); err != nil {
+			return nil, errors.New(WebhookHeadersInvalid)
+		}
+	}
+	return w, nil
+}
+
+func (w webhook) SendMessage(vars map[string]interface{}) error {
+	event, err := webhookEventOf(vars)
+	if err != nil {
+		return err
+	}
+	data, err := RenderWebhookPayload(w.format, event)
+	if err != nil {
+		return err
+	}
+	wait := webhookBackoff
+	for attempt := 0; ; attempt++ {
+		retryable, err := w.post(event, data)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt >= w.retry {
+			return err
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
+
+// post 发送一次请求，返回的 bool 表示失败后是否值得重试
+func (w webhook) post(event WebhookEvent, data []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(data))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json;charset=utf-8")
+	for k, v := range w.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set(WebhookEventHeader, event.Event)
+	if w.secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookSignatureHeader, "sha256="+WebhookSignature(w.secret, timestamp, data))
+	}
+	resp, err := webhookHttpClient.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	err = fmt.Errorf("webhook response status %d: %s", resp.StatusCode, string(body))
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests, err
+}
+
+// WebhookSignature 对 "时间戳.请求体" 计算 HMAC-SHA256，接收方可据此校验来源并拒绝重放
+func WebhookSignature(secret, timestamp string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(timestamp + "."))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// webhookEventOf 优先使用 EVENT，测试消息等只有 TITLE 和 CONTENT 的情况下生成一个简单事件
+func webhookEventOf(vars map[string]interface{}) (WebhookEvent, error) {
+	if event, ok := vars["EVENT"].(WebhookEvent); ok {
+		return event, nil
+	}
+	title, _ := vars["TITLE"].(string)
+	content, _ := vars["CONTENT"].(string)
+	if title == "" && content == "" {
+		return WebhookEvent{}, errors.New(ParamEmpty)
+	}
+	return WebhookEvent{
+		Event:     "TEST",
+		Level:     constant.MsgInfo,
+		Title:     title,
+		Message:   content,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+func RenderWebhookPayload(format string, event WebhookEvent) ([]byte, error) {
+	switch format {
+	case constant.WebhookFormatJSON, "":
+		return json.Marshal(&event)
+	case constant.WebhookFormatSlack:
+		return json.Marshal(slackPayload(event))
+	case constant.WebhookFormatTeams:
+		return json.Marshal(teamsPayload(event))
+	}
+	return nil, errors.New(WebhookFormatInvalid)
+}
+
+type webhookFact struct {
+	Name  string
+	Value string
+}
+
+// webhookFacts 项目和集群在前，其余详情按名称排序，保证每次渲染的顺序一致
+func webhookFacts(event WebhookEvent) []webhookFact {
+	var facts []webhookFact
+	if event.Project != "" {
+		facts = append(facts, webhookFact{Name: "项目", Value: event.Project})
+	}
+	if event.Cluster != "" {
+		facts = append(facts, webhookFact{Name: "集群", Value: event.Cluster})
+	}
+	var keys []string
+	for k, v := range event.Detail {
+		if k != "message" && v != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		facts = append(facts, webhookFact{Name: k, Value: event.Detail[k]})
+	}
+	facts = append(facts, webhookFact{Name: "时间", Value: event.Timestamp.Format("2006-01-02 15:04:05")})
+	return facts
+}
+
+func slackPayload(event WebhookEvent) map[string]interface{} {
+	color := "good"
+	if event.Level == constant.MsgWarning {
+		color = "danger"
+	}
+	var fields []map[string]interface{}
+	for _, f := range webhookFacts(event) {
+		fields = append(fields, map[string]interface{}{"title": f.Name, "value": f.Value, "short": true})
+	}
+	return map[string]interface{}{
+		"text": "*" + event.Title + "*",
+		"attachments": []map[string]interface{}{
+			{
+				"color":    color,
+				"fallback": event.Title + ": " + event.Message,
+				"text":     event.Message,
+				"fields":   fields,
+				"footer":   "KubeOperator",
+				"ts":       event.Timestamp.Unix(),
+			},
+		},
+	}
+}
+
+func teamsPayload(event WebhookEvent) map[string]interface{} {
+	color := "2EB886"
+	if event.Level == constant.MsgWarning {
+		color = "D50000"
+	}
+	var facts []map[string]string
+	for _, f := range webhookFacts(event) {
+		facts = append(facts, map[string]string{"name": f.Name, "value": f.Value})
+	}
+	return map[string]interface{}{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    event.Title,
+		"themeColor": color,
+		"title":      event.Title,
+		"sections": []map[string]interface{}{
+			{
+				"activityTitle":    event.Message,
+				"activitySubtitle": "本消息由KubeOperator自动发送",
+				"facts":            facts,
+			},
+		},
+	}
+}
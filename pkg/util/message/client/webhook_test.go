@@ -0,0 +1,124 @@
+package client
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kmpp/pkg/constant"
+)
+
+func TestWebhookSignedRetry(t *testing.T) {
+	webhookBackoff = time.Millisecond
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := ioutil.ReadAll(r.Body)
+		timestamp := r.Header.Get(WebhookTimestampHeader)
+		if r.Header.Get(WebhookSignatureHeader) != "sha256="+WebhookSignature("s3cret", timestamp, body) {
+			t.Errorf("signature mismatch")
+		}
+		if r.Header.Get("X-Token") != "abc" {
+			t.Errorf("custom header missing")
+		}
+		var event WebhookEvent
+		if err := json.Unmarshal(body, &event); err != nil || event.Event != constant.ClusterInstall {
+			t.Errorf("unexpected body %s", string(body))
+		}
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c, err := NewWebhookClient(map[string]interface{}{
+		"WEBHOOK_URL":     server.URL,
+		"WEBHOOK_SECRET":  "s3cret",
+		"WEBHOOK_HEADERS": `{"X-Token":"abc"}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := WebhookEvent{Event: constant.ClusterInstall, Level: constant.MsgInfo, Cluster: "demo", Timestamp: time.Now()}
+	if err := c.SendMessage(map[string]interface{}{"EVENT": event}); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Errorf("expect 3 calls, got %d", calls)
+	}
+}
+
+func TestWebhookNoRetryOnClientError(t *testing.T) {
+	webhookBackoff = time.Millisecond
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	c, err := NewWebhookClient(map[string]interface{}{"WEBHOOK_URL": server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SendMessage(map[string]interface{}{"TITLE": "test", "CONTENT": "test"}); err == nil {
+		t.Error("expect error")
+	}
+	if calls != 1 {
+		t.Errorf("expect 1 call, got %d", calls)
+	}
+}
+
+func TestRenderWebhookPayload(t *testing.T) {
+	event := WebhookEvent{
+		Title:     "集群事件告警",
+		Level:     constant.MsgWarning,
+		Project:   "p1",
+		Cluster:   "c1",
+		Message:   "Back-off restarting failed container",
+		Detail:    map[string]string{"reason": "BackOff", "namespace": "default", "message": "ignored"},
+		Timestamp: time.Unix(0, 0),
+	}
+	data, err := RenderWebhookPayload(constant.WebhookFormatSlack, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var slack struct {
+		Attachments []struct {
+			Color  string `json:"color"`
+			Fields []struct {
+				Title string `json:"title"`
+			} `json:"fields"`
+		} `json:"attachments"`
+	}
+	_ = json.Unmarshal(data, &slack)
+	if len(slack.Attachments) != 1 || slack.Attachments[0].Color != "danger" {
+		t.Fatalf("unexpected slack payload %s", string(data))
+	}
+	var titles []string
+	for _, f := range slack.Attachments[0].Fields {
+		titles = append(titles, f.Title)
+	}
+	if len(titles) != 5 || titles[2] != "namespace" || titles[3] != "reason" {
+		t.Errorf("unexpected slack fields %v", titles)
+	}
+
+	data, err = RenderWebhookPayload(constant.WebhookFormatTeams, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var teams map[string]interface{}
+	_ = json.Unmarshal(data, &teams)
+	if teams["@type"] != "MessageCard" || teams["themeColor"] != "D50000" {
+		t.Errorf("unexpected teams payload %s", string(data))
+	}
+
+	if _, err := NewWebhookClient(map[string]interface{}{"WEBHOOK_URL": "http://x", "WEBHOOK_FORMAT": "xml"}); err == nil || err.Error() != WebhookFormatInvalid {
+		t.Errorf("expect format error, got %v", err)
+	}
+}
@@ -19,5 +19,8 @@ func NewMessageClient(vars map[string]interface{}) (MessageClient, error) {
 	if vars["type"] == constant.WorkWeiXin {
 		return client.NewWorkWeixinClient(vars)
 	}
+	if vars["type"] == constant.Webhook {
+		return client.NewWebhookClient(vars)
+	}
 	return nil, nil
 }
@@ -2,6 +2,7 @@ package net
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
 	"time"
@@ -34,3 +35,33 @@ func TcpPing(addr string, isTls bool) error {
 	}
 	return nil
 }
+
+// PeerCertificate 返回 TLS 端点的服务端证书，要求客户端证书的端点（如 etcd）在握手失败前也能拿到证书
+func PeerCertificate(addr string) (*x509.Certificate, error) {
+	var cert *x509.Certificate
+	config := &tls.Config{
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 {
+				return nil
+			}
+			c, err := x509.ParseCertificate(rawCerts[0])
+			if err != nil {
+				return err
+			}
+			cert = c
+			return nil
+		},
+	}
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second}, "tcp", addr, config)
+	if err == nil {
+		conn.Close()
+	}
+	if cert == nil {
+		if err == nil {
+			err = fmt.Errorf("no certificate")
+		}
+		return nil, errors.Wrap(err, fmt.Sprintf("get certificate of %s failed", addr))
+	}
+	return cert, nil
+}
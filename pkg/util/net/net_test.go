@@ -2,6 +2,8 @@ package net
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 )
@@ -23,3 +25,15 @@ func TestTcpPing(t *testing.T) {
 	}
 
 }
+
+func TestPeerCertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	cert, err := PeerCertificate(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cert.Equal(server.Certificate()) {
+		t.Error("unexpected certificate")
+	}
+}
@@ -0,0 +1,49 @@
+package registry
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Config struct {
+	Protocol     string
+	Hostname     string
+	RepoPort     int
+	RegistryPort int
+}
+
+type probe struct {
+	name string
+	url  string
+}
+
+var client = &http.Client{
+	Timeout: 5 * time.Second,
+	Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	},
+}
+
+// Probe 依次检查镜像仓库 v2 接口、helm 仓库索引和 nexus 服务状态（yum 等 raw 仓库由 nexus 提供）
+func Probe(c *Config) error {
+	probes := []probe{
+		{name: "registry", url: fmt.Sprintf("%s://%s:%d/v2/", c.Protocol, c.Hostname, c.RegistryPort)},
+		{name: "helm", url: fmt.Sprintf("%s://%s:%d/repository/applications/index.yaml", c.Protocol, c.Hostname, c.RepoPort)},
+		{name: "repo", url: fmt.Sprintf("%s://%s:%d/service/rest/v1/status", c.Protocol, c.Hostname, c.RepoPort)},
+	}
+	for _, p := range probes {
+		resp, err := client.Get(p.url)
+		if err != nil {
+			return fmt.Errorf("%s probe failed: %s", p.name, err.Error())
+		}
+		resp.Body.Close()
+		// 未登录访问 v2 接口返回 401，说明仓库可用
+		if resp.StatusCode == http.StatusOK || (p.name == "registry" && resp.StatusCode == http.StatusUnauthorized) {
+			continue
+		}
+		return fmt.Errorf("%s probe failed: %s returned %d", p.name, p.url, resp.StatusCode)
+	}
+	return nil
+}
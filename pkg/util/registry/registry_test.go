@@ -0,0 +1,38 @@
+package registry
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestProbe(t *testing.T) {
+	helmReady := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/":
+			w.WriteHeader(http.StatusUnauthorized)
+		case "/repository/applications/index.yaml":
+			if !helmReady {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case "/service/rest/v1/status":
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	host, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+	p, _ := strconv.Atoi(port)
+	c := &Config{Protocol: "http", Hostname: host, RepoPort: p, RegistryPort: p}
+
+	if err := Probe(c); err != nil {
+		t.Fatal(err)
+	}
+	helmReady = false
+	if err := Probe(c); err == nil {
+		t.Error("expected helm probe to fail")
+	}
+}
@@ -0,0 +1,62 @@
+package secret
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const defaultFileRoot = "/run/secrets"
+
+// fileProvider 读取挂载到 secret.file.root 目录下的文件，不允许引用目录之外的文件
+type fileProvider struct{}
+
+func fileRoot() string {
+	if root := viper.GetString("secret.file.root"); root != "" {
+		return filepath.Clean(root)
+	}
+	return defaultFileRoot
+}
+
+func (f *fileProvider) path(ref string) (string, error) {
+	if !filepath.IsAbs(ref) {
+		return "", ErrReferenceInvalid
+	}
+	p := filepath.Clean(ref)
+	root := fileRoot()
+	if !strings.HasPrefix(p, root+string(filepath.Separator)) {
+		return "", ErrReferenceInvalid
+	}
+	return p, nil
+}
+
+func (f *fileProvider) Validate(ref string) error {
+	_, err := f.path(ref)
+	return err
+}
+
+func (f *fileProvider) Resolve(ref string) (string, error) {
+	p, err := f.path(ref)
+	if err != nil {
+		return "", err
+	}
+	// 挂载的目录内可能是指向目录外的符号链接
+	real, err := filepath.EvalSymlinks(p)
+	if err != nil {
+		return "", err
+	}
+	realRoot, err := filepath.EvalSymlinks(fileRoot())
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(real, realRoot+string(filepath.Separator)) {
+		return "", ErrReferenceInvalid
+	}
+	data, err := ioutil.ReadFile(real)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,132 @@
+package secret
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+// 凭据字段可以直接保存密钥，也可以保存对外部密钥的引用，在使用时再解析：
+//   vault://secret/data/ssh/node1#private_key  从 Vault KV 读取指定 key
+//   file:///run/secrets/node1_key              读取挂载的文件
+//   mem://node1                                内存实现，用于测试和本地开发
+// 没有已注册前缀的值视为明文原样返回。
+
+var ErrReferenceInvalid = errors.New("SECRET_REFERENCE_INVALID")
+
+// Provider 解析去掉 scheme 后的引用
+type Provider interface {
+	Validate(ref string) error
+	Resolve(ref string) (string, error)
+}
+
+var (
+	mu        sync.RWMutex
+	providers = map[string]Provider{
+		"vault": &vaultProvider{},
+		"file":  &fileProvider{},
+	}
+)
+
+// Register 注册或替换 scheme 对应的 Provider
+func Register(scheme string, provider Provider) {
+	mu.Lock()
+	defer mu.Unlock()
+	providers[scheme] = provider
+}
+
+func parse(value string) (Provider, string, bool) {
+	i := strings.Index(value, "://")
+	if i <= 0 {
+		return nil, "", false
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	p, ok := providers[value[:i]]
+	if !ok {
+		return nil, "", false
+	}
+	return p, value[i+3:], true
+}
+
+// IsReference 判断值是否为外部密钥引用
+func IsReference(value string) bool {
+	_, _, ok := parse(value)
+	return ok
+}
+
+// Validate 检查引用格式，不访问外部系统，明文值直接通过
+func Validate(value string) error {
+	p, ref, ok := parse(value)
+	if !ok {
+		return nil
+	}
+	return p.Validate(ref)
+}
+
+// Resolve 解析引用得到密钥内容，明文值原样返回
+func Resolve(value string) (string, error) {
+	p, ref, ok := parse(value)
+	if !ok {
+		return value, nil
+	}
+	if err := p.Validate(ref); err != nil {
+		return "", err
+	}
+	return p.Resolve(ref)
+}
+
+// ResolveVars 解析 map 中所有字符串类型的引用，返回新的 map
+func ResolveVars(vars map[string]interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(vars))
+	for k, v := range vars {
+		s, ok := v.(string)
+		if !ok {
+			result[k] = v
+			continue
+		}
+		resolved, err := Resolve(s)
+		if err != nil {
+			return nil, err
+		}
+		result[k] = resolved
+	}
+	return result, nil
+}
+
+// MemoryProvider 内存中的密钥，mem://name 引用
+type MemoryProvider struct {
+	sync.RWMutex
+	secrets map[string]string
+}
+
+func NewMemoryProvider(secrets map[string]string) *MemoryProvider {
+	m := &MemoryProvider{secrets: make(map[string]string)}
+	for k, v := range secrets {
+		m.secrets[k] = v
+	}
+	return m
+}
+
+func (m *MemoryProvider) Set(name, value string) {
+	m.Lock()
+	defer m.Unlock()
+	m.secrets[name] = value
+}
+
+func (m *MemoryProvider) Validate(ref string) error {
+	if ref == "" {
+		return ErrReferenceInvalid
+	}
+	return nil
+}
+
+func (m *MemoryProvider) Resolve(ref string) (string, error) {
+	m.RLock()
+	defer m.RUnlock()
+	value, ok := m.secrets[ref]
+	if !ok {
+		return "", errors.New("secret " + ref + " not found")
+	}
+	return value, nil
+}
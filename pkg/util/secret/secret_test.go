@@ -0,0 +1,100 @@
+package secret
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestResolveMemory(t *testing.T) {
+	Register("mem", NewMemoryProvider(map[string]string{"node1": "-----BEGIN KEY-----"}))
+	value, err := Resolve("mem://node1")
+	if err != nil || value != "-----BEGIN KEY-----" {
+		t.Fatalf("unexpected %q %v", value, err)
+	}
+	if _, err := Resolve("mem://node2"); err == nil {
+		t.Error("missing secret should fail")
+	}
+	if value, _ := Resolve("Calong@2015"); value != "Calong@2015" {
+		t.Error("plain value should be returned as is")
+	}
+}
+
+func TestResolveFile(t *testing.T) {
+	root, err := ioutil.TempDir("", "secrets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	viper.Set("secret.file.root", root)
+	defer viper.Set("secret.file.root", "")
+	if err := ioutil.WriteFile(filepath.Join(root, "node1"), []byte("password\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	value, err := Resolve("file://" + filepath.Join(root, "node1"))
+	if err != nil || value != "password" {
+		t.Fatalf("unexpected %q %v", value, err)
+	}
+	for _, ref := range []string{"file://etc/passwd", "file:///etc/passwd", "file://" + root + "/../passwd"} {
+		if err := Validate(ref); err != ErrReferenceInvalid {
+			t.Errorf("%s should be invalid", ref)
+		}
+	}
+	if err := os.Symlink("/etc/hostname", filepath.Join(root, "link")); err == nil {
+		if _, err := Resolve("file://" + filepath.Join(root, "link")); err == nil {
+			t.Error("symlink out of root should fail")
+		}
+	}
+}
+
+func TestResolveVault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/ssh/node1":
+			_, _ = w.Write([]byte(`{"data":{"data":{"private_key":"KEY"},"metadata":{"version":1}}}`))
+		case "/v1/kv/s3":
+			_, _ = w.Write([]byte(`{"data":{"secretKey":"S3KEY"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	viper.Set("secret.vault.address", server.URL)
+	viper.Set("secret.vault.token", "s.token")
+	viper.Set("secret.vault.allowed_prefixes", []string{"secret/data/ssh/", "/kv"})
+	defer viper.Set("secret.vault.address", "")
+	defer viper.Set("secret.vault.token", "")
+	defer viper.Set("secret.vault.allowed_prefixes", nil)
+
+	if value, err := Resolve("vault://secret/data/ssh/node1#private_key"); err != nil || value != "KEY" {
+		t.Fatalf("kv v2: unexpected %q %v", value, err)
+	}
+	vars, err := ResolveVars(map[string]interface{}{"accessKey": "AK", "secretKey": "vault://kv/s3#secretKey", "port": 22})
+	if err != nil || vars["secretKey"] != "S3KEY" || vars["accessKey"] != "AK" || vars["port"] != 22 {
+		t.Fatalf("kv v1: unexpected %v %v", vars, err)
+	}
+	if _, err := Resolve("vault://secret/data/ssh/node1#password"); err == nil {
+		t.Error("missing key should fail")
+	}
+	if err := Validate("vault://secret/data/ssh/node1"); err != ErrReferenceInvalid {
+		t.Error("reference without key should be invalid")
+	}
+	for _, ref := range []string{"vault://secret/data/other#key", "vault://kv-admin/s3#secretKey", "vault://sys/policy#name", "vault://secret/data#key"} {
+		if _, err := Resolve(ref); err != ErrReferenceInvalid {
+			t.Errorf("%s out of the allowed prefixes should be invalid", ref)
+		}
+	}
+	viper.Set("secret.vault.allowed_prefixes", nil)
+	if err := Validate("vault://secret/data/ssh/node1#private_key"); err != ErrReferenceInvalid {
+		t.Error("vault references should be rejected when no prefix is allowed")
+	}
+}
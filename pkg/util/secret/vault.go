@@ -0,0 +1,100 @@
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+var vaultHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// vaultProvider 通过 HTTP API 读取 Vault KV，引用格式为 <path>#<key>，
+// path 为 /v1/ 之后的完整路径，KV v2 需要包含 data 段，如 secret/data/ssh/node1；
+// path 必须位于 secret.vault.allowed_prefixes 配置的路径之下，未配置时不允许引用 Vault
+type vaultProvider struct{}
+
+func (v *vaultProvider) Validate(ref string) error {
+	i := strings.LastIndex(ref, "#")
+	if i <= 0 || i == len(ref)-1 || strings.Contains(ref[:i], "..") {
+		return ErrReferenceInvalid
+	}
+	if !vaultPathAllowed(strings.Trim(ref[:i], "/")) {
+		return ErrReferenceInvalid
+	}
+	return nil
+}
+
+// vaultPathAllowed 按路径段匹配允许的前缀，secret/data/kmpp 不会匹配 secret/data/kmpp-other
+func vaultPathAllowed(path string) bool {
+	for _, prefix := range viper.GetStringSlice("secret.vault.allowed_prefixes") {
+		prefix = strings.Trim(prefix, "/")
+		if prefix == "" {
+			continue
+		}
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *vaultProvider) Resolve(ref string) (string, error) {
+	i := strings.LastIndex(ref, "#")
+	path, key := strings.Trim(ref[:i], "/"), ref[i+1:]
+
+	address := viper.GetString("secret.vault.address")
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	token := viper.GetString("secret.vault.token")
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	if address == "" || token == "" {
+		return "", fmt.Errorf("vault address or token is not configured")
+	}
+	req, err := http.NewRequest(http.MethodGet, strings.TrimRight(address, "/")+"/v1/"+path, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := viper.GetString("secret.vault.namespace"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+	resp, err := vaultHTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("read vault secret %s failed, status %d", path, resp.StatusCode)
+	}
+	var result struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", err
+	}
+	data := result.Data
+	// KV v2 的数据在 data.data 中
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		if _, hasMeta := data["metadata"]; hasMeta {
+			data = inner
+		}
+	}
+	value, ok := data[key].(string)
+	if !ok {
+		return "", fmt.Errorf("key %s not found in vault secret %s", key, path)
+	}
+	return value, nil
+}
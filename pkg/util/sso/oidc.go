@@ -0,0 +1,171 @@
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/coreos/go-oidc"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrIdTokenMissing = errors.New("SSO_ID_TOKEN_MISSING")
+	ErrNonceMismatch  = errors.New("SSO_NONCE_MISMATCH")
+	ErrUsernameEmpty  = errors.New("SSO_USERNAME_EMPTY")
+)
+
+type Config struct {
+	Issuer        string
+	ClientID      string
+	ClientSecret  string
+	RedirectURL   string
+	Scopes        []string
+	UsernameClaim string
+	EmailClaim    string
+	GroupsClaim   string
+}
+
+// AuthRequest 一次授权码登录需要在会话中保存的 state、nonce 和 PKCE verifier
+type AuthRequest struct {
+	URL      string
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+// Identity 从 ID Token 中解析出的用户信息
+type Identity struct {
+	Subject  string
+	Username string
+	Email    string
+	Groups   []string
+}
+
+type Client struct {
+	config   Config
+	oauth    oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+func NewClient(ctx context.Context, c Config) (*Client, error) {
+	provider, err := oidc.NewProvider(ctx, c.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	scopes := c.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{oidc.ScopeOpenID, "profile", "email"}
+	}
+	if c.UsernameClaim == "" {
+		c.UsernameClaim = "preferred_username"
+	}
+	if c.EmailClaim == "" {
+		c.EmailClaim = "email"
+	}
+	if c.GroupsClaim == "" {
+		c.GroupsClaim = "groups"
+	}
+	return &Client{
+		config: c,
+		oauth: oauth2.Config{
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+			RedirectURL:  c.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       scopes,
+		},
+		verifier: provider.Verifier(&oidc.Config{ClientID: c.ClientID}),
+	}, nil
+}
+
+// AuthCodeURL 生成带 PKCE(S256) 的授权地址
+func (c *Client) AuthCodeURL() (*AuthRequest, error) {
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	url := c.oauth.AuthCodeURL(state,
+		oidc.Nonce(nonce),
+		oauth2.SetAuthURLParam("code_challenge", codeChallenge(verifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+	return &AuthRequest{URL: url, State: state, Nonce: nonce, Verifier: verifier}, nil
+}
+
+// Exchange 用授权码换取 Token，校验 ID Token 并解析用户信息
+func (c *Client) Exchange(ctx context.Context, code, verifier, nonce string) (*Identity, error) {
+	token, err := c.oauth.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", verifier))
+	if err != nil {
+		return nil, err
+	}
+	raw, ok := token.Extra("id_token").(string)
+	if !ok || raw == "" {
+		return nil, ErrIdTokenMissing
+	}
+	idToken, err := c.verifier.Verify(ctx, raw)
+	if err != nil {
+		return nil, err
+	}
+	if idToken.Nonce != nonce {
+		return nil, ErrNonceMismatch
+	}
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, err
+	}
+	identity := &Identity{
+		Subject:  idToken.Subject,
+		Username: claimString(claims, c.config.UsernameClaim),
+		Email:    claimString(claims, c.config.EmailClaim),
+		Groups:   claimStrings(claims, c.config.GroupsClaim),
+	}
+	if identity.Username == "" {
+		return nil, ErrUsernameEmpty
+	}
+	return identity, nil
+}
+
+func claimString(claims map[string]interface{}, name string) string {
+	if v, ok := claims[name]; ok && v != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return ""
+}
+
+func claimStrings(claims map[string]interface{}, name string) []string {
+	var result []string
+	switch v := claims[name].(type) {
+	case []interface{}:
+		for _, item := range v {
+			result = append(result, fmt.Sprintf("%v", item))
+		}
+	case string:
+		result = append(result, v)
+	}
+	return result
+}
+
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func randomString() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}